@@ -5,15 +5,23 @@ import (
 	"fmt"
 	"log/slog"
 	"os"
+	"strings"
+	"time"
 
 	// _ "github.com/nikhil-shrestha/go-pos/docs"
 	"github.com/nikhil-shrestha/go-pos/internal/adapter/auth/paseto"
 	"github.com/nikhil-shrestha/go-pos/internal/adapter/config"
 	"github.com/nikhil-shrestha/go-pos/internal/adapter/handler/http"
 	"github.com/nikhil-shrestha/go-pos/internal/adapter/logger"
+	"github.com/nikhil-shrestha/go-pos/internal/adapter/notifier/console"
+	"github.com/nikhil-shrestha/go-pos/internal/adapter/notifier/smtp"
+	"github.com/nikhil-shrestha/go-pos/internal/adapter/storage/filesystem"
 	"github.com/nikhil-shrestha/go-pos/internal/adapter/storage/postgres"
 	"github.com/nikhil-shrestha/go-pos/internal/adapter/storage/postgres/repository"
 	"github.com/nikhil-shrestha/go-pos/internal/adapter/storage/redis"
+	"github.com/nikhil-shrestha/go-pos/internal/adapter/storage/s3"
+	"github.com/nikhil-shrestha/go-pos/internal/core/domain"
+	"github.com/nikhil-shrestha/go-pos/internal/core/port"
 	"github.com/nikhil-shrestha/go-pos/internal/core/service"
 )
 
@@ -35,7 +43,7 @@ import (
 //	@securityDefinitions.apikey	BearerAuth
 //	@in							header
 //	@name						Authorization
-//	@description				Type "Bearer" followed by a space and the access token.
+//	@description				Type "Bearer" followed by a space and the access token, or "ApiKey" followed by a space and the API key.
 func main() {
 	// Load environment variables
 	config, err := config.New()
@@ -86,46 +94,147 @@ func main() {
 		os.Exit(1)
 	}
 
+	refreshDuration, err := time.ParseDuration(config.Token.RefreshDuration)
+	if err != nil {
+		slog.Error("Error parsing refresh token duration", "error", err)
+		os.Exit(1)
+	}
+
+	// Two-factor authentication is required for the listed roles, and optional for the others
+	twoFactorPolicy := &domain.TwoFactorPolicy{
+		Issuer: config.TwoFactor.Issuer,
+	}
+	if twoFactorPolicy.Issuer == "" {
+		twoFactorPolicy.Issuer = config.App.Name
+	}
+	if config.TwoFactor.RequiredRoles != "" {
+		for _, role := range strings.Split(config.TwoFactor.RequiredRoles, ",") {
+			twoFactorPolicy.RequiredRoles = append(twoFactorPolicy.RequiredRoles, domain.UserRole(strings.TrimSpace(role)))
+		}
+	}
+
+	// Init file storage
+	var storage port.FileStorage
+	if config.Storage.Driver == "s3" {
+		storage, err = s3.New(ctx, config.Storage)
+	} else {
+		storage, err = filesystem.New(config.Storage)
+	}
+	if err != nil {
+		slog.Error("Error initializing file storage", "error", err)
+		os.Exit(1)
+	}
+
+	slog.Info("Successfully initialized the file storage", "driver", config.Storage.Driver)
+
+	// Init notifier
+	var notifier port.Notifier
+	if config.Notifier.Driver == "smtp" {
+		notifier = smtp.New(config.Notifier)
+	} else {
+		notifier = console.New(config.Notifier)
+	}
+
+	slog.Info("Successfully initialized the notifier", "driver", config.Notifier.Driver)
+
 	// Dependency injection
+	// Audit
+	auditRepo := repository.NewAuditRepository(db)
+	auditService := service.NewAuditService(auditRepo, db)
+	auditHandler := http.NewAuditHandler(auditService)
+
 	// User
 	userRepo := repository.NewUserRepository(db)
-	userService := service.NewUserService(userRepo, cache)
+	userService := service.NewUserService(userRepo, cache, auditService, refreshDuration)
 	userHandler := http.NewUserHandler(userService)
 
+	// Role
+	roleRepo := repository.NewRoleRepository(db)
+	roleService := service.NewRoleService(roleRepo, cache, auditService, refreshDuration)
+	roleHandler := http.NewRoleHandler(roleService)
+
+	// Terminal
+	terminalRepo := repository.NewTerminalRepository(db)
+	terminalService := service.NewTerminalService(terminalRepo, cache, auditService)
+	terminalHandler := http.NewTerminalHandler(terminalService)
+
+	// Two-factor
+	twoFactorRepo := repository.NewTwoFactorRepository(db)
+	twoFactorService := service.NewTwoFactorService(twoFactorRepo, userRepo, cache, auditService, twoFactorPolicy)
+	twoFactorHandler := http.NewTwoFactorHandler(twoFactorService)
+
 	// Auth
-	authService := service.NewAuthService(userRepo, token)
+	authService := service.NewAuthService(userRepo, roleRepo, terminalRepo, token, twoFactorService, cache, notifier, auditService, refreshDuration)
 	authHandler := http.NewAuthHandler(authService)
 
+	// API key
+	apiKeyRepo := repository.NewAPIKeyRepository(db)
+	apiKeyService := service.NewAPIKeyService(apiKeyRepo, cache, auditService)
+	apiKeyHandler := http.NewAPIKeyHandler(apiKeyService)
+
+	// Override
+	overrideService := service.NewOverrideService(userRepo, roleRepo, cache)
+	overrideHandler := http.NewOverrideHandler(overrideService)
+
+	// Idempotency
+	idempotencyRepo := repository.NewIdempotencyRepository(db)
+	idempotencyService := service.NewIdempotencyService(idempotencyRepo, cache)
+
 	// Payment
 	paymentRepo := repository.NewPaymentRepository(db)
-	paymentService := service.NewPaymentService(paymentRepo, cache)
+	paymentService := service.NewPaymentService(paymentRepo, cache, auditService)
 	paymentHandler := http.NewPaymentHandler(paymentService)
 
 	// Category
 	categoryRepo := repository.NewCategoryRepository(db)
-	categoryService := service.NewCategoryService(categoryRepo, cache)
+	categoryService := service.NewCategoryService(categoryRepo, cache, auditService)
 	categoryHandler := http.NewCategoryHandler(categoryService)
 
 	// Product
 	productRepo := repository.NewProductRepository(db)
-	productService := service.NewProductService(productRepo, categoryRepo, cache)
+	productService := service.NewProductService(productRepo, categoryRepo, cache, auditService)
 	productHandler := http.NewProductHandler(productService)
 
 	// Order
 	orderRepo := repository.NewOrderRepository(db)
-	orderService := service.NewOrderService(orderRepo, productRepo, categoryRepo, userRepo, paymentRepo, cache)
+	orderService := service.NewOrderService(orderRepo, productRepo, categoryRepo, userRepo, paymentRepo, auditService, cache)
 	orderHandler := http.NewOrderHandler(orderService)
 
+	// Analytics
+	analyticsRepo := repository.NewAnalyticsRepository(db)
+	analyticsService := service.NewAnalyticsService(analyticsRepo)
+	analyticsHandler := http.NewAnalyticsHandler(analyticsService)
+
+	// Key
+	keyHandler := http.NewKeyHandler(token)
+
+	// Image
+	imageService := service.NewImageService(productRepo, categoryRepo, paymentRepo, storage, cache, auditService)
+	imageHandler := http.NewImageHandler(imageService)
+
 	// Init router
 	router, err := http.NewRouter(
 		config.HTTP,
-		token,
+		config.Storage,
+		authService,
+		overrideService,
+		apiKeyService,
+		idempotencyService,
 		*userHandler,
 		*authHandler,
+		*twoFactorHandler,
+		*roleHandler,
+		*overrideHandler,
+		*terminalHandler,
 		*paymentHandler,
 		*categoryHandler,
 		*productHandler,
 		*orderHandler,
+		*analyticsHandler,
+		*imageHandler,
+		*keyHandler,
+		*apiKeyHandler,
+		*auditHandler,
 	)
 	if err != nil {
 		slog.Error("Error initializing router", "error", err)
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/nikhil-shrestha/go-pos/internal/adapter/auth/paseto"
+	"github.com/nikhil-shrestha/go-pos/internal/adapter/config"
+)
+
+// usage is the help text of the token command
+const usage = `Usage: token <command> [flags]
+
+Commands:
+  generate -mode M  Print a new key ring with a single key for the local or public TOKEN_MODE
+  rotate -keep N    Put a new key in front of TOKEN_KEYS and keep the N newest keys, leaving TOKEN_KEY_ID on the active key
+  activate -id ID   Make the key of TOKEN_KEYS with the id seal new tokens, defaulting to the newest key
+
+Copy the printed TOKEN_KEYS and TOKEN_KEY_ID values to the environment and restart the application.
+Rotating takes two deployments: roll out the rotated TOKEN_KEYS to every instance first, and only then
+activate the new key, so that no instance receives a token sealed with a key it does not know yet.
+`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	switch os.Args[1] {
+	case "generate":
+		flags := flag.NewFlagSet("generate", flag.ExitOnError)
+		mode := flags.String("mode", string(paseto.LocalMode), "token mode of the key, local or public")
+		_ = flags.Parse(os.Args[2:])
+
+		keys, keyID, _, err := paseto.RotateKeys(*mode, "", "", 1)
+		if err != nil {
+			slog.Error("Error generating token key", "error", err)
+			os.Exit(1)
+		}
+
+		printKeys(keys, keyID)
+	case "rotate":
+		flags := flag.NewFlagSet("rotate", flag.ExitOnError)
+		keep := flags.Int("keep", 2, "number of keys to keep, including the new key")
+		_ = flags.Parse(os.Args[2:])
+
+		config, err := config.New()
+		if err != nil {
+			slog.Error("Error loading environment variables", "error", err)
+			os.Exit(1)
+		}
+
+		keys, keyID, newKeyID, err := paseto.RotateKeys(config.Token.Mode, config.Token.Keys, config.Token.KeyID, *keep)
+		if err != nil {
+			slog.Error("Error rotating token keys", "error", err)
+			os.Exit(1)
+		}
+
+		printKeys(keys, keyID)
+		fmt.Fprintf(os.Stderr, "Once every instance runs with these TOKEN_KEYS, run: token activate -id %s\n", newKeyID)
+	case "activate":
+		flags := flag.NewFlagSet("activate", flag.ExitOnError)
+		id := flags.String("id", "", "id of the key to activate, defaulting to the newest key")
+		_ = flags.Parse(os.Args[2:])
+
+		config, err := config.New()
+		if err != nil {
+			slog.Error("Error loading environment variables", "error", err)
+			os.Exit(1)
+		}
+
+		keyID, err := paseto.ActivateKey(config.Token.Mode, config.Token.Keys, *id)
+		if err != nil {
+			slog.Error("Error activating token key", "error", err)
+			os.Exit(1)
+		}
+
+		printKeys(config.Token.Keys, keyID)
+	default:
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+}
+
+// printKeys prints the key ring as environment variables
+func printKeys(keys, keyID string) {
+	fmt.Printf("TOKEN_KEYS=%q\n", keys)
+	fmt.Printf("TOKEN_KEY_ID=%q\n", keyID)
+}
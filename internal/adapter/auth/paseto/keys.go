@@ -0,0 +1,314 @@
+package paseto
+
+import (
+	"encoding/base64"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"aidanwoods.dev/go-paseto"
+	"github.com/nikhil-shrestha/go-pos/internal/core/domain"
+	"github.com/google/uuid"
+)
+
+// Mode is the purpose of the tokens, which decides whether they are encrypted with a shared secret or signed with a key pair
+type Mode string
+
+// Mode values
+const (
+	// LocalMode encrypts tokens with a symmetric key, so only this service can read and verify them
+	LocalMode Mode = "local"
+	// PublicMode signs tokens with an asymmetric key, so anyone holding the public key can verify them
+	PublicMode Mode = "public"
+)
+
+// parseMode parses the configured mode, defaulting to LocalMode
+func parseMode(mode string) (Mode, error) {
+	switch Mode(mode) {
+	case "", LocalMode:
+		return LocalMode, nil
+	case PublicMode:
+		return PublicMode, nil
+	default:
+		return "", domain.ErrTokenMode
+	}
+}
+
+// protocol returns the paseto protocol of the mode
+func (m Mode) protocol() paseto.Protocol {
+	if m == PublicMode {
+		return paseto.V4Public
+	}
+	return paseto.V4Local
+}
+
+// keyIDFormat is the time layout of generated key ids, which keeps them sortable
+const keyIDFormat = "20060102150405"
+
+// keyFooter is the footer of a token, which identifies the key that encrypted or signed it
+type keyFooter struct {
+	KeyID string `json:"kid"`
+}
+
+// tokenKey seals and opens tokens with a single key of the key ring
+type tokenKey interface {
+	// seal encrypts or signs the token
+	seal(token *paseto.Token, footer []byte) string
+	// open decrypts or verifies the token and checks its rules
+	open(parser *paseto.Parser, token string) (*paseto.Token, error)
+	// export returns the key as hex, which is the format of the key ring
+	export() string
+	// public returns the public key, or false if the key is a shared secret
+	public() (paseto.V4AsymmetricPublicKey, bool)
+}
+
+/**
+ * localKey implements tokenKey interface
+ * and encrypts tokens with a v4.local symmetric key
+ */
+type localKey struct {
+	key paseto.V4SymmetricKey
+}
+
+// seal encrypts the token
+func (lk localKey) seal(token *paseto.Token, footer []byte) string {
+	token.SetFooter(footer)
+	return token.V4Encrypt(lk.key, nil)
+}
+
+// open decrypts the token
+func (lk localKey) open(parser *paseto.Parser, token string) (*paseto.Token, error) {
+	return parser.ParseV4Local(lk.key, token, nil)
+}
+
+// export returns the symmetric key as hex
+func (lk localKey) export() string {
+	return lk.key.ExportHex()
+}
+
+// public returns false, since a symmetric key has no public part
+func (lk localKey) public() (paseto.V4AsymmetricPublicKey, bool) {
+	return paseto.V4AsymmetricPublicKey{}, false
+}
+
+/**
+ * publicKey implements tokenKey interface
+ * and signs tokens with a v4.public asymmetric secret key
+ */
+type publicKey struct {
+	key paseto.V4AsymmetricSecretKey
+}
+
+// seal signs the token
+func (pk publicKey) seal(token *paseto.Token, footer []byte) string {
+	token.SetFooter(footer)
+	return token.V4Sign(pk.key, nil)
+}
+
+// open verifies the signature of the token with the public part of the key
+func (pk publicKey) open(parser *paseto.Parser, token string) (*paseto.Token, error) {
+	return parser.ParseV4Public(pk.key.Public(), token, nil)
+}
+
+// export returns the secret key as hex
+func (pk publicKey) export() string {
+	return pk.key.ExportHex()
+}
+
+// public returns the public part of the key
+func (pk publicKey) public() (paseto.V4AsymmetricPublicKey, bool) {
+	return pk.key.Public(), true
+}
+
+// newTokenKey creates a random key for the mode
+func newTokenKey(mode Mode) tokenKey {
+	if mode == PublicMode {
+		return publicKey{paseto.NewV4AsymmetricSecretKey()}
+	}
+	return localKey{paseto.NewV4SymmetricKey()}
+}
+
+// parseTokenKey parses a hex encoded key for the mode
+func parseTokenKey(mode Mode, hex string) (tokenKey, error) {
+	if mode == PublicMode {
+		key, err := paseto.NewV4AsymmetricSecretKeyFromHex(hex)
+		if err != nil {
+			return nil, err
+		}
+		return publicKey{key}, nil
+	}
+
+	key, err := paseto.V4SymmetricKeyFromHex(hex)
+	if err != nil {
+		return nil, err
+	}
+	return localKey{key}, nil
+}
+
+// keyRing holds the keys that can verify tokens by their id, and the id of the key that seals new tokens
+type keyRing struct {
+	mode     Mode
+	activeID string
+	ids      []string
+	keys     map[string]tokenKey
+}
+
+// parseKeyRing parses a comma separated list of "id:hex" keys. The active key defaults to the first key of the list
+func parseKeyRing(mode Mode, keys, activeID string) (*keyRing, error) {
+	ring := &keyRing{
+		mode:     mode,
+		activeID: activeID,
+		keys:     make(map[string]tokenKey),
+	}
+
+	for _, entry := range strings.Split(keys, ",") {
+		id, hex, ok := strings.Cut(strings.TrimSpace(entry), ":")
+		if !ok || id == "" {
+			return nil, domain.ErrTokenKey
+		}
+
+		key, err := parseTokenKey(mode, hex)
+		if err != nil {
+			return nil, domain.ErrTokenKey
+		}
+
+		if _, exists := ring.keys[id]; exists {
+			return nil, domain.ErrTokenKey
+		}
+
+		ring.ids = append(ring.ids, id)
+		ring.keys[id] = key
+
+		if ring.activeID == "" {
+			ring.activeID = id
+		}
+	}
+
+	if _, ok := ring.keys[ring.activeID]; !ok {
+		return nil, domain.ErrTokenKey
+	}
+
+	return ring, nil
+}
+
+// newEphemeralKeyRing creates a key ring with a single random key, which does not survive a restart
+func newEphemeralKeyRing(mode Mode) *keyRing {
+	id := newKeyID()
+
+	return &keyRing{
+		mode:     mode,
+		activeID: id,
+		ids:      []string{id},
+		keys: map[string]tokenKey{
+			id: newTokenKey(mode),
+		},
+	}
+}
+
+// active returns the id and the key that seal new tokens
+func (kr *keyRing) active() (string, tokenKey) {
+	return kr.activeID, kr.keys[kr.activeID]
+}
+
+// get returns the key with the id
+func (kr *keyRing) get(id string) (tokenKey, bool) {
+	key, ok := kr.keys[id]
+	return key, ok
+}
+
+// publicKeys returns the public keys of the key ring in the configured order, which is empty in local mode
+func (kr *keyRing) publicKeys() []domain.TokenKey {
+	var tokenKeys []domain.TokenKey
+
+	for _, id := range kr.ids {
+		key, ok := kr.keys[id].public()
+		if !ok {
+			continue
+		}
+
+		tokenKeys = append(tokenKeys, domain.TokenKey{
+			ID:        id,
+			Version:   "v4",
+			Purpose:   string(PublicMode),
+			PublicKey: key.ExportHex(),
+			PASERK:    "k4.public." + base64.RawURLEncoding.EncodeToString(key.ExportBytes()),
+			Active:    id == kr.activeID,
+		})
+	}
+
+	return tokenKeys
+}
+
+// newKeyID creates a key id from the current time and a random suffix, so keys generated within the same second differ
+func newKeyID() string {
+	return fmt.Sprintf("%s-%s", time.Now().UTC().Format(keyIDFormat), uuid.NewString()[:8])
+}
+
+// GenerateKey creates a new key ring entry for the mode
+func GenerateKey(mode Mode) string {
+	key := newTokenKey(mode)
+
+	return fmt.Sprintf("%s:%s", newKeyID(), key.export())
+}
+
+// RotateKeys puts a new key in front of the key ring and drops the oldest keys beyond keep, returning the new key ring,
+// the id of the key that stays active and the id of the new key. The new key only verifies tokens until it is activated
+// with ActivateKey, so that every instance can learn it before it seals tokens. The dropped keys stop verifying tokens,
+// so keep should cover every key that sealed a token which has not expired yet
+func RotateKeys(mode string, keys, keyID string, keep int) (string, string, string, error) {
+	tokenMode, err := parseMode(mode)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	if keep < 1 {
+		return "", "", "", domain.ErrTokenKey
+	}
+
+	entry := GenerateKey(tokenMode)
+	newID, _, _ := strings.Cut(entry, ":")
+
+	if strings.TrimSpace(keys) == "" {
+		return entry, newID, newID, nil
+	}
+
+	ring, err := parseKeyRing(tokenMode, keys, keyID)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	entries := []string{entry}
+	for _, entry := range strings.Split(keys, ",") {
+		entries = append(entries, strings.TrimSpace(entry))
+	}
+
+	if len(entries) > keep {
+		entries = entries[:keep]
+	}
+
+	activeID, _ := ring.active()
+	if !slices.ContainsFunc(entries, func(entry string) bool { return strings.HasPrefix(entry, activeID+":") }) {
+		return "", "", "", domain.ErrTokenKey
+	}
+
+	return strings.Join(entries, ","), activeID, newID, nil
+}
+
+// ActivateKey returns the id of the key of the key ring that seals new tokens, defaulting to the newest key.
+// It should only be activated once every instance verifies tokens with a key ring that has it
+func ActivateKey(mode string, keys, keyID string) (string, error) {
+	tokenMode, err := parseMode(mode)
+	if err != nil {
+		return "", err
+	}
+
+	ring, err := parseKeyRing(tokenMode, keys, keyID)
+	if err != nil {
+		return "", err
+	}
+
+	activeID, _ := ring.active()
+
+	return activeID, nil
+}
@@ -1,81 +1,121 @@
 package paseto
 
 import (
+	"encoding/json"
+	"log/slog"
 	"time"
 
 	"aidanwoods.dev/go-paseto"
 	"github.com/nikhil-shrestha/go-pos/internal/adapter/config"
 	"github.com/nikhil-shrestha/go-pos/internal/core/domain"
-	"github.com/nikhil-shrestha/go-pos/internal/core/port"
 	"github.com/google/uuid"
 )
 
 /**
- * PasetoToken implements port.TokenService interface
- * and provides an access to the paseto library
+ * PasetoToken implements port.TokenService and port.TokenKeyService
+ * interfaces and provides an access to the paseto library
  */
 type PasetoToken struct {
-	token    *paseto.Token
-	key      *paseto.V4SymmetricKey
+	keys     *keyRing
 	parser   *paseto.Parser
 	duration time.Duration
 }
 
-// New creates a new paseto instance
-func New(config *config.Token) (port.TokenService, error) {
+// New creates a new paseto instance with the mode and the key ring of the configuration
+func New(config *config.Token) (*PasetoToken, error) {
 	durationStr := config.Duration
 	duration, err := time.ParseDuration(durationStr)
 	if err != nil {
 		return nil, domain.ErrTokenDuration
 	}
 
-	token := paseto.NewToken()
-	key := paseto.NewV4SymmetricKey()
+	mode, err := parseMode(config.Mode)
+	if err != nil {
+		return nil, err
+	}
+
+	var keys *keyRing
+	if config.Keys == "" {
+		slog.Warn("TOKEN_KEYS is not set, using a random key that invalidates every token on restart", "mode", mode)
+		keys = newEphemeralKeyRing(mode)
+	} else {
+		keys, err = parseKeyRing(mode, config.Keys, config.KeyID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	parser := paseto.NewParser()
 
 	return &PasetoToken{
-		&token,
-		&key,
+		keys,
 		&parser,
 		duration,
 	}, nil
 }
 
-// CreateToken creates a new paseto token
-func (pt *PasetoToken) CreateToken(user *domain.User) (string, error) {
+// CreateToken creates a new paseto token, encrypted in local mode and signed in public mode
+func (pt *PasetoToken) CreateToken(user *domain.User) (string, *domain.TokenPayload, error) {
 	id, err := uuid.NewRandom()
 	if err != nil {
-		return "", domain.ErrTokenCreation
+		return "", nil, domain.ErrTokenCreation
 	}
 
+	issuedAt := time.Now()
+	expiredAt := issuedAt.Add(pt.duration)
+
 	payload := &domain.TokenPayload{
-		ID:     id,
-		UserID: user.ID,
-		Role:   user.Role,
+		ID:          id,
+		UserID:      user.ID,
+		Role:        user.Role,
+		Permissions: user.Permissions,
+		TerminalID:  user.TerminalID,
+		IssuedAt:    issuedAt,
+		ExpiresAt:   expiredAt,
 	}
 
-	err = pt.token.Set("payload", payload)
+	token := paseto.NewToken()
+
+	err = token.Set("payload", payload)
 	if err != nil {
-		return "", domain.ErrTokenCreation
+		return "", nil, domain.ErrTokenCreation
 	}
 
-	issuedAt := time.Now()
-	expiredAt := issuedAt.Add(pt.duration)
+	token.SetIssuedAt(issuedAt)
+	token.SetNotBefore(issuedAt)
+	token.SetExpiration(expiredAt)
 
-	pt.token.SetIssuedAt(issuedAt)
-	pt.token.SetNotBefore(issuedAt)
-	pt.token.SetExpiration(expiredAt)
+	keyID, key := pt.keys.active()
 
-	token := pt.token.V4Encrypt(*pt.key, nil)
+	footer, err := json.Marshal(keyFooter{keyID})
+	if err != nil {
+		return "", nil, domain.ErrTokenCreation
+	}
 
-	return token, nil
+	return key.seal(&token, footer), payload, nil
 }
 
 // VerifyToken verifies the paseto token
 func (pt *PasetoToken) VerifyToken(token string) (*domain.TokenPayload, error) {
 	var payload *domain.TokenPayload
+	var footer keyFooter
+
+	rawFooter, err := pt.parser.UnsafeParseFooter(pt.keys.mode.protocol(), token)
+	if err != nil {
+		return nil, domain.ErrInvalidToken
+	}
+
+	err = json.Unmarshal(rawFooter, &footer)
+	if err != nil {
+		return nil, domain.ErrInvalidToken
+	}
 
-	parsedToken, err := pt.parser.ParseV4Local(*pt.key, token, nil)
+	key, ok := pt.keys.get(footer.KeyID)
+	if !ok {
+		return nil, domain.ErrInvalidToken
+	}
+
+	parsedToken, err := key.open(pt.parser, token)
 	if err != nil {
 		if err.Error() == "this token has expired" {
 			return nil, domain.ErrExpiredToken
@@ -90,3 +130,8 @@ func (pt *PasetoToken) VerifyToken(token string) (*domain.TokenPayload, error) {
 
 	return payload, nil
 }
+
+// ListPublicKeys returns the public keys that verify the tokens signed in public mode
+func (pt *PasetoToken) ListPublicKeys() []domain.TokenKey {
+	return pt.keys.publicKeys()
+}
@@ -0,0 +1,168 @@
+package paseto
+
+import (
+	"testing"
+
+	"aidanwoods.dev/go-paseto"
+	"github.com/nikhil-shrestha/go-pos/internal/adapter/config"
+	"github.com/nikhil-shrestha/go-pos/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPasetoToken_RotateKeys(t *testing.T) {
+	t.Parallel()
+
+	user := &domain.User{ID: 1, Role: domain.Admin}
+
+	for _, mode := range []Mode{LocalMode, PublicMode} {
+		mode := mode
+
+		t.Run(string(mode), func(t *testing.T) {
+			t.Parallel()
+
+			oldKeys, oldKeyID, _, err := RotateKeys(string(mode), "", "", 2)
+			assert.NoError(t, err)
+
+			oldToken, err := New(&config.Token{Duration: "15m", Mode: string(mode), Keys: oldKeys, KeyID: oldKeyID})
+			assert.NoError(t, err)
+
+			token, createdPayload, err := oldToken.CreateToken(user)
+			assert.NoError(t, err)
+
+			rotatedKeys, activeKeyID, newKeyID, err := RotateKeys(string(mode), oldKeys, oldKeyID, 2)
+			assert.NoError(t, err)
+			assert.Equal(t, oldKeyID, activeKeyID, "rotating must not activate the new key")
+			assert.NotEqual(t, oldKeyID, newKeyID)
+
+			rotatedToken, err := New(&config.Token{Duration: "15m", Mode: string(mode), Keys: rotatedKeys, KeyID: activeKeyID})
+			assert.NoError(t, err)
+
+			rotatedTokenString, _, err := rotatedToken.CreateToken(user)
+			assert.NoError(t, err)
+
+			_, err = oldToken.VerifyToken(rotatedTokenString)
+			assert.NoError(t, err, "instances without the new key must verify the tokens of instances with it")
+
+			newKeyID, err = ActivateKey(string(mode), rotatedKeys, newKeyID)
+			assert.NoError(t, err)
+
+			newToken, err := New(&config.Token{Duration: "15m", Mode: string(mode), Keys: rotatedKeys, KeyID: newKeyID})
+			assert.NoError(t, err)
+
+			payload, err := newToken.VerifyToken(token)
+			assert.NoError(t, err, "tokens of the previous key must keep verifying")
+			assert.Equal(t, createdPayload.ID, payload.ID)
+			assert.Equal(t, user.ID, payload.UserID)
+
+			_, _, _, err = RotateKeys(string(mode), rotatedKeys, newKeyID, 1)
+			assert.Equal(t, domain.ErrTokenKey, err, "the active key must not be dropped")
+
+			droppedKeys, droppedKeyID, _, err := RotateKeys(string(mode), rotatedKeys, newKeyID, 2)
+			assert.NoError(t, err)
+			assert.Equal(t, newKeyID, droppedKeyID)
+
+			droppedToken, err := New(&config.Token{Duration: "15m", Mode: string(mode), Keys: droppedKeys, KeyID: droppedKeyID})
+			assert.NoError(t, err)
+
+			_, err = droppedToken.VerifyToken(token)
+			assert.Equal(t, domain.ErrInvalidToken, err, "tokens of a dropped key must not verify")
+		})
+	}
+}
+
+func TestActivateKey(t *testing.T) {
+	t.Parallel()
+
+	oldKeys, oldKeyID, _, err := RotateKeys(string(LocalMode), "", "", 2)
+	assert.NoError(t, err)
+
+	keys, _, newKeyID, err := RotateKeys(string(LocalMode), oldKeys, oldKeyID, 2)
+	assert.NoError(t, err)
+
+	keyID, err := ActivateKey(string(LocalMode), keys, "")
+	assert.NoError(t, err)
+	assert.Equal(t, newKeyID, keyID, "the newest key must be activated by default")
+
+	keyID, err = ActivateKey(string(LocalMode), keys, oldKeyID)
+	assert.NoError(t, err)
+	assert.Equal(t, oldKeyID, keyID)
+
+	_, err = ActivateKey(string(LocalMode), keys, "unknown")
+	assert.Equal(t, domain.ErrTokenKey, err)
+}
+
+func TestPasetoToken_ListPublicKeys(t *testing.T) {
+	t.Parallel()
+
+	localKeys, _, _, err := RotateKeys(string(LocalMode), "", "", 1)
+	assert.NoError(t, err)
+
+	localToken, err := New(&config.Token{Duration: "15m", Keys: localKeys})
+	assert.NoError(t, err)
+	assert.Empty(t, localToken.ListPublicKeys(), "shared secrets must not be published")
+
+	oldKeys, oldKeyID, _, err := RotateKeys(string(PublicMode), "", "", 2)
+	assert.NoError(t, err)
+
+	keys, activeKeyID, newKeyID, err := RotateKeys(string(PublicMode), oldKeys, oldKeyID, 2)
+	assert.NoError(t, err)
+
+	// The new key is published before it signs, so third parties can learn it in advance
+	rotatedToken, err := New(&config.Token{Duration: "15m", Mode: string(PublicMode), Keys: keys, KeyID: activeKeyID})
+	assert.NoError(t, err)
+
+	publicKeys := rotatedToken.ListPublicKeys()
+	assert.Len(t, publicKeys, 2)
+	assert.Equal(t, newKeyID, publicKeys[0].ID)
+	assert.False(t, publicKeys[0].Active)
+	assert.True(t, publicKeys[1].Active)
+
+	publicToken, err := New(&config.Token{Duration: "15m", Mode: string(PublicMode), Keys: keys, KeyID: newKeyID})
+	assert.NoError(t, err)
+
+	publicKeys = publicToken.ListPublicKeys()
+	assert.Len(t, publicKeys, 2)
+	assert.True(t, publicKeys[0].Active)
+	assert.False(t, publicKeys[1].Active)
+
+	token, _, err := publicToken.CreateToken(&domain.User{ID: 1, Role: domain.Cashier})
+	assert.NoError(t, err)
+
+	// A third party only holds the published key
+	publicKey, err := paseto.NewV4AsymmetricPublicKeyFromHex(publicKeys[0].PublicKey)
+	assert.NoError(t, err)
+
+	_, err = paseto.NewParser().ParseV4Public(publicKey, token, nil)
+	assert.NoError(t, err, "the published key must verify the token")
+}
+
+func TestNew_InvalidKeys(t *testing.T) {
+	t.Parallel()
+
+	keys, _, _, err := RotateKeys(string(LocalMode), "", "", 1)
+	assert.NoError(t, err)
+
+	testCases := []struct {
+		desc     string
+		input    *config.Token
+		expected error
+	}{
+		{desc: "Malformed", input: &config.Token{Duration: "15m", Keys: "key"}, expected: domain.ErrTokenKey},
+		{desc: "InvalidHex", input: &config.Token{Duration: "15m", Keys: "key:zz"}, expected: domain.ErrTokenKey},
+		{desc: "DuplicateID", input: &config.Token{Duration: "15m", Keys: keys + "," + keys}, expected: domain.ErrTokenKey},
+		{desc: "UnknownActiveID", input: &config.Token{Duration: "15m", Keys: keys, KeyID: "unknown"}, expected: domain.ErrTokenKey},
+		{desc: "SymmetricKeyInPublicMode", input: &config.Token{Duration: "15m", Mode: string(PublicMode), Keys: keys}, expected: domain.ErrTokenKey},
+		{desc: "InvalidMode", input: &config.Token{Duration: "15m", Mode: "secret", Keys: keys}, expected: domain.ErrTokenMode},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := New(tc.input)
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+}
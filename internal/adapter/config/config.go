@@ -6,14 +6,17 @@ import (
 	"github.com/joho/godotenv"
 )
 
-// Container contains environment variables for the application, database, cache, token, and http server
+// Container contains environment variables for the application, database, cache, token, two-factor authentication, http server, file storage, and notifier
 type (
 	Container struct {
-		App   *App
-		Token *Token
-		Redis *Redis
-		DB    *DB
-		HTTP  *HTTP
+		App       *App
+		Token     *Token
+		TwoFactor *TwoFactor
+		Redis     *Redis
+		DB        *DB
+		HTTP      *HTTP
+		Storage   *Storage
+		Notifier  *Notifier
 	}
 	// App contains all the environment variables for the application
 	App struct {
@@ -22,7 +25,16 @@ type (
 	}
 	// Token contains all the environment variables for the token service
 	Token struct {
-		Duration string
+		Duration        string
+		RefreshDuration string
+		Mode            string
+		Keys            string
+		KeyID           string
+	}
+	// TwoFactor contains all the environment variables for two-factor authentication
+	TwoFactor struct {
+		Issuer        string
+		RequiredRoles string
 	}
 	// Redis contains all the environment variables for the cache service
 	Redis struct {
@@ -44,6 +56,29 @@ type (
 		URL            string
 		Port           string
 		AllowedOrigins string
+		TrustedProxies string
+	}
+	// Storage contains all the environment variables for the file storage
+	Storage struct {
+		Driver    string
+		Path      string
+		URL       string
+		Endpoint  string
+		Region    string
+		Bucket    string
+		AccessKey string
+		SecretKey string
+		UseSSL    string
+	}
+	// Notifier contains all the environment variables for the notifier
+	Notifier struct {
+		Driver   string
+		Host     string
+		Port     string
+		Username string
+		Password string
+		From     string
+		ResetURL string
 	}
 )
 
@@ -62,7 +97,16 @@ func New() (*Container, error) {
 	}
 
 	token := &Token{
-		Duration: os.Getenv("TOKEN_DURATION"),
+		Duration:        os.Getenv("TOKEN_DURATION"),
+		RefreshDuration: os.Getenv("TOKEN_REFRESH_DURATION"),
+		Mode:            os.Getenv("TOKEN_MODE"),
+		Keys:            os.Getenv("TOKEN_KEYS"),
+		KeyID:           os.Getenv("TOKEN_KEY_ID"),
+	}
+
+	twoFactor := &TwoFactor{
+		Issuer:        os.Getenv("TWO_FACTOR_ISSUER"),
+		RequiredRoles: os.Getenv("TWO_FACTOR_REQUIRED_ROLES"),
 	}
 
 	redis := &Redis{
@@ -84,13 +128,47 @@ func New() (*Container, error) {
 		URL:            os.Getenv("HTTP_URL"),
 		Port:           os.Getenv("HTTP_PORT"),
 		AllowedOrigins: os.Getenv("HTTP_ALLOWED_ORIGINS"),
+		TrustedProxies: os.Getenv("HTTP_TRUSTED_PROXIES"),
+	}
+
+	storage := &Storage{
+		Driver:    os.Getenv("STORAGE_DRIVER"),
+		Path:      os.Getenv("STORAGE_PATH"),
+		URL:       os.Getenv("STORAGE_URL"),
+		Endpoint:  os.Getenv("STORAGE_ENDPOINT"),
+		Region:    os.Getenv("STORAGE_REGION"),
+		Bucket:    os.Getenv("STORAGE_BUCKET"),
+		AccessKey: os.Getenv("STORAGE_ACCESS_KEY"),
+		SecretKey: os.Getenv("STORAGE_SECRET_KEY"),
+		UseSSL:    os.Getenv("STORAGE_USE_SSL"),
+	}
+
+	// Uploads of the local file storage are kept apart from the working directory, which holds the .env file
+	if storage.Path == "" {
+		storage.Path = "./uploads"
+	}
+	if storage.URL == "" {
+		storage.URL = "/uploads"
+	}
+
+	notifier := &Notifier{
+		Driver:   os.Getenv("NOTIFIER_DRIVER"),
+		Host:     os.Getenv("NOTIFIER_HOST"),
+		Port:     os.Getenv("NOTIFIER_PORT"),
+		Username: os.Getenv("NOTIFIER_USERNAME"),
+		Password: os.Getenv("NOTIFIER_PASSWORD"),
+		From:     os.Getenv("NOTIFIER_FROM"),
+		ResetURL: os.Getenv("NOTIFIER_RESET_URL"),
 	}
 
 	return &Container{
 		app,
 		token,
+		twoFactor,
 		redis,
 		db,
 		http,
+		storage,
+		notifier,
 	}, nil
 }
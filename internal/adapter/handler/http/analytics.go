@@ -0,0 +1,404 @@
+package http
+
+import (
+	"context"
+	"time"
+
+	"github.com/nikhil-shrestha/go-pos/internal/core/domain"
+	"github.com/nikhil-shrestha/go-pos/internal/core/port"
+	"github.com/gin-gonic/gin"
+)
+
+// reportDateLayout is the accepted date format of the report query parameters
+const reportDateLayout = "2006-01-02"
+
+// AnalyticsHandler represents the HTTP handler for sales report requests
+type AnalyticsHandler struct {
+	svc port.AnalyticsService
+}
+
+// NewAnalyticsHandler creates a new AnalyticsHandler instance
+func NewAnalyticsHandler(svc port.AnalyticsService) *AnalyticsHandler {
+	return &AnalyticsHandler{
+		svc,
+	}
+}
+
+// reportRequest represents the query parameters shared by every sales report
+type reportRequest struct {
+	StartDate string `form:"start_date" binding:"required,datetime=2006-01-02" example:"2024-01-01"`
+	EndDate   string `form:"end_date" binding:"required,datetime=2006-01-02" example:"2024-01-31"`
+	TimeZone  string `form:"tz" binding:"omitempty,timezone" example:"Asia/Kathmandu"`
+}
+
+// toFilter converts the request into a report filter, treating the end date as inclusive
+func (req reportRequest) toFilter() (*domain.ReportFilter, error) {
+	timeZone := req.TimeZone
+	if timeZone == "" {
+		timeZone = time.UTC.String()
+	}
+
+	location, err := time.LoadLocation(timeZone)
+	if err != nil {
+		return nil, domain.ErrInvalidTimeZone
+	}
+
+	startDate, err := time.ParseInLocation(reportDateLayout, req.StartDate, location)
+	if err != nil {
+		return nil, err
+	}
+
+	endDate, err := time.ParseInLocation(reportDateLayout, req.EndDate, location)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.ReportFilter{
+		StartDate: startDate,
+		EndDate:   endDate.AddDate(0, 0, 1),
+		TimeZone:  timeZone,
+	}, nil
+}
+
+// GetSalesSummary godoc
+//
+//	@Summary		Get sales summary
+//	@Description	Get the total orders, items, revenue, average order value, and average basket size within a date range
+//	@Tags			Analytics
+//	@Accept			json
+//	@Produce		json
+//	@Param			start_date	query		string					true	"Start date (YYYY-MM-DD)"
+//	@Param			end_date	query		string					true	"End date (YYYY-MM-DD), inclusive"
+//	@Param			tz			query		string					false	"IANA time zone, defaults to UTC"
+//	@Success		200			{object}	salesSummaryResponse	"Sales summary displayed"
+//	@Failure		400			{object}	errorResponse			"Validation error"
+//	@Failure		401			{object}	errorResponse			"Unauthorized error"
+//	@Failure		403			{object}	errorResponse			"Forbidden error"
+//	@Failure		500			{object}	errorResponse			"Internal server error"
+//	@Router			/analytics/summary [get]
+//	@Security		BearerAuth
+func (ah *AnalyticsHandler) GetSalesSummary(ctx *gin.Context) {
+	var req reportRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		validationError(ctx, err)
+		return
+	}
+
+	filter, err := req.toFilter()
+	if err != nil {
+		validationError(ctx, err)
+		return
+	}
+
+	summary, err := ah.svc.GetSalesSummary(ctx, filter)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	rsp := newSalesSummaryResponse(summary)
+
+	handleSuccess(ctx, rsp)
+}
+
+// listSalesByPeriodRequest represents the query parameters for listing sales by period
+type listSalesByPeriodRequest struct {
+	reportRequest
+	Interval domain.ReportInterval `form:"interval" binding:"omitempty,report_interval" example:"day"`
+}
+
+// ListSalesByPeriod godoc
+//
+//	@Summary		List sales by period
+//	@Description	List the orders, items, and revenue grouped by hour or day in the requested time zone
+//	@Tags			Analytics
+//	@Accept			json
+//	@Produce		json
+//	@Param			start_date	query		string					true	"Start date (YYYY-MM-DD)"
+//	@Param			end_date	query		string					true	"End date (YYYY-MM-DD), inclusive"
+//	@Param			tz			query		string					false	"IANA time zone, defaults to UTC"
+//	@Param			interval	query		string					false	"Interval (hour or day), defaults to day"
+//	@Success		200			{array}		salesPeriodResponse		"Sales by period displayed"
+//	@Failure		400			{object}	errorResponse			"Validation error"
+//	@Failure		401			{object}	errorResponse			"Unauthorized error"
+//	@Failure		403			{object}	errorResponse			"Forbidden error"
+//	@Failure		500			{object}	errorResponse			"Internal server error"
+//	@Router			/analytics/sales [get]
+//	@Security		BearerAuth
+func (ah *AnalyticsHandler) ListSalesByPeriod(ctx *gin.Context) {
+	var req listSalesByPeriodRequest
+	var periodsList []salesPeriodResponse
+
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		validationError(ctx, err)
+		return
+	}
+
+	filter, err := req.toFilter()
+	if err != nil {
+		validationError(ctx, err)
+		return
+	}
+
+	filter.Interval = req.Interval
+
+	periods, err := ah.svc.ListSalesByPeriod(ctx, filter)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	for _, period := range periods {
+		periodsList = append(periodsList, newSalesPeriodResponse(&period))
+	}
+
+	handleSuccess(ctx, periodsList)
+}
+
+// listSalesGroupRequest represents the query parameters for listing sales by category, product, cashier, or payment
+type listSalesGroupRequest struct {
+	reportRequest
+	Limit uint64 `form:"limit" binding:"omitempty,min=1" example:"10"`
+}
+
+// ListSalesByCategory godoc
+//
+//	@Summary		List sales by category
+//	@Description	List the orders, items, and revenue grouped by category name, highest revenue first. Categories are keyed by the name their products were sold under, so the groups have no id
+//	@Tags			Analytics
+//	@Accept			json
+//	@Produce		json
+//	@Param			start_date	query		string				true	"Start date (YYYY-MM-DD)"
+//	@Param			end_date	query		string				true	"End date (YYYY-MM-DD), inclusive"
+//	@Param			tz			query		string				false	"IANA time zone, defaults to UTC"
+//	@Param			limit		query		uint64				false	"Top N categories"
+//	@Success		200			{array}		salesGroupResponse	"Sales by category displayed"
+//	@Failure		400			{object}	errorResponse		"Validation error"
+//	@Failure		401			{object}	errorResponse		"Unauthorized error"
+//	@Failure		403			{object}	errorResponse		"Forbidden error"
+//	@Failure		500			{object}	errorResponse		"Internal server error"
+//	@Router			/analytics/categories [get]
+//	@Security		BearerAuth
+func (ah *AnalyticsHandler) ListSalesByCategory(ctx *gin.Context) {
+	ah.listSalesGroups(ctx, ah.svc.ListSalesByCategory)
+}
+
+// ListSalesByProduct godoc
+//
+//	@Summary		List best selling products
+//	@Description	List the orders, items, and revenue grouped by product, highest quantity sold first
+//	@Tags			Analytics
+//	@Accept			json
+//	@Produce		json
+//	@Param			start_date	query		string				true	"Start date (YYYY-MM-DD)"
+//	@Param			end_date	query		string				true	"End date (YYYY-MM-DD), inclusive"
+//	@Param			tz			query		string				false	"IANA time zone, defaults to UTC"
+//	@Param			limit		query		uint64				false	"Top N products"
+//	@Success		200			{array}		salesGroupResponse	"Sales by product displayed"
+//	@Failure		400			{object}	errorResponse		"Validation error"
+//	@Failure		401			{object}	errorResponse		"Unauthorized error"
+//	@Failure		403			{object}	errorResponse		"Forbidden error"
+//	@Failure		500			{object}	errorResponse		"Internal server error"
+//	@Router			/analytics/products [get]
+//	@Security		BearerAuth
+func (ah *AnalyticsHandler) ListSalesByProduct(ctx *gin.Context) {
+	ah.listSalesGroups(ctx, ah.svc.ListSalesByProduct)
+}
+
+// ListSalesByCashier godoc
+//
+//	@Summary		List sales by cashier
+//	@Description	List the orders, items, and revenue grouped by the user who created the orders, highest revenue first
+//	@Tags			Analytics
+//	@Accept			json
+//	@Produce		json
+//	@Param			start_date	query		string				true	"Start date (YYYY-MM-DD)"
+//	@Param			end_date	query		string				true	"End date (YYYY-MM-DD), inclusive"
+//	@Param			tz			query		string				false	"IANA time zone, defaults to UTC"
+//	@Param			limit		query		uint64				false	"Top N cashiers"
+//	@Success		200			{array}		salesGroupResponse	"Sales by cashier displayed"
+//	@Failure		400			{object}	errorResponse		"Validation error"
+//	@Failure		401			{object}	errorResponse		"Unauthorized error"
+//	@Failure		403			{object}	errorResponse		"Forbidden error"
+//	@Failure		500			{object}	errorResponse		"Internal server error"
+//	@Router			/analytics/cashiers [get]
+//	@Security		BearerAuth
+func (ah *AnalyticsHandler) ListSalesByCashier(ctx *gin.Context) {
+	ah.listSalesGroups(ctx, ah.svc.ListSalesByCashier)
+}
+
+// ListSalesByPayment godoc
+//
+//	@Summary		List sales by payment
+//	@Description	List the orders, items, and revenue grouped by payment, highest revenue first
+//	@Tags			Analytics
+//	@Accept			json
+//	@Produce		json
+//	@Param			start_date	query		string				true	"Start date (YYYY-MM-DD)"
+//	@Param			end_date	query		string				true	"End date (YYYY-MM-DD), inclusive"
+//	@Param			tz			query		string				false	"IANA time zone, defaults to UTC"
+//	@Param			limit		query		uint64				false	"Top N payments"
+//	@Success		200			{array}		salesGroupResponse	"Sales by payment displayed"
+//	@Failure		400			{object}	errorResponse		"Validation error"
+//	@Failure		401			{object}	errorResponse		"Unauthorized error"
+//	@Failure		403			{object}	errorResponse		"Forbidden error"
+//	@Failure		500			{object}	errorResponse		"Internal server error"
+//	@Router			/analytics/payments [get]
+//	@Security		BearerAuth
+func (ah *AnalyticsHandler) ListSalesByPayment(ctx *gin.Context) {
+	ah.listSalesGroups(ctx, ah.svc.ListSalesByPayment)
+}
+
+// listSalesGroups binds a grouped report request and responds with the result of the given service method
+func (ah *AnalyticsHandler) listSalesGroups(
+	ctx *gin.Context,
+	list func(ctx context.Context, filter *domain.ReportFilter) ([]domain.SalesGroup, error),
+) {
+	var req listSalesGroupRequest
+	var groupsList []salesGroupResponse
+
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		validationError(ctx, err)
+		return
+	}
+
+	filter, err := req.toFilter()
+	if err != nil {
+		validationError(ctx, err)
+		return
+	}
+
+	filter.Limit = req.Limit
+
+	groups, err := list(ctx, filter)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	for _, group := range groups {
+		groupsList = append(groupsList, newSalesGroupResponse(&group))
+	}
+
+	handleSuccess(ctx, groupsList)
+}
+
+// exportReportRequest represents the query parameters for exporting a sales report
+type exportReportRequest struct {
+	reportRequest
+	Report   string                `form:"report" binding:"required,oneof=summary sales categories products cashiers payments" example:"sales"`
+	Interval domain.ReportInterval `form:"interval" binding:"omitempty,report_interval" example:"day"`
+	Limit    uint64                `form:"limit" binding:"omitempty,min=1" example:"10"`
+	Format   exportFormat          `form:"format" binding:"required,oneof=csv xlsx" example:"csv"`
+}
+
+// ExportReport godoc
+//
+//	@Summary		Export a sales report
+//	@Description	Export the summary, sales by period, category, product, cashier, or payment report as a CSV or XLSX file
+//	@Tags			Analytics
+//	@Produce		text/csv
+//	@Produce		application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+//	@Param			report		query		string			true	"Report (summary, sales, categories, products, cashiers, or payments)"
+//	@Param			start_date	query		string			true	"Start date (YYYY-MM-DD)"
+//	@Param			end_date	query		string			true	"End date (YYYY-MM-DD), inclusive"
+//	@Param			tz			query		string			false	"IANA time zone, defaults to UTC"
+//	@Param			interval	query		string			false	"Interval of the sales report (hour or day), defaults to day"
+//	@Param			limit		query		uint64			false	"Top N rows of a grouped report"
+//	@Param			format		query		string			true	"File format (csv or xlsx)"
+//	@Success		200			{file}		file			"Report exported"
+//	@Failure		400			{object}	errorResponse	"Validation error"
+//	@Failure		401			{object}	errorResponse	"Unauthorized error"
+//	@Failure		403			{object}	errorResponse	"Forbidden error"
+//	@Failure		500			{object}	errorResponse	"Internal server error"
+//	@Router			/analytics/export [get]
+//	@Security		BearerAuth
+func (ah *AnalyticsHandler) ExportReport(ctx *gin.Context) {
+	var req exportReportRequest
+	var header []string
+	var rows [][]any
+
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		validationError(ctx, err)
+		return
+	}
+
+	filter, err := req.toFilter()
+	if err != nil {
+		validationError(ctx, err)
+		return
+	}
+
+	filter.Interval = req.Interval
+	filter.Limit = req.Limit
+
+	switch req.Report {
+	case "summary":
+		summary, err := ah.svc.GetSalesSummary(ctx, filter)
+		if err != nil {
+			handleError(ctx, err)
+			return
+		}
+
+		header = salesSummaryExportHeader
+		rows = append(rows, newSalesSummaryExportRow(summary))
+	case "sales":
+		periods, err := ah.svc.ListSalesByPeriod(ctx, filter)
+		if err != nil {
+			handleError(ctx, err)
+			return
+		}
+
+		header = salesPeriodExportHeader
+		for _, period := range periods {
+			rows = append(rows, newSalesPeriodExportRow(&period))
+		}
+	case "categories":
+		groups, err := ah.svc.ListSalesByCategory(ctx, filter)
+		if err != nil {
+			handleError(ctx, err)
+			return
+		}
+
+		header = salesCategoryExportHeader
+		for _, group := range groups {
+			rows = append(rows, newSalesCategoryExportRow(&group))
+		}
+	default:
+		list := map[string]func(ctx context.Context, filter *domain.ReportFilter) ([]domain.SalesGroup, error){
+			"products": ah.svc.ListSalesByProduct,
+			"cashiers": ah.svc.ListSalesByCashier,
+			"payments": ah.svc.ListSalesByPayment,
+		}[req.Report]
+
+		groups, err := list(ctx, filter)
+		if err != nil {
+			handleError(ctx, err)
+			return
+		}
+
+		header = salesGroupExportHeader
+		for _, group := range groups {
+			rows = append(rows, newSalesGroupExportRow(&group))
+		}
+	}
+
+	writer, err := newExportWriter(ctx, req.Format, req.Report+"-report", header)
+	if err != nil {
+		handleExportError(ctx, domain.ErrInternal)
+		return
+	}
+
+	for _, row := range rows {
+		err = writer.Write(row)
+		if err != nil {
+			handleExportError(ctx, domain.ErrInternal)
+			return
+		}
+	}
+
+	err = writer.Close()
+	if err != nil {
+		handleExportError(ctx, domain.ErrInternal)
+	}
+}
@@ -0,0 +1,165 @@
+package http
+
+import (
+	"time"
+
+	"github.com/nikhil-shrestha/go-pos/internal/core/domain"
+	"github.com/nikhil-shrestha/go-pos/internal/core/port"
+	"github.com/gin-gonic/gin"
+)
+
+// APIKeyHandler represents the HTTP handler for API key-related requests
+type APIKeyHandler struct {
+	svc port.APIKeyService
+}
+
+// NewAPIKeyHandler creates a new APIKeyHandler instance
+func NewAPIKeyHandler(svc port.APIKeyService) *APIKeyHandler {
+	return &APIKeyHandler{
+		svc,
+	}
+}
+
+// createAPIKeyRequest represents a request body for creating a new API key
+type createAPIKeyRequest struct {
+	Name        string              `json:"name" binding:"required" example:"Online Store"`
+	Permissions []domain.Permission `json:"permissions" binding:"required,min=1,dive,api_key_permission" example:"products.write,orders.create"`
+	ExpiresAt   *time.Time          `json:"expires_at" binding:"omitempty" example:"2030-01-01T00:00:00Z"`
+}
+
+// CreateAPIKey godoc
+//
+//	@Summary		Create a new API key
+//	@Description	Create an API key that integrations send as "ApiKey <key>" in the Authorization header. It can only be granted permissions of products, categories, orders and reports that the admin has, and the key is only returned once.
+//	@Tags			API Keys
+//	@Accept			json
+//	@Produce		json
+//	@Param			createAPIKeyRequest	body		createAPIKeyRequest	true	"Create API key request"
+//	@Success		200					{object}	apiKeyResponse		"API key created"
+//	@Failure		400					{object}	errorResponse		"Validation error"
+//	@Failure		401					{object}	errorResponse		"Unauthorized error"
+//	@Failure		403					{object}	errorResponse		"Forbidden error"
+//	@Failure		409					{object}	errorResponse		"Data conflict error"
+//	@Failure		500					{object}	errorResponse		"Internal server error"
+//	@Router			/api-keys [post]
+//	@Security		BearerAuth
+func (akh *APIKeyHandler) CreateAPIKey(ctx *gin.Context) {
+	var req createAPIKeyRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		validationError(ctx, err)
+		return
+	}
+
+	authPayload := getAuthPayload(ctx, authorizationPayloadKey)
+
+	apiKey := domain.APIKey{
+		Name:        req.Name,
+		Permissions: req.Permissions,
+		ExpiresAt:   req.ExpiresAt,
+	}
+
+	_, err := akh.svc.CreateAPIKey(ctx, authPayload, &apiKey)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	rsp := newAPIKeyResponse(&apiKey)
+
+	handleSuccess(ctx, rsp)
+}
+
+// listAPIKeysRequest represents a request body for listing API keys
+type listAPIKeysRequest struct {
+	Skip  uint64           `form:"skip" binding:"required,min=0" example:"0"`
+	Limit uint64           `form:"limit" binding:"required,min=5" example:"5"`
+	Sort  string           `form:"sort" binding:"omitempty,oneof=id name created_at expires_at last_used_at" example:"created_at"`
+	Order domain.SortOrder `form:"order" binding:"omitempty,oneof=asc desc" example:"desc"`
+}
+
+// ListAPIKeys godoc
+//
+//	@Summary		List API keys
+//	@Description	List API keys with their prefix, permissions, expiry and last use, with pagination
+//	@Tags			API Keys
+//	@Accept			json
+//	@Produce		json
+//	@Param			skip	query		uint64			true	"Skip"
+//	@Param			limit	query		uint64			true	"Limit"
+//	@Param			sort	query		string			false	"Sort by (id, name, created_at, expires_at, last_used_at)"
+//	@Param			order	query		string			false	"Sort order (asc, desc)"
+//	@Success		200		{object}	meta			"API keys displayed"
+//	@Failure		400		{object}	errorResponse	"Validation error"
+//	@Failure		401		{object}	errorResponse	"Unauthorized error"
+//	@Failure		403		{object}	errorResponse	"Forbidden error"
+//	@Failure		500		{object}	errorResponse	"Internal server error"
+//	@Router			/api-keys [get]
+//	@Security		BearerAuth
+func (akh *APIKeyHandler) ListAPIKeys(ctx *gin.Context) {
+	var req listAPIKeysRequest
+	var apiKeysList []apiKeyResponse
+
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		validationError(ctx, err)
+		return
+	}
+
+	params := &domain.ListParams{
+		Skip:  req.Skip,
+		Limit: req.Limit,
+		Sort:  req.Sort,
+		Order: req.Order,
+	}
+
+	apiKeys, total, err := akh.svc.ListAPIKeys(ctx, params)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	for _, apiKey := range apiKeys {
+		apiKeysList = append(apiKeysList, newAPIKeyResponse(&apiKey))
+	}
+
+	meta := newMeta(total, req.Limit, req.Skip)
+	rsp := toMap(meta, apiKeysList, "api_keys")
+
+	handleSuccess(ctx, rsp)
+}
+
+// revokeAPIKeyRequest represents a request body for revoking an API key
+type revokeAPIKeyRequest struct {
+	ID uint64 `uri:"id" binding:"required,min=1" example:"1"`
+}
+
+// RevokeAPIKey godoc
+//
+//	@Summary		Revoke an API key
+//	@Description	Delete an API key by id, after which requests made with it are rejected
+//	@Tags			API Keys
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		uint64			true	"API key ID"
+//	@Success		200	{object}	response		"API key revoked"
+//	@Failure		400	{object}	errorResponse	"Validation error"
+//	@Failure		401	{object}	errorResponse	"Unauthorized error"
+//	@Failure		403	{object}	errorResponse	"Forbidden error"
+//	@Failure		404	{object}	errorResponse	"Data not found error"
+//	@Failure		500	{object}	errorResponse	"Internal server error"
+//	@Router			/api-keys/{id} [delete]
+//	@Security		BearerAuth
+func (akh *APIKeyHandler) RevokeAPIKey(ctx *gin.Context) {
+	var req revokeAPIKeyRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		validationError(ctx, err)
+		return
+	}
+
+	err := akh.svc.RevokeAPIKey(ctx, req.ID)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	handleSuccess(ctx, nil)
+}
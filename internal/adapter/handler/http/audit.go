@@ -0,0 +1,156 @@
+package http
+
+import (
+	"time"
+
+	"github.com/nikhil-shrestha/go-pos/internal/core/domain"
+	"github.com/nikhil-shrestha/go-pos/internal/core/port"
+	"github.com/gin-gonic/gin"
+)
+
+// AuditHandler represents the HTTP handler for audit log-related requests
+type AuditHandler struct {
+	svc port.AuditService
+}
+
+// NewAuditHandler creates a new AuditHandler instance
+func NewAuditHandler(svc port.AuditService) *AuditHandler {
+	return &AuditHandler{
+		svc,
+	}
+}
+
+// listAuditLogsRequest represents a request body for listing audit logs
+type listAuditLogsRequest struct {
+	Skip      uint64           `form:"skip" binding:"required,min=0" example:"0"`
+	Limit     uint64           `form:"limit" binding:"required,min=5" example:"5"`
+	Sort      string           `form:"sort" binding:"omitempty,oneof=id action entity created_at" example:"created_at"`
+	Order     domain.SortOrder `form:"order,default=desc" binding:"omitempty,oneof=asc desc" example:"desc"`
+	UserID    uint64           `form:"user_id" binding:"omitempty,min=1" example:"1"`
+	Action    string           `form:"action" binding:"omitempty" example:"product.update"`
+	Entity    string           `form:"entity" binding:"omitempty" example:"product"`
+	EntityID  uint64           `form:"entity_id" binding:"omitempty,min=1" example:"1"`
+	RequestID string           `form:"request_id" binding:"omitempty" example:"b4c1f4f2-4b8a-4a0e-9a4f-3d2f0c6f1e2a"`
+	StartDate string           `form:"start_date" binding:"omitempty,datetime=2006-01-02" example:"2024-01-01"`
+	EndDate   string           `form:"end_date" binding:"omitempty,datetime=2006-01-02" example:"2024-01-31"`
+}
+
+// toFilter converts the request into an audit log filter, treating the dates as UTC and the end date as inclusive
+func (req listAuditLogsRequest) toFilter() (*domain.AuditLogFilter, error) {
+	filter := &domain.AuditLogFilter{
+		UserID:    req.UserID,
+		Action:    req.Action,
+		Entity:    req.Entity,
+		EntityID:  req.EntityID,
+		RequestID: req.RequestID,
+	}
+
+	if req.StartDate != "" {
+		startDate, err := time.Parse(reportDateLayout, req.StartDate)
+		if err != nil {
+			return nil, err
+		}
+
+		filter.StartDate = &startDate
+	}
+
+	if req.EndDate != "" {
+		endDate, err := time.Parse(reportDateLayout, req.EndDate)
+		if err != nil {
+			return nil, err
+		}
+
+		endDate = endDate.AddDate(0, 0, 1)
+		filter.EndDate = &endDate
+	}
+
+	return filter, nil
+}
+
+// ListAuditLogs godoc
+//
+//	@Summary		List audit logs
+//	@Description	List the recorded creates, updates and deletes with their actor, changes, IP and request id, newest first, with filters and pagination
+//	@Tags			Audit Logs
+//	@Accept			json
+//	@Produce		json
+//	@Param			skip		query		uint64			true	"Skip"
+//	@Param			limit		query		uint64			true	"Limit"
+//	@Param			sort		query		string			false	"Sort by (id, action, entity, created_at)"
+//	@Param			order		query		string			false	"Sort order (asc, desc), desc by default"
+//	@Param			user_id		query		uint64			false	"User ID"
+//	@Param			action		query		string			false	"Action"
+//	@Param			entity		query		string			false	"Entity"
+//	@Param			entity_id	query		uint64			false	"Entity ID"
+//	@Param			request_id	query		string			false	"Request ID"
+//	@Param			start_date	query		string			false	"Start date (YYYY-MM-DD)"
+//	@Param			end_date	query		string			false	"End date (YYYY-MM-DD), inclusive"
+//	@Success		200			{object}	meta			"Audit logs displayed"
+//	@Failure		400			{object}	errorResponse	"Validation error"
+//	@Failure		401			{object}	errorResponse	"Unauthorized error"
+//	@Failure		403			{object}	errorResponse	"Forbidden error"
+//	@Failure		500			{object}	errorResponse	"Internal server error"
+//	@Router			/audit-logs [get]
+//	@Security		BearerAuth
+func (ah *AuditHandler) ListAuditLogs(ctx *gin.Context) {
+	var req listAuditLogsRequest
+	var auditLogsList []auditLogResponse
+
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		validationError(ctx, err)
+		return
+	}
+
+	filter, err := req.toFilter()
+	if err != nil {
+		validationError(ctx, err)
+		return
+	}
+
+	params := &domain.ListParams{
+		Skip:  req.Skip,
+		Limit: req.Limit,
+		Sort:  req.Sort,
+		Order: req.Order,
+	}
+
+	auditLogs, total, err := ah.svc.ListAuditLogs(ctx, filter, params)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	for _, auditLog := range auditLogs {
+		auditLogsList = append(auditLogsList, newAuditLogResponse(&auditLog))
+	}
+
+	meta := newMeta(total, req.Limit, req.Skip)
+	rsp := toMap(meta, auditLogsList, "audit_logs")
+
+	handleSuccess(ctx, rsp)
+}
+
+// VerifyAuditLogs godoc
+//
+//	@Summary		Verify the audit trail
+//	@Description	Recompute the hash chain of the audit trail and return the first log that was edited or follows a removed log
+//	@Tags			Audit Logs
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	auditVerificationResponse	"Audit trail verified"
+//	@Failure		401	{object}	errorResponse				"Unauthorized error"
+//	@Failure		403	{object}	errorResponse				"Forbidden error"
+//	@Failure		500	{object}	errorResponse				"Internal server error"
+//	@Router			/audit-logs/verify [get]
+//	@Security		BearerAuth
+func (ah *AuditHandler) VerifyAuditLogs(ctx *gin.Context) {
+	verification, err := ah.svc.VerifyAuditLogs(ctx)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	rsp := newAuditVerificationResponse(verification)
+
+	handleSuccess(ctx, rsp)
+}
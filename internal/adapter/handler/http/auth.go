@@ -1,6 +1,8 @@
 package http
 
 import (
+	"io"
+
 	"github.com/nikhil-shrestha/go-pos/internal/core/port"
 	"github.com/gin-gonic/gin"
 )
@@ -26,7 +28,9 @@ type loginRequest struct {
 // Login godoc
 //
 //	@Summary		Login and get an access token
-//	@Description	Logs in a registered user and returns an access token if the credentials are valid.
+//	@Description	Logs in a registered user and returns an access token and a refresh token if the credentials are valid.
+//	@Description	Too many failed logins lock the account or the client IP for a growing period, given in the Retry-After header.
+//	@Description	A user with two-factor authentication, or whose role requires it, gets a challenge to answer at /users/login/2fa instead of the tokens.
 //	@Tags			Users
 //	@Accept			json
 //	@Produce		json
@@ -34,6 +38,7 @@ type loginRequest struct {
 //	@Success		200		{object}	authResponse	"Succesfully logged in"
 //	@Failure		400		{object}	errorResponse	"Validation error"
 //	@Failure		401		{object}	errorResponse	"Unauthorized error"
+//	@Failure		429		{object}	errorResponse	"Too many failed attempts"
 //	@Failure		500		{object}	errorResponse	"Internal server error"
 //	@Router			/users/login [post]
 func (ah *AuthHandler) Login(ctx *gin.Context) {
@@ -43,7 +48,195 @@ func (ah *AuthHandler) Login(ctx *gin.Context) {
 		return
 	}
 
-	token, err := ah.svc.Login(ctx, req.Email, req.Password)
+	token, err := ah.svc.Login(ctx, req.Email, req.Password, ctx.ClientIP())
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	rsp := newAuthResponse(token)
+
+	handleSuccess(ctx, rsp)
+}
+
+// verifyChallengeRequest represents the request body for answering a login challenge
+type verifyChallengeRequest struct {
+	ChallengeToken string `json:"challenge_token" binding:"required,uuid" example:"4979cf6e-d215-4ff8-9d0d-b3e99bcc7750"`
+	Code           string `json:"code" binding:"required" example:"123456"`
+}
+
+// VerifyChallenge godoc
+//
+//	@Summary		Answer a login challenge
+//	@Description	Completes a login with a TOTP code or a recovery code, which can only be used once.
+//	@Description	For a setup challenge the code comes from the secret given by /users/login/2fa/setup, and the response has the recovery codes.
+//	@Tags			Users
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		verifyChallengeRequest	true	"Login challenge request body"
+//	@Success		200		{object}	authResponse			"Succesfully logged in"
+//	@Failure		400		{object}	errorResponse			"Validation error"
+//	@Failure		401		{object}	errorResponse			"Unauthorized error"
+//	@Failure		409		{object}	errorResponse			"Data conflict error"
+//	@Failure		429		{object}	errorResponse			"Too many failed attempts"
+//	@Failure		500		{object}	errorResponse			"Internal server error"
+//	@Router			/users/login/2fa [post]
+func (ah *AuthHandler) VerifyChallenge(ctx *gin.Context) {
+	var req verifyChallengeRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		validationError(ctx, err)
+		return
+	}
+
+	token, err := ah.svc.VerifyChallenge(ctx, req.ChallengeToken, req.Code)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	rsp := newAuthResponse(token)
+
+	handleSuccess(ctx, rsp)
+}
+
+// refreshRequest represents the request body for refreshing an access token
+type refreshRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required" example:"b4c1f4f2-4b8a-4a0e-9a4f-3d2f0c6f1e2a.5e0a8b1c-2d3e-4f5a-8b7c-9d0e1f2a3b4c"`
+}
+
+// Refresh godoc
+//
+//	@Summary		Refresh an access token
+//	@Description	Exchanges a refresh token for a new access token and a new refresh token. Each refresh token can only be used once, reusing it revokes the session.
+//	@Tags			Users
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		refreshRequest	true	"Refresh request body"
+//	@Success		200		{object}	authResponse	"Succesfully refreshed"
+//	@Failure		400		{object}	errorResponse	"Validation error"
+//	@Failure		401		{object}	errorResponse	"Unauthorized error"
+//	@Failure		500		{object}	errorResponse	"Internal server error"
+//	@Router			/users/refresh [post]
+func (ah *AuthHandler) Refresh(ctx *gin.Context) {
+	var req refreshRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		validationError(ctx, err)
+		return
+	}
+
+	token, err := ah.svc.Refresh(ctx, req.RefreshToken)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	rsp := newAuthResponse(token)
+
+	handleSuccess(ctx, rsp)
+}
+
+// logoutRequest represents the request body for logging out a user
+type logoutRequest struct {
+	RefreshToken string `json:"refresh_token" example:"b4c1f4f2-4b8a-4a0e-9a4f-3d2f0c6f1e2a.5e0a8b1c-2d3e-4f5a-8b7c-9d0e1f2a3b4c"`
+}
+
+// Logout godoc
+//
+//	@Summary		Logout
+//	@Description	Revokes the access token and, if given, the session of the refresh token
+//	@Tags			Users
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		logoutRequest	false	"Logout request body"
+//	@Success		200		{object}	response		"Succesfully logged out"
+//	@Failure		400		{object}	errorResponse	"Validation error"
+//	@Failure		401		{object}	errorResponse	"Unauthorized error"
+//	@Failure		500		{object}	errorResponse	"Internal server error"
+//	@Router			/users/logout [post]
+//	@Security		BearerAuth
+func (ah *AuthHandler) Logout(ctx *gin.Context) {
+	var req logoutRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil && err != io.EOF {
+		validationError(ctx, err)
+		return
+	}
+
+	authPayload := getAuthPayload(ctx, authorizationPayloadKey)
+
+	err := ah.svc.Logout(ctx, authPayload, req.RefreshToken)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	handleSuccess(ctx, nil)
+}
+
+// LogoutAll godoc
+//
+//	@Summary		Logout from all sessions
+//	@Description	Revokes every access token and refresh token issued to the logged in user
+//	@Tags			Users
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	response		"Succesfully logged out from all sessions"
+//	@Failure		401	{object}	errorResponse	"Unauthorized error"
+//	@Failure		500	{object}	errorResponse	"Internal server error"
+//	@Router			/users/logout/all [post]
+//	@Security		BearerAuth
+func (ah *AuthHandler) LogoutAll(ctx *gin.Context) {
+	authPayload := getAuthPayload(ctx, authorizationPayloadKey)
+
+	err := ah.svc.LogoutAll(ctx, authPayload)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	handleSuccess(ctx, nil)
+}
+
+// pinLoginRequest represents the request body for logging in a user with a PIN on a terminal
+type pinLoginRequest struct {
+	TerminalID uint64 `uri:"id" binding:"required,min=1" swaggerignore:"true"`
+	UserID     uint64 `json:"user_id" binding:"required,min=1" example:"1"`
+	Pin        string `json:"pin" binding:"required,numeric,min=4,max=8" example:"1234" minLength:"4" maxLength:"8"`
+}
+
+// PinLogin godoc
+//
+//	@Summary		Login with a PIN on a terminal
+//	@Description	Logs in a user with their PIN on a registered terminal. The tokens are only accepted with the X-Terminal-Key header of the same terminal.
+//	@Description	PIN login is locked for the user for 15 minutes after 5 failed attempts.
+//	@Description	Users whose role requires two-factor authentication must log in with a password.
+//	@Tags			Terminals
+//	@Accept			json
+//	@Produce		json
+//	@Param			id				path		uint64			true	"Terminal ID"
+//	@Param			X-Terminal-Key	header		string			true	"Terminal key"
+//	@Param			request			body		pinLoginRequest	true	"PIN login request body"
+//	@Success		200				{object}	authResponse	"Succesfully logged in"
+//	@Failure		400				{object}	errorResponse	"Validation error"
+//	@Failure		401				{object}	errorResponse	"Unauthorized error"
+//	@Failure		403				{object}	errorResponse	"Forbidden error"
+//	@Failure		429				{object}	errorResponse	"Too many failed attempts"
+//	@Failure		500				{object}	errorResponse	"Internal server error"
+//	@Router			/terminals/{id}/pin-login [post]
+func (ah *AuthHandler) PinLogin(ctx *gin.Context) {
+	var req pinLoginRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		validationError(ctx, err)
+		return
+	}
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		validationError(ctx, err)
+		return
+	}
+
+	terminalKey := ctx.GetHeader(terminalKeyHeaderKey)
+
+	token, err := ah.svc.PinLogin(ctx, req.TerminalID, terminalKey, req.UserID, req.Pin)
 	if err != nil {
 		handleError(ctx, err)
 		return
@@ -53,3 +246,147 @@ func (ah *AuthHandler) Login(ctx *gin.Context) {
 
 	handleSuccess(ctx, rsp)
 }
+
+// unlockUserRequest represents the request body for unlocking a user
+type unlockUserRequest struct {
+	ID uint64 `uri:"id" binding:"required,min=1" example:"1"`
+}
+
+// UnlockUser godoc
+//
+//	@Summary		Unlock a user
+//	@Description	Lifts the login and PIN lockouts of a user after too many failed attempts, before they expire
+//	@Tags			Users
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		uint64			true	"User ID"
+//	@Success		200	{object}	response		"User unlocked"
+//	@Failure		400	{object}	errorResponse	"Validation error"
+//	@Failure		401	{object}	errorResponse	"Unauthorized error"
+//	@Failure		403	{object}	errorResponse	"Forbidden error"
+//	@Failure		404	{object}	errorResponse	"Data not found error"
+//	@Failure		500	{object}	errorResponse	"Internal server error"
+//	@Router			/users/{id}/unlock [post]
+//	@Security		BearerAuth
+func (ah *AuthHandler) UnlockUser(ctx *gin.Context) {
+	var req unlockUserRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		validationError(ctx, err)
+		return
+	}
+
+	err := ah.svc.UnlockUser(ctx, req.ID)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	handleSuccess(ctx, nil)
+}
+
+// changePasswordRequest represents the request body for changing the password of the logged in user
+type changePasswordRequest struct {
+	CurrentPassword string `json:"current_password" binding:"required" example:"12345678"`
+	NewPassword     string `json:"new_password" binding:"required,min=8" example:"87654321" minLength:"8"`
+}
+
+// ChangePassword godoc
+//
+//	@Summary		Change the password
+//	@Description	Changes the password of the logged in user after checking the current one, and logs the user out of every session
+//	@Tags			Users
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		changePasswordRequest	true	"Change password request body"
+//	@Success		200		{object}	response				"Password changed"
+//	@Failure		400		{object}	errorResponse			"Validation error"
+//	@Failure		401		{object}	errorResponse			"Unauthorized error"
+//	@Failure		429		{object}	errorResponse			"Too many failed attempts"
+//	@Failure		500		{object}	errorResponse			"Internal server error"
+//	@Router			/users/me/password [put]
+//	@Security		BearerAuth
+func (ah *AuthHandler) ChangePassword(ctx *gin.Context) {
+	var req changePasswordRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		validationError(ctx, err)
+		return
+	}
+
+	authPayload := getAuthPayload(ctx, authorizationPayloadKey)
+
+	err := ah.svc.ChangePassword(ctx, authPayload.UserID, req.CurrentPassword, req.NewPassword)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	handleSuccess(ctx, nil)
+}
+
+// forgotPasswordRequest represents the request body for asking for a password reset
+type forgotPasswordRequest struct {
+	Email string `json:"email" binding:"required,email" example:"test@example.com"`
+}
+
+// RequestPasswordReset godoc
+//
+//	@Summary		Ask for a password reset
+//	@Description	Sends a single-use token that resets the password to the user with the email. The response is the same whether or not there is such a user.
+//	@Tags			Users
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		forgotPasswordRequest	true	"Forgot password request body"
+//	@Success		200		{object}	response				"Password reset requested"
+//	@Failure		400		{object}	errorResponse			"Validation error"
+//	@Failure		500		{object}	errorResponse			"Internal server error"
+//	@Router			/users/password/forgot [post]
+func (ah *AuthHandler) RequestPasswordReset(ctx *gin.Context) {
+	var req forgotPasswordRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		validationError(ctx, err)
+		return
+	}
+
+	err := ah.svc.RequestPasswordReset(ctx, req.Email)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	handleSuccess(ctx, nil)
+}
+
+// resetPasswordRequest represents the request body for resetting a password
+type resetPasswordRequest struct {
+	Token    string `json:"token" binding:"required" example:"1.9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"`
+	Password string `json:"password" binding:"required,min=8" example:"87654321" minLength:"8"`
+}
+
+// ResetPassword godoc
+//
+//	@Summary		Reset the password
+//	@Description	Sets a new password with a password reset token, which expires after an hour and can only be used once, and logs the user out of every session
+//	@Tags			Users
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		resetPasswordRequest	true	"Reset password request body"
+//	@Success		200		{object}	response				"Password reset"
+//	@Failure		400		{object}	errorResponse			"Validation error"
+//	@Failure		401		{object}	errorResponse			"Unauthorized error"
+//	@Failure		500		{object}	errorResponse			"Internal server error"
+//	@Router			/users/password/reset [post]
+func (ah *AuthHandler) ResetPassword(ctx *gin.Context) {
+	var req resetPasswordRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		validationError(ctx, err)
+		return
+	}
+
+	err := ah.svc.ResetPassword(ctx, req.Token, req.Password)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	handleSuccess(ctx, nil)
+}
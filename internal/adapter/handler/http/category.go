@@ -20,18 +20,20 @@ func NewCategoryHandler(svc port.CategoryService) *CategoryHandler {
 
 // createCategoryRequest represents a request body for creating a new category
 type createCategoryRequest struct {
-	Name string `json:"name" binding:"required" example:"Foods"`
+	ParentID uint64 `json:"parent_id" binding:"omitempty,min=1" example:"1"`
+	Name     string `json:"name" binding:"required" example:"Foods"`
 }
 
 // CreateCategory godoc
 //
 //	@Summary		Create a new category
-//	@Description	create a new category with name
+//	@Description	create a new category with name, optionally nested under a parent category
 //	@Tags			Categories
 //	@Accept			json
 //	@Produce		json
 //	@Param			createCategoryRequest	body		createCategoryRequest	true	"Create category request"
 //	@Success		200						{object}	categoryResponse		"Category created"
+//	@Header			200						{string}	ETag					"ETag of the created category"
 //	@Failure		400						{object}	errorResponse			"Validation error"
 //	@Failure		401						{object}	errorResponse			"Unauthorized error"
 //	@Failure		403						{object}	errorResponse			"Forbidden error"
@@ -48,7 +50,8 @@ func (ch *CategoryHandler) CreateCategory(ctx *gin.Context) {
 	}
 
 	category := domain.Category{
-		Name: req.Name,
+		ParentID: req.ParentID,
+		Name:     req.Name,
 	}
 
 	_, err := ch.svc.CreateCategory(ctx, &category)
@@ -57,6 +60,7 @@ func (ch *CategoryHandler) CreateCategory(ctx *gin.Context) {
 		return
 	}
 
+	setETag(ctx, category.Version)
 	rsp := newCategoryResponse(&category)
 
 	handleSuccess(ctx, rsp)
@@ -76,6 +80,7 @@ type getCategoryRequest struct {
 //	@Produce		json
 //	@Param			id	path		uint64				true	"Category ID"
 //	@Success		200	{object}	categoryResponse	"Category retrieved"
+//	@Header			200	{string}	ETag				"ETag of the category"
 //	@Failure		400	{object}	errorResponse		"Validation error"
 //	@Failure		404	{object}	errorResponse		"Data not found error"
 //	@Failure		500	{object}	errorResponse		"Internal server error"
@@ -94,6 +99,7 @@ func (ch *CategoryHandler) GetCategory(ctx *gin.Context) {
 		return
 	}
 
+	setETag(ctx, category.Version)
 	rsp := newCategoryResponse(category)
 
 	handleSuccess(ctx, rsp)
@@ -101,22 +107,29 @@ func (ch *CategoryHandler) GetCategory(ctx *gin.Context) {
 
 // listCategoriesRequest represents a request body for listing categories
 type listCategoriesRequest struct {
-	Skip  uint64 `form:"skip" binding:"required,min=0" example:"0"`
-	Limit uint64 `form:"limit" binding:"required,min=5" example:"5"`
+	Skip           uint64           `form:"skip" binding:"required,min=0" example:"0"`
+	Limit          uint64           `form:"limit" binding:"required,min=5" example:"5"`
+	Sort           string           `form:"sort" binding:"omitempty,oneof=id name created_at" example:"created_at"`
+	Order          domain.SortOrder `form:"order" binding:"omitempty,oneof=asc desc" example:"desc"`
+	IncludeDeleted bool             `form:"include_deleted" binding:"omitempty" example:"false"`
 }
 
 // ListCategories godoc
 //
 //	@Summary		List categories
-//	@Description	List categories with pagination
+//	@Description	List root categories with pagination, each with its tree of subcategories
 //	@Tags			Categories
 //	@Accept			json
 //	@Produce		json
-//	@Param			skip	query		uint64			true	"Skip"
-//	@Param			limit	query		uint64			true	"Limit"
-//	@Success		200		{object}	meta			"Categories displayed"
-//	@Failure		400		{object}	errorResponse	"Validation error"
-//	@Failure		500		{object}	errorResponse	"Internal server error"
+//	@Param			skip			query		uint64			true	"Skip"
+//	@Param			limit			query		uint64			true	"Limit"
+//	@Param			sort			query		string			false	"Sort by (id, name, created_at)"
+//	@Param			order			query		string			false	"Sort order (asc, desc)"
+//	@Param			include_deleted	query		bool			false	"Include the soft deleted categories, requires the permission to manage them"
+//	@Success		200				{object}	meta			"Categories displayed"
+//	@Failure		400				{object}	errorResponse	"Validation error"
+//	@Failure		403				{object}	errorResponse	"Forbidden error"
+//	@Failure		500				{object}	errorResponse	"Internal server error"
 //	@Router			/categories [get]
 //	@Security		BearerAuth
 func (ch *CategoryHandler) ListCategories(ctx *gin.Context) {
@@ -128,7 +141,19 @@ func (ch *CategoryHandler) ListCategories(ctx *gin.Context) {
 		return
 	}
 
-	categories, err := ch.svc.ListCategories(ctx, req.Skip, req.Limit)
+	if err := checkIncludeDeleted(ctx, req.IncludeDeleted, domain.CategoriesWrite); err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	params := &domain.ListParams{
+		Skip:  req.Skip,
+		Limit: req.Limit,
+		Sort:  req.Sort,
+		Order: req.Order,
+	}
+
+	categories, total, err := ch.svc.ListCategories(ctx, req.IncludeDeleted, params)
 	if err != nil {
 		handleError(ctx, err)
 		return
@@ -138,33 +163,38 @@ func (ch *CategoryHandler) ListCategories(ctx *gin.Context) {
 		categoriesList = append(categoriesList, newCategoryResponse(&category))
 	}
 
-	total := uint64(len(categoriesList))
 	meta := newMeta(total, req.Limit, req.Skip)
 	rsp := toMap(meta, categoriesList, "categories")
 
 	handleSuccess(ctx, rsp)
 }
 
-// updateCategoryRequest represents a request body for updating a category
+// updateCategoryRequest represents a request body for updating a category, whose parent is kept when parent_id
+// is left out and cleared when it is 0
 type updateCategoryRequest struct {
-	Name string `json:"name" binding:"omitempty,required" example:"Beverages"`
+	ParentID *uint64 `json:"parent_id" binding:"omitempty" example:"1"`
+	Name     string  `json:"name" binding:"omitempty,required" example:"Beverages"`
 }
 
 // UpdateCategory godoc
 //
 //	@Summary		Update a category
-//	@Description	update a category's name by id
+//	@Description	update a category's name or parent category by id, a parent_id of 0 moving it to the top level
 //	@Tags			Categories
 //	@Accept			json
 //	@Produce		json
 //	@Param			id						path		uint64					true	"Category ID"
+//	@Param			If-Match				header		string					true	"ETag of the category that was read"
 //	@Param			updateCategoryRequest	body		updateCategoryRequest	true	"Update category request"
 //	@Success		200						{object}	categoryResponse		"Category updated"
+//	@Header			200						{string}	ETag					"ETag of the updated category"
 //	@Failure		400						{object}	errorResponse			"Validation error"
 //	@Failure		401						{object}	errorResponse			"Unauthorized error"
 //	@Failure		403						{object}	errorResponse			"Forbidden error"
 //	@Failure		404						{object}	errorResponse			"Data not found error"
 //	@Failure		409						{object}	errorResponse			"Data conflict error"
+//	@Failure		412						{object}	errorResponse			"Version mismatch error"
+//	@Failure		428						{object}	errorResponse			"Precondition required error"
 //	@Failure		500						{object}	errorResponse			"Internal server error"
 //	@Router			/categories/{id} [put]
 //	@Security		BearerAuth
@@ -182,17 +212,28 @@ func (ch *CategoryHandler) UpdateCategory(ctx *gin.Context) {
 		return
 	}
 
+	version, err := getIfMatch(ctx)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+
 	category := domain.Category{
-		ID:   id,
-		Name: req.Name,
+		ID:      id,
+		Name:    req.Name,
+		Version: version,
+	}
+	if req.ParentID != nil {
+		category.ParentID = *req.ParentID
 	}
 
-	_, err = ch.svc.UpdateCategory(ctx, &category)
+	_, err = ch.svc.UpdateCategory(ctx, &category, req.ParentID != nil)
 	if err != nil {
 		handleError(ctx, err)
 		return
 	}
 
+	setETag(ctx, category.Version)
 	rsp := newCategoryResponse(&category)
 
 	handleSuccess(ctx, rsp)
@@ -210,13 +251,17 @@ type deleteCategoryRequest struct {
 //	@Tags			Categories
 //	@Accept			json
 //	@Produce		json
-//	@Param			id	path		uint64			true	"Category ID"
-//	@Success		200	{object}	response		"Category deleted"
-//	@Failure		400	{object}	errorResponse	"Validation error"
-//	@Failure		401	{object}	errorResponse	"Unauthorized error"
-//	@Failure		403	{object}	errorResponse	"Forbidden error"
-//	@Failure		404	{object}	errorResponse	"Data not found error"
-//	@Failure		500	{object}	errorResponse	"Internal server error"
+//	@Param			id			path		uint64			true	"Category ID"
+//	@Param			If-Match	header		string			true	"ETag of the category that was read"
+//	@Success		200			{object}	response		"Category deleted"
+//	@Failure		400			{object}	errorResponse	"Validation error"
+//	@Failure		401			{object}	errorResponse	"Unauthorized error"
+//	@Failure		403			{object}	errorResponse	"Forbidden error"
+//	@Failure		404			{object}	errorResponse	"Data not found error"
+//	@Failure		409			{object}	errorResponse	"Data conflict error"
+//	@Failure		412			{object}	errorResponse	"Version mismatch error"
+//	@Failure		428			{object}	errorResponse	"Precondition required error"
+//	@Failure		500			{object}	errorResponse	"Internal server error"
 //	@Router			/categories/{id} [delete]
 //	@Security		BearerAuth
 func (ch *CategoryHandler) DeleteCategory(ctx *gin.Context) {
@@ -226,7 +271,13 @@ func (ch *CategoryHandler) DeleteCategory(ctx *gin.Context) {
 		return
 	}
 
-	err := ch.svc.DeleteCategory(ctx, req.ID)
+	version, err := getIfMatch(ctx)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	err = ch.svc.DeleteCategory(ctx, req.ID, version)
 	if err != nil {
 		handleError(ctx, err)
 		return
@@ -234,3 +285,45 @@ func (ch *CategoryHandler) DeleteCategory(ctx *gin.Context) {
 
 	handleSuccess(ctx, nil)
 }
+
+// restoreCategoryRequest represents the request body for restoring a soft deleted category
+type restoreCategoryRequest struct {
+	ID uint64 `uri:"id" binding:"required,min=1" example:"1"`
+}
+
+// RestoreCategory godoc
+//
+//	@Summary		Restore a category
+//	@Description	Restore a soft deleted category by id
+//	@Tags			Categories
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		uint64				true	"Category ID"
+//	@Success		200	{object}	categoryResponse	"Category restored"
+//	@Header			200	{string}	ETag				"ETag of the restored category"
+//	@Failure		400	{object}	errorResponse		"Validation error"
+//	@Failure		401	{object}	errorResponse		"Unauthorized error"
+//	@Failure		403	{object}	errorResponse		"Forbidden error"
+//	@Failure		404	{object}	errorResponse		"Data not found error"
+//	@Failure		409	{object}	errorResponse		"Data conflict error"
+//	@Failure		500	{object}	errorResponse		"Internal server error"
+//	@Router			/categories/{id}/restore [post]
+//	@Security		BearerAuth
+func (ch *CategoryHandler) RestoreCategory(ctx *gin.Context) {
+	var req restoreCategoryRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		validationError(ctx, err)
+		return
+	}
+
+	category, err := ch.svc.RestoreCategory(ctx, req.ID)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	setETag(ctx, category.Version)
+	rsp := newCategoryResponse(category)
+
+	handleSuccess(ctx, rsp)
+}
@@ -0,0 +1,296 @@
+package http
+
+import (
+	"encoding/csv"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/nikhil-shrestha/go-pos/internal/core/domain"
+	"github.com/gin-gonic/gin"
+	"github.com/xuri/excelize/v2"
+)
+
+// exportFormat is the file format of an exported list or report
+type exportFormat string
+
+// exportFormat values
+const (
+	csvFormat  exportFormat = "csv"
+	xlsxFormat exportFormat = "xlsx"
+)
+
+// exportSheetName is the name of the only worksheet of an exported XLSX file
+const exportSheetName = "Sheet1"
+
+// exportWriter writes the rows of an export to the response body
+type exportWriter interface {
+	// Write appends a row to the file
+	Write(values []any) error
+	// Close flushes the remaining rows to the response body
+	Close() error
+}
+
+// newExportWriter sets the download headers and returns a writer for the requested format
+func newExportWriter(ctx *gin.Context, format exportFormat, name string, header []string) (exportWriter, error) {
+	filename := fmt.Sprintf("%s-%s.%s", name, time.Now().Format("20060102150405"), format)
+	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+
+	var writer exportWriter
+
+	switch format {
+	case xlsxFormat:
+		ctx.Header("Content-Type", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
+
+		file := excelize.NewFile()
+		stream, err := file.NewStreamWriter(exportSheetName)
+		if err != nil {
+			return nil, err
+		}
+
+		writer = &xlsxExportWriter{ctx, file, stream, 0}
+	default:
+		ctx.Header("Content-Type", "text/csv; charset=utf-8")
+
+		writer = &csvExportWriter{csv.NewWriter(ctx.Writer)}
+	}
+
+	headerValues := make([]any, len(header))
+	for i, column := range header {
+		headerValues[i] = column
+	}
+
+	err := writer.Write(headerValues)
+	if err != nil {
+		return nil, err
+	}
+
+	return writer, nil
+}
+
+/**
+ * csvExportWriter implements exportWriter interface
+ * and streams rows directly to the response body
+ */
+type csvExportWriter struct {
+	writer *csv.Writer
+}
+
+// Write converts the values to strings and writes them as a CSV record
+func (cw *csvExportWriter) Write(values []any) error {
+	record := make([]string, len(values))
+	for i, value := range values {
+		record[i] = formatExportValue(value)
+	}
+
+	return cw.writer.Write(record)
+}
+
+// Close flushes the buffered CSV records
+func (cw *csvExportWriter) Close() error {
+	cw.writer.Flush()
+	return cw.writer.Error()
+}
+
+/**
+ * xlsxExportWriter implements exportWriter interface
+ * and uses the excelize stream writer, which spills rows to a temporary file
+ * instead of keeping the whole sheet in memory
+ */
+type xlsxExportWriter struct {
+	ctx    *gin.Context
+	file   *excelize.File
+	stream *excelize.StreamWriter
+	rows   int
+}
+
+// Write appends the values as the next row of the sheet
+func (xw *xlsxExportWriter) Write(values []any) error {
+	xw.rows++
+
+	cell, err := excelize.CoordinatesToCellName(1, xw.rows)
+	if err != nil {
+		return err
+	}
+
+	for i, value := range values {
+		if t, ok := value.(time.Time); ok {
+			values[i] = formatExportValue(t)
+		}
+	}
+
+	return xw.stream.SetRow(cell, values)
+}
+
+// Close finishes the sheet and writes the file to the response body
+func (xw *xlsxExportWriter) Close() error {
+	defer xw.file.Close()
+
+	err := xw.stream.Flush()
+	if err != nil {
+		return err
+	}
+
+	return xw.file.Write(xw.ctx.Writer)
+}
+
+// formatExportValue converts a value into its textual representation in an export
+func formatExportValue(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case time.Time:
+		return v.Format(time.RFC3339)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// productExportHeader is the header row of a product export
+var productExportHeader = []string{
+	"ID", "SKU", "Barcode", "Name", "Category ID", "Category", "Stock", "Price", "Image", "Created At", "Updated At",
+}
+
+// newProductExportRow is a helper function to create an export row for handling product data
+func newProductExportRow(product *domain.Product) []any {
+	var categoryName string
+	if product.Category != nil {
+		categoryName = product.Category.Name
+	}
+
+	return []any{
+		product.ID,
+		product.SKU.String(),
+		product.Barcode,
+		product.Name,
+		product.CategoryID,
+		categoryName,
+		product.Stock,
+		product.Price,
+		product.Image,
+		product.CreatedAt,
+		product.UpdatedAt,
+	}
+}
+
+// orderExportHeader is the header row of an order export, with one row per order product
+var orderExportHeader = []string{
+	"Order ID", "Receipt Code", "User ID", "Payment ID", "Customer Name", "Total Price", "Total Paid", "Total Return", "Status", "Created At",
+	"Product ID", "Product SKU", "Product Name", "Quantity", "Line Total",
+}
+
+// newOrderExportRows is a helper function to create export rows for handling order data and its order products
+func newOrderExportRows(order *domain.Order) [][]any {
+	var rows [][]any
+
+	orderValues := []any{
+		order.ID,
+		order.ReceiptCode.String(),
+		order.UserID,
+		order.PaymentID,
+		order.CustomerName,
+		order.TotalPrice,
+		order.TotalPaid,
+		order.TotalReturn,
+		string(order.Status),
+		order.CreatedAt,
+	}
+
+	if len(order.Products) == 0 {
+		row := append(append([]any{}, orderValues...), nil, nil, nil, nil, nil)
+		return append(rows, row)
+	}
+
+	for _, orderProduct := range order.Products {
+		row := append(append([]any{}, orderValues...),
+			orderProduct.ProductID,
+			orderProduct.ProductSKU.String(),
+			orderProduct.ProductName,
+			orderProduct.Quantity,
+			orderProduct.TotalPrice,
+		)
+		rows = append(rows, row)
+	}
+
+	return rows
+}
+
+// salesSummaryExportHeader is the header row of a sales summary export
+var salesSummaryExportHeader = []string{
+	"Total Orders", "Total Items", "Total Revenue", "Average Order Value", "Average Basket Size",
+}
+
+// newSalesSummaryExportRow is a helper function to create an export row for handling sales summary data
+func newSalesSummaryExportRow(summary *domain.SalesSummary) []any {
+	return []any{
+		summary.TotalOrders,
+		summary.TotalItems,
+		summary.TotalRevenue,
+		summary.AverageOrderValue,
+		summary.AverageBasketSize,
+	}
+}
+
+// salesPeriodExportHeader is the header row of a sales by period export
+var salesPeriodExportHeader = []string{
+	"Period", "Total Orders", "Total Items", "Total Revenue",
+}
+
+// newSalesPeriodExportRow is a helper function to create an export row for handling sales by period data
+func newSalesPeriodExportRow(period *domain.SalesPeriod) []any {
+	return []any{
+		period.Period,
+		period.TotalOrders,
+		period.TotalItems,
+		period.TotalRevenue,
+	}
+}
+
+// salesGroupExportHeader is the header row of a sales by category, product, cashier, or payment export
+var salesGroupExportHeader = []string{
+	"ID", "Name", "Total Orders", "Total Items", "Total Revenue",
+}
+
+// newSalesGroupExportRow is a helper function to create an export row for handling grouped sales data
+func newSalesGroupExportRow(group *domain.SalesGroup) []any {
+	return []any{
+		group.ID,
+		group.Name,
+		group.TotalOrders,
+		group.TotalItems,
+		group.TotalRevenue,
+	}
+}
+
+// salesCategoryExportHeader is the header row of a sales by category export, whose groups are named after
+// the category of the products when they were sold and have no ID
+var salesCategoryExportHeader = []string{
+	"Category", "Total Orders", "Total Items", "Total Revenue",
+}
+
+// newSalesCategoryExportRow is a helper function to create an export row for handling sales by category data
+func newSalesCategoryExportRow(group *domain.SalesGroup) []any {
+	return []any{
+		group.Name,
+		group.TotalOrders,
+		group.TotalItems,
+		group.TotalRevenue,
+	}
+}
+
+// handleExportError sends an error response if nothing has been streamed yet, otherwise it aborts the response
+func handleExportError(ctx *gin.Context, err error) {
+	if ctx.Writer.Written() {
+		_ = ctx.Error(err)
+		ctx.Abort()
+		return
+	}
+
+	ctx.Header("Content-Disposition", "")
+	ctx.Header("Content-Type", "")
+	handleError(ctx, err)
+}
@@ -1,7 +1,10 @@
 package http
 
 import (
+	"encoding/base64"
+	"encoding/json"
 	"strconv"
+	"strings"
 
 	"github.com/nikhil-shrestha/go-pos/internal/core/domain"
 	"github.com/gin-gonic/gin"
@@ -14,13 +17,97 @@ func stringToUint64(str string) (uint64, error) {
 	return num, err
 }
 
+// setETag is a helper function to set the ETag header to the version of the data in the response
+func setETag(ctx *gin.Context, version uint64) {
+	ctx.Header("ETag", strconv.Quote(strconv.FormatUint(version, 10)))
+}
+
+// getIfMatch is a helper function to get the version the client read from the If-Match header, which is required to change data.
+// Only a single strong ETag can match, so a weak or unknown one is reported as a version mismatch
+func getIfMatch(ctx *gin.Context) (uint64, error) {
+	ifMatch := strings.TrimSpace(ctx.GetHeader("If-Match"))
+	if ifMatch == "" {
+		return 0, domain.ErrPreconditionRequired
+	}
+
+	etag, err := strconv.Unquote(ifMatch)
+	if err != nil || !strings.HasPrefix(ifMatch, `"`) {
+		return 0, domain.ErrVersionMismatch
+	}
+
+	version, err := stringToUint64(etag)
+	if err != nil || version == 0 {
+		return 0, domain.ErrVersionMismatch
+	}
+
+	return version, nil
+}
+
 // getAuthPayload is a helper function to get the auth payload from the context
 func getAuthPayload(ctx *gin.Context, key string) *domain.TokenPayload {
 	return ctx.MustGet(key).(*domain.TokenPayload)
 }
 
+// getApproverID is a helper function to get the id of the supervisor who approved the request, or 0 if the user needed no approval
+func getApproverID(ctx *gin.Context) uint64 {
+	override, ok := ctx.Get(overridePayloadKey)
+	if !ok {
+		return 0
+	}
+
+	return override.(*domain.Override).ApprovedBy
+}
+
+// checkIncludeDeleted is a helper function to check that the user asking for the soft deleted records of a list has the permission to manage them
+func checkIncludeDeleted(ctx *gin.Context, includeDeleted bool, permission domain.Permission) error {
+	if !includeDeleted {
+		return nil
+	}
+
+	payload := getAuthPayload(ctx, authorizationPayloadKey)
+	if !payload.HasPermission(permission) {
+		return domain.ErrForbidden
+	}
+
+	return nil
+}
+
+// encodeCursor is a helper function to encode a cursor into an opaque token, or an empty string if there is no cursor
+func encodeCursor(cursor *domain.Cursor) string {
+	if cursor == nil {
+		return ""
+	}
+
+	data, err := json.Marshal(cursor)
+	if err != nil {
+		return ""
+	}
+
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeCursor is a helper function to decode an opaque token into a cursor, or nil if there is no token
+func decodeCursor(token string) (*domain.Cursor, error) {
+	if token == "" {
+		return nil, nil
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, domain.ErrInvalidCursor
+	}
+
+	var cursor domain.Cursor
+	err = json.Unmarshal(data, &cursor)
+	if err != nil || cursor.ID == 0 || cursor.CreatedAt.IsZero() {
+		return nil, domain.ErrInvalidCursor
+	}
+
+	return &cursor, nil
+}
+
 // toMap is a helper function to add meta and data to a map
-func toMap(m meta, data any, key string) map[string]any {
+func toMap(m any, data any, key string) map[string]any {
 	return map[string]any{
 		"meta": m,
 		key:    data,
@@ -0,0 +1,152 @@
+package http
+
+import (
+	"errors"
+	"io"
+	"mime/multipart"
+	"net/http"
+
+	"github.com/nikhil-shrestha/go-pos/internal/core/domain"
+	"github.com/nikhil-shrestha/go-pos/internal/core/port"
+	"github.com/gin-gonic/gin"
+)
+
+// maxMultipartOverhead is the room left for the multipart headers of an image upload
+const maxMultipartOverhead = 1 << 20
+
+// ImageHandler represents the HTTP handler for image upload requests
+type ImageHandler struct {
+	svc port.ImageService
+}
+
+// NewImageHandler creates a new ImageHandler instance
+func NewImageHandler(svc port.ImageService) *ImageHandler {
+	return &ImageHandler{
+		svc,
+	}
+}
+
+// uploadImageRequest represents a request body for uploading an image
+type uploadImageRequest struct {
+	Image *multipart.FileHeader `form:"image" binding:"required" swaggerignore:"true"`
+}
+
+// UploadProductImage godoc
+//
+//	@Summary		Upload a product image
+//	@Description	Upload a JPEG, PNG, or WebP image of at most 5 MB for a product and generate its thumbnail
+//	@Tags			Products
+//	@Accept			multipart/form-data
+//	@Produce		json
+//	@Param			id		path		uint64			true	"Product ID"
+//	@Param			image	formData	file			true	"Product image"
+//	@Success		200		{object}	productResponse	"Product image uploaded"
+//	@Header			200		{string}	ETag			"ETag of the updated product"
+//	@Failure		400		{object}	errorResponse	"Validation error"
+//	@Failure		401		{object}	errorResponse	"Unauthorized error"
+//	@Failure		403		{object}	errorResponse	"Forbidden error"
+//	@Failure		404		{object}	errorResponse	"Data not found error"
+//	@Failure		413		{object}	errorResponse	"Image too large error"
+//	@Failure		415		{object}	errorResponse	"Unsupported image error"
+//	@Failure		500		{object}	errorResponse	"Internal server error"
+//	@Router			/products/{id}/image [post]
+//	@Security		BearerAuth
+func (ih *ImageHandler) UploadProductImage(ctx *gin.Context) {
+	id, image, ok := bindImageUpload(ctx)
+	if !ok {
+		return
+	}
+
+	product, err := ih.svc.UploadProductImage(ctx, id, image)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	setETag(ctx, product.Version)
+	rsp := newProductResponse(product)
+
+	handleSuccess(ctx, rsp)
+}
+
+// UploadPaymentLogo godoc
+//
+//	@Summary		Upload a payment logo
+//	@Description	Upload a JPEG, PNG, or WebP logo of at most 5 MB for a payment and generate its thumbnail
+//	@Tags			Payments
+//	@Accept			multipart/form-data
+//	@Produce		json
+//	@Param			id		path		uint64			true	"Payment ID"
+//	@Param			image	formData	file			true	"Payment logo"
+//	@Success		200		{object}	paymentResponse	"Payment logo uploaded"
+//	@Header			200		{string}	ETag			"ETag of the updated payment"
+//	@Failure		400		{object}	errorResponse	"Validation error"
+//	@Failure		401		{object}	errorResponse	"Unauthorized error"
+//	@Failure		403		{object}	errorResponse	"Forbidden error"
+//	@Failure		404		{object}	errorResponse	"Data not found error"
+//	@Failure		413		{object}	errorResponse	"Image too large error"
+//	@Failure		415		{object}	errorResponse	"Unsupported image error"
+//	@Failure		500		{object}	errorResponse	"Internal server error"
+//	@Router			/payments/{id}/logo [post]
+//	@Security		BearerAuth
+func (ih *ImageHandler) UploadPaymentLogo(ctx *gin.Context) {
+	id, image, ok := bindImageUpload(ctx)
+	if !ok {
+		return
+	}
+
+	payment, err := ih.svc.UploadPaymentLogo(ctx, id, image)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	setETag(ctx, payment.Version)
+	rsp := newPaymentResponse(payment)
+
+	handleSuccess(ctx, rsp)
+}
+
+// bindImageUpload binds an image upload request and reads the image, sending an error response if it fails
+func bindImageUpload(ctx *gin.Context) (uint64, *domain.File, bool) {
+	var req uploadImageRequest
+
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, domain.MaxImageSize+maxMultipartOverhead)
+
+	idStr := ctx.Param("id")
+	id, err := stringToUint64(idStr)
+	if err != nil {
+		validationError(ctx, err)
+		return 0, nil, false
+	}
+
+	if err := ctx.ShouldBind(&req); err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			handleError(ctx, domain.ErrImageTooLarge)
+			return 0, nil, false
+		}
+
+		validationError(ctx, err)
+		return 0, nil, false
+	}
+
+	file, err := req.Image.Open()
+	if err != nil {
+		handleError(ctx, domain.ErrInternal)
+		return 0, nil, false
+	}
+	defer file.Close()
+
+	content, err := io.ReadAll(io.LimitReader(file, domain.MaxImageSize+1))
+	if err != nil {
+		handleError(ctx, domain.ErrInternal)
+		return 0, nil, false
+	}
+
+	return id, &domain.File{
+		Name:        req.Image.Filename,
+		ContentType: req.Image.Header.Get("Content-Type"),
+		Content:     content,
+	}, true
+}
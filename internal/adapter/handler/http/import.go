@@ -0,0 +1,260 @@
+package http
+
+import (
+	"encoding/csv"
+	"io"
+	"mime/multipart"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/nikhil-shrestha/go-pos/internal/core/domain"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/google/uuid"
+	"github.com/xuri/excelize/v2"
+)
+
+// importReader reads the records of an uploaded import file
+type importReader interface {
+	// Read returns the next record, or io.EOF when there are no more records
+	Read() ([]string, error)
+	// Line returns the line number of the last record read, starting at 1
+	Line() int
+	// Close releases the uploaded file
+	Close() error
+}
+
+// newImportReader opens the uploaded file and returns a reader for its format based on the file extension
+func newImportReader(fileHeader *multipart.FileHeader) (importReader, error) {
+	file, err := fileHeader.Open()
+	if err != nil {
+		return nil, err
+	}
+
+	switch exportFormat(strings.TrimPrefix(strings.ToLower(filepath.Ext(fileHeader.Filename)), ".")) {
+	case csvFormat:
+		reader := csv.NewReader(file)
+		reader.FieldsPerRecord = -1
+		reader.TrimLeadingSpace = true
+
+		return &csvImportReader{file, reader}, nil
+	case xlsxFormat:
+		workbook, err := excelize.OpenReader(file)
+		if err != nil {
+			file.Close()
+			return nil, err
+		}
+
+		rows, err := workbook.Rows(workbook.GetSheetName(0))
+		if err != nil {
+			workbook.Close()
+			file.Close()
+			return nil, err
+		}
+
+		return &xlsxImportReader{file, workbook, rows, 0}, nil
+	default:
+		file.Close()
+		return nil, domain.ErrInvalidImportFile
+	}
+}
+
+/**
+ * csvImportReader implements importReader interface
+ * and reads the records of a CSV file
+ */
+type csvImportReader struct {
+	file   multipart.File
+	reader *csv.Reader
+}
+
+// Read returns the next CSV record
+func (cr *csvImportReader) Read() ([]string, error) {
+	return cr.reader.Read()
+}
+
+// Line returns the line of the last CSV record, which accounts for skipped blank lines and quoted line breaks
+func (cr *csvImportReader) Line() int {
+	line, _ := cr.reader.FieldPos(0)
+	return line
+}
+
+// Close closes the uploaded file
+func (cr *csvImportReader) Close() error {
+	return cr.file.Close()
+}
+
+/**
+ * xlsxImportReader implements importReader interface
+ * and reads the rows of the first sheet of an XLSX file
+ */
+type xlsxImportReader struct {
+	file     multipart.File
+	workbook *excelize.File
+	rows     *excelize.Rows
+	line     int
+}
+
+// Read returns the cells of the next row
+func (xr *xlsxImportReader) Read() ([]string, error) {
+	if !xr.rows.Next() {
+		err := xr.rows.Error()
+		if err != nil {
+			return nil, err
+		}
+		return nil, io.EOF
+	}
+
+	xr.line++
+
+	return xr.rows.Columns()
+}
+
+// Line returns the number of the last row read
+func (xr *xlsxImportReader) Line() int {
+	return xr.line
+}
+
+// Close closes the workbook and the uploaded file
+func (xr *xlsxImportReader) Close() error {
+	_ = xr.rows.Close()
+	_ = xr.workbook.Close()
+	return xr.file.Close()
+}
+
+// importColumns maps the lowercased header names of an import file to their column index
+type importColumns map[string]int
+
+// newImportColumns reads the header record and checks that every required column is present
+func newImportColumns(header []string, required ...string) (importColumns, bool) {
+	columns := make(importColumns)
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+
+	for _, name := range required {
+		if _, ok := columns[name]; !ok {
+			return nil, false
+		}
+	}
+
+	return columns, true
+}
+
+// get returns the trimmed value of the named column, or an empty string if the record is too short
+func (ic importColumns) get(record []string, name string) string {
+	i, ok := ic[name]
+	if !ok || i >= len(record) {
+		return ""
+	}
+
+	return strings.TrimSpace(record[i])
+}
+
+// isEmptyRecord reports whether every cell of the record is blank
+func isEmptyRecord(record []string) bool {
+	for _, value := range record {
+		if strings.TrimSpace(value) != "" {
+			return false
+		}
+	}
+
+	return true
+}
+
+// productImportColumns are the columns a product import file must have, the export header being a valid import file
+var productImportColumns = []string{"name", "category", "stock", "price", "image"}
+
+// importProductRow represents a row of a product import file, validated with the same rules as createProductRequest
+type importProductRow struct {
+	SKU      string  `binding:"omitempty,uuid"`
+	Barcode  string  `binding:"omitempty,max=64"`
+	Name     string  `binding:"required"`
+	Category string  `binding:"required"`
+	Image    string  `binding:"required"`
+	Price    float64 `binding:"required,min=0"`
+	Stock    int64   `binding:"required,min=0"`
+}
+
+// newProductImport reads and validates every row of a product import file
+func newProductImport(reader importReader, dryRun bool) (*domain.ProductImport, error) {
+	header, err := reader.Read()
+	if err != nil {
+		return nil, domain.ErrInvalidImportFile
+	}
+
+	columns, ok := newImportColumns(header, productImportColumns...)
+	if !ok {
+		return nil, domain.ErrInvalidImportFile
+	}
+
+	productImport := &domain.ProductImport{
+		DryRun: dryRun,
+	}
+
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, domain.ErrInvalidImportFile
+		}
+
+		if isEmptyRecord(record) {
+			continue
+		}
+
+		productImport.Rows = append(productImport.Rows, newProductImportRow(reader.Line(), columns, record))
+	}
+
+	return productImport, nil
+}
+
+// newProductImportRow parses and validates a single record of a product import file
+func newProductImportRow(line int, columns importColumns, record []string) domain.ProductImportRow {
+	var errMsgs []string
+
+	row := importProductRow{
+		SKU:      columns.get(record, "sku"),
+		Barcode:  columns.get(record, "barcode"),
+		Name:     columns.get(record, "name"),
+		Category: columns.get(record, "category"),
+		Image:    columns.get(record, "image"),
+	}
+
+	price, err := strconv.ParseFloat(columns.get(record, "price"), 64)
+	if err != nil {
+		errMsgs = append(errMsgs, "Price must be a number")
+	}
+	row.Price = price
+
+	stock, err := strconv.ParseInt(columns.get(record, "stock"), 10, 64)
+	if err != nil {
+		errMsgs = append(errMsgs, "Stock must be an integer")
+	}
+	row.Stock = stock
+
+	err = binding.Validator.ValidateStruct(&row)
+	if err != nil {
+		errMsgs = append(errMsgs, parseError(err)...)
+	}
+
+	sku, _ := uuid.Parse(row.SKU)
+
+	return domain.ProductImportRow{
+		Line: line,
+		Product: &domain.Product{
+			SKU:     sku,
+			Barcode: row.Barcode,
+			Name:    row.Name,
+			Image:   row.Image,
+			Price:   row.Price,
+			Stock:   row.Stock,
+			Category: &domain.Category{
+				Name: row.Category,
+			},
+		},
+		Errors: errMsgs,
+	}
+}
@@ -0,0 +1,72 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/nikhil-shrestha/go-pos/internal/core/domain"
+	"github.com/nikhil-shrestha/go-pos/internal/core/port"
+	"github.com/gin-gonic/gin"
+)
+
+// KeyHandler represents the HTTP handler for publishing the keys that verify tokens
+type KeyHandler struct {
+	svc port.TokenKeyService
+}
+
+// NewKeyHandler creates a new KeyHandler instance
+func NewKeyHandler(svc port.TokenKeyService) *KeyHandler {
+	return &KeyHandler{
+		svc,
+	}
+}
+
+// tokenKeyResponse represents a public key response body
+type tokenKeyResponse struct {
+	ID        string `json:"kid" example:"20240101000000-1a2b3c4d"`
+	Version   string `json:"version" example:"v4"`
+	Purpose   string `json:"purpose" example:"public"`
+	PublicKey string `json:"key" example:"1eb9dbbbbc047c03fd70604e0071f0987e16b28b757225c11f00415d0e20b1a2"`
+	PASERK    string `json:"paserk" example:"k4.public.Hrnbu7wEfAP9cGBOAHHwmH4Wsot1ciXBHwBBXQ4gsaI"`
+	Active    bool   `json:"active" example:"true"`
+}
+
+// tokenKeysResponse represents the response body of the published keys, which is not wrapped
+// in the usual response envelope since third parties fetch it from a well-known location
+type tokenKeysResponse struct {
+	Keys []tokenKeyResponse `json:"keys"`
+}
+
+// newTokenKeysResponse is a helper function to create a response body for handling public key data
+func newTokenKeysResponse(tokenKeys []domain.TokenKey) tokenKeysResponse {
+	keys := []tokenKeyResponse{}
+
+	for _, tokenKey := range tokenKeys {
+		keys = append(keys, tokenKeyResponse{
+			ID:        tokenKey.ID,
+			Version:   tokenKey.Version,
+			Purpose:   tokenKey.Purpose,
+			PublicKey: tokenKey.PublicKey,
+			PASERK:    tokenKey.PASERK,
+			Active:    tokenKey.Active,
+		})
+	}
+
+	return tokenKeysResponse{
+		Keys: keys,
+	}
+}
+
+// ListPublicKeys godoc
+//
+//	@Summary		List the public keys of the tokens
+//	@Description	Lists the public keys that verify v4.public access tokens, matched by the "kid" of the token footer. The list is empty when tokens are v4.local.
+//	@Tags			Keys
+//	@Produce		json
+//	@Success		200	{object}	tokenKeysResponse	"Public keys"
+//	@Router			/.well-known/paseto-keys [get]
+func (kh *KeyHandler) ListPublicKeys(ctx *gin.Context) {
+	rsp := newTokenKeysResponse(kh.svc.ListPublicKeys())
+
+	ctx.Header("Cache-Control", "public, max-age=300")
+	ctx.JSON(http.StatusOK, rsp)
+}
@@ -1,24 +1,72 @@
 package http
 
 import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"regexp"
 	"strings"
 
 	"github.com/nikhil-shrestha/go-pos/internal/core/domain"
 	"github.com/nikhil-shrestha/go-pos/internal/core/port"
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 )
 
 const (
 	// authorizationHeaderKey is the key for authorization header in the request
 	authorizationHeaderKey = "authorization"
-	// authorizationType is the accepted authorization type
+	// authorizationType is the authorization type of access tokens
 	authorizationType = "bearer"
+	// apiKeyAuthorizationType is the authorization type of API keys
+	apiKeyAuthorizationType = "apikey"
 	// authorizationPayloadKey is the key for authorization payload in the context
 	authorizationPayloadKey = "authorization_payload"
+	// terminalKeyHeaderKey is the key for the header carrying the key of the terminal a PIN login token is bound to
+	terminalKeyHeaderKey = "x-terminal-key"
+	// overrideHeaderKey is the key for the header carrying a supervisor's approval token
+	overrideHeaderKey = "x-override-token"
+	// overridePayloadKey is the key for the supervisor's approval in the context
+	overridePayloadKey = "override_payload"
+	// requestIDHeaderKey is the key for the header carrying the id of the request
+	requestIDHeaderKey = "x-request-id"
+	// idempotencyKeyHeaderKey is the key for the header carrying the client's key for retrying the request safely
+	idempotencyKeyHeaderKey = "idempotency-key"
+	// idempotentReplayedHeaderKey is the key for the header telling the client the response is of an earlier request
+	idempotentReplayedHeaderKey = "idempotent-replayed"
+	// maxIdempotencyKeyLength is the maximum length of an idempotency key
+	maxIdempotencyKeyLength = 255
 )
 
-// authMiddleware is a middleware to check if the user is authenticated
-func authMiddleware(token port.TokenService) gin.HandlerFunc {
+// requestIDPattern is the format of a request id set by the client or a proxy
+var requestIDPattern = regexp.MustCompile(`^[A-Za-z0-9._-]{1,128}$`)
+
+// requestMiddleware is a middleware to tag the request with an id, which is taken from the request header
+// if the client or a proxy set one, and to record who made the request from where for the audit trail
+func requestMiddleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		requestID := ctx.GetHeader(requestIDHeaderKey)
+		if !requestIDPattern.MatchString(requestID) {
+			requestID = uuid.NewString()
+		}
+
+		ctx.Header(requestIDHeaderKey, requestID)
+
+		actor := &domain.Actor{
+			IP:        ctx.ClientIP(),
+			RequestID: requestID,
+		}
+		ctx.Request = ctx.Request.WithContext(domain.NewActorContext(ctx.Request.Context(), actor))
+
+		ctx.Next()
+	}
+}
+
+// authMiddleware is a middleware to check if the user is authenticated with an access token that has not been revoked,
+// or if the request is authenticated with an API key that has not expired or been revoked
+func authMiddleware(auth port.AuthService, apiKeys port.APIKeyService) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		authorizationHeader := ctx.GetHeader(authorizationHeaderKey)
 
@@ -37,32 +85,60 @@ func authMiddleware(token port.TokenService) gin.HandlerFunc {
 			return
 		}
 
+		var payload *domain.TokenPayload
+		var err error
+
 		currentAuthorizationType := strings.ToLower(fields[0])
-		if currentAuthorizationType != authorizationType {
-			err := domain.ErrInvalidAuthorizationType
+		switch currentAuthorizationType {
+		case authorizationType:
+			accessToken := fields[1]
+			terminalKey := ctx.GetHeader(terminalKeyHeaderKey)
+			payload, err = auth.VerifyToken(ctx, accessToken, terminalKey)
+		case apiKeyAuthorizationType:
+			apiKey := fields[1]
+			payload, err = apiKeys.VerifyAPIKey(ctx, apiKey)
+		default:
+			err = domain.ErrInvalidAuthorizationType
+		}
+		if err != nil {
 			handleAbort(ctx, err)
 			return
 		}
 
-		accessToken := fields[1]
-		payload, err := token.VerifyToken(accessToken)
-		if err != nil {
+		actor, ok := domain.ActorFromContext(ctx)
+		if ok {
+			actor.UserID = payload.UserID
+			actor.APIKeyID = payload.APIKeyID
+		}
+
+		ctx.Set(authorizationPayloadKey, payload)
+		ctx.Next()
+	}
+}
+
+// sessionMiddleware is a middleware to reject requests authenticated with an API key,
+// for the routes that act on the account of the user or need a supervisor
+func sessionMiddleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		payload := getAuthPayload(ctx, authorizationPayloadKey)
+
+		if payload.APIKeyID != 0 {
+			err := domain.ErrForbidden
 			handleAbort(ctx, err)
 			return
 		}
 
-		ctx.Set(authorizationPayloadKey, payload)
 		ctx.Next()
 	}
 }
 
-// adminMiddleware is a middleware to check if the user is an admin
-func adminMiddleware() gin.HandlerFunc {
+// permissionMiddleware is a middleware to check if the role of the user has the permission
+func permissionMiddleware(permission domain.Permission) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		payload := getAuthPayload(ctx, authorizationPayloadKey)
 
-		isAdmin := payload.Role == domain.Admin
-		if !isAdmin {
+		isAllowed := payload.HasPermission(permission)
+		if !isAllowed {
 			err := domain.ErrForbidden
 			handleAbort(ctx, err)
 			return
@@ -71,3 +147,120 @@ func adminMiddleware() gin.HandlerFunc {
 		ctx.Next()
 	}
 }
+
+// overrideMiddleware is a middleware to let a user without the permission of a restricted action
+// take it once with a supervisor's approval token, which is consumed by the request
+func overrideMiddleware(svc port.OverrideService, action domain.OverrideAction) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		payload := getAuthPayload(ctx, authorizationPayloadKey)
+
+		permission, _ := action.Permission()
+		if payload.HasPermission(permission) {
+			ctx.Next()
+			return
+		}
+
+		overrideToken := ctx.GetHeader(overrideHeaderKey)
+		if overrideToken == "" {
+			err := domain.ErrOverrideRequired
+			handleAbort(ctx, err)
+			return
+		}
+
+		override, err := svc.UseOverride(ctx, payload, action, overrideToken)
+		if err != nil {
+			handleAbort(ctx, err)
+			return
+		}
+
+		actor, ok := domain.ActorFromContext(ctx)
+		if ok {
+			actor.ApproverID = override.ApprovedBy
+		}
+
+		ctx.Set(overridePayloadKey, override)
+		ctx.Next()
+	}
+}
+
+// idempotencyMiddleware is a middleware to run a request with an Idempotency-Key header only once. A retry of the request
+// with the same key gets the response of the successful request back, waiting for it while it is running, and a failed
+// request frees the key so that it can be retried. The services behind it only fail before they commit a change,
+// so a request that failed did not change anything
+func idempotencyMiddleware(svc port.IdempotencyService) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		key := ctx.GetHeader(idempotencyKeyHeaderKey)
+		if key == "" {
+			ctx.Next()
+			return
+		}
+
+		if len(key) > maxIdempotencyKeyLength {
+			err := domain.ErrInvalidIdempotencyKey
+			handleAbort(ctx, err)
+			return
+		}
+
+		body, err := io.ReadAll(ctx.Request.Body)
+		if err != nil {
+			err := domain.ErrInternal
+			handleAbort(ctx, err)
+			return
+		}
+		ctx.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		payload := getAuthPayload(ctx, authorizationPayloadKey)
+		scopedKey := fmt.Sprintf("%d:%d:%s", payload.UserID, payload.APIKeyID, key)
+		request := append([]byte(ctx.Request.Method+" "+ctx.Request.URL.Path+"\n"), body...)
+
+		idempotentRequest, err := svc.BeginRequest(ctx, scopedKey, request)
+		if err != nil {
+			handleAbort(ctx, err)
+			return
+		}
+
+		if idempotentRequest.IsCompleted() {
+			ctx.Header(idempotentReplayedHeaderKey, "true")
+			ctx.Data(idempotentRequest.ResponseStatus, gin.MIMEJSON+"; charset=utf-8", idempotentRequest.ResponseBody)
+			ctx.Abort()
+			return
+		}
+
+		recorder := &responseRecorder{ResponseWriter: ctx.Writer}
+		ctx.Writer = recorder
+		ctx.Next()
+
+		// the response is kept even if the client has gone, as its retry must not run the request again
+		keepCtx := context.WithoutCancel(ctx)
+
+		status := recorder.Status()
+		if status >= 200 && status < 300 {
+			idempotentRequest.ResponseStatus = status
+			idempotentRequest.ResponseBody = recorder.body.Bytes()
+			err = svc.CompleteRequest(keepCtx, idempotentRequest)
+		} else {
+			err = svc.ReleaseRequest(keepCtx, idempotentRequest)
+		}
+		if err != nil {
+			slog.Error("Error storing the idempotent request", "key", scopedKey, "error", err)
+		}
+	}
+}
+
+// responseRecorder is a gin.ResponseWriter which keeps a copy of the response body it writes
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+// Write writes the data to the response and keeps a copy of it
+func (rr *responseRecorder) Write(data []byte) (int, error) {
+	rr.body.Write(data)
+	return rr.ResponseWriter.Write(data)
+}
+
+// WriteString writes the string to the response and keeps a copy of it
+func (rr *responseRecorder) WriteString(s string) (int, error) {
+	rr.body.WriteString(s)
+	return rr.ResponseWriter.WriteString(s)
+}
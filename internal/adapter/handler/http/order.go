@@ -1,9 +1,12 @@
 package http
 
 import (
+	"time"
+
 	"github.com/nikhil-shrestha/go-pos/internal/core/domain"
 	"github.com/nikhil-shrestha/go-pos/internal/core/port"
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 )
 
 // OrderHandler represents the HTTP handler for order-related requests
@@ -21,7 +24,7 @@ func NewOrderHandler(svc port.OrderService) *OrderHandler {
 // orderProductRequest represents an order product request body
 type orderProductRequest struct {
 	ProductID uint64 `json:"product_id" binding:"required,min=1" example:"1"`
-	Quantity  int64  `json:"qty" binding:"required,number" example:"1"`
+	Quantity  int64  `json:"qty" binding:"required,min=1" example:"1"`
 }
 
 // createOrderRequest represents a request body for creating a new order
@@ -35,15 +38,18 @@ type createOrderRequest struct {
 // CreateOrder godoc
 //
 //	@Summary		Create a new order
-//	@Description	Create a new order and return the order data with purchase details
+//	@Description	Create a new order and return the order data with purchase details. A retry with the same Idempotency-Key header returns the order created by the first request instead of creating another one
 //	@Tags			Orders
 //	@Accept			json
 //	@Produce		json
+//	@Param			Idempotency-Key		header		string				false	"Key to retry the request safely with"
 //	@Param			createOrderRequest	body		createOrderRequest	true	"Create order request"
 //	@Success		200					{object}	orderResponse		"Order created"
+//	@Header			200					{string}	Idempotent-Replayed	"true if the order was created by an earlier request with the same key"
 //	@Failure		400					{object}	errorResponse		"Validation error"
 //	@Failure		404					{object}	errorResponse		"Data not found error"
 //	@Failure		409					{object}	errorResponse		"Data conflict error"
+//	@Failure		422					{object}	errorResponse		"Idempotency key reused error"
 //	@Failure		500					{object}	errorResponse		"Internal server error"
 //	@Router			/orders [post]
 //	@Security		BearerAuth
@@ -121,25 +127,107 @@ func (oh *OrderHandler) GetOrder(ctx *gin.Context) {
 	handleSuccess(ctx, rsp)
 }
 
-// listOrdersRequest represents a request body for listing orders
+// listOrdersRequest represents a request body for listing orders, paginated with a cursor instead when skip is omitted
 type listOrdersRequest struct {
-	Skip  uint64 `form:"skip" binding:"required,min=0" example:"0"`
-	Limit uint64 `form:"limit" binding:"required,min=5" example:"5"`
+	orderFilterRequest
+	Skip   uint64           `form:"skip" binding:"omitempty,min=1" example:"1"`
+	Limit  uint64           `form:"limit" binding:"required,min=5" example:"5"`
+	Cursor string           `form:"cursor" binding:"omitempty,excluded_with=Skip" example:"eyJjcmVhdGVkX2F0IjoiMjAyNC0wMS0wMVQwMDowMDowMFoiLCJpZCI6MX0"`
+	Sort   string           `form:"sort" binding:"omitempty,excluded_without=Skip,oneof=id customer_name total_price created_at" example:"created_at"`
+	Order  domain.SortOrder `form:"order" binding:"omitempty,oneof=asc desc" example:"desc"`
+}
+
+// orderFilterRequest represents the filters of a request for listing or exporting orders
+type orderFilterRequest struct {
+	StartDate    string             `form:"start_date" binding:"omitempty,datetime=2006-01-02" example:"2024-01-01"`
+	EndDate      string             `form:"end_date" binding:"omitempty,datetime=2006-01-02" example:"2024-01-31"`
+	TimeZone     string             `form:"tz" binding:"omitempty,timezone" example:"Asia/Kathmandu"`
+	UserID       uint64             `form:"user_id" binding:"omitempty,min=1" example:"1"`
+	PaymentID    uint64             `form:"payment_id" binding:"omitempty,min=1" example:"1"`
+	CustomerName string             `form:"customer_name" binding:"omitempty" example:"John Doe"`
+	ReceiptCode  string             `form:"receipt_code" binding:"omitempty,uuid" example:"4979cf6e-d215-4ff8-9d0d-b3e99bcc7750"`
+	MinTotal     float64            `form:"min_total" binding:"omitempty,min=0" example:"10000"`
+	MaxTotal     float64            `form:"max_total" binding:"omitempty,min=0" example:"100000"`
+	Status       domain.OrderStatus `form:"status" binding:"omitempty,order_status" example:"completed"`
+}
+
+// toFilter converts the request into an order filter, treating the dates as days in the time zone and the end date as inclusive
+func (req orderFilterRequest) toFilter() (*domain.OrderFilter, error) {
+	filter := &domain.OrderFilter{
+		UserID:       req.UserID,
+		PaymentID:    req.PaymentID,
+		CustomerName: req.CustomerName,
+		MinTotal:     req.MinTotal,
+		MaxTotal:     req.MaxTotal,
+		Status:       req.Status,
+	}
+
+	if req.ReceiptCode != "" {
+		receiptCode, err := uuid.Parse(req.ReceiptCode)
+		if err != nil {
+			return nil, err
+		}
+
+		filter.ReceiptCode = receiptCode
+	}
+
+	location := time.UTC
+	if req.TimeZone != "" {
+		var err error
+		location, err = time.LoadLocation(req.TimeZone)
+		if err != nil {
+			return nil, domain.ErrInvalidTimeZone
+		}
+	}
+
+	if req.StartDate != "" {
+		startDate, err := time.ParseInLocation(reportDateLayout, req.StartDate, location)
+		if err != nil {
+			return nil, err
+		}
+
+		filter.StartDate = &startDate
+	}
+
+	if req.EndDate != "" {
+		endDate, err := time.ParseInLocation(reportDateLayout, req.EndDate, location)
+		if err != nil {
+			return nil, err
+		}
+
+		endDate = endDate.AddDate(0, 0, 1)
+		filter.EndDate = &endDate
+	}
+
+	return filter, nil
 }
 
 // ListOrders godoc
 //
 //	@Summary		List orders
-//	@Description	List orders and return an array of order data with purchase details
+//	@Description	List orders matching the filters and return an array of order data with purchase details. Without skip, the orders are sorted by creation time and paginated with the next_cursor of the previous page
 //	@Tags			Orders
 //	@Accept			json
 //	@Produce		json
-//	@Param			skip	query		uint64			true	"Skip records"
-//	@Param			limit	query		uint64			true	"Limit records"
-//	@Success		200		{object}	meta			"Orders displayed"
-//	@Failure		400		{object}	errorResponse	"Validation error"
-//	@Failure		401		{object}	errorResponse	"Unauthorized error"
-//	@Failure		500		{object}	errorResponse	"Internal server error"
+//	@Param			skip			query		uint64			false	"Skip records"
+//	@Param			limit			query		uint64			true	"Limit records"
+//	@Param			cursor			query		string			false	"Cursor of the page, used without skip"
+//	@Param			sort			query		string			false	"Sort by (id, customer_name, total_price, created_at), used with skip"
+//	@Param			order			query		string			false	"Sort order (asc, desc)"
+//	@Param			start_date		query		string			false	"Start date (YYYY-MM-DD)"
+//	@Param			end_date		query		string			false	"End date (YYYY-MM-DD), inclusive"
+//	@Param			tz				query		string			false	"IANA time zone of the dates, defaults to UTC"
+//	@Param			user_id			query		uint64			false	"Cashier ID"
+//	@Param			payment_id		query		uint64			false	"Payment ID"
+//	@Param			customer_name	query		string			false	"Part of the customer name"
+//	@Param			receipt_code	query		string			false	"Receipt code"
+//	@Param			min_total		query		number			false	"Minimum total price"
+//	@Param			max_total		query		number			false	"Maximum total price"
+//	@Param			status			query		string			false	"Status (completed, voided)"
+//	@Success		200				{object}	meta			"Orders displayed"
+//	@Failure		400				{object}	errorResponse	"Validation error"
+//	@Failure		401				{object}	errorResponse	"Unauthorized error"
+//	@Failure		500				{object}	errorResponse	"Internal server error"
 //	@Router			/orders [get]
 //	@Security		BearerAuth
 func (oh *OrderHandler) ListOrders(ctx *gin.Context) {
@@ -151,7 +239,25 @@ func (oh *OrderHandler) ListOrders(ctx *gin.Context) {
 		return
 	}
 
-	orders, err := oh.svc.ListOrders(ctx, req.Skip, req.Limit)
+	filter, err := req.toFilter()
+	if err != nil {
+		validationError(ctx, err)
+		return
+	}
+
+	if req.Skip == 0 {
+		oh.listOrdersByCursor(ctx, filter, req)
+		return
+	}
+
+	params := &domain.ListParams{
+		Skip:  req.Skip,
+		Limit: req.Limit,
+		Sort:  req.Sort,
+		Order: req.Order,
+	}
+
+	orders, total, err := oh.svc.ListOrders(ctx, filter, params)
 	if err != nil {
 		handleError(ctx, err)
 		return
@@ -161,9 +267,181 @@ func (oh *OrderHandler) ListOrders(ctx *gin.Context) {
 		ordersList = append(ordersList, newOrderResponse(&order))
 	}
 
-	total := uint64(len(ordersList))
 	meta := newMeta(total, req.Limit, req.Skip)
 	rsp := toMap(meta, ordersList, "orders")
 
 	handleSuccess(ctx, rsp)
 }
+
+// listOrdersByCursor lists the orders matching the filter following the cursor of a request without skip
+func (oh *OrderHandler) listOrdersByCursor(ctx *gin.Context, filter *domain.OrderFilter, req listOrdersRequest) {
+	var ordersList []orderResponse
+
+	cursor, err := decodeCursor(req.Cursor)
+	if err != nil {
+		validationError(ctx, err)
+		return
+	}
+
+	params := &domain.CursorParams{
+		Cursor: cursor,
+		Limit:  req.Limit,
+		Order:  req.Order,
+	}
+
+	orders, next, err := oh.svc.ListOrdersByCursor(ctx, filter, params)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	for _, order := range orders {
+		ordersList = append(ordersList, newOrderResponse(&order))
+	}
+
+	meta := newCursorMeta(req.Limit, encodeCursor(next))
+	rsp := toMap(meta, ordersList, "orders")
+
+	handleSuccess(ctx, rsp)
+}
+
+// exportOrdersRequest represents a request body for exporting orders
+type exportOrdersRequest struct {
+	orderFilterRequest
+	Format exportFormat `form:"format" binding:"required,oneof=csv xlsx" example:"csv"`
+}
+
+// ExportOrders godoc
+//
+//	@Summary		Export orders
+//	@Description	Export the orders matching the filters with one row per purchased product as a CSV or XLSX file
+//	@Tags			Orders
+//	@Produce		text/csv
+//	@Produce		application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+//	@Param			format			query		string			true	"File format (csv or xlsx)"
+//	@Param			start_date		query		string			false	"Start date (YYYY-MM-DD)"
+//	@Param			end_date		query		string			false	"End date (YYYY-MM-DD), inclusive"
+//	@Param			tz				query		string			false	"IANA time zone of the dates, defaults to UTC"
+//	@Param			user_id			query		uint64			false	"Cashier ID"
+//	@Param			payment_id		query		uint64			false	"Payment ID"
+//	@Param			customer_name	query		string			false	"Part of the customer name"
+//	@Param			receipt_code	query		string			false	"Receipt code"
+//	@Param			min_total		query		number			false	"Minimum total price"
+//	@Param			max_total		query		number			false	"Maximum total price"
+//	@Param			status			query		string			false	"Status (completed, voided)"
+//	@Success		200				{file}		file			"Orders exported"
+//	@Failure		400				{object}	errorResponse	"Validation error"
+//	@Failure		401				{object}	errorResponse	"Unauthorized error"
+//	@Failure		403				{object}	errorResponse	"Forbidden error"
+//	@Failure		500				{object}	errorResponse	"Internal server error"
+//	@Router			/orders/export [get]
+//	@Security		BearerAuth
+func (oh *OrderHandler) ExportOrders(ctx *gin.Context) {
+	var req exportOrdersRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		validationError(ctx, err)
+		return
+	}
+
+	filter, err := req.toFilter()
+	if err != nil {
+		validationError(ctx, err)
+		return
+	}
+
+	writer, err := newExportWriter(ctx, req.Format, "orders", orderExportHeader)
+	if err != nil {
+		handleExportError(ctx, domain.ErrInternal)
+		return
+	}
+
+	err = oh.svc.ExportOrders(ctx, filter, func(order *domain.Order) error {
+		for _, row := range newOrderExportRows(order) {
+			err := writer.Write(row)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		handleExportError(ctx, err)
+		return
+	}
+
+	err = writer.Close()
+	if err != nil {
+		handleExportError(ctx, domain.ErrInternal)
+	}
+}
+
+// voidOrderRequest represents a request body for voiding an order
+type voidOrderRequest struct {
+	ID uint64 `uri:"id" binding:"required,min=1" example:"1"`
+}
+
+// VoidOrder godoc
+//
+//	@Summary		Void an order
+//	@Description	Void a completed order and put its products back in stock.
+//	@Description	Users without the orders.void permission need a supervisor's approval token for the order.void action.
+//	@Tags			Orders
+//	@Accept			json
+//	@Produce		json
+//	@Param			id					path		uint64			true	"Order ID"
+//	@Param			X-Override-Token	header		string			false	"Supervisor's approval token"
+//	@Success		200					{object}	orderResponse	"Order voided"
+//	@Failure		400					{object}	errorResponse	"Validation error"
+//	@Failure		401					{object}	errorResponse	"Unauthorized error"
+//	@Failure		403					{object}	errorResponse	"Forbidden error"
+//	@Failure		404					{object}	errorResponse	"Data not found error"
+//	@Failure		409					{object}	errorResponse	"Data conflict error"
+//	@Failure		500					{object}	errorResponse	"Internal server error"
+//	@Router			/orders/{id}/void [post]
+//	@Security		BearerAuth
+func (oh *OrderHandler) VoidOrder(ctx *gin.Context) {
+	var req voidOrderRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		validationError(ctx, err)
+		return
+	}
+
+	authPayload := getAuthPayload(ctx, authorizationPayloadKey)
+
+	order, err := oh.svc.VoidOrder(ctx, req.ID, authPayload.UserID, getApproverID(ctx))
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	rsp := newOrderResponse(order)
+
+	handleSuccess(ctx, rsp)
+}
+
+// OpenDrawer godoc
+//
+//	@Summary		Open the cash drawer
+//	@Description	Record a no-sale opening of the cash drawer.
+//	@Description	Users without the drawer.open permission need a supervisor's approval token for the drawer.open action.
+//	@Tags			Orders
+//	@Produce		json
+//	@Param			X-Override-Token	header		string			false	"Supervisor's approval token"
+//	@Success		200					{object}	response		"Drawer opened"
+//	@Failure		401					{object}	errorResponse	"Unauthorized error"
+//	@Failure		403					{object}	errorResponse	"Forbidden error"
+//	@Failure		500					{object}	errorResponse	"Internal server error"
+//	@Router			/drawer/open [post]
+//	@Security		BearerAuth
+func (oh *OrderHandler) OpenDrawer(ctx *gin.Context) {
+	authPayload := getAuthPayload(ctx, authorizationPayloadKey)
+
+	err := oh.svc.OpenDrawer(ctx, authPayload.UserID, getApproverID(ctx))
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	handleSuccess(ctx, nil)
+}
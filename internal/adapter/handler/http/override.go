@@ -0,0 +1,73 @@
+package http
+
+import (
+	"github.com/nikhil-shrestha/go-pos/internal/core/domain"
+	"github.com/nikhil-shrestha/go-pos/internal/core/port"
+	"github.com/gin-gonic/gin"
+)
+
+// OverrideHandler represents the HTTP handler for supervisor override-related requests
+type OverrideHandler struct {
+	svc port.OverrideService
+}
+
+// NewOverrideHandler creates a new OverrideHandler instance
+func NewOverrideHandler(svc port.OverrideService) *OverrideHandler {
+	return &OverrideHandler{
+		svc,
+	}
+}
+
+// requestOverrideRequest represents the request body for a supervisor's approval of a restricted action,
+// in which the supervisor authenticates with either their email and password or their user id and PIN
+type requestOverrideRequest struct {
+	Action   domain.OverrideAction `json:"action" binding:"required,override_action" example:"order.void"`
+	Email    string                `json:"email" binding:"required_without=UserID,omitempty,email" example:"manager@example.com"`
+	Password string                `json:"password" binding:"required_with=Email,omitempty,min=8" example:"12345678" minLength:"8"`
+	UserID   uint64                `json:"user_id" binding:"required_without=Email,excluded_with=Email" example:"1"`
+	Pin      string                `json:"pin" binding:"required_with=UserID,omitempty,numeric,min=4,max=8" example:"1234"`
+}
+
+// RequestOverride godoc
+//
+//	@Summary		Approve a restricted action
+//	@Description	A supervisor enters their email and password, or their user id and PIN, on the cashier's session to approve a single restricted action.
+//	@Description	The returned token expires after two minutes and must be sent once in the X-Override-Token header of the restricted request.
+//	@Tags			Overrides
+//	@Accept			json
+//	@Produce		json
+//	@Param			requestOverrideRequest	body		requestOverrideRequest	true	"Request override body"
+//	@Success		200						{object}	overrideResponse		"Action approved"
+//	@Failure		400						{object}	errorResponse			"Validation error"
+//	@Failure		401						{object}	errorResponse			"Unauthorized error"
+//	@Failure		403						{object}	errorResponse			"Forbidden error"
+//	@Failure		429						{object}	errorResponse			"Too many failed PIN attempts"
+//	@Failure		500						{object}	errorResponse			"Internal server error"
+//	@Router			/overrides [post]
+//	@Security		BearerAuth
+func (ovh *OverrideHandler) RequestOverride(ctx *gin.Context) {
+	var req requestOverrideRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		validationError(ctx, err)
+		return
+	}
+
+	authPayload := getAuthPayload(ctx, authorizationPayloadKey)
+
+	var override *domain.Override
+	var err error
+
+	if req.UserID != 0 {
+		override, err = ovh.svc.RequestPinOverride(ctx, authPayload, req.Action, req.UserID, req.Pin)
+	} else {
+		override, err = ovh.svc.RequestOverride(ctx, authPayload, req.Action, req.Email, req.Password)
+	}
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	rsp := newOverrideResponse(override)
+
+	handleSuccess(ctx, rsp)
+}
@@ -34,6 +34,7 @@ type createPaymentRequest struct {
 //	@Produce		json
 //	@Param			createPaymentRequest	body		createPaymentRequest	true	"Create payment request"
 //	@Success		200						{object}	paymentResponse			"Payment created"
+//	@Header			200						{string}	ETag					"ETag of the created payment"
 //	@Failure		400						{object}	errorResponse			"Validation error"
 //	@Failure		401						{object}	errorResponse			"Unauthorized error"
 //	@Failure		403						{object}	errorResponse			"Forbidden error"
@@ -61,6 +62,7 @@ func (ph *PaymentHandler) CreatePayment(ctx *gin.Context) {
 		return
 	}
 
+	setETag(ctx, payment.Version)
 	rsp := newPaymentResponse(&payment)
 
 	handleSuccess(ctx, rsp)
@@ -80,6 +82,7 @@ type getPaymentRequest struct {
 //	@Produce		json
 //	@Param			id	path		int				true	"Payment ID"
 //	@Success		200	{object}	paymentResponse	"Payment retrieved"
+//	@Header			200	{string}	ETag			"ETag of the payment"
 //	@Failure		400	{object}	errorResponse	"Validation error"
 //	@Failure		404	{object}	errorResponse	"Data not found error"
 //	@Failure		500	{object}	errorResponse	"Internal server error"
@@ -98,6 +101,7 @@ func (ph *PaymentHandler) GetPayment(ctx *gin.Context) {
 		return
 	}
 
+	setETag(ctx, payment.Version)
 	rsp := newPaymentResponse(payment)
 
 	handleSuccess(ctx, rsp)
@@ -105,8 +109,11 @@ func (ph *PaymentHandler) GetPayment(ctx *gin.Context) {
 
 // listPaymentsRequest represents a request body for listing payments
 type listPaymentsRequest struct {
-	Skip  uint64 `form:"skip" binding:"required,min=0" example:"0"`
-	Limit uint64 `form:"limit" binding:"required,min=5" example:"5"`
+	Skip           uint64           `form:"skip" binding:"required,min=0" example:"0"`
+	Limit          uint64           `form:"limit" binding:"required,min=5" example:"5"`
+	Sort           string           `form:"sort" binding:"omitempty,oneof=id name type created_at" example:"created_at"`
+	Order          domain.SortOrder `form:"order" binding:"omitempty,oneof=asc desc" example:"desc"`
+	IncludeDeleted bool             `form:"include_deleted" binding:"omitempty" example:"false"`
 }
 
 // ListPayments godoc
@@ -116,11 +123,15 @@ type listPaymentsRequest struct {
 //	@Tags			Payments
 //	@Accept			json
 //	@Produce		json
-//	@Param			skip	query		uint64			true	"Skip"
-//	@Param			limit	query		uint64			true	"Limit"
-//	@Success		200		{object}	meta			"Payments displayed"
-//	@Failure		400		{object}	errorResponse	"Validation error"
-//	@Failure		500		{object}	errorResponse	"Internal server error"
+//	@Param			skip			query		uint64			true	"Skip"
+//	@Param			limit			query		uint64			true	"Limit"
+//	@Param			sort			query		string			false	"Sort by (id, name, type, created_at)"
+//	@Param			order			query		string			false	"Sort order (asc, desc)"
+//	@Param			include_deleted	query		bool			false	"Include the soft deleted payments, requires the permission to manage them"
+//	@Success		200				{object}	meta			"Payments displayed"
+//	@Failure		400				{object}	errorResponse	"Validation error"
+//	@Failure		403				{object}	errorResponse	"Forbidden error"
+//	@Failure		500				{object}	errorResponse	"Internal server error"
 //	@Router			/payments [get]
 //	@Security		BearerAuth
 func (ph *PaymentHandler) ListPayments(ctx *gin.Context) {
@@ -132,7 +143,19 @@ func (ph *PaymentHandler) ListPayments(ctx *gin.Context) {
 		return
 	}
 
-	payments, err := ph.svc.ListPayments(ctx, req.Skip, req.Limit)
+	if err := checkIncludeDeleted(ctx, req.IncludeDeleted, domain.PaymentsWrite); err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	params := &domain.ListParams{
+		Skip:  req.Skip,
+		Limit: req.Limit,
+		Sort:  req.Sort,
+		Order: req.Order,
+	}
+
+	payments, total, err := ph.svc.ListPayments(ctx, req.IncludeDeleted, params)
 	if err != nil {
 		handleError(ctx, err)
 		return
@@ -142,7 +165,6 @@ func (ph *PaymentHandler) ListPayments(ctx *gin.Context) {
 		paymentsList = append(paymentsList, newPaymentResponse(&payment))
 	}
 
-	total := uint64(len(paymentsList))
 	meta := newMeta(total, req.Limit, req.Skip)
 	rsp := toMap(meta, paymentsList, "payments")
 
@@ -164,13 +186,17 @@ type updatePaymentRequest struct {
 //	@Accept			json
 //	@Produce		json
 //	@Param			id						path		int						true	"Payment ID"
+//	@Param			If-Match				header		string					true	"ETag of the payment that was read"
 //	@Param			updatePaymentRequest	body		updatePaymentRequest	true	"Update payment request"
 //	@Success		200						{object}	paymentResponse			"Payment updated"
+//	@Header			200						{string}	ETag					"ETag of the updated payment"
 //	@Failure		400						{object}	errorResponse			"Validation error"
 //	@Failure		401						{object}	errorResponse			"Unauthorized error"
 //	@Failure		403						{object}	errorResponse			"Forbidden error"
 //	@Failure		404						{object}	errorResponse			"Data not found error"
 //	@Failure		409						{object}	errorResponse			"Data conflict error"
+//	@Failure		412						{object}	errorResponse			"Version mismatch error"
+//	@Failure		428						{object}	errorResponse			"Precondition required error"
 //	@Failure		500						{object}	errorResponse			"Internal server error"
 //	@Router			/payments/{id} [put]
 //	@Security		BearerAuth
@@ -188,11 +214,18 @@ func (ph *PaymentHandler) UpdatePayment(ctx *gin.Context) {
 		return
 	}
 
+	version, err := getIfMatch(ctx)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+
 	payment := domain.Payment{
-		ID:   id,
-		Name: req.Name,
-		Type: req.Type,
-		Logo: req.Logo,
+		ID:      id,
+		Name:    req.Name,
+		Type:    req.Type,
+		Logo:    req.Logo,
+		Version: version,
 	}
 
 	_, err = ph.svc.UpdatePayment(ctx, &payment)
@@ -201,6 +234,7 @@ func (ph *PaymentHandler) UpdatePayment(ctx *gin.Context) {
 		return
 	}
 
+	setETag(ctx, payment.Version)
 	rsp := newPaymentResponse(&payment)
 
 	handleSuccess(ctx, rsp)
@@ -218,27 +252,78 @@ type deletePaymentRequest struct {
 //	@Tags			Payments
 //	@Accept			json
 //	@Produce		json
+//	@Param			id			path		uint64			true	"Payment ID"
+//	@Param			If-Match	header		string			true	"ETag of the payment that was read"
+//	@Success		200			{object}	response		"Payment deleted"
+//	@Failure		400			{object}	errorResponse	"Validation error"
+//	@Failure		401			{object}	errorResponse	"Unauthorized error"
+//	@Failure		403			{object}	errorResponse	"Forbidden error"
+//	@Failure		404			{object}	errorResponse	"Data not found error"
+//	@Failure		412			{object}	errorResponse	"Version mismatch error"
+//	@Failure		428			{object}	errorResponse	"Precondition required error"
+//	@Failure		500			{object}	errorResponse	"Internal server error"
+//	@Router			/payments/{id} [delete]
+//	@Security		BearerAuth
+func (ph *PaymentHandler) DeletePayment(ctx *gin.Context) {
+	var req deletePaymentRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		validationError(ctx, err)
+		return
+	}
+
+	version, err := getIfMatch(ctx)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	err = ph.svc.DeletePayment(ctx, req.ID, version)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	handleSuccess(ctx, nil)
+}
+
+// restorePaymentRequest represents the request body for restoring a soft deleted payment
+type restorePaymentRequest struct {
+	ID uint64 `uri:"id" binding:"required,min=1" example:"1"`
+}
+
+// RestorePayment godoc
+//
+//	@Summary		Restore a payment
+//	@Description	Restore a soft deleted payment by id
+//	@Tags			Payments
+//	@Accept			json
+//	@Produce		json
 //	@Param			id	path		uint64			true	"Payment ID"
-//	@Success		200	{object}	response		"Payment deleted"
+//	@Success		200	{object}	paymentResponse	"Payment restored"
+//	@Header			200	{string}	ETag			"ETag of the restored payment"
 //	@Failure		400	{object}	errorResponse	"Validation error"
 //	@Failure		401	{object}	errorResponse	"Unauthorized error"
 //	@Failure		403	{object}	errorResponse	"Forbidden error"
 //	@Failure		404	{object}	errorResponse	"Data not found error"
+//	@Failure		409	{object}	errorResponse	"Data conflict error"
 //	@Failure		500	{object}	errorResponse	"Internal server error"
-//	@Router			/payments/{id} [delete]
+//	@Router			/payments/{id}/restore [post]
 //	@Security		BearerAuth
-func (ph *PaymentHandler) DeletePayment(ctx *gin.Context) {
-	var req deletePaymentRequest
+func (ph *PaymentHandler) RestorePayment(ctx *gin.Context) {
+	var req restorePaymentRequest
 	if err := ctx.ShouldBindUri(&req); err != nil {
 		validationError(ctx, err)
 		return
 	}
 
-	err := ph.svc.DeletePayment(ctx, req.ID)
+	payment, err := ph.svc.RestorePayment(ctx, req.ID)
 	if err != nil {
 		handleError(ctx, err)
 		return
 	}
 
-	handleSuccess(ctx, nil)
+	setETag(ctx, payment.Version)
+	rsp := newPaymentResponse(payment)
+
+	handleSuccess(ctx, rsp)
 }
@@ -1,6 +1,8 @@
 package http
 
 import (
+	"mime/multipart"
+
 	"github.com/nikhil-shrestha/go-pos/internal/core/domain"
 	"github.com/nikhil-shrestha/go-pos/internal/core/port"
 	"github.com/gin-gonic/gin"
@@ -22,6 +24,7 @@ func NewProductHandler(svc port.ProductService) *ProductHandler {
 type createProductRequest struct {
 	CategoryID uint64  `json:"category_id" binding:"required,min=1" example:"1"`
 	Name       string  `json:"name" binding:"required" example:"Chiki Ball"`
+	Barcode    string  `json:"barcode" binding:"omitempty,max=64" example:"8991102380101"`
 	Image      string  `json:"image" binding:"required" example:"https://example.com/chiki-ball.png"`
 	Price      float64 `json:"price" binding:"required,min=0" example:"5000"`
 	Stock      int64   `json:"stock" binding:"required,min=0" example:"100"`
@@ -36,6 +39,7 @@ type createProductRequest struct {
 //	@Produce		json
 //	@Param			createProductRequest	body		createProductRequest	true	"Create product request"
 //	@Success		200						{object}	productResponse			"Product created"
+//	@Header			200						{string}	ETag					"ETag of the created product"
 //	@Failure		400						{object}	errorResponse			"Validation error"
 //	@Failure		401						{object}	errorResponse			"Unauthorized error"
 //	@Failure		403						{object}	errorResponse			"Forbidden error"
@@ -54,6 +58,7 @@ func (ph *ProductHandler) CreateProduct(ctx *gin.Context) {
 	product := domain.Product{
 		CategoryID: req.CategoryID,
 		Name:       req.Name,
+		Barcode:    req.Barcode,
 		Image:      req.Image,
 		Price:      req.Price,
 		Stock:      req.Stock,
@@ -65,6 +70,7 @@ func (ph *ProductHandler) CreateProduct(ctx *gin.Context) {
 		return
 	}
 
+	setETag(ctx, product.Version)
 	rsp := newProductResponse(&product)
 
 	handleSuccess(ctx, rsp)
@@ -84,6 +90,7 @@ type getProductRequest struct {
 //	@Produce		json
 //	@Param			id	path		uint64			true	"Product ID"
 //	@Success		200	{object}	productResponse	"Product retrieved"
+//	@Header			200	{string}	ETag			"ETag of the product"
 //	@Failure		400	{object}	errorResponse	"Validation error"
 //	@Failure		404	{object}	errorResponse	"Data not found error"
 //	@Failure		500	{object}	errorResponse	"Internal server error"
@@ -102,33 +109,43 @@ func (ph *ProductHandler) GetProduct(ctx *gin.Context) {
 		return
 	}
 
+	setETag(ctx, product.Version)
 	rsp := newProductResponse(product)
 
 	handleSuccess(ctx, rsp)
 }
 
-// listProductsRequest represents a request body for listing products
+// listProductsRequest represents a request body for listing products, paginated with a cursor instead when skip is omitted
 type listProductsRequest struct {
-	CategoryID uint64 `form:"category_id" binding:"omitempty,min=1" example:"1"`
-	Query      string `form:"q" binding:"omitempty" example:"Chiki"`
-	Skip       uint64 `form:"skip" binding:"required,min=0" example:"0"`
-	Limit      uint64 `form:"limit" binding:"required,min=5" example:"5"`
+	CategoryID     uint64           `form:"category_id" binding:"omitempty,min=1" example:"1"`
+	Query          string           `form:"q" binding:"omitempty" example:"Chiki"`
+	Skip           uint64           `form:"skip" binding:"omitempty,min=1" example:"1"`
+	Limit          uint64           `form:"limit" binding:"required,min=5" example:"5"`
+	Cursor         string           `form:"cursor" binding:"omitempty,excluded_with=Skip" example:"eyJjcmVhdGVkX2F0IjoiMjAyNC0wMS0wMVQwMDowMDowMFoiLCJpZCI6MX0"`
+	Sort           string           `form:"sort" binding:"omitempty,excluded_without=Skip,oneof=id name sku price stock created_at" example:"created_at"`
+	Order          domain.SortOrder `form:"order" binding:"omitempty,oneof=asc desc" example:"desc"`
+	IncludeDeleted bool             `form:"include_deleted" binding:"omitempty" example:"false"`
 }
 
 // ListProducts godoc
 //
 //	@Summary		List products
-//	@Description	List products with pagination
+//	@Description	List products with pagination. Without skip, the products are sorted by creation time and paginated with the next_cursor of the previous page
 //	@Tags			Products
 //	@Accept			json
 //	@Produce		json
-//	@Param			category_id	query		uint64			false	"Category ID"
-//	@Param			q			query		string			false	"Query"
-//	@Param			skip		query		uint64			true	"Skip"
-//	@Param			limit		query		uint64			true	"Limit"
-//	@Success		200			{object}	meta			"Products retrieved"
-//	@Failure		400			{object}	errorResponse	"Validation error"
-//	@Failure		500			{object}	errorResponse	"Internal server error"
+//	@Param			category_id		query		uint64			false	"Category ID, including its subcategories"
+//	@Param			q				query		string			false	"Query"
+//	@Param			skip			query		uint64			false	"Skip"
+//	@Param			limit			query		uint64			true	"Limit"
+//	@Param			cursor			query		string			false	"Cursor of the page, used without skip"
+//	@Param			sort			query		string			false	"Sort by (id, name, sku, price, stock, created_at), used with skip"
+//	@Param			order			query		string			false	"Sort order (asc, desc)"
+//	@Param			include_deleted	query		bool			false	"Include the soft deleted products, requires the permission to manage them"
+//	@Success		200				{object}	meta			"Products retrieved"
+//	@Failure		400				{object}	errorResponse	"Validation error"
+//	@Failure		403				{object}	errorResponse	"Forbidden error"
+//	@Failure		500				{object}	errorResponse	"Internal server error"
 //	@Router			/products [get]
 //	@Security		BearerAuth
 func (ph *ProductHandler) ListProducts(ctx *gin.Context) {
@@ -140,7 +157,24 @@ func (ph *ProductHandler) ListProducts(ctx *gin.Context) {
 		return
 	}
 
-	products, err := ph.svc.ListProducts(ctx, req.Query, req.CategoryID, req.Skip, req.Limit)
+	if err := checkIncludeDeleted(ctx, req.IncludeDeleted, domain.ProductsWrite); err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	if req.Skip == 0 {
+		ph.listProductsByCursor(ctx, req)
+		return
+	}
+
+	params := &domain.ListParams{
+		Skip:  req.Skip,
+		Limit: req.Limit,
+		Sort:  req.Sort,
+		Order: req.Order,
+	}
+
+	products, total, err := ph.svc.ListProducts(ctx, req.Query, req.CategoryID, req.IncludeDeleted, params)
 	if err != nil {
 		handleError(ctx, err)
 		return
@@ -150,17 +184,154 @@ func (ph *ProductHandler) ListProducts(ctx *gin.Context) {
 		productsList = append(productsList, newProductResponse(&product))
 	}
 
-	total := uint64(len(productsList))
 	meta := newMeta(total, req.Limit, req.Skip)
 	rsp := toMap(meta, productsList, "products")
 
 	handleSuccess(ctx, rsp)
 }
 
+// listProductsByCursor lists the products following the cursor of a request without skip
+func (ph *ProductHandler) listProductsByCursor(ctx *gin.Context, req listProductsRequest) {
+	var productsList []productResponse
+
+	cursor, err := decodeCursor(req.Cursor)
+	if err != nil {
+		validationError(ctx, err)
+		return
+	}
+
+	params := &domain.CursorParams{
+		Cursor: cursor,
+		Limit:  req.Limit,
+		Order:  req.Order,
+	}
+
+	products, next, err := ph.svc.ListProductsByCursor(ctx, req.Query, req.CategoryID, req.IncludeDeleted, params)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	for _, product := range products {
+		productsList = append(productsList, newProductResponse(&product))
+	}
+
+	meta := newCursorMeta(req.Limit, encodeCursor(next))
+	rsp := toMap(meta, productsList, "products")
+
+	handleSuccess(ctx, rsp)
+}
+
+// exportProductsRequest represents a request body for exporting products
+type exportProductsRequest struct {
+	CategoryID uint64       `form:"category_id" binding:"omitempty,min=1" example:"1"`
+	Query      string       `form:"q" binding:"omitempty" example:"Chiki"`
+	Format     exportFormat `form:"format" binding:"required,oneof=csv xlsx" example:"csv"`
+}
+
+// ExportProducts godoc
+//
+//	@Summary		Export products
+//	@Description	Export all products matching the filters with their category and stock as a CSV or XLSX file
+//	@Tags			Products
+//	@Produce		text/csv
+//	@Produce		application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+//	@Param			category_id	query		uint64			false	"Category ID, including its subcategories"
+//	@Param			q			query		string			false	"Query"
+//	@Param			format		query		string			true	"File format (csv or xlsx)"
+//	@Success		200			{file}		file			"Products exported"
+//	@Failure		400			{object}	errorResponse	"Validation error"
+//	@Failure		401			{object}	errorResponse	"Unauthorized error"
+//	@Failure		403			{object}	errorResponse	"Forbidden error"
+//	@Failure		500			{object}	errorResponse	"Internal server error"
+//	@Router			/products/export [get]
+//	@Security		BearerAuth
+func (ph *ProductHandler) ExportProducts(ctx *gin.Context) {
+	var req exportProductsRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		validationError(ctx, err)
+		return
+	}
+
+	writer, err := newExportWriter(ctx, req.Format, "products", productExportHeader)
+	if err != nil {
+		handleExportError(ctx, domain.ErrInternal)
+		return
+	}
+
+	err = ph.svc.ExportProducts(ctx, req.Query, req.CategoryID, func(product *domain.Product) error {
+		return writer.Write(newProductExportRow(product))
+	})
+	if err != nil {
+		handleExportError(ctx, err)
+		return
+	}
+
+	err = writer.Close()
+	if err != nil {
+		handleExportError(ctx, domain.ErrInternal)
+	}
+}
+
+// importProductsRequest represents a request body for importing products
+type importProductsRequest struct {
+	File   *multipart.FileHeader `form:"file" binding:"required" swaggerignore:"true"`
+	DryRun bool                  `form:"dry_run" binding:"omitempty" example:"true"`
+}
+
+// ImportProducts godoc
+//
+//	@Summary		Import products
+//	@Description	Create or update products in bulk from a CSV or XLSX file, matching existing products by SKU or barcode and categories by name.
+//	@Description	Every row is validated and reported, and nothing is saved unless all rows are valid.
+//	@Tags			Products
+//	@Accept			multipart/form-data
+//	@Produce		json
+//	@Param			file	formData	file					true	"CSV or XLSX file with the product export columns"
+//	@Param			dry_run	query		bool					false	"Validate the file without saving"
+//	@Success		200		{object}	productImportResponse	"Products imported"
+//	@Failure		400		{object}	errorResponse			"Validation error"
+//	@Failure		401		{object}	errorResponse			"Unauthorized error"
+//	@Failure		403		{object}	errorResponse			"Forbidden error"
+//	@Failure		500		{object}	errorResponse			"Internal server error"
+//	@Router			/products/import [post]
+//	@Security		BearerAuth
+func (ph *ProductHandler) ImportProducts(ctx *gin.Context) {
+	var req importProductsRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		validationError(ctx, err)
+		return
+	}
+
+	reader, err := newImportReader(req.File)
+	if err != nil {
+		handleError(ctx, domain.ErrInvalidImportFile)
+		return
+	}
+	defer reader.Close()
+
+	productImport, err := newProductImport(reader, req.DryRun)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	productImport, err = ph.svc.ImportProducts(ctx, productImport)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	rsp := newProductImportResponse(productImport)
+
+	handleSuccess(ctx, rsp)
+}
+
 // updateProductRequest represents a request body for updating a product
 type updateProductRequest struct {
 	CategoryID uint64  `json:"category_id" binding:"omitempty,required,min=1" example:"1"`
 	Name       string  `json:"name" binding:"omitempty,required" example:"Nutrisari Jeruk"`
+	Barcode    string  `json:"barcode" binding:"omitempty,required,max=64" example:"8992696404441"`
 	Image      string  `json:"image" binding:"omitempty,required" example:"https://example.com/nutrisari-jeruk.png"`
 	Price      float64 `json:"price" binding:"omitempty,required,min=0" example:"2000"`
 	Stock      int64   `json:"stock" binding:"omitempty,required,min=0" example:"200"`
@@ -169,18 +340,22 @@ type updateProductRequest struct {
 // UpdateProduct godoc
 //
 //	@Summary		Update a product
-//	@Description	update a product's name, image, price, or stock by id
+//	@Description	update a product's name, barcode, image, price, or stock by id
 //	@Tags			Products
 //	@Accept			json
 //	@Produce		json
 //	@Param			id						path		uint64					true	"Product ID"
+//	@Param			If-Match				header		string					true	"ETag of the product that was read"
 //	@Param			updateProductRequest	body		updateProductRequest	true	"Update product request"
 //	@Success		200						{object}	productResponse			"Product updated"
+//	@Header			200						{string}	ETag					"ETag of the updated product"
 //	@Failure		400						{object}	errorResponse			"Validation error"
 //	@Failure		401						{object}	errorResponse			"Unauthorized error"
 //	@Failure		403						{object}	errorResponse			"Forbidden error"
 //	@Failure		404						{object}	errorResponse			"Data not found error"
 //	@Failure		409						{object}	errorResponse			"Data conflict error"
+//	@Failure		412						{object}	errorResponse			"Version mismatch error"
+//	@Failure		428						{object}	errorResponse			"Precondition required error"
 //	@Failure		500						{object}	errorResponse			"Internal server error"
 //	@Router			/products/{id} [put]
 //	@Security		BearerAuth
@@ -198,13 +373,21 @@ func (ph *ProductHandler) UpdateProduct(ctx *gin.Context) {
 		return
 	}
 
+	version, err := getIfMatch(ctx)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+
 	product := domain.Product{
 		ID:         id,
 		CategoryID: req.CategoryID,
 		Name:       req.Name,
+		Barcode:    req.Barcode,
 		Image:      req.Image,
 		Price:      req.Price,
 		Stock:      req.Stock,
+		Version:    version,
 	}
 
 	_, err = ph.svc.UpdateProduct(ctx, &product)
@@ -213,6 +396,7 @@ func (ph *ProductHandler) UpdateProduct(ctx *gin.Context) {
 		return
 	}
 
+	setETag(ctx, product.Version)
 	rsp := newProductResponse(&product)
 
 	handleSuccess(ctx, rsp)
@@ -230,27 +414,78 @@ type deleteProductRequest struct {
 //	@Tags			Products
 //	@Accept			json
 //	@Produce		json
+//	@Param			id			path		uint64			true	"Product ID"
+//	@Param			If-Match	header		string			true	"ETag of the product that was read"
+//	@Success		200			{object}	response		"Product deleted"
+//	@Failure		400			{object}	errorResponse	"Validation error"
+//	@Failure		401			{object}	errorResponse	"Unauthorized error"
+//	@Failure		403			{object}	errorResponse	"Forbidden error"
+//	@Failure		404			{object}	errorResponse	"Data not found error"
+//	@Failure		412			{object}	errorResponse	"Version mismatch error"
+//	@Failure		428			{object}	errorResponse	"Precondition required error"
+//	@Failure		500			{object}	errorResponse	"Internal server error"
+//	@Router			/products/{id} [delete]
+//	@Security		BearerAuth
+func (ph *ProductHandler) DeleteProduct(ctx *gin.Context) {
+	var req deleteProductRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		validationError(ctx, err)
+		return
+	}
+
+	version, err := getIfMatch(ctx)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	err = ph.svc.DeleteProduct(ctx, req.ID, version)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	handleSuccess(ctx, nil)
+}
+
+// restoreProductRequest represents the request body for restoring a soft deleted product
+type restoreProductRequest struct {
+	ID uint64 `uri:"id" binding:"required,min=1" example:"1"`
+}
+
+// RestoreProduct godoc
+//
+//	@Summary		Restore a product
+//	@Description	Restore a soft deleted product by id
+//	@Tags			Products
+//	@Accept			json
+//	@Produce		json
 //	@Param			id	path		uint64			true	"Product ID"
-//	@Success		200	{object}	response		"Product deleted"
+//	@Success		200	{object}	productResponse	"Product restored"
+//	@Header			200	{string}	ETag			"ETag of the restored product"
 //	@Failure		400	{object}	errorResponse	"Validation error"
 //	@Failure		401	{object}	errorResponse	"Unauthorized error"
 //	@Failure		403	{object}	errorResponse	"Forbidden error"
 //	@Failure		404	{object}	errorResponse	"Data not found error"
+//	@Failure		409	{object}	errorResponse	"Data conflict error"
 //	@Failure		500	{object}	errorResponse	"Internal server error"
-//	@Router			/products/{id} [delete]
+//	@Router			/products/{id}/restore [post]
 //	@Security		BearerAuth
-func (ph *ProductHandler) DeleteProduct(ctx *gin.Context) {
-	var req deleteProductRequest
+func (ph *ProductHandler) RestoreProduct(ctx *gin.Context) {
+	var req restoreProductRequest
 	if err := ctx.ShouldBindUri(&req); err != nil {
 		validationError(ctx, err)
 		return
 	}
 
-	err := ph.svc.DeleteProduct(ctx, req.ID)
+	product, err := ph.svc.RestoreProduct(ctx, req.ID)
 	if err != nil {
 		handleError(ctx, err)
 		return
 	}
 
-	handleSuccess(ctx, nil)
+	setETag(ctx, product.Version)
+	rsp := newProductResponse(product)
+
+	handleSuccess(ctx, rsp)
 }
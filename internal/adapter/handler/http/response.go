@@ -1,13 +1,17 @@
 package http
 
 import (
+	"encoding/base64"
 	"errors"
+	"math"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/nikhil-shrestha/go-pos/internal/core/domain"
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
 )
 
 // response represents a response body format
@@ -42,27 +46,213 @@ func newMeta(total, limit, skip uint64) meta {
 	}
 }
 
-// authResponse represents an authentication response body
+// cursorMeta represents metadata for a response paginated with cursors, without a next cursor on the last page
+type cursorMeta struct {
+	Limit      uint64 `json:"limit" example:"10"`
+	NextCursor string `json:"next_cursor,omitempty" example:"eyJjcmVhdGVkX2F0IjoiMjAyNC0wMS0wMVQwMDowMDowMFoiLCJpZCI6MX0"`
+}
+
+// newCursorMeta is a helper function to create metadata for a response paginated with cursors
+func newCursorMeta(limit uint64, nextCursor string) cursorMeta {
+	return cursorMeta{
+		Limit:      limit,
+		NextCursor: nextCursor,
+	}
+}
+
+// authResponse represents an authentication response body, which has a challenge instead of the tokens if a two-factor code is needed
 type authResponse struct {
-	AccessToken string `json:"token" example:"v2.local.Gdh5kiOTyyaQ3_bNykYDeYHO21Jg2..."`
+	AccessToken   string             `json:"token,omitempty" example:"v4.local.Gdh5kiOTyyaQ3_bNykYDeYHO21Jg2..."`
+	RefreshToken  string             `json:"refresh_token,omitempty" example:"b4c1f4f2-4b8a-4a0e-9a4f-3d2f0c6f1e2a.5e0a8b1c-2d3e-4f5a-8b7c-9d0e1f2a3b4c"`
+	Challenge     *challengeResponse `json:"challenge,omitempty"`
+	RecoveryCodes []string           `json:"recovery_codes,omitempty" example:"3f9a1-c07be,8d2e4-51fa9"`
 }
 
 // newAuthResponse is a helper function to create a response body for handling authentication data
-func newAuthResponse(token string) authResponse {
-	return authResponse{
-		AccessToken: token,
+func newAuthResponse(token *domain.AuthToken) authResponse {
+	rsp := authResponse{
+		AccessToken:   token.AccessToken,
+		RefreshToken:  token.RefreshToken,
+		RecoveryCodes: token.RecoveryCodes,
+	}
+
+	if token.Challenge != nil {
+		challenge := newChallengeResponse(token.Challenge)
+		rsp.Challenge = &challenge
 	}
+
+	return rsp
 }
 
-// userResponse represents a user response body
-type userResponse struct {
+// challengeResponse represents a login challenge response body
+type challengeResponse struct {
+	Token         string    `json:"token" example:"4979cf6e-d215-4ff8-9d0d-b3e99bcc7750"`
+	SetupRequired bool      `json:"setup_required" example:"false"`
+	ExpiresAt     time.Time `json:"expires_at" example:"1970-01-01T00:00:00Z"`
+}
+
+// newChallengeResponse is a helper function to create a response body for handling login challenge data
+func newChallengeResponse(challenge *domain.LoginChallenge) challengeResponse {
+	return challengeResponse{
+		Token:         challenge.ID.String(),
+		SetupRequired: challenge.Setup,
+		ExpiresAt:     challenge.ExpiresAt,
+	}
+}
+
+// twoFactorSetupResponse represents a two-factor setup response body
+type twoFactorSetupResponse struct {
+	Secret string `json:"secret" example:"JBSWY3DPEHPK3PXP"`
+	URI    string `json:"uri" example:"otpauth://totp/Go%20POS:test@example.com?algorithm=SHA1&digits=6&issuer=Go%20POS&period=30&secret=JBSWY3DPEHPK3PXP"`
+	QRCode string `json:"qr_code" example:"data:image/png;base64,iVBORw0KGgo..."`
+}
+
+// newTwoFactorSetupResponse is a helper function to create a response body for handling two-factor setup data
+func newTwoFactorSetupResponse(setup *domain.TwoFactorSetup) twoFactorSetupResponse {
+	return twoFactorSetupResponse{
+		Secret: setup.Secret,
+		URI:    setup.URI,
+		QRCode: "data:image/png;base64," + base64.StdEncoding.EncodeToString(setup.QRCode),
+	}
+}
+
+// recoveryCodesResponse represents a recovery codes response body
+type recoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes" example:"3f9a1-c07be,8d2e4-51fa9"`
+}
+
+// overrideResponse represents a supervisor's approval response body
+type overrideResponse struct {
+	Token       string                `json:"token" example:"4979cf6e-d215-4ff8-9d0d-b3e99bcc7750"`
+	Action      domain.OverrideAction `json:"action" example:"order.void"`
+	RequestedBy uint64                `json:"requested_by" example:"2"`
+	ApprovedBy  uint64                `json:"approved_by" example:"1"`
+	ExpiresAt   time.Time             `json:"expires_at" example:"1970-01-01T00:00:00Z"`
+}
+
+// newOverrideResponse is a helper function to create a response body for handling a supervisor's approval
+func newOverrideResponse(override *domain.Override) overrideResponse {
+	return overrideResponse{
+		Token:       override.ID.String(),
+		Action:      override.Action,
+		RequestedBy: override.RequestedBy,
+		ApprovedBy:  override.ApprovedBy,
+		ExpiresAt:   override.ExpiresAt,
+	}
+}
+
+// terminalResponse represents a terminal response body
+type terminalResponse struct {
 	ID        uint64    `json:"id" example:"1"`
-	Name      string    `json:"name" example:"John Doe"`
-	Email     string    `json:"email" example:"test@example.com"`
+	Name      string    `json:"name" example:"Front Counter 1"`
+	Key       string    `json:"key,omitempty" example:"9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"`
 	CreatedAt time.Time `json:"created_at" example:"1970-01-01T00:00:00Z"`
 	UpdatedAt time.Time `json:"updated_at" example:"1970-01-01T00:00:00Z"`
 }
 
+// newTerminalResponse is a helper function to create a response body for handling terminal data
+func newTerminalResponse(terminal *domain.Terminal) terminalResponse {
+	return terminalResponse{
+		ID:        terminal.ID,
+		Name:      terminal.Name,
+		Key:       terminal.Key,
+		CreatedAt: terminal.CreatedAt,
+		UpdatedAt: terminal.UpdatedAt,
+	}
+}
+
+// apiKeyResponse represents an API key response body
+type apiKeyResponse struct {
+	ID          uint64              `json:"id" example:"1"`
+	Name        string              `json:"name" example:"Online Store"`
+	Prefix      string              `json:"prefix" example:"1a2b3c4d"`
+	Key         string              `json:"key,omitempty" example:"gopos_1a2b3c4d_9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"`
+	Permissions []domain.Permission `json:"permissions" example:"products.write,orders.create"`
+	CreatedBy   uint64              `json:"created_by" example:"1"`
+	ExpiresAt   *time.Time          `json:"expires_at" example:"1970-01-01T00:00:00Z"`
+	LastUsedAt  *time.Time          `json:"last_used_at" example:"1970-01-01T00:00:00Z"`
+	CreatedAt   time.Time           `json:"created_at" example:"1970-01-01T00:00:00Z"`
+	UpdatedAt   time.Time           `json:"updated_at" example:"1970-01-01T00:00:00Z"`
+}
+
+// newAPIKeyResponse is a helper function to create a response body for handling API key data
+func newAPIKeyResponse(apiKey *domain.APIKey) apiKeyResponse {
+	return apiKeyResponse{
+		ID:          apiKey.ID,
+		Name:        apiKey.Name,
+		Prefix:      apiKey.Prefix,
+		Key:         apiKey.Key,
+		Permissions: apiKey.Permissions,
+		CreatedBy:   apiKey.CreatedBy,
+		ExpiresAt:   apiKey.ExpiresAt,
+		LastUsedAt:  apiKey.LastUsedAt,
+		CreatedAt:   apiKey.CreatedAt,
+		UpdatedAt:   apiKey.UpdatedAt,
+	}
+}
+
+// auditLogResponse represents an audit log response body
+type auditLogResponse struct {
+	ID         uint64                        `json:"id" example:"1"`
+	UserID     uint64                        `json:"user_id,omitempty" example:"1"`
+	APIKeyID   uint64                        `json:"api_key_id,omitempty" example:"1"`
+	ApproverID uint64                        `json:"approver_id,omitempty" example:"2"`
+	Action     string                        `json:"action" example:"product.update"`
+	Entity     string                        `json:"entity" example:"product"`
+	EntityID   uint64                        `json:"entity_id,omitempty" example:"1"`
+	Changes    map[string]domain.AuditChange `json:"changes,omitempty"`
+	IP         string                        `json:"ip,omitempty" example:"127.0.0.1"`
+	RequestID  string                        `json:"request_id,omitempty" example:"b4c1f4f2-4b8a-4a0e-9a4f-3d2f0c6f1e2a"`
+	PrevHash   string                        `json:"prev_hash" example:"9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"`
+	Hash       string                        `json:"hash" example:"60303ae22b998861bce3b28f33eec1be758a213c86c93c076dbe9f558c11c752"`
+	CreatedAt  time.Time                     `json:"created_at" example:"1970-01-01T00:00:00Z"`
+}
+
+// newAuditLogResponse is a helper function to create a response body for handling audit log data
+func newAuditLogResponse(auditLog *domain.AuditLog) auditLogResponse {
+	return auditLogResponse{
+		ID:         auditLog.ID,
+		UserID:     auditLog.UserID,
+		APIKeyID:   auditLog.APIKeyID,
+		ApproverID: auditLog.ApproverID,
+		Action:     auditLog.Action,
+		Entity:     auditLog.Entity,
+		EntityID:   auditLog.EntityID,
+		Changes:    auditLog.Changes,
+		IP:         auditLog.IP,
+		RequestID:  auditLog.RequestID,
+		PrevHash:   auditLog.PrevHash,
+		Hash:       auditLog.Hash,
+		CreatedAt:  auditLog.CreatedAt,
+	}
+}
+
+// auditVerificationResponse represents a response body of checking the hash chain of the audit trail
+type auditVerificationResponse struct {
+	Valid    bool   `json:"valid" example:"true"`
+	Checked  uint64 `json:"checked" example:"100"`
+	BrokenID uint64 `json:"broken_id,omitempty" example:"42"`
+}
+
+// newAuditVerificationResponse is a helper function to create a response body for handling audit verification data
+func newAuditVerificationResponse(verification *domain.AuditVerification) auditVerificationResponse {
+	return auditVerificationResponse{
+		Valid:    verification.IsValid(),
+		Checked:  verification.Checked,
+		BrokenID: verification.BrokenID,
+	}
+}
+
+// userResponse represents a user response body
+type userResponse struct {
+	ID        uint64     `json:"id" example:"1"`
+	Name      string     `json:"name" example:"John Doe"`
+	Email     string     `json:"email" example:"test@example.com"`
+	CreatedAt time.Time  `json:"created_at" example:"1970-01-01T00:00:00Z"`
+	UpdatedAt time.Time  `json:"updated_at" example:"1970-01-01T00:00:00Z"`
+	DeletedAt *time.Time `json:"deleted_at,omitempty" example:"1970-01-01T00:00:00Z"`
+}
+
 // newUserResponse is a helper function to create a response body for handling user data
 func newUserResponse(user *domain.User) userResponse {
 	return userResponse{
@@ -71,52 +261,91 @@ func newUserResponse(user *domain.User) userResponse {
 		Email:     user.Email,
 		CreatedAt: user.CreatedAt,
 		UpdatedAt: user.UpdatedAt,
+		DeletedAt: user.DeletedAt,
+	}
+}
+
+// roleResponse represents a role response body
+type roleResponse struct {
+	ID          uint64              `json:"id" example:"1"`
+	Name        domain.UserRole     `json:"name" example:"supervisor"`
+	Permissions []domain.Permission `json:"permissions" example:"orders.create,reports.view"`
+	CreatedAt   time.Time           `json:"created_at" example:"1970-01-01T00:00:00Z"`
+	UpdatedAt   time.Time           `json:"updated_at" example:"1970-01-01T00:00:00Z"`
+}
+
+// newRoleResponse is a helper function to create a response body for handling role data
+func newRoleResponse(role *domain.Role) roleResponse {
+	return roleResponse{
+		ID:          role.ID,
+		Name:        role.Name,
+		Permissions: role.Permissions,
+		CreatedAt:   role.CreatedAt,
+		UpdatedAt:   role.UpdatedAt,
 	}
 }
 
 // paymentResponse represents a payment response body
 type paymentResponse struct {
-	ID   uint64             `json:"id" example:"1"`
-	Name string             `json:"name" example:"Tunai"`
-	Type domain.PaymentType `json:"type" example:"CASH"`
-	Logo string             `json:"logo" example:"https://example.com/cash.png"`
+	ID            uint64             `json:"id" example:"1"`
+	Name          string             `json:"name" example:"Tunai"`
+	Type          domain.PaymentType `json:"type" example:"CASH"`
+	Logo          string             `json:"logo" example:"https://example.com/cash.png"`
+	LogoThumbnail string             `json:"logo_thumbnail" example:"https://example.com/cash_thumb.png"`
+	DeletedAt     *time.Time         `json:"deleted_at,omitempty" example:"1970-01-01T00:00:00Z"`
 }
 
 // newPaymentResponse is a helper function to create a response body for handling payment data
 func newPaymentResponse(payment *domain.Payment) paymentResponse {
 	return paymentResponse{
-		ID:   payment.ID,
-		Name: payment.Name,
-		Type: payment.Type,
-		Logo: payment.Logo,
+		ID:            payment.ID,
+		Name:          payment.Name,
+		Type:          payment.Type,
+		Logo:          payment.Logo,
+		LogoThumbnail: payment.LogoThumbnail,
+		DeletedAt:     payment.DeletedAt,
 	}
 }
 
 // categoryResponse represents a category response body
 type categoryResponse struct {
-	ID   uint64 `json:"id" example:"1"`
-	Name string `json:"name" example:"Foods"`
+	ID        uint64             `json:"id" example:"1"`
+	ParentID  uint64             `json:"parent_id,omitempty" example:"1"`
+	Name      string             `json:"name" example:"Foods"`
+	DeletedAt *time.Time         `json:"deleted_at,omitempty" example:"1970-01-01T00:00:00Z"`
+	Children  []categoryResponse `json:"children,omitempty"`
 }
 
-// newCategoryResponse is a helper function to create a response body for handling category data
+// newCategoryResponse is a helper function to create a response body for handling category data and its subcategories
 func newCategoryResponse(category *domain.Category) categoryResponse {
-	return categoryResponse{
-		ID:   category.ID,
-		Name: category.Name,
+	rsp := categoryResponse{
+		ID:        category.ID,
+		ParentID:  category.ParentID,
+		Name:      category.Name,
+		DeletedAt: category.DeletedAt,
 	}
+
+	for _, child := range category.Children {
+		rsp.Children = append(rsp.Children, newCategoryResponse(&child))
+	}
+
+	return rsp
 }
 
 // productResponse represents a product response body
 type productResponse struct {
 	ID        uint64           `json:"id" example:"1"`
 	SKU       string           `json:"sku" example:"9a4c25d3-9786-492c-b084-85cb75c1ee3e"`
+	Barcode   string           `json:"barcode" example:"8991102380101"`
 	Name      string           `json:"name" example:"Chiki Ball"`
 	Stock     int64            `json:"stock" example:"100"`
 	Price     float64          `json:"price" example:"5000"`
 	Image     string           `json:"image" example:"https://example.com/chiki-ball.png"`
+	Thumbnail string           `json:"thumbnail" example:"https://example.com/chiki-ball_thumb.png"`
 	Category  categoryResponse `json:"category"`
 	CreatedAt time.Time        `json:"created_at" example:"1970-01-01T00:00:00Z"`
 	UpdatedAt time.Time        `json:"updated_at" example:"1970-01-01T00:00:00Z"`
+	DeletedAt *time.Time       `json:"deleted_at,omitempty" example:"1970-01-01T00:00:00Z"`
 }
 
 // newProductResponse is a helper function to create a response body for handling product data
@@ -124,14 +353,78 @@ func newProductResponse(product *domain.Product) productResponse {
 	return productResponse{
 		ID:        product.ID,
 		SKU:       product.SKU.String(),
+		Barcode:   product.Barcode,
 		Name:      product.Name,
 		Stock:     product.Stock,
 		Price:     product.Price,
 		Image:     product.Image,
+		Thumbnail: product.Thumbnail,
 		Category:  newCategoryResponse(product.Category),
 		CreatedAt: product.CreatedAt,
 		UpdatedAt: product.UpdatedAt,
+		DeletedAt: product.DeletedAt,
+	}
+}
+
+// productImportResponse represents a product import report response body
+type productImportResponse struct {
+	DryRun  bool                       `json:"dry_run" example:"false"`
+	Applied bool                       `json:"applied" example:"true"`
+	Created int                        `json:"created" example:"10"`
+	Updated int                        `json:"updated" example:"2"`
+	Failed  int                        `json:"failed" example:"0"`
+	Rows    []productImportRowResponse `json:"rows"`
+}
+
+// productImportRowResponse represents the result of a single row of a product import
+type productImportRowResponse struct {
+	Line      int      `json:"line" example:"2"`
+	ProductID uint64   `json:"product_id,omitempty" example:"1"`
+	SKU       string   `json:"sku,omitempty" example:"9a4c25d3-9786-492c-b084-85cb75c1ee3e"`
+	Barcode   string   `json:"barcode,omitempty" example:"8991102380101"`
+	Name      string   `json:"name" example:"Chiki Ball"`
+	Action    string   `json:"action,omitempty" example:"create"`
+	Errors    []string `json:"errors,omitempty" example:"Price must be a number"`
+}
+
+// newProductImportResponse is a helper function to create a response body for handling product import data
+func newProductImportResponse(productImport *domain.ProductImport) productImportResponse {
+	rsp := productImportResponse{
+		DryRun:  productImport.DryRun,
+		Applied: productImport.Applied,
+		Rows:    []productImportRowResponse{},
 	}
+
+	for _, row := range productImport.Rows {
+		rowRsp := productImportRowResponse{
+			Line:    row.Line,
+			Barcode: row.Product.Barcode,
+			Name:    row.Product.Name,
+			Action:  string(row.Action),
+			Errors:  row.Errors,
+		}
+
+		// products created by a dry run or a failed import have been rolled back
+		if productImport.Applied || row.Action != domain.ProductCreated {
+			rowRsp.ProductID = row.Product.ID
+			if row.Product.SKU != uuid.Nil {
+				rowRsp.SKU = row.Product.SKU.String()
+			}
+		}
+
+		switch {
+		case len(row.Errors) > 0:
+			rsp.Failed++
+		case row.Action == domain.ProductCreated:
+			rsp.Created++
+		case row.Action == domain.ProductUpdated:
+			rsp.Updated++
+		}
+
+		rsp.Rows = append(rsp.Rows, rowRsp)
+	}
+
+	return rsp
 }
 
 // orderResponse represents an order response body
@@ -144,6 +437,8 @@ type orderResponse struct {
 	TotalPaid    float64                `json:"total_paid" example:"100000"`
 	TotalReturn  float64                `json:"total_return" example:"0"`
 	ReceiptCode  string                 `json:"receipt_id" example:"4979cf6e-d215-4ff8-9d0d-b3e99bcc7750"`
+	Status       domain.OrderStatus     `json:"status" example:"completed"`
+	VoidedAt     *time.Time             `json:"voided_at,omitempty" example:"1970-01-01T00:00:00Z"`
 	Products     []orderProductResponse `json:"products"`
 	PaymentType  paymentResponse        `json:"payment_type"`
 	CreatedAt    time.Time              `json:"created_at" example:"1970-01-01T00:00:00Z"`
@@ -161,6 +456,8 @@ func newOrderResponse(order *domain.Order) orderResponse {
 		TotalPaid:    order.TotalPaid,
 		TotalReturn:  order.TotalReturn,
 		ReceiptCode:  order.ReceiptCode.String(),
+		Status:       order.Status,
+		VoidedAt:     order.VoidedAt,
 		Products:     newOrderProductResponse(order.Products),
 		PaymentType:  newPaymentResponse(order.Payment),
 		CreatedAt:    order.CreatedAt,
@@ -168,18 +465,20 @@ func newOrderResponse(order *domain.Order) orderResponse {
 	}
 }
 
-// orderProductResponse represents an order product response body
+// orderProductResponse represents an order product response body, whose product details are as they were at the time of the sale
 type orderProductResponse struct {
-	ID               uint64          `json:"id" example:"1"`
-	OrderID          uint64          `json:"order_id" example:"1"`
-	ProductID        uint64          `json:"product_id" example:"1"`
-	Quantity         int64           `json:"qty" example:"1"`
-	Price            float64         `json:"price" example:"100000"`
-	TotalNormalPrice float64         `json:"total_normal_price" example:"100000"`
-	TotalFinalPrice  float64         `json:"total_final_price" example:"100000"`
-	Product          productResponse `json:"product"`
-	CreatedAt        time.Time       `json:"created_at" example:"1970-01-01T00:00:00Z"`
-	UpdatedAt        time.Time       `json:"updated_at" example:"1970-01-01T00:00:00Z"`
+	ID               uint64    `json:"id" example:"1"`
+	OrderID          uint64    `json:"order_id" example:"1"`
+	ProductID        uint64    `json:"product_id" example:"1"`
+	ProductName      string    `json:"product_name" example:"Chiki Ball"`
+	ProductSKU       string    `json:"product_sku" example:"9a4c25d3-9786-492c-b084-85cb75c1ee3e"`
+	CategoryName     string    `json:"category_name" example:"Foods"`
+	Quantity         int64     `json:"qty" example:"1"`
+	Price            float64   `json:"price" example:"100000"`
+	TotalNormalPrice float64   `json:"total_normal_price" example:"100000"`
+	TotalFinalPrice  float64   `json:"total_final_price" example:"100000"`
+	CreatedAt        time.Time `json:"created_at" example:"1970-01-01T00:00:00Z"`
+	UpdatedAt        time.Time `json:"updated_at" example:"1970-01-01T00:00:00Z"`
 }
 
 // newOrderProductResponse is a helper function to create a response body for handling order product data
@@ -191,11 +490,13 @@ func newOrderProductResponse(orderProduct []domain.OrderProduct) []orderProductR
 			ID:               orderProduct.ID,
 			OrderID:          orderProduct.OrderID,
 			ProductID:        orderProduct.ProductID,
+			ProductName:      orderProduct.ProductName,
+			ProductSKU:       orderProduct.ProductSKU.String(),
+			CategoryName:     orderProduct.CategoryName,
 			Quantity:         orderProduct.Quantity,
-			Price:            orderProduct.Product.Price,
+			Price:            orderProduct.ProductPrice,
 			TotalNormalPrice: orderProduct.TotalPrice,
 			TotalFinalPrice:  orderProduct.TotalPrice,
-			Product:          newProductResponse(orderProduct.Product),
 			CreatedAt:        orderProduct.CreatedAt,
 			UpdatedAt:        orderProduct.UpdatedAt,
 		})
@@ -204,11 +505,72 @@ func newOrderProductResponse(orderProduct []domain.OrderProduct) []orderProductR
 	return orderProductResponses
 }
 
+// salesSummaryResponse represents a sales summary response body
+type salesSummaryResponse struct {
+	TotalOrders       uint64  `json:"total_orders" example:"120"`
+	TotalItems        int64   `json:"total_items" example:"360"`
+	TotalRevenue      float64 `json:"total_revenue" example:"1200000"`
+	AverageOrderValue float64 `json:"average_order_value" example:"10000"`
+	AverageBasketSize float64 `json:"average_basket_size" example:"3"`
+}
+
+// newSalesSummaryResponse is a helper function to create a response body for handling sales summary data
+func newSalesSummaryResponse(summary *domain.SalesSummary) salesSummaryResponse {
+	return salesSummaryResponse{
+		TotalOrders:       summary.TotalOrders,
+		TotalItems:        summary.TotalItems,
+		TotalRevenue:      summary.TotalRevenue,
+		AverageOrderValue: summary.AverageOrderValue,
+		AverageBasketSize: summary.AverageBasketSize,
+	}
+}
+
+// salesPeriodResponse represents a sales by period response body
+type salesPeriodResponse struct {
+	Period       time.Time `json:"period" example:"1970-01-01T00:00:00Z"`
+	TotalOrders  uint64    `json:"total_orders" example:"12"`
+	TotalItems   int64     `json:"total_items" example:"36"`
+	TotalRevenue float64   `json:"total_revenue" example:"120000"`
+}
+
+// newSalesPeriodResponse is a helper function to create a response body for handling sales by period data
+func newSalesPeriodResponse(period *domain.SalesPeriod) salesPeriodResponse {
+	return salesPeriodResponse{
+		Period:       period.Period,
+		TotalOrders:  period.TotalOrders,
+		TotalItems:   period.TotalItems,
+		TotalRevenue: period.TotalRevenue,
+	}
+}
+
+// salesGroupResponse represents a sales by category, product, cashier, or payment response body,
+// where the id is left out for categories, which are keyed by name
+type salesGroupResponse struct {
+	ID           uint64  `json:"id,omitempty" example:"1"`
+	Name         string  `json:"name" example:"Foods"`
+	TotalOrders  uint64  `json:"total_orders" example:"12"`
+	TotalItems   int64   `json:"total_items" example:"36"`
+	TotalRevenue float64 `json:"total_revenue" example:"120000"`
+}
+
+// newSalesGroupResponse is a helper function to create a response body for handling grouped sales data
+func newSalesGroupResponse(group *domain.SalesGroup) salesGroupResponse {
+	return salesGroupResponse{
+		ID:           group.ID,
+		Name:         group.Name,
+		TotalOrders:  group.TotalOrders,
+		TotalItems:   group.TotalItems,
+		TotalRevenue: group.TotalRevenue,
+	}
+}
+
 // errorStatusMap is a map of defined error messages and their corresponding http status codes
 var errorStatusMap = map[error]int{
 	domain.ErrInternal:                   http.StatusInternalServerError,
 	domain.ErrDataNotFound:               http.StatusNotFound,
 	domain.ErrConflictingData:            http.StatusConflict,
+	domain.ErrVersionMismatch:            http.StatusPreconditionFailed,
+	domain.ErrPreconditionRequired:       http.StatusPreconditionRequired,
 	domain.ErrInvalidCredentials:         http.StatusUnauthorized,
 	domain.ErrUnauthorized:               http.StatusUnauthorized,
 	domain.ErrEmptyAuthorizationHeader:   http.StatusUnauthorized,
@@ -216,10 +578,69 @@ var errorStatusMap = map[error]int{
 	domain.ErrInvalidAuthorizationType:   http.StatusUnauthorized,
 	domain.ErrInvalidToken:               http.StatusUnauthorized,
 	domain.ErrExpiredToken:               http.StatusUnauthorized,
+	domain.ErrRevokedToken:               http.StatusUnauthorized,
+	domain.ErrInvalidRefreshToken:        http.StatusUnauthorized,
+	domain.ErrRefreshTokenReused:         http.StatusUnauthorized,
+	domain.ErrInvalidResetToken:          http.StatusUnauthorized,
+	domain.ErrInvalidAPIKey:              http.StatusUnauthorized,
+	domain.ErrInvalidChallenge:           http.StatusUnauthorized,
+	domain.ErrInvalidTwoFactorCode:       http.StatusUnauthorized,
+	domain.ErrTwoFactorLocked:            http.StatusTooManyRequests,
+	domain.ErrTwoFactorEnabled:           http.StatusConflict,
+	domain.ErrTwoFactorNotEnabled:        http.StatusConflict,
+	domain.ErrTwoFactorRequired:          http.StatusForbidden,
+	domain.ErrInvalidPin:                 http.StatusUnauthorized,
+	domain.ErrInvalidTerminal:            http.StatusUnauthorized,
+	domain.ErrPinLoginNotAllowed:         http.StatusForbidden,
+	domain.ErrPinLocked:                  http.StatusTooManyRequests,
+	domain.ErrAccountLocked:              http.StatusTooManyRequests,
 	domain.ErrForbidden:                  http.StatusForbidden,
+	domain.ErrBuiltInRole:                http.StatusConflict,
+	domain.ErrOverrideRequired:           http.StatusForbidden,
+	domain.ErrInvalidOverride:            http.StatusForbidden,
+	domain.ErrSelfApproval:               http.StatusForbidden,
+	domain.ErrOrderVoided:                http.StatusConflict,
+	domain.ErrInvalidIdempotencyKey:      http.StatusBadRequest,
+	domain.ErrIdempotencyKeyReused:       http.StatusUnprocessableEntity,
+	domain.ErrIdempotencyKeyInUse:        http.StatusConflict,
 	domain.ErrNoUpdatedData:              http.StatusBadRequest,
 	domain.ErrInsufficientStock:          http.StatusBadRequest,
 	domain.ErrInsufficientPayment:        http.StatusBadRequest,
+	domain.ErrInvalidQuantity:            http.StatusBadRequest,
+	domain.ErrInvalidDateRange:           http.StatusBadRequest,
+	domain.ErrInvalidTotalRange:          http.StatusBadRequest,
+	domain.ErrInvalidCursor:              http.StatusBadRequest,
+	domain.ErrInvalidTimeZone:            http.StatusBadRequest,
+	domain.ErrInvalidImportFile:          http.StatusBadRequest,
+	domain.ErrInvalidAPIKeyExpiry:        http.StatusBadRequest,
+	domain.ErrCategoryCycle:              http.StatusConflict,
+	domain.ErrImageTooLarge:              http.StatusRequestEntityTooLarge,
+	domain.ErrUnsupportedImage:           http.StatusUnsupportedMediaType,
+}
+
+// handleLockout sets the Retry-After header for an action locked after too many failed attempts and returns the kind of lockout
+func handleLockout(ctx *gin.Context, err error) error {
+	var lockoutErr *domain.LockoutError
+	if !errors.As(err, &lockoutErr) {
+		return err
+	}
+
+	retryAfter := int64(math.Ceil(lockoutErr.RetryAfter.Seconds()))
+	ctx.Header("Retry-After", strconv.FormatInt(retryAfter, 10))
+
+	return lockoutErr.Err
+}
+
+// errorStatus returns the status code of the error, or of the defined error it wraps
+func errorStatus(err error) int {
+	for ; err != nil; err = errors.Unwrap(err) {
+		statusCode, ok := errorStatusMap[err]
+		if ok {
+			return statusCode
+		}
+	}
+
+	return http.StatusInternalServerError
 }
 
 // validationError sends an error response for some specific request validation error
@@ -231,10 +652,9 @@ func validationError(ctx *gin.Context, err error) {
 
 // handleError determines the status code of an error and returns a JSON response with the error message and status code
 func handleError(ctx *gin.Context, err error) {
-	statusCode, ok := errorStatusMap[err]
-	if !ok {
-		statusCode = http.StatusInternalServerError
-	}
+	err = handleLockout(ctx, err)
+
+	statusCode := errorStatus(err)
 
 	errMsg := parseError(err)
 	errRsp := newErrorResponse(errMsg)
@@ -243,10 +663,9 @@ func handleError(ctx *gin.Context, err error) {
 
 // handleAbort sends an error response and aborts the request with the specified status code and error message
 func handleAbort(ctx *gin.Context, err error) {
-	statusCode, ok := errorStatusMap[err]
-	if !ok {
-		statusCode = http.StatusInternalServerError
-	}
+	err = handleLockout(ctx, err)
+
+	statusCode := errorStatus(err)
 
 	errMsg := parseError(err)
 	errRsp := newErrorResponse(errMsg)
@@ -0,0 +1,270 @@
+package http
+
+import (
+	"github.com/nikhil-shrestha/go-pos/internal/core/domain"
+	"github.com/nikhil-shrestha/go-pos/internal/core/port"
+	"github.com/gin-gonic/gin"
+)
+
+// RoleHandler represents the HTTP handler for role-related requests
+type RoleHandler struct {
+	svc port.RoleService
+}
+
+// NewRoleHandler creates a new RoleHandler instance
+func NewRoleHandler(svc port.RoleService) *RoleHandler {
+	return &RoleHandler{
+		svc,
+	}
+}
+
+// createRoleRequest represents a request body for creating a new role
+type createRoleRequest struct {
+	Name        domain.UserRole     `json:"name" binding:"required,user_role" example:"supervisor"`
+	Permissions []domain.Permission `json:"permissions" binding:"required,dive,permission" example:"orders.create,reports.view"`
+}
+
+// CreateRole godoc
+//
+//	@Summary		Create a new role
+//	@Description	create a new role with a name and its permissions
+//	@Tags			Roles
+//	@Accept			json
+//	@Produce		json
+//	@Param			createRoleRequest	body		createRoleRequest	true	"Create role request"
+//	@Success		200					{object}	roleResponse		"Role created"
+//	@Failure		400					{object}	errorResponse		"Validation error"
+//	@Failure		401					{object}	errorResponse		"Unauthorized error"
+//	@Failure		403					{object}	errorResponse		"Forbidden error"
+//	@Failure		409					{object}	errorResponse		"Data conflict error"
+//	@Failure		500					{object}	errorResponse		"Internal server error"
+//	@Router			/roles [post]
+//	@Security		BearerAuth
+func (rh *RoleHandler) CreateRole(ctx *gin.Context) {
+	var req createRoleRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		validationError(ctx, err)
+		return
+	}
+
+	role := domain.Role{
+		Name:        req.Name,
+		Permissions: req.Permissions,
+	}
+
+	_, err := rh.svc.CreateRole(ctx, &role)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	rsp := newRoleResponse(&role)
+
+	handleSuccess(ctx, rsp)
+}
+
+// ListPermissions godoc
+//
+//	@Summary		List permissions
+//	@Description	List every permission that can be granted to a role
+//	@Tags			Roles
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{array}		string			"Permissions displayed"
+//	@Failure		401	{object}	errorResponse	"Unauthorized error"
+//	@Failure		403	{object}	errorResponse	"Forbidden error"
+//	@Router			/roles/permissions [get]
+//	@Security		BearerAuth
+func (rh *RoleHandler) ListPermissions(ctx *gin.Context) {
+	handleSuccess(ctx, domain.Permissions)
+}
+
+// getRoleRequest represents a request body for retrieving a role
+type getRoleRequest struct {
+	ID uint64 `uri:"id" binding:"required,min=1" example:"1"`
+}
+
+// GetRole godoc
+//
+//	@Summary		Get a role
+//	@Description	get a role by id
+//	@Tags			Roles
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		uint64			true	"Role ID"
+//	@Success		200	{object}	roleResponse	"Role retrieved"
+//	@Failure		400	{object}	errorResponse	"Validation error"
+//	@Failure		401	{object}	errorResponse	"Unauthorized error"
+//	@Failure		403	{object}	errorResponse	"Forbidden error"
+//	@Failure		404	{object}	errorResponse	"Data not found error"
+//	@Failure		500	{object}	errorResponse	"Internal server error"
+//	@Router			/roles/{id} [get]
+//	@Security		BearerAuth
+func (rh *RoleHandler) GetRole(ctx *gin.Context) {
+	var req getRoleRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		validationError(ctx, err)
+		return
+	}
+
+	role, err := rh.svc.GetRole(ctx, req.ID)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	rsp := newRoleResponse(role)
+
+	handleSuccess(ctx, rsp)
+}
+
+// listRolesRequest represents a request body for listing roles
+type listRolesRequest struct {
+	Skip  uint64           `form:"skip" binding:"required,min=0" example:"0"`
+	Limit uint64           `form:"limit" binding:"required,min=5" example:"5"`
+	Sort  string           `form:"sort" binding:"omitempty,oneof=id name created_at" example:"created_at"`
+	Order domain.SortOrder `form:"order" binding:"omitempty,oneof=asc desc" example:"desc"`
+}
+
+// ListRoles godoc
+//
+//	@Summary		List roles
+//	@Description	List roles with pagination
+//	@Tags			Roles
+//	@Accept			json
+//	@Produce		json
+//	@Param			skip	query		uint64			true	"Skip"
+//	@Param			limit	query		uint64			true	"Limit"
+//	@Param			sort	query		string			false	"Sort by (id, name, created_at)"
+//	@Param			order	query		string			false	"Sort order (asc, desc)"
+//	@Success		200		{object}	meta			"Roles displayed"
+//	@Failure		400		{object}	errorResponse	"Validation error"
+//	@Failure		401		{object}	errorResponse	"Unauthorized error"
+//	@Failure		403		{object}	errorResponse	"Forbidden error"
+//	@Failure		500		{object}	errorResponse	"Internal server error"
+//	@Router			/roles [get]
+//	@Security		BearerAuth
+func (rh *RoleHandler) ListRoles(ctx *gin.Context) {
+	var req listRolesRequest
+	var rolesList []roleResponse
+
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		validationError(ctx, err)
+		return
+	}
+
+	params := &domain.ListParams{
+		Skip:  req.Skip,
+		Limit: req.Limit,
+		Sort:  req.Sort,
+		Order: req.Order,
+	}
+
+	roles, total, err := rh.svc.ListRoles(ctx, params)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	for _, role := range roles {
+		rolesList = append(rolesList, newRoleResponse(&role))
+	}
+
+	meta := newMeta(total, req.Limit, req.Skip)
+	rsp := toMap(meta, rolesList, "roles")
+
+	handleSuccess(ctx, rsp)
+}
+
+// updateRoleRequest represents a request body for updating a role
+type updateRoleRequest struct {
+	Name        domain.UserRole     `json:"name" binding:"omitempty,user_role" example:"shift_supervisor"`
+	Permissions []domain.Permission `json:"permissions" binding:"omitempty,dive,permission" example:"orders.create,orders.export,reports.view"`
+}
+
+// UpdateRole godoc
+//
+//	@Summary		Update a role
+//	@Description	rename a role or replace its permissions by id. Users get the new permissions once they log in again or refresh their access token. Built-in roles cannot be renamed and the admin role cannot be changed.
+//	@Tags			Roles
+//	@Accept			json
+//	@Produce		json
+//	@Param			id					path		uint64				true	"Role ID"
+//	@Param			updateRoleRequest	body		updateRoleRequest	true	"Update role request"
+//	@Success		200					{object}	roleResponse		"Role updated"
+//	@Failure		400					{object}	errorResponse		"Validation error"
+//	@Failure		401					{object}	errorResponse		"Unauthorized error"
+//	@Failure		403					{object}	errorResponse		"Forbidden error"
+//	@Failure		404					{object}	errorResponse		"Data not found error"
+//	@Failure		409					{object}	errorResponse		"Data conflict error"
+//	@Failure		500					{object}	errorResponse		"Internal server error"
+//	@Router			/roles/{id} [put]
+//	@Security		BearerAuth
+func (rh *RoleHandler) UpdateRole(ctx *gin.Context) {
+	var req updateRoleRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		validationError(ctx, err)
+		return
+	}
+
+	idStr := ctx.Param("id")
+	id, err := stringToUint64(idStr)
+	if err != nil {
+		validationError(ctx, err)
+		return
+	}
+
+	role := domain.Role{
+		ID:          id,
+		Name:        req.Name,
+		Permissions: req.Permissions,
+	}
+
+	_, err = rh.svc.UpdateRole(ctx, &role)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	rsp := newRoleResponse(&role)
+
+	handleSuccess(ctx, rsp)
+}
+
+// deleteRoleRequest represents a request body for deleting a role
+type deleteRoleRequest struct {
+	ID uint64 `uri:"id" binding:"required,min=1" example:"1"`
+}
+
+// DeleteRole godoc
+//
+//	@Summary		Delete a role
+//	@Description	Delete a role that no user has by id. Built-in roles cannot be deleted.
+//	@Tags			Roles
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		uint64			true	"Role ID"
+//	@Success		200	{object}	response		"Role deleted"
+//	@Failure		400	{object}	errorResponse	"Validation error"
+//	@Failure		401	{object}	errorResponse	"Unauthorized error"
+//	@Failure		403	{object}	errorResponse	"Forbidden error"
+//	@Failure		404	{object}	errorResponse	"Data not found error"
+//	@Failure		409	{object}	errorResponse	"Data conflict error"
+//	@Failure		500	{object}	errorResponse	"Internal server error"
+//	@Router			/roles/{id} [delete]
+//	@Security		BearerAuth
+func (rh *RoleHandler) DeleteRole(ctx *gin.Context) {
+	var req deleteRoleRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		validationError(ctx, err)
+		return
+	}
+
+	err := rh.svc.DeleteRole(ctx, req.ID)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	handleSuccess(ctx, nil)
+}
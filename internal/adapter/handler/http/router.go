@@ -2,9 +2,11 @@ package http
 
 import (
 	"log/slog"
+	"net/url"
 	"strings"
 
 	"github.com/nikhil-shrestha/go-pos/internal/adapter/config"
+	"github.com/nikhil-shrestha/go-pos/internal/core/domain"
 	"github.com/nikhil-shrestha/go-pos/internal/core/port"
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
@@ -23,13 +25,26 @@ type Router struct {
 // NewRouter creates a new HTTP router
 func NewRouter(
 	config *config.HTTP,
-	token port.TokenService,
+	storageConfig *config.Storage,
+	auth port.AuthService,
+	overrideService port.OverrideService,
+	apiKeyService port.APIKeyService,
+	idempotencyService port.IdempotencyService,
 	userHandler UserHandler,
 	authHandler AuthHandler,
+	twoFactorHandler TwoFactorHandler,
+	roleHandler RoleHandler,
+	overrideHandler OverrideHandler,
+	terminalHandler TerminalHandler,
 	paymentHandler PaymentHandler,
 	categoryHandler CategoryHandler,
 	productHandler ProductHandler,
 	orderHandler OrderHandler,
+	analyticsHandler AnalyticsHandler,
+	imageHandler ImageHandler,
+	keyHandler KeyHandler,
+	apiKeyHandler APIKeyHandler,
+	auditHandler AuditHandler,
 ) (*Router, error) {
 	// Disable debug mode in production
 	if config.Env == "production" {
@@ -43,7 +58,22 @@ func NewRouter(
 	ginConfig.AllowOrigins = originsList
 
 	router := gin.New()
-	router.Use(sloggin.New(slog.Default()), gin.Recovery(), cors.New(ginConfig))
+
+	// Let the services read the actor of the request for the audit trail from the request context
+	router.ContextWithFallback = true
+
+	// Only trust the client IP forwarded by known proxies, since failed logins are counted per client IP
+	var trustedProxies []string
+	if config.TrustedProxies != "" {
+		trustedProxies = strings.Split(config.TrustedProxies, ",")
+	}
+
+	err := router.SetTrustedProxies(trustedProxies)
+	if err != nil {
+		return nil, err
+	}
+
+	router.Use(sloggin.New(slog.Default()), gin.Recovery(), cors.New(ginConfig), requestMiddleware())
 
 	// Custom validators
 	v, ok := binding.Validator.Engine().(*validator.Validate)
@@ -52,75 +82,171 @@ func NewRouter(
 			return nil, err
 		}
 
+		if err := v.RegisterValidation("permission", permissionValidator); err != nil {
+			return nil, err
+		}
+
+		if err := v.RegisterValidation("api_key_permission", apiKeyPermissionValidator); err != nil {
+			return nil, err
+		}
+
+		if err := v.RegisterValidation("override_action", overrideActionValidator); err != nil {
+			return nil, err
+		}
+
 		if err := v.RegisterValidation("payment_type", paymentTypeValidator); err != nil {
 			return nil, err
 		}
 
+		if err := v.RegisterValidation("report_interval", reportIntervalValidator); err != nil {
+			return nil, err
+		}
+
+		if err := v.RegisterValidation("order_status", orderStatusValidator); err != nil {
+			return nil, err
+		}
+
 	}
 
 	// Swagger
 	router.GET("/docs/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
+	// Public keys of the tokens
+	router.GET("/.well-known/paseto-keys", keyHandler.ListPublicKeys)
+
+	// Uploaded files of the local file storage
+	if storageConfig.Driver != "s3" {
+		storageURL, err := url.Parse(storageConfig.URL)
+		if err != nil {
+			return nil, err
+		}
+
+		// A catch-all at the root would conflict with every other route
+		staticPath := strings.TrimSuffix(storageURL.Path, "/")
+		if staticPath == "" {
+			return nil, domain.ErrInvalidStoragePath
+		}
+
+		router.Static(staticPath, storageConfig.Path)
+	}
+
 	v1 := router.Group("/v1")
 	{
 		user := v1.Group("/users")
 		{
 			user.POST("/", userHandler.Register)
 			user.POST("/login", authHandler.Login)
+			user.POST("/login/2fa", authHandler.VerifyChallenge)
+			user.POST("/login/2fa/setup", twoFactorHandler.SetupChallenge)
+			user.POST("/refresh", authHandler.Refresh)
+			user.POST("/password/forgot", authHandler.RequestPasswordReset)
+			user.POST("/password/reset", authHandler.ResetPassword)
 
-			authUser := user.Group("/").Use(authMiddleware(token))
+			authUser := user.Group("/").Use(authMiddleware(auth, apiKeyService), sessionMiddleware())
 			{
+				authUser.POST("/logout", authHandler.Logout)
+				authUser.POST("/logout/all", authHandler.LogoutAll)
+				authUser.PUT("/me/password", authHandler.ChangePassword)
+				authUser.POST("/me/2fa", twoFactorHandler.SetupTwoFactor)
+				authUser.POST("/me/2fa/enable", twoFactorHandler.EnableTwoFactor)
+				authUser.POST("/me/2fa/recovery-codes", twoFactorHandler.RegenerateRecoveryCodes)
+				authUser.DELETE("/me/2fa", twoFactorHandler.DisableTwoFactor)
 				authUser.GET("/", userHandler.ListUsers)
 				authUser.GET("/:id", userHandler.GetUser)
-
-				admin := authUser.Use(adminMiddleware())
-				{
-					admin.PUT("/:id", userHandler.UpdateUser)
-					admin.DELETE("/:id", userHandler.DeleteUser)
-				}
+				authUser.PUT("/:id", permissionMiddleware(domain.UsersWrite), userHandler.UpdateUser)
+				authUser.DELETE("/:id", permissionMiddleware(domain.UsersWrite), userHandler.DeleteUser)
+				authUser.POST("/:id/restore", permissionMiddleware(domain.UsersWrite), userHandler.RestoreUser)
+				authUser.POST("/:id/unlock", permissionMiddleware(domain.UsersWrite), authHandler.UnlockUser)
+				authUser.DELETE("/:id/2fa", permissionMiddleware(domain.UsersWrite), twoFactorHandler.ResetTwoFactor)
 			}
 		}
-		payment := v1.Group("/payments").Use(authMiddleware(token))
+		role := v1.Group("/roles").Use(authMiddleware(auth, apiKeyService), permissionMiddleware(domain.RolesWrite))
 		{
-			payment.GET("/", paymentHandler.ListPayments)
-			payment.GET("/:id", paymentHandler.GetPayment)
+			role.GET("/", roleHandler.ListRoles)
+			role.GET("/permissions", roleHandler.ListPermissions)
+			role.GET("/:id", roleHandler.GetRole)
+			role.POST("/", roleHandler.CreateRole)
+			role.PUT("/:id", roleHandler.UpdateRole)
+			role.DELETE("/:id", roleHandler.DeleteRole)
+		}
+		terminal := v1.Group("/terminals")
+		{
+			terminal.POST("/:id/pin-login", authHandler.PinLogin)
 
-			admin := payment.Use(adminMiddleware())
+			authTerminal := terminal.Group("/").Use(authMiddleware(auth, apiKeyService), permissionMiddleware(domain.TerminalsWrite))
 			{
-				admin.POST("/", paymentHandler.CreatePayment)
-				admin.PUT("/:id", paymentHandler.UpdatePayment)
-				admin.DELETE("/:id", paymentHandler.DeletePayment)
+				authTerminal.GET("/", terminalHandler.ListTerminals)
+				authTerminal.POST("/", terminalHandler.RegisterTerminal)
+				authTerminal.DELETE("/:id", terminalHandler.DeleteTerminal)
 			}
 		}
-		category := v1.Group("/categories").Use(authMiddleware(token))
+		apiKey := v1.Group("/api-keys").Use(authMiddleware(auth, apiKeyService), permissionMiddleware(domain.APIKeysWrite))
+		{
+			apiKey.GET("/", apiKeyHandler.ListAPIKeys)
+			apiKey.POST("/", apiKeyHandler.CreateAPIKey)
+			apiKey.DELETE("/:id", apiKeyHandler.RevokeAPIKey)
+		}
+		auditLog := v1.Group("/audit-logs").Use(authMiddleware(auth, apiKeyService), permissionMiddleware(domain.AuditLogsView))
+		{
+			auditLog.GET("/", auditHandler.ListAuditLogs)
+			auditLog.GET("/verify", auditHandler.VerifyAuditLogs)
+		}
+		override := v1.Group("/overrides").Use(authMiddleware(auth, apiKeyService), sessionMiddleware())
+		{
+			override.POST("/", overrideHandler.RequestOverride)
+		}
+		payment := v1.Group("/payments").Use(authMiddleware(auth, apiKeyService))
+		{
+			payment.GET("/", paymentHandler.ListPayments)
+			payment.GET("/:id", paymentHandler.GetPayment)
+			payment.POST("/", permissionMiddleware(domain.PaymentsWrite), paymentHandler.CreatePayment)
+			payment.POST("/:id/logo", permissionMiddleware(domain.PaymentsWrite), imageHandler.UploadPaymentLogo)
+			payment.PUT("/:id", permissionMiddleware(domain.PaymentsWrite), paymentHandler.UpdatePayment)
+			payment.DELETE("/:id", permissionMiddleware(domain.PaymentsWrite), paymentHandler.DeletePayment)
+			payment.POST("/:id/restore", permissionMiddleware(domain.PaymentsWrite), paymentHandler.RestorePayment)
+		}
+		category := v1.Group("/categories").Use(authMiddleware(auth, apiKeyService))
 		{
 			category.GET("/", categoryHandler.ListCategories)
 			category.GET("/:id", categoryHandler.GetCategory)
-
-			admin := category.Use(adminMiddleware())
-			{
-				admin.POST("/", categoryHandler.CreateCategory)
-				admin.PUT("/:id", categoryHandler.UpdateCategory)
-				admin.DELETE("/:id", categoryHandler.DeleteCategory)
-			}
+			category.POST("/", permissionMiddleware(domain.CategoriesWrite), categoryHandler.CreateCategory)
+			category.PUT("/:id", permissionMiddleware(domain.CategoriesWrite), categoryHandler.UpdateCategory)
+			category.DELETE("/:id", permissionMiddleware(domain.CategoriesWrite), categoryHandler.DeleteCategory)
+			category.POST("/:id/restore", permissionMiddleware(domain.CategoriesWrite), categoryHandler.RestoreCategory)
 		}
-		product := v1.Group("/products").Use(authMiddleware(token))
+		product := v1.Group("/products").Use(authMiddleware(auth, apiKeyService))
 		{
 			product.GET("/", productHandler.ListProducts)
 			product.GET("/:id", productHandler.GetProduct)
-
-			admin := product.Use(adminMiddleware())
-			{
-				admin.POST("/", productHandler.CreateProduct)
-				admin.PUT("/:id", productHandler.UpdateProduct)
-				admin.DELETE("/:id", productHandler.DeleteProduct)
-			}
+			product.GET("/export", permissionMiddleware(domain.ProductsExport), productHandler.ExportProducts)
+			product.POST("/import", permissionMiddleware(domain.ProductsWrite), productHandler.ImportProducts)
+			product.POST("/", permissionMiddleware(domain.ProductsWrite), productHandler.CreateProduct)
+			product.POST("/:id/image", permissionMiddleware(domain.ProductsWrite), imageHandler.UploadProductImage)
+			product.PUT("/:id", permissionMiddleware(domain.ProductsWrite), productHandler.UpdateProduct)
+			product.DELETE("/:id", permissionMiddleware(domain.ProductsWrite), productHandler.DeleteProduct)
+			product.POST("/:id/restore", permissionMiddleware(domain.ProductsWrite), productHandler.RestoreProduct)
 		}
-		order := v1.Group("/orders").Use(authMiddleware(token))
+		order := v1.Group("/orders").Use(authMiddleware(auth, apiKeyService))
 		{
-			order.POST("/", orderHandler.CreateOrder)
+			order.POST("/", permissionMiddleware(domain.OrdersCreate), idempotencyMiddleware(idempotencyService), orderHandler.CreateOrder)
 			order.GET("/", orderHandler.ListOrders)
 			order.GET("/:id", orderHandler.GetOrder)
+			order.GET("/export", permissionMiddleware(domain.OrdersExport), orderHandler.ExportOrders)
+			order.POST("/:id/void", sessionMiddleware(), overrideMiddleware(overrideService, domain.VoidOrderAction), orderHandler.VoidOrder)
+		}
+		drawer := v1.Group("/drawer").Use(authMiddleware(auth, apiKeyService), sessionMiddleware())
+		{
+			drawer.POST("/open", overrideMiddleware(overrideService, domain.OpenDrawerAction), orderHandler.OpenDrawer)
+		}
+		analytics := v1.Group("/analytics").Use(authMiddleware(auth, apiKeyService), permissionMiddleware(domain.ReportsView))
+		{
+			analytics.GET("/summary", analyticsHandler.GetSalesSummary)
+			analytics.GET("/sales", analyticsHandler.ListSalesByPeriod)
+			analytics.GET("/categories", analyticsHandler.ListSalesByCategory)
+			analytics.GET("/products", analyticsHandler.ListSalesByProduct)
+			analytics.GET("/cashiers", analyticsHandler.ListSalesByCashier)
+			analytics.GET("/payments", analyticsHandler.ListSalesByPayment)
+			analytics.GET("/export", analyticsHandler.ExportReport)
 		}
 	}
 
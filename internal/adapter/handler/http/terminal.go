@@ -0,0 +1,157 @@
+package http
+
+import (
+	"github.com/nikhil-shrestha/go-pos/internal/core/domain"
+	"github.com/nikhil-shrestha/go-pos/internal/core/port"
+	"github.com/gin-gonic/gin"
+)
+
+// TerminalHandler represents the HTTP handler for terminal-related requests
+type TerminalHandler struct {
+	svc port.TerminalService
+}
+
+// NewTerminalHandler creates a new TerminalHandler instance
+func NewTerminalHandler(svc port.TerminalService) *TerminalHandler {
+	return &TerminalHandler{
+		svc,
+	}
+}
+
+// registerTerminalRequest represents a request body for registering a new terminal
+type registerTerminalRequest struct {
+	Name string `json:"name" binding:"required" example:"Front Counter 1"`
+}
+
+// RegisterTerminal godoc
+//
+//	@Summary		Register a new terminal
+//	@Description	Register a till on which cashiers can log in with their PIN. The key is only returned once and must be configured on the terminal.
+//	@Tags			Terminals
+//	@Accept			json
+//	@Produce		json
+//	@Param			registerTerminalRequest	body		registerTerminalRequest	true	"Register terminal request"
+//	@Success		200						{object}	terminalResponse		"Terminal registered"
+//	@Failure		400						{object}	errorResponse			"Validation error"
+//	@Failure		401						{object}	errorResponse			"Unauthorized error"
+//	@Failure		403						{object}	errorResponse			"Forbidden error"
+//	@Failure		409						{object}	errorResponse			"Data conflict error"
+//	@Failure		500						{object}	errorResponse			"Internal server error"
+//	@Router			/terminals [post]
+//	@Security		BearerAuth
+func (th *TerminalHandler) RegisterTerminal(ctx *gin.Context) {
+	var req registerTerminalRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		validationError(ctx, err)
+		return
+	}
+
+	terminal := domain.Terminal{
+		Name: req.Name,
+	}
+
+	_, err := th.svc.RegisterTerminal(ctx, &terminal)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	rsp := newTerminalResponse(&terminal)
+
+	handleSuccess(ctx, rsp)
+}
+
+// listTerminalsRequest represents a request body for listing terminals
+type listTerminalsRequest struct {
+	Skip  uint64           `form:"skip" binding:"required,min=0" example:"0"`
+	Limit uint64           `form:"limit" binding:"required,min=5" example:"5"`
+	Sort  string           `form:"sort" binding:"omitempty,oneof=id name created_at" example:"created_at"`
+	Order domain.SortOrder `form:"order" binding:"omitempty,oneof=asc desc" example:"desc"`
+}
+
+// ListTerminals godoc
+//
+//	@Summary		List terminals
+//	@Description	List registered terminals with pagination
+//	@Tags			Terminals
+//	@Accept			json
+//	@Produce		json
+//	@Param			skip	query		uint64			true	"Skip"
+//	@Param			limit	query		uint64			true	"Limit"
+//	@Param			sort	query		string			false	"Sort by (id, name, created_at)"
+//	@Param			order	query		string			false	"Sort order (asc, desc)"
+//	@Success		200		{object}	meta			"Terminals displayed"
+//	@Failure		400		{object}	errorResponse	"Validation error"
+//	@Failure		401		{object}	errorResponse	"Unauthorized error"
+//	@Failure		403		{object}	errorResponse	"Forbidden error"
+//	@Failure		500		{object}	errorResponse	"Internal server error"
+//	@Router			/terminals [get]
+//	@Security		BearerAuth
+func (th *TerminalHandler) ListTerminals(ctx *gin.Context) {
+	var req listTerminalsRequest
+	var terminalsList []terminalResponse
+
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		validationError(ctx, err)
+		return
+	}
+
+	params := &domain.ListParams{
+		Skip:  req.Skip,
+		Limit: req.Limit,
+		Sort:  req.Sort,
+		Order: req.Order,
+	}
+
+	terminals, total, err := th.svc.ListTerminals(ctx, params)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	for _, terminal := range terminals {
+		terminalsList = append(terminalsList, newTerminalResponse(&terminal))
+	}
+
+	meta := newMeta(total, req.Limit, req.Skip)
+	rsp := toMap(meta, terminalsList, "terminals")
+
+	handleSuccess(ctx, rsp)
+}
+
+// deleteTerminalRequest represents a request body for deleting a terminal
+type deleteTerminalRequest struct {
+	ID uint64 `uri:"id" binding:"required,min=1" example:"1"`
+}
+
+// DeleteTerminal godoc
+//
+//	@Summary		Delete a terminal
+//	@Description	Delete a terminal by id, which rejects the tokens of the PIN logins made on it
+//	@Tags			Terminals
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		uint64			true	"Terminal ID"
+//	@Success		200	{object}	response		"Terminal deleted"
+//	@Failure		400	{object}	errorResponse	"Validation error"
+//	@Failure		401	{object}	errorResponse	"Unauthorized error"
+//	@Failure		403	{object}	errorResponse	"Forbidden error"
+//	@Failure		404	{object}	errorResponse	"Data not found error"
+//	@Failure		500	{object}	errorResponse	"Internal server error"
+//	@Router			/terminals/{id} [delete]
+//	@Security		BearerAuth
+func (th *TerminalHandler) DeleteTerminal(ctx *gin.Context) {
+	var req deleteTerminalRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		validationError(ctx, err)
+		return
+	}
+
+	err := th.svc.DeleteTerminal(ctx, req.ID)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	handleSuccess(ctx, nil)
+}
@@ -0,0 +1,229 @@
+package http
+
+import (
+	"github.com/nikhil-shrestha/go-pos/internal/core/port"
+	"github.com/gin-gonic/gin"
+)
+
+// TwoFactorHandler represents the HTTP handler for two-factor authentication-related requests
+type TwoFactorHandler struct {
+	svc port.TwoFactorService
+}
+
+// NewTwoFactorHandler creates a new TwoFactorHandler instance
+func NewTwoFactorHandler(svc port.TwoFactorService) *TwoFactorHandler {
+	return &TwoFactorHandler{
+		svc,
+	}
+}
+
+// SetupTwoFactor godoc
+//
+//	@Summary		Set up two-factor authentication
+//	@Description	Provisions a TOTP secret for the logged in user to add to an authenticator app, with its otpauth URI and QR code.
+//	@Description	The secret is pending until a code of it is sent to /users/me/2fa/enable, and setting up again replaces it.
+//	@Tags			Users
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	twoFactorSetupResponse	"Two-factor authentication set up"
+//	@Failure		401	{object}	errorResponse			"Unauthorized error"
+//	@Failure		409	{object}	errorResponse			"Data conflict error"
+//	@Failure		500	{object}	errorResponse			"Internal server error"
+//	@Router			/users/me/2fa [post]
+//	@Security		BearerAuth
+func (tfh *TwoFactorHandler) SetupTwoFactor(ctx *gin.Context) {
+	authPayload := getAuthPayload(ctx, authorizationPayloadKey)
+
+	setup, err := tfh.svc.SetupTwoFactor(ctx, authPayload.UserID)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	rsp := newTwoFactorSetupResponse(setup)
+
+	handleSuccess(ctx, rsp)
+}
+
+// twoFactorCodeRequest represents the request body for confirming an action with a two-factor code
+type twoFactorCodeRequest struct {
+	Code string `json:"code" binding:"required" example:"123456"`
+}
+
+// EnableTwoFactor godoc
+//
+//	@Summary		Enable two-factor authentication
+//	@Description	Confirms the pending TOTP secret of the logged in user with a code of it and returns the recovery codes, which are only shown once
+//	@Tags			Users
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		twoFactorCodeRequest	true	"Two-factor code request body"
+//	@Success		200		{object}	recoveryCodesResponse	"Two-factor authentication enabled"
+//	@Failure		400		{object}	errorResponse			"Validation error"
+//	@Failure		401		{object}	errorResponse			"Unauthorized error"
+//	@Failure		409		{object}	errorResponse			"Data conflict error"
+//	@Failure		429		{object}	errorResponse			"Too many failed attempts"
+//	@Failure		500		{object}	errorResponse			"Internal server error"
+//	@Router			/users/me/2fa/enable [post]
+//	@Security		BearerAuth
+func (tfh *TwoFactorHandler) EnableTwoFactor(ctx *gin.Context) {
+	var req twoFactorCodeRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		validationError(ctx, err)
+		return
+	}
+
+	authPayload := getAuthPayload(ctx, authorizationPayloadKey)
+
+	recoveryCodes, err := tfh.svc.EnableTwoFactor(ctx, authPayload.UserID, req.Code)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	handleSuccess(ctx, recoveryCodesResponse{recoveryCodes})
+}
+
+// RegenerateRecoveryCodes godoc
+//
+//	@Summary		Regenerate recovery codes
+//	@Description	Replaces the recovery codes of the logged in user after checking a TOTP or recovery code, so the previous ones stop working
+//	@Tags			Users
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		twoFactorCodeRequest	true	"Two-factor code request body"
+//	@Success		200		{object}	recoveryCodesResponse	"Recovery codes regenerated"
+//	@Failure		400		{object}	errorResponse			"Validation error"
+//	@Failure		401		{object}	errorResponse			"Unauthorized error"
+//	@Failure		409		{object}	errorResponse			"Data conflict error"
+//	@Failure		429		{object}	errorResponse			"Too many failed attempts"
+//	@Failure		500		{object}	errorResponse			"Internal server error"
+//	@Router			/users/me/2fa/recovery-codes [post]
+//	@Security		BearerAuth
+func (tfh *TwoFactorHandler) RegenerateRecoveryCodes(ctx *gin.Context) {
+	var req twoFactorCodeRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		validationError(ctx, err)
+		return
+	}
+
+	authPayload := getAuthPayload(ctx, authorizationPayloadKey)
+
+	recoveryCodes, err := tfh.svc.RegenerateRecoveryCodes(ctx, authPayload.UserID, req.Code)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	handleSuccess(ctx, recoveryCodesResponse{recoveryCodes})
+}
+
+// DisableTwoFactor godoc
+//
+//	@Summary		Disable two-factor authentication
+//	@Description	Turns off two-factor authentication of the logged in user after checking a TOTP or recovery code, unless their role requires it
+//	@Tags			Users
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		twoFactorCodeRequest	true	"Two-factor code request body"
+//	@Success		200		{object}	response				"Two-factor authentication disabled"
+//	@Failure		400		{object}	errorResponse			"Validation error"
+//	@Failure		401		{object}	errorResponse			"Unauthorized error"
+//	@Failure		403		{object}	errorResponse			"Forbidden error"
+//	@Failure		409		{object}	errorResponse			"Data conflict error"
+//	@Failure		429		{object}	errorResponse			"Too many failed attempts"
+//	@Failure		500		{object}	errorResponse			"Internal server error"
+//	@Router			/users/me/2fa [delete]
+//	@Security		BearerAuth
+func (tfh *TwoFactorHandler) DisableTwoFactor(ctx *gin.Context) {
+	var req twoFactorCodeRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		validationError(ctx, err)
+		return
+	}
+
+	authPayload := getAuthPayload(ctx, authorizationPayloadKey)
+
+	err := tfh.svc.DisableTwoFactor(ctx, authPayload.UserID, req.Code)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	handleSuccess(ctx, nil)
+}
+
+// resetTwoFactorRequest represents the request body for resetting the two-factor authentication of a user
+type resetTwoFactorRequest struct {
+	ID uint64 `uri:"id" binding:"required,min=1" example:"1"`
+}
+
+// ResetTwoFactor godoc
+//
+//	@Summary		Reset two-factor authentication of a user
+//	@Description	Turns off two-factor authentication of a user who lost both the authenticator and the recovery codes. A user whose role requires it enrols again on the next login.
+//	@Tags			Users
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		uint64			true	"User ID"
+//	@Success		200	{object}	response		"Two-factor authentication reset"
+//	@Failure		400	{object}	errorResponse	"Validation error"
+//	@Failure		401	{object}	errorResponse	"Unauthorized error"
+//	@Failure		403	{object}	errorResponse	"Forbidden error"
+//	@Failure		404	{object}	errorResponse	"Data not found error"
+//	@Failure		500	{object}	errorResponse	"Internal server error"
+//	@Router			/users/{id}/2fa [delete]
+//	@Security		BearerAuth
+func (tfh *TwoFactorHandler) ResetTwoFactor(ctx *gin.Context) {
+	var req resetTwoFactorRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		validationError(ctx, err)
+		return
+	}
+
+	err := tfh.svc.ResetTwoFactor(ctx, req.ID)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	handleSuccess(ctx, nil)
+}
+
+// setupChallengeRequest represents the request body for setting up two-factor authentication during login
+type setupChallengeRequest struct {
+	ChallengeToken string `json:"challenge_token" binding:"required,uuid" example:"4979cf6e-d215-4ff8-9d0d-b3e99bcc7750"`
+}
+
+// SetupChallenge godoc
+//
+//	@Summary		Set up two-factor authentication during login
+//	@Description	Provisions a TOTP secret for a user whose role requires two-factor authentication and who got a setup challenge when logging in.
+//	@Description	A code of the secret sent to /users/login/2fa enables it and completes the login.
+//	@Tags			Users
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		setupChallengeRequest	true	"Setup challenge request body"
+//	@Success		200		{object}	twoFactorSetupResponse	"Two-factor authentication set up"
+//	@Failure		400		{object}	errorResponse			"Validation error"
+//	@Failure		401		{object}	errorResponse			"Unauthorized error"
+//	@Failure		409		{object}	errorResponse			"Data conflict error"
+//	@Failure		500		{object}	errorResponse			"Internal server error"
+//	@Router			/users/login/2fa/setup [post]
+func (tfh *TwoFactorHandler) SetupChallenge(ctx *gin.Context) {
+	var req setupChallengeRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		validationError(ctx, err)
+		return
+	}
+
+	setup, err := tfh.svc.SetupChallenge(ctx, req.ChallengeToken)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	rsp := newTwoFactorSetupResponse(setup)
+
+	handleSuccess(ctx, rsp)
+}
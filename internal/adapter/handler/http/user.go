@@ -34,6 +34,7 @@ type registerRequest struct {
 //	@Produce		json
 //	@Param			registerRequest	body		registerRequest	true	"Register request"
 //	@Success		200				{object}	userResponse	"User created"
+//	@Header			200				{string}	ETag			"ETag of the created user"
 //	@Failure		400				{object}	errorResponse	"Validation error"
 //	@Failure		401				{object}	errorResponse	"Unauthorized error"
 //	@Failure		404				{object}	errorResponse	"Data not found error"
@@ -59,6 +60,7 @@ func (uh *UserHandler) Register(ctx *gin.Context) {
 		return
 	}
 
+	setETag(ctx, user.Version)
 	rsp := newUserResponse(&user)
 
 	handleSuccess(ctx, rsp)
@@ -66,8 +68,11 @@ func (uh *UserHandler) Register(ctx *gin.Context) {
 
 // listUsersRequest represents the request body for listing users
 type listUsersRequest struct {
-	Skip  uint64 `form:"skip" binding:"required,min=0" example:"0"`
-	Limit uint64 `form:"limit" binding:"required,min=5" example:"5"`
+	Skip           uint64           `form:"skip" binding:"required,min=0" example:"0"`
+	Limit          uint64           `form:"limit" binding:"required,min=5" example:"5"`
+	Sort           string           `form:"sort" binding:"omitempty,oneof=id name email created_at" example:"created_at"`
+	Order          domain.SortOrder `form:"order" binding:"omitempty,oneof=asc desc" example:"desc"`
+	IncludeDeleted bool             `form:"include_deleted" binding:"omitempty" example:"false"`
 }
 
 // ListUsers godoc
@@ -77,11 +82,15 @@ type listUsersRequest struct {
 //	@Tags			Users
 //	@Accept			json
 //	@Produce		json
-//	@Param			skip	query		uint64			true	"Skip"
-//	@Param			limit	query		uint64			true	"Limit"
-//	@Success		200		{object}	meta			"Users displayed"
-//	@Failure		400		{object}	errorResponse	"Validation error"
-//	@Failure		500		{object}	errorResponse	"Internal server error"
+//	@Param			skip			query		uint64			true	"Skip"
+//	@Param			limit			query		uint64			true	"Limit"
+//	@Param			sort			query		string			false	"Sort by (id, name, email, created_at)"
+//	@Param			order			query		string			false	"Sort order (asc, desc)"
+//	@Param			include_deleted	query		bool			false	"Include the soft deleted users, requires the permission to manage them"
+//	@Success		200				{object}	meta			"Users displayed"
+//	@Failure		400				{object}	errorResponse	"Validation error"
+//	@Failure		403				{object}	errorResponse	"Forbidden error"
+//	@Failure		500				{object}	errorResponse	"Internal server error"
 //	@Router			/users [get]
 //	@Security		BearerAuth
 func (uh *UserHandler) ListUsers(ctx *gin.Context) {
@@ -93,7 +102,19 @@ func (uh *UserHandler) ListUsers(ctx *gin.Context) {
 		return
 	}
 
-	users, err := uh.svc.ListUsers(ctx, req.Skip, req.Limit)
+	if err := checkIncludeDeleted(ctx, req.IncludeDeleted, domain.UsersWrite); err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	params := &domain.ListParams{
+		Skip:  req.Skip,
+		Limit: req.Limit,
+		Sort:  req.Sort,
+		Order: req.Order,
+	}
+
+	users, total, err := uh.svc.ListUsers(ctx, req.IncludeDeleted, params)
 	if err != nil {
 		handleError(ctx, err)
 		return
@@ -103,7 +124,6 @@ func (uh *UserHandler) ListUsers(ctx *gin.Context) {
 		usersList = append(usersList, newUserResponse(&user))
 	}
 
-	total := uint64(len(usersList))
 	meta := newMeta(total, req.Limit, req.Skip)
 	rsp := toMap(meta, usersList, "users")
 
@@ -124,6 +144,7 @@ type getUserRequest struct {
 //	@Produce		json
 //	@Param			id	path		uint64			true	"User ID"
 //	@Success		200	{object}	userResponse	"User displayed"
+//	@Header			200	{string}	ETag			"ETag of the user"
 //	@Failure		400	{object}	errorResponse	"Validation error"
 //	@Failure		404	{object}	errorResponse	"Data not found error"
 //	@Failure		500	{object}	errorResponse	"Internal server error"
@@ -142,6 +163,7 @@ func (uh *UserHandler) GetUser(ctx *gin.Context) {
 		return
 	}
 
+	setETag(ctx, user.Version)
 	rsp := newUserResponse(user)
 
 	handleSuccess(ctx, rsp)
@@ -152,23 +174,28 @@ type updateUserRequest struct {
 	Name     string          `json:"name" binding:"omitempty,required" example:"John Doe"`
 	Email    string          `json:"email" binding:"omitempty,required,email" example:"test@example.com"`
 	Password string          `json:"password" binding:"omitempty,required,min=8" example:"12345678"`
+	Pin      string          `json:"pin" binding:"omitempty,numeric,min=4,max=8" example:"1234"`
 	Role     domain.UserRole `json:"role" binding:"omitempty,required,user_role" example:"admin"`
 }
 
 // UpdateUser godoc
 //
 //	@Summary		Update a user
-//	@Description	Update a user's name, email, password, or role by id
+//	@Description	Update a user's name, email, password, PIN, or role by id
 //	@Tags			Users
 //	@Accept			json
 //	@Produce		json
 //	@Param			id					path		uint64				true	"User ID"
+//	@Param			If-Match			header		string				true	"ETag of the user that was read"
 //	@Param			updateUserRequest	body		updateUserRequest	true	"Update user request"
 //	@Success		200					{object}	userResponse		"User updated"
+//	@Header			200					{string}	ETag				"ETag of the updated user"
 //	@Failure		400					{object}	errorResponse		"Validation error"
 //	@Failure		401					{object}	errorResponse		"Unauthorized error"
 //	@Failure		403					{object}	errorResponse		"Forbidden error"
 //	@Failure		404					{object}	errorResponse		"Data not found error"
+//	@Failure		412					{object}	errorResponse		"Version mismatch error"
+//	@Failure		428					{object}	errorResponse		"Precondition required error"
 //	@Failure		500					{object}	errorResponse		"Internal server error"
 //	@Router			/users/{id} [put]
 //	@Security		BearerAuth
@@ -186,12 +213,20 @@ func (uh *UserHandler) UpdateUser(ctx *gin.Context) {
 		return
 	}
 
+	version, err := getIfMatch(ctx)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+
 	user := domain.User{
 		ID:       id,
 		Name:     req.Name,
 		Email:    req.Email,
 		Password: req.Password,
+		Pin:      req.Pin,
 		Role:     req.Role,
+		Version:  version,
 	}
 
 	_, err = uh.svc.UpdateUser(ctx, &user)
@@ -200,6 +235,7 @@ func (uh *UserHandler) UpdateUser(ctx *gin.Context) {
 		return
 	}
 
+	setETag(ctx, user.Version)
 	rsp := newUserResponse(&user)
 
 	handleSuccess(ctx, rsp)
@@ -217,27 +253,78 @@ type deleteUserRequest struct {
 //	@Tags			Users
 //	@Accept			json
 //	@Produce		json
+//	@Param			id			path		uint64			true	"User ID"
+//	@Param			If-Match	header		string			true	"ETag of the user that was read"
+//	@Success		200			{object}	response		"User deleted"
+//	@Failure		400			{object}	errorResponse	"Validation error"
+//	@Failure		401			{object}	errorResponse	"Unauthorized error"
+//	@Failure		403			{object}	errorResponse	"Forbidden error"
+//	@Failure		404			{object}	errorResponse	"Data not found error"
+//	@Failure		412			{object}	errorResponse	"Version mismatch error"
+//	@Failure		428			{object}	errorResponse	"Precondition required error"
+//	@Failure		500			{object}	errorResponse	"Internal server error"
+//	@Router			/users/{id} [delete]
+//	@Security		BearerAuth
+func (uh *UserHandler) DeleteUser(ctx *gin.Context) {
+	var req deleteUserRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		validationError(ctx, err)
+		return
+	}
+
+	version, err := getIfMatch(ctx)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	err = uh.svc.DeleteUser(ctx, req.ID, version)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	handleSuccess(ctx, nil)
+}
+
+// restoreUserRequest represents the request body for restoring a soft deleted user
+type restoreUserRequest struct {
+	ID uint64 `uri:"id" binding:"required,min=1" example:"1"`
+}
+
+// RestoreUser godoc
+//
+//	@Summary		Restore a user
+//	@Description	Restore a soft deleted user by id
+//	@Tags			Users
+//	@Accept			json
+//	@Produce		json
 //	@Param			id	path		uint64			true	"User ID"
-//	@Success		200	{object}	response		"User deleted"
+//	@Success		200	{object}	userResponse	"User restored"
+//	@Header			200	{string}	ETag			"ETag of the restored user"
 //	@Failure		400	{object}	errorResponse	"Validation error"
 //	@Failure		401	{object}	errorResponse	"Unauthorized error"
 //	@Failure		403	{object}	errorResponse	"Forbidden error"
 //	@Failure		404	{object}	errorResponse	"Data not found error"
+//	@Failure		409	{object}	errorResponse	"Data conflict error"
 //	@Failure		500	{object}	errorResponse	"Internal server error"
-//	@Router			/users/{id} [delete]
+//	@Router			/users/{id}/restore [post]
 //	@Security		BearerAuth
-func (uh *UserHandler) DeleteUser(ctx *gin.Context) {
-	var req deleteUserRequest
+func (uh *UserHandler) RestoreUser(ctx *gin.Context) {
+	var req restoreUserRequest
 	if err := ctx.ShouldBindUri(&req); err != nil {
 		validationError(ctx, err)
 		return
 	}
 
-	err := uh.svc.DeleteUser(ctx, req.ID)
+	user, err := uh.svc.RestoreUser(ctx, req.ID)
 	if err != nil {
 		handleError(ctx, err)
 		return
 	}
 
-	handleSuccess(ctx, nil)
+	setETag(ctx, user.Version)
+	rsp := newUserResponse(user)
+
+	handleSuccess(ctx, rsp)
 }
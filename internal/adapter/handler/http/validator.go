@@ -1,20 +1,34 @@
 package http
 
 import (
+	"regexp"
+
 	"github.com/nikhil-shrestha/go-pos/internal/core/domain"
 	"github.com/go-playground/validator/v10"
 )
 
-// userRoleValidator is a custom validator for validating user roles
+// userRolePattern is the format of a role name, a lowercase word that may contain digits and underscores
+var userRolePattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,63}$`)
+
+// userRoleValidator is a custom validator for validating role names, whose existence is checked by the database
 var userRoleValidator validator.Func = func(fl validator.FieldLevel) bool {
 	userRole := fl.Field().Interface().(domain.UserRole)
 
-	switch userRole {
-	case "admin", "cashier":
-		return true
-	default:
-		return false
-	}
+	return userRolePattern.MatchString(string(userRole))
+}
+
+// permissionValidator is a custom validator for validating role permissions
+var permissionValidator validator.Func = func(fl validator.FieldLevel) bool {
+	permission := fl.Field().Interface().(domain.Permission)
+
+	return permission.IsValid()
+}
+
+// apiKeyPermissionValidator is a custom validator for validating the permissions an API key can be granted
+var apiKeyPermissionValidator validator.Func = func(fl validator.FieldLevel) bool {
+	permission := fl.Field().Interface().(domain.Permission)
+
+	return permission.IsAPIKeyPermission()
 }
 
 // paymentTypeValidator is a custom validator for validating payment types
@@ -28,3 +42,35 @@ var paymentTypeValidator validator.Func = func(fl validator.FieldLevel) bool {
 		return false
 	}
 }
+
+// reportIntervalValidator is a custom validator for validating report intervals
+var reportIntervalValidator validator.Func = func(fl validator.FieldLevel) bool {
+	reportInterval := fl.Field().Interface().(domain.ReportInterval)
+
+	switch reportInterval {
+	case "hour", "day":
+		return true
+	default:
+		return false
+	}
+}
+
+// orderStatusValidator is a custom validator for validating order statuses
+var orderStatusValidator validator.Func = func(fl validator.FieldLevel) bool {
+	orderStatus := fl.Field().Interface().(domain.OrderStatus)
+
+	switch orderStatus {
+	case domain.OrderCompleted, domain.OrderVoided:
+		return true
+	default:
+		return false
+	}
+}
+
+// overrideActionValidator is a custom validator for validating the actions that need a supervisor's approval
+var overrideActionValidator validator.Func = func(fl validator.FieldLevel) bool {
+	overrideAction := fl.Field().Interface().(domain.OverrideAction)
+
+	_, ok := overrideAction.Permission()
+	return ok
+}
@@ -0,0 +1,32 @@
+package console
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/nikhil-shrestha/go-pos/internal/adapter/config"
+	"github.com/nikhil-shrestha/go-pos/internal/core/domain"
+	"github.com/nikhil-shrestha/go-pos/internal/core/port"
+)
+
+/**
+ * Console implements port.Notifier interface
+ * and writes the messages to the application log instead of sending them, for development
+ */
+type Console struct {
+	resetURL string
+}
+
+// New creates a new console notifier instance
+func New(config *config.Notifier) port.Notifier {
+	return &Console{
+		config.ResetURL,
+	}
+}
+
+// SendPasswordReset logs the link that resets the password of the user
+func (c *Console) SendPasswordReset(ctx context.Context, user *domain.User, token string) error {
+	slog.InfoContext(ctx, "Password reset requested", "email", user.Email, "link", c.resetURL+token)
+
+	return nil
+}
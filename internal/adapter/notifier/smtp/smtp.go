@@ -0,0 +1,66 @@
+package smtp
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strings"
+
+	"github.com/nikhil-shrestha/go-pos/internal/adapter/config"
+	"github.com/nikhil-shrestha/go-pos/internal/core/domain"
+	"github.com/nikhil-shrestha/go-pos/internal/core/port"
+)
+
+/**
+ * SMTP implements port.Notifier interface
+ * and provides an access to a mail server to email the messages
+ */
+type SMTP struct {
+	addr     string
+	auth     smtp.Auth
+	from     string
+	resetURL string
+}
+
+// New creates a new SMTP notifier instance, authenticating to the mail server if a username is given
+func New(config *config.Notifier) port.Notifier {
+	var auth smtp.Auth
+	if config.Username != "" {
+		auth = smtp.PlainAuth("", config.Username, config.Password, config.Host)
+	}
+
+	return &SMTP{
+		net.JoinHostPort(config.Host, config.Port),
+		auth,
+		config.From,
+		config.ResetURL,
+	}
+}
+
+// SendPasswordReset emails the user a link that resets their password
+func (s *SMTP) SendPasswordReset(ctx context.Context, user *domain.User, token string) error {
+	body := fmt.Sprintf(
+		"Hi %s,\r\n\r\nUse the link below to reset your password. It expires in an hour and works only once.\r\n\r\n%s%s\r\n\r\nIf you did not ask to reset your password, you can ignore this email.\r\n",
+		user.Name,
+		s.resetURL,
+		token,
+	)
+
+	return s.send(user.Email, "Reset your password", body)
+}
+
+// send emails a plain text message to the recipient
+func (s *SMTP) send(to, subject, body string) error {
+	headers := []string{
+		"From: " + s.from,
+		"To: " + to,
+		"Subject: " + subject,
+		"MIME-Version: 1.0",
+		"Content-Type: text/plain; charset=\"utf-8\"",
+	}
+
+	msg := strings.Join(headers, "\r\n") + "\r\n\r\n" + body
+
+	return smtp.SendMail(s.addr, s.auth, s.from, []string{to}, []byte(msg))
+}
@@ -0,0 +1,104 @@
+package filesystem
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/nikhil-shrestha/go-pos/internal/adapter/config"
+	"github.com/nikhil-shrestha/go-pos/internal/core/domain"
+	"github.com/nikhil-shrestha/go-pos/internal/core/port"
+)
+
+/**
+ * FileSystem implements port.FileStorage interface
+ * and provides an access to a directory of the local file system
+ */
+type FileSystem struct {
+	root string
+	url  string
+}
+
+// New creates a new file system storage instance, creating the root directory if it does not exist.
+// The root is served as is, so it must be neither the root of the file system nor the working directory
+func New(config *config.Storage) (port.FileStorage, error) {
+	if strings.TrimSpace(config.Path) == "" {
+		return nil, domain.ErrInvalidStoragePath
+	}
+
+	root, err := filepath.Abs(config.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+
+	if root == filepath.Dir(root) || root == wd {
+		return nil, domain.ErrInvalidStoragePath
+	}
+
+	err = os.MkdirAll(root, 0o755)
+	if err != nil {
+		return nil, err
+	}
+
+	return &FileSystem{
+		root,
+		strings.TrimSuffix(config.URL, "/"),
+	}, nil
+}
+
+// Upload writes the file under the root directory
+func (fs *FileSystem) Upload(ctx context.Context, key string, file *domain.File) (string, error) {
+	path, err := fs.path(key)
+	if err != nil {
+		return "", err
+	}
+
+	err = os.MkdirAll(filepath.Dir(path), 0o755)
+	if err != nil {
+		return "", err
+	}
+
+	err = os.WriteFile(path, file.Content, 0o644)
+	if err != nil {
+		return "", err
+	}
+
+	return fs.url + "/" + key, nil
+}
+
+// Delete removes the file of the URL from the root directory
+func (fs *FileSystem) Delete(ctx context.Context, url string) error {
+	key, ok := strings.CutPrefix(url, fs.url+"/")
+	if !ok {
+		return nil
+	}
+
+	path, err := fs.path(key)
+	if err != nil {
+		return err
+	}
+
+	err = os.Remove(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	return nil
+}
+
+// path converts a key into a path inside the root directory
+func (fs *FileSystem) path(key string) (string, error) {
+	path := filepath.Join(fs.root, filepath.FromSlash(key))
+	if !strings.HasPrefix(path, fs.root+string(filepath.Separator)) {
+		return "", os.ErrInvalid
+	}
+
+	return path, nil
+}
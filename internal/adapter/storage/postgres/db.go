@@ -10,6 +10,7 @@ import (
 	"github.com/golang-migrate/migrate/v4"
 	_ "github.com/golang-migrate/migrate/v4/database/postgres"
 	"github.com/golang-migrate/migrate/v4/source/iofs"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
@@ -81,6 +82,64 @@ func (db *DB) Migrate() error {
 	return nil
 }
 
+// txKey is the key for the transaction in the context
+type txKey struct{}
+
+// WithinTransaction runs fn in a transaction, committing it if fn returns nil. The context passed to fn carries
+// the transaction, which the queries made with that context run in. If the context already carries a transaction,
+// fn runs in a savepoint of it
+func (db *DB) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return pgx.BeginFunc(ctx, db, func(tx pgx.Tx) error {
+		return fn(context.WithValue(ctx, txKey{}, tx))
+	})
+}
+
+// transaction returns the transaction the context carries, if any
+func transaction(ctx context.Context) (pgx.Tx, bool) {
+	tx, ok := ctx.Value(txKey{}).(pgx.Tx)
+	return tx, ok
+}
+
+// Begin starts a transaction, or a savepoint of the transaction the context carries
+func (db *DB) Begin(ctx context.Context) (pgx.Tx, error) {
+	tx, ok := transaction(ctx)
+	if ok {
+		return tx.Begin(ctx)
+	}
+
+	return db.Pool.Begin(ctx)
+}
+
+// Exec executes the statement in the transaction the context carries, or in a connection of the pool
+func (db *DB) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	tx, ok := transaction(ctx)
+	if ok {
+		return tx.Exec(ctx, sql, args...)
+	}
+
+	return db.Pool.Exec(ctx, sql, args...)
+}
+
+// Query runs the query in the transaction the context carries, or in a connection of the pool
+func (db *DB) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	tx, ok := transaction(ctx)
+	if ok {
+		return tx.Query(ctx, sql, args...)
+	}
+
+	return db.Pool.Query(ctx, sql, args...)
+}
+
+// QueryRow runs the query in the transaction the context carries, or in a connection of the pool
+func (db *DB) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	tx, ok := transaction(ctx)
+	if ok {
+		return tx.QueryRow(ctx, sql, args...)
+	}
+
+	return db.Pool.QueryRow(ctx, sql, args...)
+}
+
 // ErrorCode returns the error code of the given error
 func (db *DB) ErrorCode(err error) string {
 	pgErr := err.(*pgconn.PgError)
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS "products_barcode";
+
+ALTER TABLE
+    "products" DROP COLUMN IF EXISTS "barcode";
\ No newline at end of file
@@ -0,0 +1,8 @@
+ALTER TABLE
+    "products"
+ADD
+    COLUMN "barcode" varchar NOT NULL DEFAULT '';
+
+CREATE UNIQUE INDEX "products_barcode" ON "products" ("barcode")
+WHERE
+    "barcode" <> '';
\ No newline at end of file
@@ -0,0 +1,10 @@
+ALTER TABLE
+    "categories" DROP CONSTRAINT IF EXISTS "categories_parent_id_check";
+
+ALTER TABLE
+    "categories" DROP CONSTRAINT IF EXISTS "fk_categories_categories";
+
+DROP INDEX IF EXISTS "categories_parent_id";
+
+ALTER TABLE
+    "categories" DROP COLUMN IF EXISTS "parent_id";
\ No newline at end of file
@@ -0,0 +1,16 @@
+ALTER TABLE
+    "categories"
+ADD
+    COLUMN "parent_id" bigint;
+
+CREATE INDEX "categories_parent_id" ON "categories" ("parent_id");
+
+ALTER TABLE
+    "categories"
+ADD
+    CONSTRAINT "fk_categories_categories" FOREIGN KEY ("parent_id") REFERENCES "categories" ("id") ON DELETE NO ACTION ON UPDATE NO ACTION;
+
+ALTER TABLE
+    "categories"
+ADD
+    CONSTRAINT "categories_parent_id_check" CHECK ("parent_id" <> "id");
\ No newline at end of file
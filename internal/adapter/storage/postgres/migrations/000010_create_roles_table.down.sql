@@ -0,0 +1,15 @@
+CREATE TYPE "users_role_enum" AS ENUM ('admin', 'cashier');
+
+ALTER TABLE "users" DROP CONSTRAINT IF EXISTS "fk_users_roles";
+
+UPDATE "users" SET "role" = 'cashier' WHERE "role" NOT IN ('admin', 'cashier');
+
+ALTER TABLE "users" ALTER COLUMN "role" DROP NOT NULL;
+
+ALTER TABLE "users" ALTER COLUMN "role" DROP DEFAULT;
+
+ALTER TABLE "users" ALTER COLUMN "role" TYPE users_role_enum USING "role"::users_role_enum;
+
+ALTER TABLE "users" ALTER COLUMN "role" SET DEFAULT 'cashier';
+
+DROP TABLE IF EXISTS "roles";
@@ -0,0 +1,27 @@
+CREATE TABLE "roles" (
+    "id" BIGSERIAL PRIMARY KEY,
+    "name" varchar NOT NULL,
+    "permissions" varchar[] NOT NULL DEFAULT '{}',
+    "created_at" timestamptz NOT NULL DEFAULT (now()),
+    "updated_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE UNIQUE INDEX "role_name" ON "roles" ("name");
+
+INSERT INTO "roles" ("name", "permissions") VALUES
+    ('admin', '{users.write,roles.write,payments.write,categories.write,products.write,products.export,orders.create,orders.export,reports.view}'),
+    ('cashier', '{orders.create}');
+
+ALTER TABLE "users" ALTER COLUMN "role" DROP DEFAULT;
+
+ALTER TABLE "users" ALTER COLUMN "role" TYPE varchar USING "role"::varchar;
+
+UPDATE "users" SET "role" = 'cashier' WHERE "role" IS NULL;
+
+ALTER TABLE "users" ALTER COLUMN "role" SET DEFAULT 'cashier';
+
+ALTER TABLE "users" ALTER COLUMN "role" SET NOT NULL;
+
+ALTER TABLE "users" ADD CONSTRAINT "fk_users_roles" FOREIGN KEY ("role") REFERENCES "roles" ("name") ON UPDATE CASCADE;
+
+DROP TYPE IF EXISTS "users_role_enum";
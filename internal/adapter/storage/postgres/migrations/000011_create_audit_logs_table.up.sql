@@ -0,0 +1,23 @@
+CREATE TABLE "audit_logs" (
+    "id" BIGSERIAL PRIMARY KEY,
+    "user_id" bigint NOT NULL,
+    "approver_id" bigint,
+    "action" varchar NOT NULL,
+    "entity" varchar NOT NULL,
+    "entity_id" bigint,
+    "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX "audit_logs_user_id" ON "audit_logs" ("user_id");
+
+CREATE INDEX "audit_logs_entity" ON "audit_logs" ("entity", "entity_id");
+
+ALTER TABLE
+    "audit_logs"
+ADD
+    CONSTRAINT "fk_users_audit_logs" FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE NO ACTION ON UPDATE NO ACTION;
+
+ALTER TABLE
+    "audit_logs"
+ADD
+    CONSTRAINT "fk_approvers_audit_logs" FOREIGN KEY ("approver_id") REFERENCES "users" ("id") ON DELETE NO ACTION ON UPDATE NO ACTION;
@@ -0,0 +1,11 @@
+UPDATE "roles" SET "permissions" = array_remove(array_remove("permissions", 'orders.void'), 'drawer.open');
+
+DROP INDEX IF EXISTS "orders_status";
+
+ALTER TABLE
+    "orders" DROP COLUMN IF EXISTS "voided_at";
+
+ALTER TABLE
+    "orders" DROP COLUMN IF EXISTS "status";
+
+DROP TYPE IF EXISTS "orders_status_enum";
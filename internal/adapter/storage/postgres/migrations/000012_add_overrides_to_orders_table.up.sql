@@ -0,0 +1,15 @@
+CREATE TYPE "orders_status_enum" AS ENUM ('completed', 'voided');
+
+ALTER TABLE
+    "orders"
+ADD
+    COLUMN "status" orders_status_enum NOT NULL DEFAULT 'completed';
+
+ALTER TABLE
+    "orders"
+ADD
+    COLUMN "voided_at" timestamptz;
+
+CREATE INDEX "orders_status" ON "orders" ("status");
+
+UPDATE "roles" SET "permissions" = array_cat("permissions", '{orders.void,drawer.open}') WHERE "name" = 'admin';
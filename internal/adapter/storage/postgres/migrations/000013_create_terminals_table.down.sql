@@ -0,0 +1,6 @@
+UPDATE "roles" SET "permissions" = array_remove("permissions", 'terminals.write');
+
+ALTER TABLE
+    "users" DROP COLUMN IF EXISTS "pin";
+
+DROP TABLE IF EXISTS "terminals";
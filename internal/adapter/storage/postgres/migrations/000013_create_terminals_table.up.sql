@@ -0,0 +1,16 @@
+CREATE TABLE "terminals" (
+    "id" BIGSERIAL PRIMARY KEY,
+    "name" varchar NOT NULL,
+    "key_hash" varchar NOT NULL,
+    "created_at" timestamptz NOT NULL DEFAULT (now()),
+    "updated_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE UNIQUE INDEX "terminal_name" ON "terminals" ("name");
+
+ALTER TABLE
+    "users"
+ADD
+    COLUMN "pin" varchar NOT NULL DEFAULT '';
+
+UPDATE "roles" SET "permissions" = array_append("permissions", 'terminals.write') WHERE "name" = 'admin';
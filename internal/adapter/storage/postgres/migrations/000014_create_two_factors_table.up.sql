@@ -0,0 +1,10 @@
+CREATE TABLE "two_factors" (
+    "user_id" bigint PRIMARY KEY,
+    "secret" varchar NOT NULL,
+    "recovery_codes" varchar[] NOT NULL DEFAULT '{}',
+    "enabled_at" timestamptz,
+    "created_at" timestamptz NOT NULL DEFAULT (now()),
+    "updated_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "two_factors" ADD CONSTRAINT "fk_users_two_factors" FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE;
@@ -0,0 +1,3 @@
+UPDATE "roles" SET "permissions" = array_remove("permissions", 'api_keys.write');
+
+DROP TABLE IF EXISTS "api_keys";
@@ -0,0 +1,18 @@
+CREATE TABLE "api_keys" (
+    "id" BIGSERIAL PRIMARY KEY,
+    "name" varchar NOT NULL,
+    "prefix" varchar NOT NULL,
+    "key_hash" varchar NOT NULL,
+    "permissions" varchar[] NOT NULL DEFAULT '{}',
+    "created_by" bigint NOT NULL,
+    "expires_at" timestamptz,
+    "last_used_at" timestamptz,
+    "created_at" timestamptz NOT NULL DEFAULT (now()),
+    "updated_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE UNIQUE INDEX "api_key_prefix" ON "api_keys" ("prefix");
+
+ALTER TABLE "api_keys" ADD CONSTRAINT "fk_users_api_keys" FOREIGN KEY ("created_by") REFERENCES "users" ("id") ON DELETE CASCADE;
+
+UPDATE "roles" SET "permissions" = array_append("permissions", 'api_keys.write') WHERE "name" = 'admin';
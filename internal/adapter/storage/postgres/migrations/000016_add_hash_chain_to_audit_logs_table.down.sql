@@ -0,0 +1,33 @@
+UPDATE "roles" SET "permissions" = array_remove("permissions", 'audit_logs.view');
+
+DROP TRIGGER IF EXISTS "audit_logs_no_truncate" ON "audit_logs";
+
+DROP TRIGGER IF EXISTS "audit_logs_append_only" ON "audit_logs";
+
+DROP FUNCTION IF EXISTS "audit_logs_append_only";
+
+DROP INDEX IF EXISTS "audit_logs_created_at";
+
+DROP INDEX IF EXISTS "audit_logs_action";
+
+DELETE FROM "audit_logs" WHERE "user_id" IS NULL;
+
+ALTER TABLE
+    "audit_logs" DROP COLUMN IF EXISTS "hash",
+    DROP COLUMN IF EXISTS "prev_hash",
+    DROP COLUMN IF EXISTS "request_id",
+    DROP COLUMN IF EXISTS "ip",
+    DROP COLUMN IF EXISTS "changes",
+    DROP COLUMN IF EXISTS "api_key_id";
+
+ALTER TABLE "audit_logs" ALTER COLUMN "user_id" SET NOT NULL;
+
+ALTER TABLE
+    "audit_logs"
+ADD
+    CONSTRAINT "fk_users_audit_logs" FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE NO ACTION ON UPDATE NO ACTION NOT VALID;
+
+ALTER TABLE
+    "audit_logs"
+ADD
+    CONSTRAINT "fk_approvers_audit_logs" FOREIGN KEY ("approver_id") REFERENCES "users" ("id") ON DELETE NO ACTION ON UPDATE NO ACTION NOT VALID;
@@ -0,0 +1,40 @@
+ALTER TABLE "audit_logs" DROP CONSTRAINT IF EXISTS "fk_users_audit_logs";
+
+ALTER TABLE "audit_logs" DROP CONSTRAINT IF EXISTS "fk_approvers_audit_logs";
+
+ALTER TABLE "audit_logs" ALTER COLUMN "user_id" DROP NOT NULL;
+
+ALTER TABLE
+    "audit_logs"
+ADD
+    COLUMN "api_key_id" bigint,
+ADD
+    COLUMN "changes" json,
+ADD
+    COLUMN "ip" varchar,
+ADD
+    COLUMN "request_id" varchar,
+ADD
+    COLUMN "prev_hash" varchar NOT NULL DEFAULT '',
+ADD
+    COLUMN "hash" varchar NOT NULL DEFAULT '';
+
+CREATE INDEX "audit_logs_action" ON "audit_logs" ("action");
+
+CREATE INDEX "audit_logs_created_at" ON "audit_logs" ("created_at");
+
+CREATE OR REPLACE FUNCTION "audit_logs_append_only"() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_logs is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER "audit_logs_append_only"
+    BEFORE UPDATE OR DELETE ON "audit_logs"
+    FOR EACH ROW EXECUTE FUNCTION "audit_logs_append_only"();
+
+CREATE TRIGGER "audit_logs_no_truncate"
+    BEFORE TRUNCATE ON "audit_logs"
+    FOR EACH STATEMENT EXECUTE FUNCTION "audit_logs_append_only"();
+
+UPDATE "roles" SET "permissions" = array_append("permissions", 'audit_logs.view') WHERE "name" = 'admin';
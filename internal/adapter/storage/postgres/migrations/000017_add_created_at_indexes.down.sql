@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS "products_created_at_id";
+
+DROP INDEX IF EXISTS "orders_created_at_id";
@@ -0,0 +1,3 @@
+CREATE INDEX "orders_created_at_id" ON "orders" ("created_at", "id");
+
+CREATE INDEX "products_created_at_id" ON "products" ("created_at", "id");
@@ -0,0 +1,5 @@
+ALTER TABLE
+    "order_products" DROP COLUMN IF EXISTS "category_name",
+    DROP COLUMN IF EXISTS "product_price",
+    DROP COLUMN IF EXISTS "product_sku",
+    DROP COLUMN IF EXISTS "product_name";
@@ -0,0 +1,26 @@
+ALTER TABLE
+    "order_products"
+ADD
+    COLUMN "product_name" varchar,
+ADD
+    COLUMN "product_sku" uuid,
+ADD
+    COLUMN "product_price" decimal(18, 2),
+ADD
+    COLUMN "category_name" varchar NOT NULL DEFAULT '';
+
+UPDATE "order_products" op
+SET
+    "product_name" = p."name",
+    "product_sku" = p."sku",
+    "product_price" = p."price",
+    "category_name" = COALESCE(c."name", '')
+FROM "products" p
+LEFT JOIN "categories" c ON c."id" = p."category_id"
+WHERE p."id" = op."product_id";
+
+ALTER TABLE
+    "order_products"
+ALTER COLUMN "product_name" SET NOT NULL,
+ALTER COLUMN "product_sku" SET NOT NULL,
+ALTER COLUMN "product_price" SET NOT NULL;
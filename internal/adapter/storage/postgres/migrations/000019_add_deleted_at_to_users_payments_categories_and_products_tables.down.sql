@@ -0,0 +1,29 @@
+DROP INDEX IF EXISTS "products_barcode";
+
+CREATE UNIQUE INDEX "products_barcode" ON "products" ("barcode")
+WHERE
+    "barcode" <> '';
+
+DROP INDEX IF EXISTS "category_name";
+
+CREATE UNIQUE INDEX "category_name" ON "categories" ("name");
+
+DROP INDEX IF EXISTS "payment_name";
+
+CREATE UNIQUE INDEX "payment_name" ON "payments" ("name");
+
+DROP INDEX IF EXISTS "email";
+
+CREATE UNIQUE INDEX "email" ON "users" ("email");
+
+ALTER TABLE
+    "products" DROP COLUMN IF EXISTS "deleted_at";
+
+ALTER TABLE
+    "categories" DROP COLUMN IF EXISTS "deleted_at";
+
+ALTER TABLE
+    "payments" DROP COLUMN IF EXISTS "deleted_at";
+
+ALTER TABLE
+    "users" DROP COLUMN IF EXISTS "deleted_at";
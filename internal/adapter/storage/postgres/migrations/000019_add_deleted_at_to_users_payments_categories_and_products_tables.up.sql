@@ -0,0 +1,44 @@
+ALTER TABLE
+    "users"
+ADD
+    COLUMN "deleted_at" timestamptz;
+
+ALTER TABLE
+    "payments"
+ADD
+    COLUMN "deleted_at" timestamptz;
+
+ALTER TABLE
+    "categories"
+ADD
+    COLUMN "deleted_at" timestamptz;
+
+ALTER TABLE
+    "products"
+ADD
+    COLUMN "deleted_at" timestamptz;
+
+DROP INDEX IF EXISTS "email";
+
+CREATE UNIQUE INDEX "email" ON "users" ("email")
+WHERE
+    "deleted_at" IS NULL;
+
+DROP INDEX IF EXISTS "payment_name";
+
+CREATE UNIQUE INDEX "payment_name" ON "payments" ("name")
+WHERE
+    "deleted_at" IS NULL;
+
+DROP INDEX IF EXISTS "category_name";
+
+CREATE UNIQUE INDEX "category_name" ON "categories" ("name")
+WHERE
+    "deleted_at" IS NULL;
+
+DROP INDEX IF EXISTS "products_barcode";
+
+CREATE UNIQUE INDEX "products_barcode" ON "products" ("barcode")
+WHERE
+    "barcode" <> ''
+    AND "deleted_at" IS NULL;
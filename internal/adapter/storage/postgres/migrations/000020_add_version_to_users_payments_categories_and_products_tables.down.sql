@@ -0,0 +1,11 @@
+ALTER TABLE
+    "users" DROP COLUMN IF EXISTS "version";
+
+ALTER TABLE
+    "payments" DROP COLUMN IF EXISTS "version";
+
+ALTER TABLE
+    "categories" DROP COLUMN IF EXISTS "version";
+
+ALTER TABLE
+    "products" DROP COLUMN IF EXISTS "version";
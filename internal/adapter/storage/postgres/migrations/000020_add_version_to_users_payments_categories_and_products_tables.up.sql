@@ -0,0 +1,19 @@
+ALTER TABLE
+    "users"
+ADD
+    COLUMN "version" bigint NOT NULL DEFAULT 1;
+
+ALTER TABLE
+    "payments"
+ADD
+    COLUMN "version" bigint NOT NULL DEFAULT 1;
+
+ALTER TABLE
+    "categories"
+ADD
+    COLUMN "version" bigint NOT NULL DEFAULT 1;
+
+ALTER TABLE
+    "products"
+ADD
+    COLUMN "version" bigint NOT NULL DEFAULT 1;
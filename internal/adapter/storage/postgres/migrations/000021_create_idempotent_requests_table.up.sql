@@ -0,0 +1,10 @@
+CREATE TABLE "idempotent_requests" (
+    "key" varchar PRIMARY KEY,
+    "request_hash" varchar NOT NULL,
+    "response_status" integer NOT NULL DEFAULT 0,
+    "response_body" bytea,
+    "created_at" timestamptz NOT NULL DEFAULT (now()),
+    "expires_at" timestamptz NOT NULL
+);
+
+CREATE INDEX "idempotent_request_expires_at" ON "idempotent_requests" ("expires_at");
@@ -0,0 +1,226 @@
+package repository
+
+import (
+	"context"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/nikhil-shrestha/go-pos/internal/adapter/storage/postgres"
+	"github.com/nikhil-shrestha/go-pos/internal/core/domain"
+)
+
+// orderItemsJoin joins the total quantity of each order without multiplying the order rows
+const orderItemsJoin = "LEFT JOIN LATERAL (SELECT SUM(quantity) AS quantity FROM order_products WHERE order_id = o.id) op ON true"
+
+/**
+ * AnalyticsRepository implements port.AnalyticsRepository interface
+ * and provides an access to the postgres database
+ */
+type AnalyticsRepository struct {
+	db *postgres.DB
+}
+
+// NewAnalyticsRepository creates a new analytics repository instance
+func NewAnalyticsRepository(db *postgres.DB) *AnalyticsRepository {
+	return &AnalyticsRepository{
+		db,
+	}
+}
+
+// GetSalesSummary aggregates the completed orders created within the date range
+func (ar *AnalyticsRepository) GetSalesSummary(ctx context.Context, filter *domain.ReportFilter) (*domain.SalesSummary, error) {
+	var summary domain.SalesSummary
+
+	query := ar.db.QueryBuilder.Select(
+		"COUNT(o.id)",
+		"COALESCE(SUM(op.quantity), 0)::bigint",
+		"COALESCE(SUM(o.total_price), 0)",
+		"COALESCE(AVG(o.total_price), 0)",
+		"COALESCE(AVG(op.quantity), 0)",
+	).
+		From("orders o").
+		JoinClause(orderItemsJoin).
+		Where(sq.GtOrEq{"o.created_at": filter.StartDate}).
+		Where(sq.Lt{"o.created_at": filter.EndDate}).
+		Where(sq.Eq{"o.status": domain.OrderCompleted})
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = ar.db.QueryRow(ctx, sql, args...).Scan(
+		&summary.TotalOrders,
+		&summary.TotalItems,
+		&summary.TotalRevenue,
+		&summary.AverageOrderValue,
+		&summary.AverageBasketSize,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &summary, nil
+}
+
+// ListSalesByPeriod aggregates the orders by hour or day in the filter's time zone
+func (ar *AnalyticsRepository) ListSalesByPeriod(ctx context.Context, filter *domain.ReportFilter) ([]domain.SalesPeriod, error) {
+	var period domain.SalesPeriod
+	var periods []domain.SalesPeriod
+
+	query := ar.db.QueryBuilder.Select().
+		Column(sq.Expr("date_trunc(?, o.created_at, ?) AS period", string(filter.Interval), filter.TimeZone)).
+		Columns(
+			"COUNT(o.id)",
+			"COALESCE(SUM(op.quantity), 0)::bigint",
+			"COALESCE(SUM(o.total_price), 0)",
+		).
+		From("orders o").
+		JoinClause(orderItemsJoin).
+		Where(sq.GtOrEq{"o.created_at": filter.StartDate}).
+		Where(sq.Lt{"o.created_at": filter.EndDate}).
+		Where(sq.Eq{"o.status": domain.OrderCompleted}).
+		GroupBy("period").
+		OrderBy("period")
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := ar.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		err := rows.Scan(
+			&period.Period,
+			&period.TotalOrders,
+			&period.TotalItems,
+			&period.TotalRevenue,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		periods = append(periods, period)
+	}
+
+	return periods, rows.Err()
+}
+
+// ListSalesByCategory aggregates the order products by the category name their product had when it was sold,
+// so moving or renaming products and categories does not change past sales
+func (ar *AnalyticsRepository) ListSalesByCategory(ctx context.Context, filter *domain.ReportFilter) ([]domain.SalesGroup, error) {
+	// Category names are the key, so the groups are left without an ID
+	query := ar.db.QueryBuilder.Select(
+		"0",
+		"op.category_name",
+		"COUNT(DISTINCT o.id)",
+		"COALESCE(SUM(op.quantity), 0)::bigint AS total_items",
+		"COALESCE(SUM(op.total_price), 0) AS total_revenue",
+	).
+		From("order_products op").
+		Join("orders o ON o.id = op.order_id").
+		GroupBy("op.category_name").
+		OrderBy("total_revenue DESC", "op.category_name")
+
+	return ar.listSalesGroups(ctx, query, filter)
+}
+
+// ListSalesByProduct aggregates the order products by product and the name it was sold under, best sellers first
+func (ar *AnalyticsRepository) ListSalesByProduct(ctx context.Context, filter *domain.ReportFilter) ([]domain.SalesGroup, error) {
+	query := ar.db.QueryBuilder.Select(
+		"op.product_id",
+		"op.product_name",
+		"COUNT(DISTINCT o.id)",
+		"COALESCE(SUM(op.quantity), 0)::bigint AS total_items",
+		"COALESCE(SUM(op.total_price), 0) AS total_revenue",
+	).
+		From("order_products op").
+		Join("orders o ON o.id = op.order_id").
+		GroupBy("op.product_id", "op.product_name").
+		OrderBy("total_items DESC", "total_revenue DESC", "op.product_id", "op.product_name")
+
+	return ar.listSalesGroups(ctx, query, filter)
+}
+
+// ListSalesByCashier aggregates the orders by the user who created them
+func (ar *AnalyticsRepository) ListSalesByCashier(ctx context.Context, filter *domain.ReportFilter) ([]domain.SalesGroup, error) {
+	query := ar.db.QueryBuilder.Select(
+		"u.id",
+		"u.name",
+		"COUNT(o.id)",
+		"COALESCE(SUM(op.quantity), 0)::bigint AS total_items",
+		"COALESCE(SUM(o.total_price), 0) AS total_revenue",
+	).
+		From("orders o").
+		Join("users u ON u.id = o.user_id").
+		JoinClause(orderItemsJoin).
+		GroupBy("u.id", "u.name").
+		OrderBy("total_revenue DESC", "u.id")
+
+	return ar.listSalesGroups(ctx, query, filter)
+}
+
+// ListSalesByPayment aggregates the orders by payment
+func (ar *AnalyticsRepository) ListSalesByPayment(ctx context.Context, filter *domain.ReportFilter) ([]domain.SalesGroup, error) {
+	query := ar.db.QueryBuilder.Select(
+		"pm.id",
+		"pm.name",
+		"COUNT(o.id)",
+		"COALESCE(SUM(op.quantity), 0)::bigint AS total_items",
+		"COALESCE(SUM(o.total_price), 0) AS total_revenue",
+	).
+		From("orders o").
+		Join("payments pm ON pm.id = o.payment_id").
+		JoinClause(orderItemsJoin).
+		GroupBy("pm.id", "pm.name").
+		OrderBy("total_revenue DESC", "pm.id")
+
+	return ar.listSalesGroups(ctx, query, filter)
+}
+
+// listSalesGroups applies the date range and limit of the filter to a grouped query and scans the result
+func (ar *AnalyticsRepository) listSalesGroups(ctx context.Context, query sq.SelectBuilder, filter *domain.ReportFilter) ([]domain.SalesGroup, error) {
+	var group domain.SalesGroup
+	var groups []domain.SalesGroup
+
+	query = query.
+		Where(sq.GtOrEq{"o.created_at": filter.StartDate}).
+		Where(sq.Lt{"o.created_at": filter.EndDate}).
+		Where(sq.Eq{"o.status": domain.OrderCompleted})
+
+	if filter.Limit != 0 {
+		query = query.Limit(filter.Limit)
+	}
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := ar.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		err := rows.Scan(
+			&group.ID,
+			&group.Name,
+			&group.TotalOrders,
+			&group.TotalItems,
+			&group.TotalRevenue,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		groups = append(groups, group)
+	}
+
+	return groups, rows.Err()
+}
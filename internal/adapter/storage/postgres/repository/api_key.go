@@ -0,0 +1,203 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/nikhil-shrestha/go-pos/internal/adapter/storage/postgres"
+	"github.com/nikhil-shrestha/go-pos/internal/core/domain"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+/**
+ * APIKeyRepository implements port.APIKeyRepository interface
+ * and provides an access to the postgres database
+ */
+type APIKeyRepository struct {
+	db *postgres.DB
+}
+
+// NewAPIKeyRepository creates a new API key repository instance
+func NewAPIKeyRepository(db *postgres.DB) *APIKeyRepository {
+	return &APIKeyRepository{
+		db,
+	}
+}
+
+// CreateAPIKey creates a new API key in the database
+func (akr *APIKeyRepository) CreateAPIKey(ctx context.Context, apiKey *domain.APIKey) (*domain.APIKey, error) {
+	query := akr.db.QueryBuilder.Insert("api_keys").
+		Columns("name", "prefix", "key_hash", "permissions", "created_by", "expires_at").
+		Values(apiKey.Name, apiKey.Prefix, apiKey.KeyHash, permissionsToStrings(apiKey.Permissions), apiKey.CreatedBy, apiKey.ExpiresAt).
+		Suffix("RETURNING *")
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = scanAPIKey(akr.db.QueryRow(ctx, sql, args...), apiKey)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return nil, domain.ErrConflictingData
+		}
+		return nil, err
+	}
+
+	return apiKey, nil
+}
+
+// GetAPIKeyByPrefix retrieves an API key by its prefix from the database,
+// skipping the keys of deleted users so they cannot act as them anymore
+func (akr *APIKeyRepository) GetAPIKeyByPrefix(ctx context.Context, prefix string) (*domain.APIKey, error) {
+	var apiKey domain.APIKey
+
+	query := akr.db.QueryBuilder.Select("ak.*").
+		From("api_keys ak").
+		Join("users u ON u.id = ak.created_by").
+		Where(sq.Eq{"ak.prefix": prefix, "u.deleted_at": nil}).
+		Limit(1)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = scanAPIKey(akr.db.QueryRow(ctx, sql, args...), &apiKey)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, domain.ErrDataNotFound
+		}
+		return nil, err
+	}
+
+	return &apiKey, nil
+}
+
+// apiKeySortColumns are the columns API keys can be sorted by
+var apiKeySortColumns = map[string]string{
+	"id":           "id",
+	"name":         "name",
+	"created_at":   "created_at",
+	"expires_at":   "expires_at",
+	"last_used_at": "last_used_at",
+}
+
+// ListAPIKeys retrieves a list of API keys from the database
+func (akr *APIKeyRepository) ListAPIKeys(ctx context.Context, params *domain.ListParams) ([]domain.APIKey, uint64, error) {
+	var apiKeys []domain.APIKey
+
+	total, err := countRows(ctx, akr.db, akr.db.QueryBuilder.Select().From("api_keys"))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	query := akr.db.QueryBuilder.Select("*").
+		From("api_keys").
+		OrderBy(orderBy(params, apiKeySortColumns)).
+		Limit(params.Limit).
+		Offset((params.Skip - 1) * params.Limit)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	rows, err := akr.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var apiKey domain.APIKey
+
+		err := scanAPIKey(rows, &apiKey)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		apiKeys = append(apiKeys, apiKey)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	return apiKeys, total, nil
+}
+
+// TouchAPIKey updates when an API key was last used in the database
+func (akr *APIKeyRepository) TouchAPIKey(ctx context.Context, id uint64, lastUsedAt time.Time) error {
+	query := akr.db.QueryBuilder.Update("api_keys").
+		Set("last_used_at", lastUsedAt).
+		Where(sq.Eq{"id": id})
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = akr.db.Exec(ctx, sql, args...)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// DeleteAPIKey deletes an API key by id from the database
+func (akr *APIKeyRepository) DeleteAPIKey(ctx context.Context, id uint64) (*domain.APIKey, error) {
+	var apiKey domain.APIKey
+
+	query := akr.db.QueryBuilder.Delete("api_keys").
+		Where(sq.Eq{"id": id}).
+		Suffix("RETURNING *")
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = scanAPIKey(akr.db.QueryRow(ctx, sql, args...), &apiKey)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, domain.ErrDataNotFound
+		}
+		return nil, err
+	}
+
+	return &apiKey, nil
+}
+
+// scanAPIKey scans an API key row, converting the permissions array
+func scanAPIKey(row pgx.Row, apiKey *domain.APIKey) error {
+	var permissions []string
+
+	err := row.Scan(
+		&apiKey.ID,
+		&apiKey.Name,
+		&apiKey.Prefix,
+		&apiKey.KeyHash,
+		&permissions,
+		&apiKey.CreatedBy,
+		&apiKey.ExpiresAt,
+		&apiKey.LastUsedAt,
+		&apiKey.CreatedAt,
+		&apiKey.UpdatedAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	apiKey.Permissions = make([]domain.Permission, len(permissions))
+	for i, permission := range permissions {
+		apiKey.Permissions[i] = domain.Permission(permission)
+	}
+
+	return nil
+}
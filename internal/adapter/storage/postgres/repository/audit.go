@@ -0,0 +1,261 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/nikhil-shrestha/go-pos/internal/adapter/storage/postgres"
+	"github.com/nikhil-shrestha/go-pos/internal/core/domain"
+	"github.com/jackc/pgx/v5"
+)
+
+// auditLogColumns are the columns of an audit log, in the order scanAuditLog reads them
+var auditLogColumns = []string{
+	"id",
+	"user_id",
+	"api_key_id",
+	"approver_id",
+	"action",
+	"entity",
+	"entity_id",
+	"changes",
+	"ip",
+	"request_id",
+	"prev_hash",
+	"hash",
+	"created_at",
+}
+
+/**
+ * AuditRepository implements port.AuditRepository interface
+ * and provides an access to the postgres database
+ */
+type AuditRepository struct {
+	db *postgres.DB
+}
+
+// NewAuditRepository creates a new audit repository instance
+func NewAuditRepository(db *postgres.DB) *AuditRepository {
+	return &AuditRepository{
+		db,
+	}
+}
+
+// CreateAuditLog creates a new audit log in the database. The chain is locked until the transaction ends, so that
+// every log is chained to the one committed right before it. Other writes to the table are not held up, but other
+// logs are until the commit, so the log is best created as the last step of the transaction
+func (ar *AuditRepository) CreateAuditLog(ctx context.Context, auditLog *domain.AuditLog) (*domain.AuditLog, error) {
+	var changes []byte
+	var err error
+
+	if len(auditLog.Changes) > 0 {
+		changes, err = json.Marshal(auditLog.Changes)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	tx, err := ar.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	err = lockUntilCommit(ctx, tx, auditChainLock)
+	if err != nil {
+		return nil, err
+	}
+
+	prevQuery := ar.db.QueryBuilder.Select("hash").
+		From("audit_logs").
+		OrderBy("id DESC").
+		Limit(1)
+
+	sql, args, err := prevQuery.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	var prevHash string
+
+	err = tx.QueryRow(ctx, sql, args...).Scan(&prevHash)
+	if err != nil && err != pgx.ErrNoRows {
+		return nil, err
+	}
+
+	auditLog.PrevHash = prevHash
+	auditLog.CreatedAt = time.Now().UTC().Truncate(time.Microsecond)
+	auditLog.Hash = auditLog.ComputeHash()
+
+	query := ar.db.QueryBuilder.Insert("audit_logs").
+		Columns("user_id", "api_key_id", "approver_id", "action", "entity", "entity_id", "changes", "ip", "request_id", "prev_hash", "hash", "created_at").
+		Values(
+			nullUint64(auditLog.UserID),
+			nullUint64(auditLog.APIKeyID),
+			nullUint64(auditLog.ApproverID),
+			auditLog.Action,
+			auditLog.Entity,
+			nullUint64(auditLog.EntityID),
+			changes,
+			nullString(auditLog.IP),
+			nullString(auditLog.RequestID),
+			auditLog.PrevHash,
+			auditLog.Hash,
+			auditLog.CreatedAt,
+		).
+		Suffix("RETURNING id")
+
+	sql, args, err = query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.QueryRow(ctx, sql, args...).Scan(&auditLog.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return auditLog, nil
+}
+
+// auditLogSortColumns are the columns audit logs can be sorted by
+var auditLogSortColumns = map[string]string{
+	"id":         "id",
+	"action":     "action",
+	"entity":     "entity",
+	"created_at": "created_at",
+}
+
+// ListAuditLogs retrieves a sorted page of audit logs matching the filter from the database, with their total count
+func (ar *AuditRepository) ListAuditLogs(ctx context.Context, filter *domain.AuditLogFilter, params *domain.ListParams) ([]domain.AuditLog, uint64, error) {
+	query := ar.db.QueryBuilder.Select().
+		From("audit_logs")
+
+	if filter.UserID != 0 {
+		query = query.Where(sq.Eq{"user_id": filter.UserID})
+	}
+	if filter.Action != "" {
+		query = query.Where(sq.Eq{"action": filter.Action})
+	}
+	if filter.Entity != "" {
+		query = query.Where(sq.Eq{"entity": filter.Entity})
+	}
+	if filter.EntityID != 0 {
+		query = query.Where(sq.Eq{"entity_id": filter.EntityID})
+	}
+	if filter.RequestID != "" {
+		query = query.Where(sq.Eq{"request_id": filter.RequestID})
+	}
+	if filter.StartDate != nil {
+		query = query.Where(sq.GtOrEq{"created_at": *filter.StartDate})
+	}
+	if filter.EndDate != nil {
+		query = query.Where(sq.Lt{"created_at": *filter.EndDate})
+	}
+
+	total, err := countRows(ctx, ar.db, query)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	query = query.Columns(auditLogColumns...).
+		OrderBy(orderBy(params, auditLogSortColumns)).
+		Limit(params.Limit).
+		Offset((params.Skip - 1) * params.Limit)
+
+	auditLogs, err := ar.listAuditLogs(ctx, query)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return auditLogs, total, nil
+}
+
+// ListAuditLogsAfter retrieves the audit logs following the given id from the database, oldest first
+func (ar *AuditRepository) ListAuditLogsAfter(ctx context.Context, id, limit uint64) ([]domain.AuditLog, error) {
+	query := ar.db.QueryBuilder.Select(auditLogColumns...).
+		From("audit_logs").
+		Where(sq.Gt{"id": id}).
+		OrderBy("id").
+		Limit(limit)
+
+	return ar.listAuditLogs(ctx, query)
+}
+
+// listAuditLogs runs a select query of audit logs and scans its rows
+func (ar *AuditRepository) listAuditLogs(ctx context.Context, query sq.SelectBuilder) ([]domain.AuditLog, error) {
+	var auditLogs []domain.AuditLog
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := ar.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var auditLog domain.AuditLog
+
+		err := scanAuditLog(rows, &auditLog)
+		if err != nil {
+			return nil, err
+		}
+
+		auditLogs = append(auditLogs, auditLog)
+	}
+
+	return auditLogs, rows.Err()
+}
+
+// scanAuditLog scans an audit log row, converting its nullable columns and its changes
+func scanAuditLog(row pgx.Row, auditLog *domain.AuditLog) error {
+	var userID, apiKeyID, approverID, entityID sql.NullInt64
+	var ip, requestID sql.NullString
+	var changes []byte
+
+	err := row.Scan(
+		&auditLog.ID,
+		&userID,
+		&apiKeyID,
+		&approverID,
+		&auditLog.Action,
+		&auditLog.Entity,
+		&entityID,
+		&changes,
+		&ip,
+		&requestID,
+		&auditLog.PrevHash,
+		&auditLog.Hash,
+		&auditLog.CreatedAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	auditLog.UserID = uint64(userID.Int64)
+	auditLog.APIKeyID = uint64(apiKeyID.Int64)
+	auditLog.ApproverID = uint64(approverID.Int64)
+	auditLog.EntityID = uint64(entityID.Int64)
+	auditLog.IP = ip.String
+	auditLog.RequestID = requestID.String
+
+	if changes != nil {
+		return json.Unmarshal(changes, &auditLog.Changes)
+	}
+
+	return nil
+}
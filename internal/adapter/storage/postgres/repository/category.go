@@ -2,6 +2,8 @@ package repository
 
 import (
 	"context"
+	"database/sql"
+	"fmt"
 	"time"
 
 	sq "github.com/Masterminds/squirrel"
@@ -27,9 +29,11 @@ func NewCategoryRepository(db *postgres.DB) *CategoryRepository {
 
 // CreateCategory creates a new category record in the database
 func (cr *CategoryRepository) CreateCategory(ctx context.Context, category *domain.Category) (*domain.Category, error) {
+	var parentID sql.NullInt64
+
 	query := cr.db.QueryBuilder.Insert("categories").
-		Columns("name").
-		Values(category.Name).
+		Columns("name", "parent_id").
+		Values(category.Name, nullUint64(category.ParentID)).
 		Suffix("RETURNING *")
 
 	sql, args, err := query.ToSql()
@@ -42,6 +46,9 @@ func (cr *CategoryRepository) CreateCategory(ctx context.Context, category *doma
 		&category.Name,
 		&category.CreatedAt,
 		&category.UpdatedAt,
+		&parentID,
+		&category.DeletedAt,
+		&category.Version,
 	)
 	if err != nil {
 		if errCode := cr.db.ErrorCode(err); errCode == "23505" {
@@ -50,16 +57,20 @@ func (cr *CategoryRepository) CreateCategory(ctx context.Context, category *doma
 		return nil, err
 	}
 
+	category.ParentID = uint64(parentID.Int64)
+
 	return category, nil
 }
 
-// GetCategoryByID retrieves a category record from the database by id
+// GetCategoryByID retrieves a category record that is not soft deleted from the database by id
 func (cr *CategoryRepository) GetCategoryByID(ctx context.Context, id uint64) (*domain.Category, error) {
 	var category domain.Category
+	var parentID sql.NullInt64
 
 	query := cr.db.QueryBuilder.Select("*").
 		From("categories").
 		Where(sq.Eq{"id": id}).
+		Where(notDeleted).
 		Limit(1)
 
 	sql, args, err := query.ToSql()
@@ -72,6 +83,9 @@ func (cr *CategoryRepository) GetCategoryByID(ctx context.Context, id uint64) (*
 		&category.Name,
 		&category.CreatedAt,
 		&category.UpdatedAt,
+		&parentID,
+		&category.DeletedAt,
+		&category.Version,
 	)
 	if err != nil {
 		if err == pgx.ErrNoRows {
@@ -80,29 +94,112 @@ func (cr *CategoryRepository) GetCategoryByID(ctx context.Context, id uint64) (*
 		return nil, err
 	}
 
+	category.ParentID = uint64(parentID.Int64)
+
 	return &category, nil
 }
 
-// ListCategories retrieves a list of categories from the database
-func (cr *CategoryRepository) ListCategories(ctx context.Context, skip, limit uint64) ([]domain.Category, error) {
-	var category domain.Category
+// GetCategoriesByIDs retrieves the categories with the given ids from the database, soft deleted or not, skipping the ids that do not exist
+func (cr *CategoryRepository) GetCategoriesByIDs(ctx context.Context, ids []uint64) ([]domain.Category, error) {
+	query := cr.db.QueryBuilder.Select("id", "name", "created_at", "updated_at", "parent_id", "deleted_at", "version").
+		From("categories").
+		Where(sq.Eq{"id": ids})
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	return cr.listCategories(ctx, sql, args...)
+}
+
+// categorySortColumns are the columns categories can be sorted by
+var categorySortColumns = map[string]string{
+	"id":         "id",
+	"name":       "name",
+	"created_at": "created_at",
+}
+
+// categoryTreeQuery selects a page of root categories and all their descendants, skipping the soft deleted
+// categories unless they are included, formatted with the ORDER BY expression of the roots
+const categoryTreeQuery = `WITH RECURSIVE roots AS (
+	SELECT * FROM categories WHERE parent_id IS NULL AND (? OR deleted_at IS NULL) ORDER BY %s LIMIT ? OFFSET ?
+), tree AS (
+	SELECT * FROM roots
+	UNION ALL
+	SELECT c.* FROM categories c JOIN tree t ON c.parent_id = t.id WHERE ? OR c.deleted_at IS NULL
+) CYCLE id SET is_cycle USING path`
+
+// ListCategories retrieves a page of root categories from the database, each with its tree of descendants
+func (cr *CategoryRepository) ListCategories(ctx context.Context, includeDeleted bool, params *domain.ListParams) ([]domain.Category, uint64, error) {
 	var categories []domain.Category
 
-	query := cr.db.QueryBuilder.Select("*").
+	total, err := countRows(ctx, cr.db, withDeleted(cr.db.QueryBuilder.Select().
 		From("categories").
-		OrderBy("id").
-		Limit(limit).
-		Offset((skip - 1) * limit)
+		Where(sq.Eq{"parent_id": nil}), includeDeleted))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	order := orderBy(params, categorySortColumns)
+
+	query := cr.db.QueryBuilder.Select("id", "name", "created_at", "updated_at", "parent_id", "deleted_at", "version").
+		Prefix(fmt.Sprintf(categoryTreeQuery, order), includeDeleted, params.Limit, (params.Skip-1)*params.Limit, includeDeleted).
+		From("tree").
+		OrderBy(order)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	categories, err = cr.listCategories(ctx, sql, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return buildCategoryTrees(categories), total, nil
+}
+
+// categoryAncestorsQuery selects a category and its ancestors with their distance from the category
+const categoryAncestorsQuery = `WITH RECURSIVE ancestors AS (
+	SELECT *, 0 AS depth FROM categories WHERE id = ?
+	UNION ALL
+	SELECT c.*, a.depth + 1 FROM categories c JOIN ancestors a ON c.id = a.parent_id
+) CYCLE id SET is_cycle USING path`
+
+// ListCategoryAncestors retrieves a category and its ancestors from the database, starting with the category itself
+func (cr *CategoryRepository) ListCategoryAncestors(ctx context.Context, id uint64) ([]domain.Category, error) {
+	query := cr.db.QueryBuilder.Select("id", "name", "created_at", "updated_at", "parent_id", "deleted_at", "version").
+		Prefix(categoryAncestorsQuery, id).
+		From("ancestors").
+		OrderBy("depth")
 
 	sql, args, err := query.ToSql()
 	if err != nil {
 		return nil, err
 	}
 
-	rows, err := cr.db.Query(ctx, sql, args...)
+	return cr.listCategories(ctx, sql, args...)
+}
+
+// LockCategoryTree locks the category tree until the transaction the context carries ends, so that moves of
+// categories are checked for cycles one at a time
+func (cr *CategoryRepository) LockCategoryTree(ctx context.Context) error {
+	return lockUntilCommit(ctx, cr.db, categoryTreeLock)
+}
+
+// listCategories scans the categories selected by a query
+func (cr *CategoryRepository) listCategories(ctx context.Context, query string, args ...any) ([]domain.Category, error) {
+	var category domain.Category
+	var categories []domain.Category
+	var parentID sql.NullInt64
+
+	rows, err := cr.db.Query(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
+	defer rows.Close()
 
 	for rows.Next() {
 		err := rows.Scan(
@@ -110,23 +207,65 @@ func (cr *CategoryRepository) ListCategories(ctx context.Context, skip, limit ui
 			&category.Name,
 			&category.CreatedAt,
 			&category.UpdatedAt,
+			&parentID,
+			&category.DeletedAt,
+			&category.Version,
 		)
 		if err != nil {
 			return nil, err
 		}
 
+		category.ParentID = uint64(parentID.Int64)
+
 		categories = append(categories, category)
 	}
 
-	return categories, nil
+	return categories, rows.Err()
 }
 
-// UpdateCategory updates a category record in the database
+// buildCategoryTrees nests the categories under their parent and returns the root categories
+func buildCategoryTrees(categories []domain.Category) []domain.Category {
+	ids := make(map[uint64]bool, len(categories))
+	children := make(map[uint64][]domain.Category)
+
+	for _, category := range categories {
+		ids[category.ID] = true
+		children[category.ParentID] = append(children[category.ParentID], category)
+	}
+
+	var attach func(category domain.Category) domain.Category
+	attach = func(category domain.Category) domain.Category {
+		for _, child := range children[category.ID] {
+			category.Children = append(category.Children, attach(child))
+		}
+		return category
+	}
+
+	var roots []domain.Category
+	for _, category := range categories {
+		if !ids[category.ParentID] {
+			roots = append(roots, attach(category))
+		}
+	}
+
+	return roots
+}
+
+// UpdateCategory updates a category record in the database, as long as it is at the given version unless none is given.
+// The name is kept when it is empty, while the parent is always written, a parent id of 0 making it a root category
 func (cr *CategoryRepository) UpdateCategory(ctx context.Context, category *domain.Category) (*domain.Category, error) {
+	var parentID sql.NullInt64
+
+	name := nullString(category.Name)
+
 	query := cr.db.QueryBuilder.Update("categories").
-		Set("name", category.Name).
+		Set("name", sq.Expr("COALESCE(?, name)", name)).
+		Set("parent_id", nullUint64(category.ParentID)).
 		Set("updated_at", time.Now()).
+		Set("version", bumpVersion).
 		Where(sq.Eq{"id": category.ID}).
+		Where(notDeleted).
+		Where(atVersion(category.Version)).
 		Suffix("RETURNING *")
 
 	sql, args, err := query.ToSql()
@@ -139,31 +278,96 @@ func (cr *CategoryRepository) UpdateCategory(ctx context.Context, category *doma
 		&category.Name,
 		&category.CreatedAt,
 		&category.UpdatedAt,
+		&parentID,
+		&category.DeletedAt,
+		&category.Version,
 	)
 	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, notChanged(ctx, cr.db, "categories", category.ID, category.Version, domain.ErrVersionMismatch)
+		}
 		if errCode := cr.db.ErrorCode(err); errCode == "23505" {
 			return nil, domain.ErrConflictingData
 		}
 		return nil, err
 	}
 
+	category.ParentID = uint64(parentID.Int64)
+
 	return category, nil
 }
 
-// DeleteCategory deletes a category record from the database by id
-func (cr *CategoryRepository) DeleteCategory(ctx context.Context, id uint64) error {
-	query := cr.db.QueryBuilder.Delete("categories").
-		Where(sq.Eq{"id": id})
+// DeleteCategory soft deletes a category record in the database by id, as long as it is at the given version unless none is given,
+// and none of its subcategories and products are left that are not soft deleted
+func (cr *CategoryRepository) DeleteCategory(ctx context.Context, id, version uint64) error {
+	query := cr.db.QueryBuilder.Update("categories").
+		Set("deleted_at", time.Now()).
+		Set("version", bumpVersion).
+		Where(sq.Eq{"id": id}).
+		Where(notDeleted).
+		Where(atVersion(version)).
+		Where("NOT EXISTS (SELECT 1 FROM categories c WHERE c.parent_id = categories.id AND c.deleted_at IS NULL)").
+		Where("NOT EXISTS (SELECT 1 FROM products p WHERE p.category_id = categories.id AND p.deleted_at IS NULL)")
 
 	sql, args, err := query.ToSql()
 	if err != nil {
 		return err
 	}
 
-	_, err = cr.db.Exec(ctx, sql, args...)
+	result, err := cr.db.Exec(ctx, sql, args...)
 	if err != nil {
 		return err
 	}
 
+	if result.RowsAffected() == 0 {
+		return notChanged(ctx, cr.db, "categories", id, version, domain.ErrConflictingData)
+	}
+
 	return nil
 }
+
+// RestoreCategory restores a soft deleted category record in the database by id, as long as its parent is not soft deleted
+func (cr *CategoryRepository) RestoreCategory(ctx context.Context, id uint64) (*domain.Category, error) {
+	var category domain.Category
+	var parentID sql.NullInt64
+
+	query := cr.db.QueryBuilder.Update("categories").
+		Set("deleted_at", nil).
+		Set("updated_at", time.Now()).
+		Set("version", bumpVersion).
+		Where(sq.Eq{"id": id}).
+		Where(sq.NotEq{"deleted_at": nil}).
+		Where("(parent_id IS NULL OR EXISTS (SELECT 1 FROM categories c WHERE c.id = categories.parent_id AND c.deleted_at IS NULL))").
+		Suffix("RETURNING *")
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = cr.db.QueryRow(ctx, sql, args...).Scan(
+		&category.ID,
+		&category.Name,
+		&category.CreatedAt,
+		&category.UpdatedAt,
+		&parentID,
+		&category.DeletedAt,
+		&category.Version,
+	)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, notFoundOrConflict(ctx, cr.db, cr.db.QueryBuilder.Select().
+				From("categories").
+				Where(sq.Eq{"id": id}).
+				Where(sq.NotEq{"deleted_at": nil}))
+		}
+		if errCode := cr.db.ErrorCode(err); errCode == "23505" {
+			return nil, domain.ErrConflictingData
+		}
+		return nil, err
+	}
+
+	category.ParentID = uint64(parentID.Int64)
+
+	return &category, nil
+}
@@ -1,7 +1,15 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
+	"fmt"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/nikhil-shrestha/go-pos/internal/adapter/storage/postgres"
+	"github.com/nikhil-shrestha/go-pos/internal/core/domain"
 )
 
 // nullString converts a string to sql.NullString for empty string check
@@ -53,3 +61,143 @@ func nullFloat64(value float64) sql.NullFloat64 {
 		Valid:   true,
 	}
 }
+
+// orderBy returns the ORDER BY expression of a list sorted by the column of the sort parameter,
+// or by id if the list is not sorted, with the id as a tiebreaker so that pages do not overlap.
+// Only the columns listed can be sorted by, since they are written into the query
+func orderBy(params *domain.ListParams, columns map[string]string) string {
+	direction := "ASC"
+	if params.Order == domain.Descending {
+		direction = "DESC"
+	}
+
+	column, ok := columns[params.Sort]
+	if !ok || column == "id" {
+		return "id " + direction
+	}
+
+	return fmt.Sprintf("%s %s, id %s", column, direction, direction)
+}
+
+// cursorPage sorts a query by creation time and filters it to the rows following the cursor.
+// It selects one row more than the limit, which tells whether there is a next page
+func cursorPage(query sq.SelectBuilder, params *domain.CursorParams) sq.SelectBuilder {
+	direction, operator := "ASC", ">"
+	if params.Order == domain.Descending {
+		direction, operator = "DESC", "<"
+	}
+
+	if params.Cursor != nil {
+		query = query.Where(sq.Expr("(created_at, id) "+operator+" (?, ?)", params.Cursor.CreatedAt, params.Cursor.ID))
+	}
+
+	return query.
+		OrderBy("created_at "+direction, "id "+direction).
+		Limit(params.Limit + 1)
+}
+
+// countRows selects the number of rows matching a query
+func countRows(ctx context.Context, db *postgres.DB, query sq.SelectBuilder) (uint64, error) {
+	var count uint64
+
+	sql, args, err := query.Columns("COUNT(*)").ToSql()
+	if err != nil {
+		return 0, err
+	}
+
+	err = db.QueryRow(ctx, sql, args...).Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// notDeleted filters out the soft deleted rows
+var notDeleted = sq.Eq{"deleted_at": nil}
+
+// withDeleted filters a query to the rows that are not soft deleted, unless the soft deleted rows are included
+func withDeleted(query sq.SelectBuilder, includeDeleted bool) sq.SelectBuilder {
+	if includeDeleted {
+		return query
+	}
+
+	return query.Where(notDeleted)
+}
+
+// notFoundOrConflict tells why a row could not be soft deleted or restored, by counting the rows of a query
+// selecting the row regardless of the rows it depends on: either there is no such row, or the rows it
+// depends on, or that depend on it, are in the way
+func notFoundOrConflict(ctx context.Context, db *postgres.DB, query sq.SelectBuilder) error {
+	count, err := countRows(ctx, db, query)
+	if err != nil {
+		return err
+	}
+
+	if count == 0 {
+		return domain.ErrDataNotFound
+	}
+
+	return domain.ErrConflictingData
+}
+
+// Keys of the advisory locks that serialize the writes that must not interleave
+const (
+	// categoryTreeLock is held while a category is moved under another one
+	categoryTreeLock int64 = iota + 1
+	// auditChainLock is held while an audit log is chained to the last one
+	auditChainLock
+)
+
+// execer runs a statement, in a transaction or in a connection of the pool
+type execer interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+}
+
+// lockUntilCommit takes the advisory lock with the key, holding it until the transaction it is taken in ends
+func lockUntilCommit(ctx context.Context, conn execer, key int64) error {
+	_, err := conn.Exec(ctx, "SELECT pg_advisory_xact_lock($1)", key)
+	return err
+}
+
+// bumpVersion increments the version of a changed row, which is its ETag
+var bumpVersion = sq.Expr("version + 1")
+
+// atVersion filters a change to the row at the version the client read, or to any version when none is given
+func atVersion(version uint64) sq.Eq {
+	if version == 0 {
+		return sq.Eq{}
+	}
+
+	return sq.Eq{"version": version}
+}
+
+// notChanged tells why a row of a table that is not soft deleted could not be changed: either there is no
+// such row, or it is no longer at the version the client read, or else the fallback error is in the way
+func notChanged(ctx context.Context, db *postgres.DB, table string, id, version uint64, fallback error) error {
+	var currentVersion uint64
+
+	query := db.QueryBuilder.Select("version").
+		From(table).
+		Where(sq.Eq{"id": id}).
+		Where(notDeleted)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return err
+	}
+
+	err = db.QueryRow(ctx, sql, args...).Scan(&currentVersion)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return domain.ErrDataNotFound
+		}
+		return err
+	}
+
+	if version != 0 && currentVersion != version {
+		return domain.ErrVersionMismatch
+	}
+
+	return fallback
+}
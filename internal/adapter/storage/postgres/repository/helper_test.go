@@ -0,0 +1,183 @@
+//go:build integration
+
+package repository_test
+
+import (
+	"context"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/nikhil-shrestha/go-pos/internal/adapter/config"
+	"github.com/nikhil-shrestha/go-pos/internal/adapter/storage/postgres"
+	"github.com/nikhil-shrestha/go-pos/internal/adapter/storage/postgres/repository"
+	"github.com/nikhil-shrestha/go-pos/internal/core/domain"
+	"github.com/brianvoe/gofakeit/v6"
+)
+
+// newTestDB connects to the database given by the DB_* environment variables and migrates it,
+// skipping the test when no database is configured
+func newTestDB(t *testing.T) *postgres.DB {
+	t.Helper()
+
+	if os.Getenv("DB_HOST") == "" {
+		t.Skip("DB_HOST is not set, skipping database test")
+	}
+
+	db, err := postgres.New(context.Background(), &config.DB{
+		Connection: os.Getenv("DB_CONNECTION"),
+		Host:       os.Getenv("DB_HOST"),
+		Port:       os.Getenv("DB_PORT"),
+		User:       os.Getenv("DB_USER"),
+		Password:   os.Getenv("DB_PASSWORD"),
+		Name:       os.Getenv("DB_NAME"),
+	})
+	if err != nil {
+		t.Fatalf("connecting to the database: %v", err)
+	}
+	t.Cleanup(db.Close)
+
+	err = db.Migrate()
+	if err != nil {
+		t.Fatalf("migrating the database: %v", err)
+	}
+
+	return db
+}
+
+// fixture is the data an order is made of, which is removed with the orders of its user when the test ends
+type fixture struct {
+	user     *domain.User
+	payment  *domain.Payment
+	category *domain.Category
+	products []*domain.Product
+}
+
+// newFixture creates a user, a payment, a category and products with the given stocks
+func newFixture(t *testing.T, db *postgres.DB, stocks ...int64) *fixture {
+	t.Helper()
+
+	ctx := context.Background()
+	f := &fixture{}
+	var err error
+
+	f.user, err = repository.NewUserRepository(db).CreateUser(ctx, &domain.User{
+		Name:     gofakeit.Name(),
+		Email:    gofakeit.UUID() + "@example.com",
+		Password: gofakeit.Password(true, true, true, true, false, 12),
+	})
+	if err != nil {
+		t.Fatalf("creating the user: %v", err)
+	}
+
+	f.payment, err = repository.NewPaymentRepository(db).CreatePayment(ctx, &domain.Payment{
+		Name: gofakeit.UUID(),
+		Type: domain.Cash,
+	})
+	if err != nil {
+		t.Fatalf("creating the payment: %v", err)
+	}
+
+	f.category, err = repository.NewCategoryRepository(db).CreateCategory(ctx, &domain.Category{
+		Name: gofakeit.UUID(),
+	})
+	if err != nil {
+		t.Fatalf("creating the category: %v", err)
+	}
+
+	productRepo := repository.NewProductRepository(db)
+	for _, stock := range stocks {
+		product, err := productRepo.CreateProduct(ctx, &domain.Product{
+			CategoryID: f.category.ID,
+			Name:       gofakeit.UUID(),
+			Price:      10,
+			Stock:      stock,
+		})
+		if err != nil {
+			t.Fatalf("creating the product: %v", err)
+		}
+
+		f.products = append(f.products, product)
+	}
+
+	t.Cleanup(func() {
+		productIDs := make([]uint64, len(f.products))
+		for i, product := range f.products {
+			productIDs[i] = product.ID
+		}
+
+		statements := []struct {
+			sql string
+			arg any
+		}{
+			{`DELETE FROM order_products WHERE product_id = ANY($1)`, productIDs},
+			{`DELETE FROM orders WHERE user_id = $1`, f.user.ID},
+			{`DELETE FROM products WHERE id = ANY($1)`, productIDs},
+			{`DELETE FROM categories WHERE id = $1`, f.category.ID},
+			{`DELETE FROM payments WHERE id = $1`, f.payment.ID},
+			{`DELETE FROM users WHERE id = $1`, f.user.ID},
+		}
+		for _, statement := range statements {
+			_, err := db.Exec(context.Background(), statement.sql, statement.arg)
+			if err != nil {
+				t.Errorf("cleaning up the fixture: %v", err)
+			}
+		}
+	})
+
+	return f
+}
+
+// orderLine is the quantity of a product in an order
+type orderLine struct {
+	product  *domain.Product
+	quantity int64
+}
+
+// newOrder builds an order of the fixture's user with the given lines
+func (f *fixture) newOrder(lines ...orderLine) *domain.Order {
+	order := &domain.Order{
+		UserID:    f.user.ID,
+		PaymentID: f.payment.ID,
+	}
+
+	for _, line := range lines {
+		totalPrice := line.product.Price * float64(line.quantity)
+
+		order.Products = append(order.Products, domain.OrderProduct{
+			ProductID:    line.product.ID,
+			ProductName:  line.product.Name,
+			ProductSKU:   line.product.SKU,
+			ProductPrice: line.product.Price,
+			CategoryName: f.category.Name,
+			Quantity:     line.quantity,
+			TotalPrice:   totalPrice,
+		})
+		order.TotalPrice += totalPrice
+	}
+	order.TotalPaid = order.TotalPrice
+
+	return order
+}
+
+// runConcurrently runs fn n times at once, releasing all the goroutines together
+// so that they race each other, and returns the errors in the order of their runs
+func runConcurrently(n int, fn func(i int) error) []error {
+	var wg sync.WaitGroup
+	start := make(chan struct{})
+	errs := make([]error, n)
+
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			<-start
+			errs[i] = fn(i)
+		}(i)
+	}
+
+	close(start)
+	wg.Wait()
+
+	return errs
+}
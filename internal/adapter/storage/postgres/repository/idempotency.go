@@ -0,0 +1,149 @@
+package repository
+
+import (
+	"context"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/nikhil-shrestha/go-pos/internal/adapter/storage/postgres"
+	"github.com/nikhil-shrestha/go-pos/internal/core/domain"
+	"github.com/jackc/pgx/v5"
+)
+
+// replaceIdempotentRequest overwrites the request with the same key when an idempotent request is inserted
+const replaceIdempotentRequest = `ON CONFLICT ("key") DO UPDATE SET
+	request_hash = EXCLUDED.request_hash,
+	response_status = EXCLUDED.response_status,
+	response_body = EXCLUDED.response_body,
+	created_at = EXCLUDED.created_at,
+	expires_at = EXCLUDED.expires_at`
+
+/**
+ * IdempotencyRepository implements port.IdempotencyRepository interface
+ * and provides an access to the postgres database
+ */
+type IdempotencyRepository struct {
+	db *postgres.DB
+}
+
+// NewIdempotencyRepository creates a new idempotent request repository instance
+func NewIdempotencyRepository(db *postgres.DB) *IdempotencyRepository {
+	return &IdempotencyRepository{
+		db,
+	}
+}
+
+// CreateIdempotentRequest creates a new running idempotent request in the database, taking over the key of an expired request
+func (ir *IdempotencyRepository) CreateIdempotentRequest(ctx context.Context, request *domain.IdempotentRequest) error {
+	query := ir.insertIdempotentRequest(request).
+		Suffix(replaceIdempotentRequest + " WHERE idempotent_requests.expires_at < now()")
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return err
+	}
+
+	result, err := ir.db.Exec(ctx, sql, args...)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.ErrConflictingData
+	}
+
+	return nil
+}
+
+// GetIdempotentRequest retrieves an unexpired idempotent request by its key from the database
+func (ir *IdempotencyRepository) GetIdempotentRequest(ctx context.Context, key string) (*domain.IdempotentRequest, error) {
+	var request domain.IdempotentRequest
+
+	query := ir.db.QueryBuilder.Select("key", "request_hash", "response_status", "response_body", "created_at", "expires_at").
+		From("idempotent_requests").
+		Where(sq.Eq{"key": key}).
+		Where(sq.Expr("expires_at >= now()")).
+		Limit(1)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = ir.db.QueryRow(ctx, sql, args...).Scan(
+		&request.Key,
+		&request.RequestHash,
+		&request.ResponseStatus,
+		&request.ResponseBody,
+		&request.CreatedAt,
+		&request.ExpiresAt,
+	)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, domain.ErrDataNotFound
+		}
+		return nil, err
+	}
+
+	return &request, nil
+}
+
+// CompleteIdempotentRequest stores the response of an idempotent request in the database, inserting the request if it is not there
+func (ir *IdempotencyRepository) CompleteIdempotentRequest(ctx context.Context, request *domain.IdempotentRequest) error {
+	query := ir.insertIdempotentRequest(request).
+		Suffix(replaceIdempotentRequest)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = ir.db.Exec(ctx, sql, args...)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// DeleteIdempotentRequest deletes an idempotent request by its key from the database
+func (ir *IdempotencyRepository) DeleteIdempotentRequest(ctx context.Context, key string) error {
+	query := ir.db.QueryBuilder.Delete("idempotent_requests").
+		Where(sq.Eq{"key": key})
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = ir.db.Exec(ctx, sql, args...)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// DeleteExpiredIdempotentRequests deletes the expired idempotent requests from the database
+func (ir *IdempotencyRepository) DeleteExpiredIdempotentRequests(ctx context.Context) error {
+	query := ir.db.QueryBuilder.Delete("idempotent_requests").
+		Where(sq.Expr("expires_at < now()"))
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = ir.db.Exec(ctx, sql, args...)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// insertIdempotentRequest builds the insert query of an idempotent request
+func (ir *IdempotencyRepository) insertIdempotentRequest(request *domain.IdempotentRequest) sq.InsertBuilder {
+	return ir.db.QueryBuilder.Insert("idempotent_requests").
+		Columns("key", "request_hash", "response_status", "response_body", "created_at", "expires_at").
+		Values(request.Key, request.RequestHash, request.ResponseStatus, request.ResponseBody, request.CreatedAt, request.ExpiresAt)
+}
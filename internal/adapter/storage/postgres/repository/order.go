@@ -2,11 +2,13 @@ package repository
 
 import (
 	"context"
+	"slices"
 	"time"
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/nikhil-shrestha/go-pos/internal/adapter/storage/postgres"
 	"github.com/nikhil-shrestha/go-pos/internal/core/domain"
+	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 )
 
@@ -25,96 +27,145 @@ func NewOrderRepository(db *postgres.DB) *OrderRepository {
 	}
 }
 
-// CreateOrder creates a new order in the database
+// CreateOrder creates a new order in the database and takes its products out of stock in the same transaction
 func (or *OrderRepository) CreateOrder(ctx context.Context, order *domain.Order) (*domain.Order, error) {
-	var product domain.Product
 	var products []domain.OrderProduct
 
 	orderQuery := or.db.QueryBuilder.Insert("orders").
 		Columns("user_id", "payment_id", "customer_name", "total_price", "total_paid", "total_return").
 		Values(order.UserID, order.PaymentID, order.CustomerName, order.TotalPrice, order.TotalPaid, order.TotalReturn).
-		Suffix("RETURNING *")
+		Suffix("RETURNING " + orderColumns)
 
 	err := pgx.BeginFunc(ctx, or.db, func(tx pgx.Tx) error {
+		err := or.reserveStock(ctx, tx, order.Products)
+		if err != nil {
+			return err
+		}
+
 		sql, args, err := orderQuery.ToSql()
 		if err != nil {
 			return err
 		}
 
-		err = tx.QueryRow(ctx, sql, args...).Scan(
-			&order.ID,
-			&order.UserID,
-			&order.PaymentID,
-			&order.CustomerName,
-			&order.TotalPrice,
-			&order.TotalPaid,
-			&order.TotalReturn,
-			&order.ReceiptCode,
-			&order.CreatedAt,
-			&order.UpdatedAt,
-		)
+		err = scanOrder(tx.QueryRow(ctx, sql, args...), order)
 		if err != nil {
 			return err
 		}
 
 		for _, orderProduct := range order.Products {
 			orderProductQuery := or.db.QueryBuilder.Insert("order_products").
-				Columns("order_id", "product_id", "quantity", "total_price").
-				Values(order.ID, orderProduct.ProductID, orderProduct.Quantity, orderProduct.TotalPrice).
-				Suffix("RETURNING *")
+				Columns("order_id", "product_id", "product_name", "product_sku", "product_price", "category_name", "quantity", "total_price").
+				Values(order.ID, orderProduct.ProductID, orderProduct.ProductName, orderProduct.ProductSKU, orderProduct.ProductPrice, orderProduct.CategoryName, orderProduct.Quantity, orderProduct.TotalPrice).
+				Suffix("RETURNING " + orderProductColumns)
 
 			sql, args, err := orderProductQuery.ToSql()
 			if err != nil {
 				return err
 			}
 
-			err = tx.QueryRow(ctx, sql, args...).Scan(
-				&orderProduct.ID,
-				&orderProduct.OrderID,
-				&orderProduct.ProductID,
-				&orderProduct.Quantity,
-				&orderProduct.TotalPrice,
-				&orderProduct.CreatedAt,
-				&orderProduct.UpdatedAt,
-			)
+			err = scanOrderProduct(tx.QueryRow(ctx, sql, args...), &orderProduct)
 			if err != nil {
 				return err
 			}
 
 			products = append(products, orderProduct)
+		}
 
-			productQuery := or.db.QueryBuilder.Update("products").
-				Set("stock", sq.Expr("stock - ?", orderProduct.Quantity)).
-				Set("updated_at", time.Now()).
-				Where(sq.Eq{"id": orderProduct.ProductID}).
-				Suffix("RETURNING stock")
+		order.Products = products
 
-			sql, args, err = productQuery.ToSql()
-			if err != nil {
-				return err
-			}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
 
-			err = tx.QueryRow(ctx, sql, args...).Scan(
-				&product.Stock,
-			)
+	return order, err
+}
+
+// reserveStock takes the ordered quantities out of the stock of the products in the transaction, only where the stock
+// is enough, and returns domain.InsufficientStockError with the products that are short, domain.ErrDataNotFound for
+// a product that does not exist or is soft deleted, or domain.ErrInvalidQuantity for a quantity below one. The products
+// are updated in the order of their ids, so that concurrent orders lock them in the same order and do not deadlock
+func (or *OrderRepository) reserveStock(ctx context.Context, tx pgx.Tx, orderProducts []domain.OrderProduct) error {
+	var shortProductIDs []uint64
+
+	quantities := make(map[uint64]int64, len(orderProducts))
+	for _, orderProduct := range orderProducts {
+		// A quantity below one would put stock back instead of taking it out
+		if orderProduct.Quantity <= 0 {
+			return domain.ErrInvalidQuantity
+		}
+		quantities[orderProduct.ProductID] += orderProduct.Quantity
+	}
+
+	productIDs := make([]uint64, 0, len(quantities))
+	for productID := range quantities {
+		productIDs = append(productIDs, productID)
+	}
+	slices.Sort(productIDs)
+
+	for _, productID := range productIDs {
+		quantity := quantities[productID]
+
+		productQuery := or.db.QueryBuilder.Update("products").
+			Set("stock", sq.Expr("stock - ?", quantity)).
+			Set("version", bumpVersion).
+			Set("updated_at", time.Now()).
+			Where(sq.Eq{"id": productID}).
+			Where(notDeleted).
+			Where(sq.GtOrEq{"stock": quantity})
+
+		sql, args, err := productQuery.ToSql()
+		if err != nil {
+			return err
+		}
+
+		result, err := tx.Exec(ctx, sql, args...)
+		if err != nil {
+			return err
+		}
+
+		if result.RowsAffected() == 0 {
+			err = or.checkProductExists(ctx, tx, productID)
 			if err != nil {
 				return err
 			}
 
-			if product.Stock < 0 {
-				return tx.Rollback(ctx)
-			}
+			shortProductIDs = append(shortProductIDs, productID)
 		}
+	}
 
-		order.Products = products
+	if len(shortProductIDs) > 0 {
+		return &domain.InsufficientStockError{ProductIDs: shortProductIDs}
+	}
 
-		return nil
-	})
+	return nil
+}
+
+// checkProductExists returns domain.ErrDataNotFound unless the product exists and is not soft deleted,
+// telling a product deleted since it was read from one whose stock is short
+func (or *OrderRepository) checkProductExists(ctx context.Context, tx pgx.Tx, productID uint64) error {
+	var exists int
+
+	query := or.db.QueryBuilder.Select("1").
+		From("products").
+		Where(sq.Eq{"id": productID}).
+		Where(notDeleted)
+
+	sql, args, err := query.ToSql()
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	return order, err
+	err = tx.QueryRow(ctx, sql, args...).Scan(&exists)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return domain.ErrDataNotFound
+		}
+		return err
+	}
+
+	return nil
 }
 
 // GetOrderByID gets an order by ID from the database
@@ -122,14 +173,15 @@ func (or *OrderRepository) GetOrderByID(ctx context.Context, id uint64) (*domain
 	var order domain.Order
 	var orderProduct domain.OrderProduct
 
-	orderQuery := or.db.QueryBuilder.Select("*").
+	orderQuery := or.db.QueryBuilder.Select(orderColumns).
 		From("orders").
 		Where(sq.Eq{"id": id}).
 		Limit(1)
 
-	orderProductQuery := or.db.QueryBuilder.Select("*").
+	orderProductQuery := or.db.QueryBuilder.Select(orderProductColumns).
 		From("order_products").
-		Where(sq.Eq{"order_id": id})
+		Where(sq.Eq{"order_id": id}).
+		OrderBy("id")
 
 	err := pgx.BeginFunc(ctx, or.db, func(tx pgx.Tx) error {
 
@@ -138,18 +190,7 @@ func (or *OrderRepository) GetOrderByID(ctx context.Context, id uint64) (*domain
 			return err
 		}
 
-		err = tx.QueryRow(ctx, sql, args...).Scan(
-			&order.ID,
-			&order.UserID,
-			&order.PaymentID,
-			&order.CustomerName,
-			&order.TotalPrice,
-			&order.TotalPaid,
-			&order.TotalReturn,
-			&order.ReceiptCode,
-			&order.CreatedAt,
-			&order.UpdatedAt,
-		)
+		err = scanOrder(tx.QueryRow(ctx, sql, args...), &order)
 		if err != nil {
 			if err == pgx.ErrNoRows {
 				return domain.ErrDataNotFound
@@ -166,17 +207,10 @@ func (or *OrderRepository) GetOrderByID(ctx context.Context, id uint64) (*domain
 		if err != nil {
 			return err
 		}
+		defer rows.Close()
 
 		for rows.Next() {
-			err = rows.Scan(
-				&orderProduct.ID,
-				&orderProduct.OrderID,
-				&orderProduct.ProductID,
-				&orderProduct.Quantity,
-				&orderProduct.TotalPrice,
-				&orderProduct.CreatedAt,
-				&orderProduct.UpdatedAt,
-			)
+			err = scanOrderProduct(rows, &orderProduct)
 			if err != nil {
 				return err
 			}
@@ -184,7 +218,7 @@ func (or *OrderRepository) GetOrderByID(ctx context.Context, id uint64) (*domain
 			order.Products = append(order.Products, orderProduct)
 		}
 
-		return nil
+		return rows.Err()
 	})
 	if err != nil {
 		return nil, err
@@ -193,20 +227,100 @@ func (or *OrderRepository) GetOrderByID(ctx context.Context, id uint64) (*domain
 	return &order, nil
 }
 
-// ListOrders lists all orders from the database
-func (or *OrderRepository) ListOrders(ctx context.Context, skip, limit uint64) ([]domain.Order, error) {
+// orderSortColumns are the columns orders can be sorted by
+var orderSortColumns = map[string]string{
+	"id":            "id",
+	"customer_name": "customer_name",
+	"total_price":   "total_price",
+	"created_at":    "created_at",
+}
+
+// ListOrders lists the orders matching the filter from the database
+func (or *OrderRepository) ListOrders(ctx context.Context, filter *domain.OrderFilter, params *domain.ListParams) ([]domain.Order, uint64, error) {
+	filtered := or.filterOrders(filter)
+
+	total, err := countRows(ctx, or.db, filtered)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	query := filtered.Columns(orderColumns).
+		OrderBy(orderBy(params, orderSortColumns)).
+		Limit(params.Limit).
+		Offset((params.Skip - 1) * params.Limit)
+
+	orders, err := or.listOrders(ctx, query)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return orders, total, nil
+}
+
+// ListOrdersByCursor lists the orders matching the filter following the cursor from the database, sorted by creation time
+func (or *OrderRepository) ListOrdersByCursor(ctx context.Context, filter *domain.OrderFilter, params *domain.CursorParams) ([]domain.Order, *domain.Cursor, error) {
+	query := or.filterOrders(filter).
+		Columns(orderColumns)
+
+	orders, err := or.listOrders(ctx, cursorPage(query, params))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var next *domain.Cursor
+	if uint64(len(orders)) > params.Limit {
+		orders = orders[:params.Limit]
+		last := orders[len(orders)-1]
+		next = &domain.Cursor{CreatedAt: last.CreatedAt, ID: last.ID}
+	}
+
+	return orders, next, nil
+}
+
+// filterOrders selects the orders matching the filter, ignoring its zero values
+func (or *OrderRepository) filterOrders(filter *domain.OrderFilter) sq.SelectBuilder {
+	query := or.db.QueryBuilder.Select().
+		From("orders")
+
+	if filter.StartDate != nil {
+		query = query.Where(sq.GtOrEq{"created_at": *filter.StartDate})
+	}
+	if filter.EndDate != nil {
+		query = query.Where(sq.Lt{"created_at": *filter.EndDate})
+	}
+	if filter.UserID != 0 {
+		query = query.Where(sq.Eq{"user_id": filter.UserID})
+	}
+	if filter.PaymentID != 0 {
+		query = query.Where(sq.Eq{"payment_id": filter.PaymentID})
+	}
+	if filter.CustomerName != "" {
+		query = query.Where(sq.ILike{"customer_name": "%" + filter.CustomerName + "%"})
+	}
+	if filter.ReceiptCode != uuid.Nil {
+		query = query.Where(sq.Eq{"receipt_code": filter.ReceiptCode})
+	}
+	if filter.MinTotal != 0 {
+		query = query.Where(sq.GtOrEq{"total_price": filter.MinTotal})
+	}
+	if filter.MaxTotal != 0 {
+		query = query.Where(sq.LtOrEq{"total_price": filter.MaxTotal})
+	}
+	if filter.Status != "" {
+		query = query.Where(sq.Eq{"status": filter.Status})
+	}
+
+	return query
+}
+
+// listOrders selects the orders of a query together with their order products
+func (or *OrderRepository) listOrders(ctx context.Context, query sq.SelectBuilder) ([]domain.Order, error) {
 	var order domain.Order
 	var orderProduct domain.OrderProduct
 	var orders []domain.Order
 
-	ordersQuery := or.db.QueryBuilder.Select("*").
-		From("orders").
-		OrderBy("id").
-		Limit(limit).
-		Offset((skip - 1) * limit)
-
 	err := pgx.BeginFunc(ctx, or.db, func(tx pgx.Tx) error {
-		sql, args, err := ordersQuery.ToSql()
+		sql, args, err := query.ToSql()
 		if err != nil {
 			return err
 		}
@@ -215,20 +329,10 @@ func (or *OrderRepository) ListOrders(ctx context.Context, skip, limit uint64) (
 		if err != nil {
 			return err
 		}
+		defer rows.Close()
 
 		for rows.Next() {
-			err := rows.Scan(
-				&order.ID,
-				&order.UserID,
-				&order.PaymentID,
-				&order.CustomerName,
-				&order.TotalPrice,
-				&order.TotalPaid,
-				&order.TotalReturn,
-				&order.ReceiptCode,
-				&order.CreatedAt,
-				&order.UpdatedAt,
-			)
+			err := scanOrder(rows, &order)
 			if err != nil {
 				return err
 			}
@@ -236,44 +340,247 @@ func (or *OrderRepository) ListOrders(ctx context.Context, skip, limit uint64) (
 			orders = append(orders, order)
 		}
 
+		if err := rows.Err(); err != nil {
+			return err
+		}
+
+		if len(orders) == 0 {
+			return nil
+		}
+
+		ids := make([]uint64, len(orders))
+		indexes := make(map[uint64]int, len(orders))
 		for i, order := range orders {
-			orderProductQuery := or.db.QueryBuilder.Select("*").
-				From("order_products").
-				Where(sq.Eq{"order_id": order.ID})
+			ids[i] = order.ID
+			indexes[order.ID] = i
+		}
 
-			sql, args, err := orderProductQuery.ToSql()
-			if err != nil {
-				return err
-			}
+		orderProductQuery := or.db.QueryBuilder.Select(orderProductColumns).
+			From("order_products").
+			Where(sq.Eq{"order_id": ids}).
+			OrderBy("id")
+
+		sql, args, err = orderProductQuery.ToSql()
+		if err != nil {
+			return err
+		}
+
+		rows, err = tx.Query(ctx, sql, args...)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
 
-			rows, err := tx.Query(ctx, sql, args...)
+		for rows.Next() {
+			err := scanOrderProduct(rows, &orderProduct)
 			if err != nil {
 				return err
 			}
 
-			for rows.Next() {
-				err := rows.Scan(
-					&orderProduct.ID,
-					&orderProduct.OrderID,
-					&orderProduct.ProductID,
-					&orderProduct.Quantity,
-					&orderProduct.TotalPrice,
-					&orderProduct.CreatedAt,
-					&orderProduct.UpdatedAt,
-				)
+			i := indexes[orderProduct.OrderID]
+			orders[i].Products = append(orders[i].Products, orderProduct)
+		}
+
+		return rows.Err()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return orders, nil
+}
+
+// StreamOrders iterates over the orders matching the filter together with their order products, one order at a time
+func (or *OrderRepository) StreamOrders(ctx context.Context, filter *domain.OrderFilter, fn func(order *domain.Order) error) error {
+	query := or.db.QueryBuilder.Select(
+		"o.id", "o.user_id", "o.payment_id", "o.customer_name", "o.total_price", "o.total_paid", "o.total_return", "o.receipt_code", "o.status", "o.voided_at", "o.created_at", "o.updated_at",
+		"op.id", "op.product_id", "op.product_name", "op.product_sku", "op.product_price", "op.category_name", "op.quantity", "op.total_price", "op.created_at", "op.updated_at",
+	).
+		FromSelect(or.filterOrders(filter).Columns("*"), "o").
+		LeftJoin("order_products op ON op.order_id = o.id").
+		OrderBy("o.id", "op.id")
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return err
+	}
+
+	rows, err := or.db.Query(ctx, sql, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	var current *domain.Order
+
+	for rows.Next() {
+		var order domain.Order
+		var orderProductID, productID *uint64
+		var productName, categoryName *string
+		var productSKU *uuid.UUID
+		var productPrice, totalPrice *float64
+		var quantity *int64
+		var createdAt, updatedAt *time.Time
+
+		err := rows.Scan(
+			&order.ID,
+			&order.UserID,
+			&order.PaymentID,
+			&order.CustomerName,
+			&order.TotalPrice,
+			&order.TotalPaid,
+			&order.TotalReturn,
+			&order.ReceiptCode,
+			&order.Status,
+			&order.VoidedAt,
+			&order.CreatedAt,
+			&order.UpdatedAt,
+			&orderProductID,
+			&productID,
+			&productName,
+			&productSKU,
+			&productPrice,
+			&categoryName,
+			&quantity,
+			&totalPrice,
+			&createdAt,
+			&updatedAt,
+		)
+		if err != nil {
+			return err
+		}
+
+		if current == nil || current.ID != order.ID {
+			if current != nil {
+				err = fn(current)
 				if err != nil {
 					return err
 				}
+			}
+
+			current = &order
+		}
+
+		if orderProductID == nil {
+			continue
+		}
+
+		orderProduct := domain.OrderProduct{
+			ID:           *orderProductID,
+			OrderID:      current.ID,
+			ProductID:    *productID,
+			ProductName:  *productName,
+			ProductSKU:   *productSKU,
+			ProductPrice: *productPrice,
+			CategoryName: *categoryName,
+			Quantity:     *quantity,
+			TotalPrice:   *totalPrice,
+			CreatedAt:    *createdAt,
+			UpdatedAt:    *updatedAt,
+		}
+
+		current.Products = append(current.Products, orderProduct)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return err
+	}
+
+	if current != nil {
+		return fn(current)
+	}
+
+	return nil
+}
 
-				orders[i].Products = append(orders[i].Products, orderProduct)
+// VoidOrder marks a completed order as voided and puts the stock of its order products back
+func (or *OrderRepository) VoidOrder(ctx context.Context, id uint64) (*domain.Order, error) {
+	var order domain.Order
+
+	now := time.Now()
+
+	orderQuery := or.db.QueryBuilder.Update("orders").
+		Set("status", domain.OrderVoided).
+		Set("voided_at", now).
+		Set("updated_at", now).
+		Where(sq.Eq{"id": id, "status": domain.OrderCompleted}).
+		Suffix("RETURNING " + orderColumns)
+
+	stockQuery := or.db.QueryBuilder.Update("products p").
+		Set("stock", sq.Expr("p.stock + op.quantity")).
+		Set("version", sq.Expr("p.version + 1")).
+		Set("updated_at", now).
+		From("order_products op").
+		Where("op.product_id = p.id").
+		Where(sq.Eq{"op.order_id": id})
+
+	err := pgx.BeginFunc(ctx, or.db, func(tx pgx.Tx) error {
+		sql, args, err := orderQuery.ToSql()
+		if err != nil {
+			return err
+		}
+
+		err = scanOrder(tx.QueryRow(ctx, sql, args...), &order)
+		if err != nil {
+			if err == pgx.ErrNoRows {
+				return domain.ErrOrderVoided
 			}
+			return err
 		}
 
-		return nil
+		sql, args, err = stockQuery.ToSql()
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Exec(ctx, sql, args...)
+		return err
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	return orders, nil
+	return &order, nil
+}
+
+// orderColumns are the columns of the orders table in the order scanOrder reads them
+const orderColumns = "id, user_id, payment_id, customer_name, total_price, total_paid, total_return, receipt_code, status, voided_at, created_at, updated_at"
+
+// scanOrder scans a row of orderColumns into an order
+func scanOrder(row pgx.Row, order *domain.Order) error {
+	return row.Scan(
+		&order.ID,
+		&order.UserID,
+		&order.PaymentID,
+		&order.CustomerName,
+		&order.TotalPrice,
+		&order.TotalPaid,
+		&order.TotalReturn,
+		&order.ReceiptCode,
+		&order.Status,
+		&order.VoidedAt,
+		&order.CreatedAt,
+		&order.UpdatedAt,
+	)
+}
+
+// orderProductColumns are the columns of the order_products table in the order scanOrderProduct reads them
+const orderProductColumns = "id, order_id, product_id, product_name, product_sku, product_price, category_name, quantity, total_price, created_at, updated_at"
+
+// scanOrderProduct scans a row of orderProductColumns into an order product
+func scanOrderProduct(row pgx.Row, orderProduct *domain.OrderProduct) error {
+	return row.Scan(
+		&orderProduct.ID,
+		&orderProduct.OrderID,
+		&orderProduct.ProductID,
+		&orderProduct.ProductName,
+		&orderProduct.ProductSKU,
+		&orderProduct.ProductPrice,
+		&orderProduct.CategoryName,
+		&orderProduct.Quantity,
+		&orderProduct.TotalPrice,
+		&orderProduct.CreatedAt,
+		&orderProduct.UpdatedAt,
+	)
 }
@@ -0,0 +1,205 @@
+//go:build integration
+
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/nikhil-shrestha/go-pos/internal/adapter/storage/postgres/repository"
+	"github.com/nikhil-shrestha/go-pos/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrderRepository_CreateOrder_InsufficientStock(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t)
+	f := newFixture(t, db, 5, 1)
+	enough, short := f.products[0], f.products[1]
+
+	orderRepo := repository.NewOrderRepository(db)
+	productRepo := repository.NewProductRepository(db)
+
+	order, err := orderRepo.CreateOrder(ctx, f.newOrder(
+		orderLine{enough, 2},
+		orderLine{short, 1},
+		orderLine{short, 1},
+	))
+	assert.Nil(t, order, "Order mismatch")
+	assert.ErrorIs(t, err, domain.ErrInsufficientStock, "Error mismatch")
+
+	var stockErr *domain.InsufficientStockError
+	if assert.ErrorAs(t, err, &stockErr, "Error mismatch") {
+		assert.Equal(t, []uint64{short.ID}, stockErr.ProductIDs, "Short products mismatch")
+	}
+
+	for _, product := range f.products {
+		stored, err := productRepo.GetProductByID(ctx, product.ID)
+		assert.NoError(t, err, "Error mismatch")
+		assert.Equal(t, product.Stock, stored.Stock, "Stock of rolled back order mismatch")
+	}
+}
+
+func TestOrderRepository_CreateOrder_DeletedProduct(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t)
+	f := newFixture(t, db, 5, 5)
+	kept, deleted := f.products[0], f.products[1]
+
+	orderRepo := repository.NewOrderRepository(db)
+	productRepo := repository.NewProductRepository(db)
+
+	err := productRepo.DeleteProduct(ctx, deleted.ID, 0)
+	if err != nil {
+		t.Fatalf("deleting the product: %v", err)
+	}
+
+	order, err := orderRepo.CreateOrder(ctx, f.newOrder(
+		orderLine{kept, 1},
+		orderLine{deleted, 1},
+	))
+	assert.Nil(t, order, "Order mismatch")
+	assert.ErrorIs(t, err, domain.ErrDataNotFound, "Error mismatch")
+
+	stored, err := productRepo.GetProductByID(ctx, kept.ID)
+	assert.NoError(t, err, "Error mismatch")
+	assert.Equal(t, kept.Stock, stored.Stock, "Stock of rolled back order mismatch")
+}
+
+func TestOrderRepository_CreateOrder_ConcurrentSales(t *testing.T) {
+	const stock, orders = 10, 50
+
+	ctx := context.Background()
+	db := newTestDB(t)
+	f := newFixture(t, db, stock)
+	product := f.products[0]
+
+	orderRepo := repository.NewOrderRepository(db)
+	productRepo := repository.NewProductRepository(db)
+
+	errs := runConcurrently(orders, func(i int) error {
+		_, err := orderRepo.CreateOrder(ctx, f.newOrder(orderLine{product, 1}))
+		return err
+	})
+
+	var sold int
+	for _, err := range errs {
+		if err == nil {
+			sold++
+			continue
+		}
+
+		var stockErr *domain.InsufficientStockError
+		if assert.ErrorAs(t, err, &stockErr, "Error mismatch") {
+			assert.Equal(t, []uint64{product.ID}, stockErr.ProductIDs, "Short products mismatch")
+		}
+	}
+	assert.Equal(t, stock, sold, "Sold orders mismatch")
+
+	stored, err := productRepo.GetProductByID(ctx, product.ID)
+	assert.NoError(t, err, "Error mismatch")
+	assert.Equal(t, int64(0), stored.Stock, "Stock mismatch")
+}
+
+func TestOrderRepository_CreateOrder_ConcurrentSalesAcrossProducts(t *testing.T) {
+	const stock, orders = 20, 40
+
+	ctx := context.Background()
+	db := newTestDB(t)
+	f := newFixture(t, db, stock, stock)
+	first, second := f.products[0], f.products[1]
+
+	orderRepo := repository.NewOrderRepository(db)
+	productRepo := repository.NewProductRepository(db)
+
+	// half of the orders list the products the other way round, which deadlocks unless the stock is locked in one order
+	errs := runConcurrently(orders, func(i int) error {
+		lines := []orderLine{{first, 1}, {second, 1}}
+		if i%2 == 1 {
+			lines[0], lines[1] = lines[1], lines[0]
+		}
+
+		_, err := orderRepo.CreateOrder(ctx, f.newOrder(lines...))
+		return err
+	})
+
+	var sold int
+	for _, err := range errs {
+		if err == nil {
+			sold++
+			continue
+		}
+
+		assert.True(t, errors.Is(err, domain.ErrInsufficientStock), "Unexpected error: %v", err)
+	}
+	assert.Equal(t, stock, sold, "Sold orders mismatch")
+
+	for _, product := range f.products {
+		stored, err := productRepo.GetProductByID(ctx, product.ID)
+		assert.NoError(t, err, "Error mismatch")
+		assert.Equal(t, int64(0), stored.Stock, "Stock mismatch")
+	}
+}
+
+func TestOrderRepository_StreamOrders_Filter(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t)
+	f := newFixture(t, db, 10)
+	product := f.products[0]
+
+	orderRepo := repository.NewOrderRepository(db)
+
+	var ids []uint64
+	for i := 0; i < 3; i++ {
+		order, err := orderRepo.CreateOrder(ctx, f.newOrder(orderLine{product, 1}))
+		if err != nil {
+			t.Fatalf("creating the order: %v", err)
+		}
+		ids = append(ids, order.ID)
+	}
+
+	_, err := orderRepo.VoidOrder(ctx, ids[2])
+	if err != nil {
+		t.Fatalf("voiding the order: %v", err)
+	}
+
+	tomorrow := time.Now().AddDate(0, 0, 1)
+
+	testCases := []struct {
+		desc     string
+		filter   *domain.OrderFilter
+		expected []uint64
+	}{
+		{
+			desc:     "All",
+			filter:   &domain.OrderFilter{UserID: f.user.ID},
+			expected: ids,
+		},
+		{
+			desc:     "Status",
+			filter:   &domain.OrderFilter{UserID: f.user.ID, Status: domain.OrderCompleted},
+			expected: ids[:2],
+		},
+		{
+			desc:     "StartDate",
+			filter:   &domain.OrderFilter{UserID: f.user.ID, StartDate: &tomorrow},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			var streamed []uint64
+
+			err := orderRepo.StreamOrders(ctx, tc.filter, func(order *domain.Order) error {
+				assert.Len(t, order.Products, 1, "Order products mismatch")
+				streamed = append(streamed, order.ID)
+				return nil
+			})
+			assert.NoError(t, err, "Error mismatch")
+			assert.Equal(t, tc.expected, streamed, "Orders mismatch")
+		})
+	}
+}
@@ -44,6 +44,9 @@ func (pr *PaymentRepository) CreatePayment(ctx context.Context, payment *domain.
 		&payment.Logo,
 		&payment.CreatedAt,
 		&payment.UpdatedAt,
+		&payment.LogoThumbnail,
+		&payment.DeletedAt,
+		&payment.Version,
 	)
 	if err != nil {
 		if errCode := pr.db.ErrorCode(err); errCode == "23505" {
@@ -55,13 +58,14 @@ func (pr *PaymentRepository) CreatePayment(ctx context.Context, payment *domain.
 	return payment, nil
 }
 
-// GetPaymentByID retrieves a payment record from the database by id
+// GetPaymentByID retrieves a payment record that is not soft deleted from the database by id
 func (pr *PaymentRepository) GetPaymentByID(ctx context.Context, id uint64) (*domain.Payment, error) {
 	var payment domain.Payment
 
 	query := pr.db.QueryBuilder.Select("*").
 		From("payments").
 		Where(sq.Eq{"id": id}).
+		Where(notDeleted).
 		Limit(1)
 
 	sql, args, err := query.ToSql()
@@ -76,6 +80,9 @@ func (pr *PaymentRepository) GetPaymentByID(ctx context.Context, id uint64) (*do
 		&payment.Logo,
 		&payment.CreatedAt,
 		&payment.UpdatedAt,
+		&payment.LogoThumbnail,
+		&payment.DeletedAt,
+		&payment.Version,
 	)
 	if err != nil {
 		if err == pgx.ErrNoRows {
@@ -87,16 +94,14 @@ func (pr *PaymentRepository) GetPaymentByID(ctx context.Context, id uint64) (*do
 	return &payment, nil
 }
 
-// ListPayments retrieves a list of payments from the database
-func (pr *PaymentRepository) ListPayments(ctx context.Context, skip, limit uint64) ([]domain.Payment, error) {
+// GetPaymentsByIDs retrieves the payments with the given ids from the database, soft deleted or not, skipping the ids that do not exist
+func (pr *PaymentRepository) GetPaymentsByIDs(ctx context.Context, ids []uint64) ([]domain.Payment, error) {
 	var payment domain.Payment
 	var payments []domain.Payment
 
 	query := pr.db.QueryBuilder.Select("*").
 		From("payments").
-		OrderBy("id").
-		Limit(limit).
-		Offset((skip - 1) * limit)
+		Where(sq.Eq{"id": ids})
 
 	sql, args, err := query.ToSql()
 	if err != nil {
@@ -107,6 +112,7 @@ func (pr *PaymentRepository) ListPayments(ctx context.Context, skip, limit uint6
 	if err != nil {
 		return nil, err
 	}
+	defer rows.Close()
 
 	for rows.Next() {
 		err := rows.Scan(
@@ -116,6 +122,9 @@ func (pr *PaymentRepository) ListPayments(ctx context.Context, skip, limit uint6
 			&payment.Logo,
 			&payment.CreatedAt,
 			&payment.UpdatedAt,
+			&payment.LogoThumbnail,
+			&payment.DeletedAt,
+			&payment.Version,
 		)
 		if err != nil {
 			return nil, err
@@ -124,21 +133,83 @@ func (pr *PaymentRepository) ListPayments(ctx context.Context, skip, limit uint6
 		payments = append(payments, payment)
 	}
 
-	return payments, nil
+	return payments, rows.Err()
+}
+
+// paymentSortColumns are the columns payments can be sorted by
+var paymentSortColumns = map[string]string{
+	"id":         "id",
+	"name":       "name",
+	"type":       "type",
+	"created_at": "created_at",
+}
+
+// ListPayments retrieves a list of payments from the database
+func (pr *PaymentRepository) ListPayments(ctx context.Context, includeDeleted bool, params *domain.ListParams) ([]domain.Payment, uint64, error) {
+	var payment domain.Payment
+	var payments []domain.Payment
+
+	filtered := withDeleted(pr.db.QueryBuilder.Select().From("payments"), includeDeleted)
+
+	total, err := countRows(ctx, pr.db, filtered)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	query := filtered.Columns("*").
+		OrderBy(orderBy(params, paymentSortColumns)).
+		Limit(params.Limit).
+		Offset((params.Skip - 1) * params.Limit)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	rows, err := pr.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	for rows.Next() {
+		err := rows.Scan(
+			&payment.ID,
+			&payment.Name,
+			&payment.Type,
+			&payment.Logo,
+			&payment.CreatedAt,
+			&payment.UpdatedAt,
+			&payment.LogoThumbnail,
+			&payment.DeletedAt,
+			&payment.Version,
+		)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		payments = append(payments, payment)
+	}
+
+	return payments, total, nil
 }
 
-// UpdatePayment updates a payment record in the database
+// UpdatePayment updates a payment record in the database, as long as it is at the given version unless none is given
 func (pr *PaymentRepository) UpdatePayment(ctx context.Context, payment *domain.Payment) (*domain.Payment, error) {
 	name := nullString(payment.Name)
 	paymentType := nullString(string(payment.Type))
 	logo := nullString(payment.Logo)
+	logoThumbnail := nullString(payment.LogoThumbnail)
 
 	query := pr.db.QueryBuilder.Update("payments").
 		Set("name", sq.Expr("COALESCE(?, name)", name)).
 		Set("type", sq.Expr("COALESCE(?, type)", paymentType)).
 		Set("logo", sq.Expr("COALESCE(?, logo)", logo)).
+		Set("logo_thumbnail", sq.Expr("COALESCE(?, CASE WHEN ?::varchar IS NULL THEN logo_thumbnail ELSE '' END)", logoThumbnail, logo)).
 		Set("updated_at", time.Now()).
+		Set("version", bumpVersion).
 		Where(sq.Eq{"id": payment.ID}).
+		Where(notDeleted).
+		Where(atVersion(payment.Version)).
 		Suffix("RETURNING *")
 
 	sql, args, err := query.ToSql()
@@ -153,8 +224,14 @@ func (pr *PaymentRepository) UpdatePayment(ctx context.Context, payment *domain.
 		&payment.Logo,
 		&payment.CreatedAt,
 		&payment.UpdatedAt,
+		&payment.LogoThumbnail,
+		&payment.DeletedAt,
+		&payment.Version,
 	)
 	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, notChanged(ctx, pr.db, "payments", payment.ID, payment.Version, domain.ErrVersionMismatch)
+		}
 		if errCode := pr.db.ErrorCode(err); errCode == "23505" {
 			return nil, domain.ErrConflictingData
 		}
@@ -164,20 +241,69 @@ func (pr *PaymentRepository) UpdatePayment(ctx context.Context, payment *domain.
 	return payment, nil
 }
 
-// DeletePayment deletes a payment record from the database by id
-func (pr *PaymentRepository) DeletePayment(ctx context.Context, id uint64) error {
-	query := pr.db.QueryBuilder.Delete("payments").
-		Where(sq.Eq{"id": id})
+// DeletePayment soft deletes a payment record in the database by id, as long as it is at the given version unless none is given
+func (pr *PaymentRepository) DeletePayment(ctx context.Context, id, version uint64) error {
+	query := pr.db.QueryBuilder.Update("payments").
+		Set("deleted_at", time.Now()).
+		Set("version", bumpVersion).
+		Where(sq.Eq{"id": id}).
+		Where(notDeleted).
+		Where(atVersion(version))
 
 	sql, args, err := query.ToSql()
 	if err != nil {
 		return err
 	}
 
-	_, err = pr.db.Exec(ctx, sql, args...)
+	result, err := pr.db.Exec(ctx, sql, args...)
 	if err != nil {
 		return err
 	}
 
+	if result.RowsAffected() == 0 {
+		return notChanged(ctx, pr.db, "payments", id, version, domain.ErrVersionMismatch)
+	}
+
 	return nil
 }
+
+// RestorePayment restores a soft deleted payment record in the database by id
+func (pr *PaymentRepository) RestorePayment(ctx context.Context, id uint64) (*domain.Payment, error) {
+	var payment domain.Payment
+
+	query := pr.db.QueryBuilder.Update("payments").
+		Set("deleted_at", nil).
+		Set("updated_at", time.Now()).
+		Set("version", bumpVersion).
+		Where(sq.Eq{"id": id}).
+		Where(sq.NotEq{"deleted_at": nil}).
+		Suffix("RETURNING *")
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = pr.db.QueryRow(ctx, sql, args...).Scan(
+		&payment.ID,
+		&payment.Name,
+		&payment.Type,
+		&payment.Logo,
+		&payment.CreatedAt,
+		&payment.UpdatedAt,
+		&payment.LogoThumbnail,
+		&payment.DeletedAt,
+		&payment.Version,
+	)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, domain.ErrDataNotFound
+		}
+		if errCode := pr.db.ErrorCode(err); errCode == "23505" {
+			return nil, domain.ErrConflictingData
+		}
+		return nil, err
+	}
+
+	return &payment, nil
+}
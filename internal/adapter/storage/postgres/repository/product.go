@@ -2,12 +2,15 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/nikhil-shrestha/go-pos/internal/adapter/storage/postgres"
 	"github.com/nikhil-shrestha/go-pos/internal/core/domain"
+	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 )
 
 /**
@@ -28,8 +31,8 @@ func NewProductRepository(db *postgres.DB) *ProductRepository {
 // CreateProduct creates a new product record in the database
 func (pr *ProductRepository) CreateProduct(ctx context.Context, product *domain.Product) (*domain.Product, error) {
 	query := pr.db.QueryBuilder.Insert("products").
-		Columns("category_id", "name", "image", "price", "stock").
-		Values(product.CategoryID, product.Name, product.Image, product.Price, product.Stock).
+		Columns("category_id", "name", "image", "price", "stock", "barcode").
+		Values(product.CategoryID, product.Name, product.Image, product.Price, product.Stock, product.Barcode).
 		Suffix("RETURNING *")
 
 	sql, args, err := query.ToSql()
@@ -47,6 +50,10 @@ func (pr *ProductRepository) CreateProduct(ctx context.Context, product *domain.
 		&product.Image,
 		&product.CreatedAt,
 		&product.UpdatedAt,
+		&product.Barcode,
+		&product.Thumbnail,
+		&product.DeletedAt,
+		&product.Version,
 	)
 	if err != nil {
 		if errCode := pr.db.ErrorCode(err); errCode == "23505" {
@@ -58,13 +65,14 @@ func (pr *ProductRepository) CreateProduct(ctx context.Context, product *domain.
 	return product, nil
 }
 
-// GetProductByID retrieves a product record from the database by id
+// GetProductByID retrieves a product record that is not soft deleted from the database by id
 func (pr *ProductRepository) GetProductByID(ctx context.Context, id uint64) (*domain.Product, error) {
 	var product domain.Product
 
 	query := pr.db.QueryBuilder.Select("*").
 		From("products").
 		Where(sq.Eq{"id": id}).
+		Where(notDeleted).
 		Limit(1)
 
 	sql, args, err := query.ToSql()
@@ -82,6 +90,10 @@ func (pr *ProductRepository) GetProductByID(ctx context.Context, id uint64) (*do
 		&product.Image,
 		&product.CreatedAt,
 		&product.UpdatedAt,
+		&product.Barcode,
+		&product.Thumbnail,
+		&product.DeletedAt,
+		&product.Version,
 	)
 	if err != nil {
 		if err == pgx.ErrNoRows {
@@ -93,25 +105,88 @@ func (pr *ProductRepository) GetProductByID(ctx context.Context, id uint64) (*do
 	return &product, nil
 }
 
-// ListProducts retrieves a list of products from the database
-func (pr *ProductRepository) ListProducts(ctx context.Context, search string, categoryId, skip, limit uint64) ([]domain.Product, error) {
-	var product domain.Product
-	var products []domain.Product
-
+// GetProductsByIDs retrieves the products with the given ids from the database, soft deleted or not, skipping the ids that do not exist
+func (pr *ProductRepository) GetProductsByIDs(ctx context.Context, ids []uint64) ([]domain.Product, error) {
 	query := pr.db.QueryBuilder.Select("*").
 		From("products").
-		OrderBy("id").
-		Limit(limit).
-		Offset((skip - 1) * limit)
+		Where(sq.Eq{"id": ids})
+
+	return pr.listProducts(ctx, query)
+}
+
+// productSortColumns are the columns products can be sorted by
+var productSortColumns = map[string]string{
+	"id":         "id",
+	"name":       "name",
+	"sku":        "sku",
+	"price":      "price",
+	"stock":      "stock",
+	"created_at": "created_at",
+}
+
+// ListProducts retrieves a list of products from the database, including the products of descendant categories when filtering by category
+func (pr *ProductRepository) ListProducts(ctx context.Context, search string, categoryId uint64, includeDeleted bool, params *domain.ListParams) ([]domain.Product, uint64, error) {
+	filtered := pr.filterProducts(search, categoryId, includeDeleted)
+
+	total, err := countRows(ctx, pr.db, filtered)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	query := filtered.Columns("*").
+		OrderBy(orderBy(params, productSortColumns)).
+		Limit(params.Limit).
+		Offset((params.Skip - 1) * params.Limit)
+
+	products, err := pr.listProducts(ctx, query)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return products, total, nil
+}
+
+// ListProductsByCursor retrieves the products following the cursor from the database, sorted by creation time, with the same filters as ListProducts
+func (pr *ProductRepository) ListProductsByCursor(ctx context.Context, search string, categoryId uint64, includeDeleted bool, params *domain.CursorParams) ([]domain.Product, *domain.Cursor, error) {
+	query := pr.filterProducts(search, categoryId, includeDeleted).
+		Columns("*")
+
+	products, err := pr.listProducts(ctx, cursorPage(query, params))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var next *domain.Cursor
+	if uint64(len(products)) > params.Limit {
+		products = products[:params.Limit]
+		last := products[len(products)-1]
+		next = &domain.Cursor{CreatedAt: last.CreatedAt, ID: last.ID}
+	}
+
+	return products, next, nil
+}
+
+// filterProducts selects the products in the tree of a category whose name matches the search, ignoring empty filters
+func (pr *ProductRepository) filterProducts(search string, categoryId uint64, includeDeleted bool) sq.SelectBuilder {
+	query := withDeleted(pr.db.QueryBuilder.Select().
+		From("products"), includeDeleted)
 
 	if categoryId != 0 {
-		query = query.Where(sq.Eq{"category_id": categoryId})
+		query = query.Where(inCategoryTree("category_id", categoryId))
 	}
 
 	if search != "" {
 		query = query.Where(sq.ILike{"name": "%" + search + "%"})
 	}
 
+	return query
+}
+
+// listProducts scans the products selected by a query
+func (pr *ProductRepository) listProducts(ctx context.Context, query sq.SelectBuilder) ([]domain.Product, error) {
+	var product domain.Product
+	var products []domain.Product
+
 	sql, args, err := query.ToSql()
 	if err != nil {
 		return nil, err
@@ -121,6 +196,7 @@ func (pr *ProductRepository) ListProducts(ctx context.Context, search string, ca
 	if err != nil {
 		return nil, err
 	}
+	defer rows.Close()
 
 	for rows.Next() {
 		err := rows.Scan(
@@ -133,6 +209,10 @@ func (pr *ProductRepository) ListProducts(ctx context.Context, search string, ca
 			&product.Image,
 			&product.CreatedAt,
 			&product.UpdatedAt,
+			&product.Barcode,
+			&product.Thumbnail,
+			&product.DeletedAt,
+			&product.Version,
 		)
 		if err != nil {
 			return nil, err
@@ -141,16 +221,286 @@ func (pr *ProductRepository) ListProducts(ctx context.Context, search string, ca
 		products = append(products, product)
 	}
 
-	return products, nil
+	return products, rows.Err()
+}
+
+// categoryDescendantsQuery selects the id of a category and the ids of all its descendants
+const categoryDescendantsQuery = `WITH RECURSIVE descendants AS (
+	SELECT id FROM categories WHERE id = ?
+	UNION ALL
+	SELECT c.id FROM categories c JOIN descendants d ON c.parent_id = d.id
+) CYCLE id SET is_cycle USING path
+SELECT id FROM descendants`
+
+// inCategoryTree filters the category id column by a category and its descendants
+func inCategoryTree(column string, categoryId uint64) sq.Sqlizer {
+	return sq.Expr(column+" IN ("+categoryDescendantsQuery+")", categoryId)
 }
 
-// UpdateProduct updates a product record in the database
+// StreamProducts iterates over the products matching the filters that are not soft deleted together with their category, one row at a time
+func (pr *ProductRepository) StreamProducts(ctx context.Context, search string, categoryId uint64, fn func(product *domain.Product) error) error {
+	query := pr.db.QueryBuilder.Select(
+		"p.id", "p.category_id", "p.sku", "p.name", "p.stock", "p.price", "p.image", "p.created_at", "p.updated_at", "p.barcode", "p.thumbnail",
+		"c.id", "c.name", "c.created_at", "c.updated_at",
+	).
+		From("products p").
+		Join("categories c ON c.id = p.category_id").
+		Where(sq.Eq{"p.deleted_at": nil}).
+		OrderBy("p.id")
+
+	if categoryId != 0 {
+		query = query.Where(inCategoryTree("p.category_id", categoryId))
+	}
+
+	if search != "" {
+		query = query.Where(sq.ILike{"p.name": "%" + search + "%"})
+	}
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return err
+	}
+
+	rows, err := pr.db.Query(ctx, sql, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var product domain.Product
+		var category domain.Category
+
+		err := rows.Scan(
+			&product.ID,
+			&product.CategoryID,
+			&product.SKU,
+			&product.Name,
+			&product.Stock,
+			&product.Price,
+			&product.Image,
+			&product.CreatedAt,
+			&product.UpdatedAt,
+			&product.Barcode,
+			&product.Thumbnail,
+			&category.ID,
+			&category.Name,
+			&category.CreatedAt,
+			&category.UpdatedAt,
+		)
+		if err != nil {
+			return err
+		}
+
+		product.Category = &category
+
+		err = fn(&product)
+		if err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+// ImportProducts creates or updates the product of every valid row in a single transaction,
+// resolving categories by name. Each row runs in its own savepoint so that a conflicting row
+// is reported without hiding the errors of the following rows, and the transaction is only
+// committed when no row has failed and the import is not a dry run
+func (pr *ProductRepository) ImportProducts(ctx context.Context, productImport *domain.ProductImport) error {
+	tx, err := pr.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	categoryIDs := make(map[string]uint64)
+
+	for i := range productImport.Rows {
+		row := &productImport.Rows[i]
+		if len(row.Errors) > 0 {
+			continue
+		}
+
+		err := pgx.BeginFunc(ctx, tx, func(savepoint pgx.Tx) error {
+			return pr.importProduct(ctx, savepoint, row, categoryIDs)
+		})
+		if err != nil {
+			var pgErr *pgconn.PgError
+			if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+				row.Action = ""
+				row.Errors = append(row.Errors, domain.ErrConflictingData.Error())
+				continue
+			}
+			return err
+		}
+
+		categoryIDs[row.Product.Category.Name] = row.Product.CategoryID
+	}
+
+	if productImport.DryRun || productImport.Failed() {
+		return nil
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return err
+	}
+
+	productImport.Applied = true
+
+	return nil
+}
+
+// importProduct resolves the category of a row and creates or updates its product by SKU or barcode
+func (pr *ProductRepository) importProduct(ctx context.Context, tx pgx.Tx, row *domain.ProductImportRow, categoryIDs map[string]uint64) error {
+	product := row.Product
+	category := product.Category
+
+	categoryID, ok := categoryIDs[category.Name]
+	if !ok {
+		categoryQuery := pr.db.QueryBuilder.Select("id").
+			From("categories").
+			Where(sq.Eq{"name": category.Name}).
+			Where(notDeleted).
+			Limit(1)
+
+		sql, args, err := categoryQuery.ToSql()
+		if err != nil {
+			return err
+		}
+
+		err = tx.QueryRow(ctx, sql, args...).Scan(&categoryID)
+		if err == pgx.ErrNoRows {
+			createCategoryQuery := pr.db.QueryBuilder.Insert("categories").
+				Columns("name").
+				Values(category.Name).
+				Suffix("RETURNING id")
+
+			sql, args, err = createCategoryQuery.ToSql()
+			if err != nil {
+				return err
+			}
+
+			err = tx.QueryRow(ctx, sql, args...).Scan(&categoryID)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	category.ID = categoryID
+	product.CategoryID = categoryID
+
+	var existingProduct domain.Product
+
+	existingQuery := pr.db.QueryBuilder.Select("*").
+		From("products").
+		Where(notDeleted).
+		Limit(1)
+
+	switch {
+	case product.SKU != uuid.Nil:
+		existingQuery = existingQuery.Where(sq.Eq{"sku": product.SKU})
+	case product.Barcode != "":
+		existingQuery = existingQuery.Where(sq.Eq{"barcode": product.Barcode})
+	default:
+		existingQuery = existingQuery.Where("false")
+	}
+
+	sql, args, err := existingQuery.ToSql()
+	if err != nil {
+		return err
+	}
+
+	err = tx.QueryRow(ctx, sql, args...).Scan(
+		&existingProduct.ID,
+		&existingProduct.CategoryID,
+		&existingProduct.SKU,
+		&existingProduct.Name,
+		&existingProduct.Stock,
+		&existingProduct.Price,
+		&existingProduct.Image,
+		&existingProduct.CreatedAt,
+		&existingProduct.UpdatedAt,
+		&existingProduct.Barcode,
+		&existingProduct.Thumbnail,
+		&existingProduct.DeletedAt,
+		&existingProduct.Version,
+	)
+	if err != nil && err != pgx.ErrNoRows {
+		return err
+	}
+
+	var query sq.Sqlizer
+
+	if existingProduct.ID != 0 {
+		row.Action = domain.ProductUpdated
+		row.Previous = &existingProduct
+
+		query = pr.db.QueryBuilder.Update("products").
+			Set("category_id", product.CategoryID).
+			Set("name", product.Name).
+			Set("image", product.Image).
+			Set("thumbnail", sq.Expr("CASE WHEN image = ? THEN thumbnail ELSE '' END", product.Image)).
+			Set("price", product.Price).
+			Set("stock", product.Stock).
+			Set("barcode", sq.Expr("COALESCE(?, barcode)", nullString(product.Barcode))).
+			Set("updated_at", time.Now()).
+			Set("version", bumpVersion).
+			Where(sq.Eq{"id": existingProduct.ID}).
+			Suffix("RETURNING *")
+	} else {
+		row.Action = domain.ProductCreated
+
+		insertQuery := pr.db.QueryBuilder.Insert("products").
+			Columns("category_id", "name", "image", "price", "stock", "barcode").
+			Values(product.CategoryID, product.Name, product.Image, product.Price, product.Stock, product.Barcode).
+			Suffix("RETURNING *")
+
+		if product.SKU != uuid.Nil {
+			insertQuery = pr.db.QueryBuilder.Insert("products").
+				Columns("category_id", "sku", "name", "image", "price", "stock", "barcode").
+				Values(product.CategoryID, product.SKU, product.Name, product.Image, product.Price, product.Stock, product.Barcode).
+				Suffix("RETURNING *")
+		}
+
+		query = insertQuery
+	}
+
+	sql, args, err = query.ToSql()
+	if err != nil {
+		return err
+	}
+
+	return tx.QueryRow(ctx, sql, args...).Scan(
+		&product.ID,
+		&product.CategoryID,
+		&product.SKU,
+		&product.Name,
+		&product.Stock,
+		&product.Price,
+		&product.Image,
+		&product.CreatedAt,
+		&product.UpdatedAt,
+		&product.Barcode,
+		&product.Thumbnail,
+		&product.DeletedAt,
+		&product.Version,
+	)
+}
+
+// UpdateProduct updates a product record in the database, as long as it is at the given version unless none is given
 func (pr *ProductRepository) UpdateProduct(ctx context.Context, product *domain.Product) (*domain.Product, error) {
 	categoryId := nullUint64(product.CategoryID)
 	name := nullString(product.Name)
 	image := nullString(product.Image)
 	price := nullFloat64(product.Price)
 	stock := nullInt64(product.Stock)
+	barcode := nullString(product.Barcode)
+	thumbnail := nullString(product.Thumbnail)
 
 	query := pr.db.QueryBuilder.Update("products").
 		Set("name", sq.Expr("COALESCE(?, name)", name)).
@@ -158,8 +508,13 @@ func (pr *ProductRepository) UpdateProduct(ctx context.Context, product *domain.
 		Set("image", sq.Expr("COALESCE(?, image)", image)).
 		Set("price", sq.Expr("COALESCE(?, price)", price)).
 		Set("stock", sq.Expr("COALESCE(?, stock)", stock)).
+		Set("barcode", sq.Expr("COALESCE(?, barcode)", barcode)).
+		Set("thumbnail", sq.Expr("COALESCE(?, CASE WHEN ?::varchar IS NULL THEN thumbnail ELSE '' END)", thumbnail, image)).
 		Set("updated_at", time.Now()).
+		Set("version", bumpVersion).
 		Where(sq.Eq{"id": product.ID}).
+		Where(notDeleted).
+		Where(atVersion(product.Version)).
 		Suffix("RETURNING *")
 
 	sql, args, err := query.ToSql()
@@ -177,8 +532,15 @@ func (pr *ProductRepository) UpdateProduct(ctx context.Context, product *domain.
 		&product.Image,
 		&product.CreatedAt,
 		&product.UpdatedAt,
+		&product.Barcode,
+		&product.Thumbnail,
+		&product.DeletedAt,
+		&product.Version,
 	)
 	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, notChanged(ctx, pr.db, "products", product.ID, product.Version, domain.ErrVersionMismatch)
+		}
 		if errCode := pr.db.ErrorCode(err); errCode == "23505" {
 			return nil, domain.ErrConflictingData
 		}
@@ -188,20 +550,77 @@ func (pr *ProductRepository) UpdateProduct(ctx context.Context, product *domain.
 	return product, nil
 }
 
-// DeleteProduct deletes a product record from the database by id
-func (pr *ProductRepository) DeleteProduct(ctx context.Context, id uint64) error {
-	query := pr.db.QueryBuilder.Delete("products").
-		Where(sq.Eq{"id": id})
+// DeleteProduct soft deletes a product record in the database by id, as long as it is at the given version unless none is given
+func (pr *ProductRepository) DeleteProduct(ctx context.Context, id, version uint64) error {
+	query := pr.db.QueryBuilder.Update("products").
+		Set("deleted_at", time.Now()).
+		Set("version", bumpVersion).
+		Where(sq.Eq{"id": id}).
+		Where(notDeleted).
+		Where(atVersion(version))
 
 	sql, args, err := query.ToSql()
 	if err != nil {
 		return err
 	}
 
-	_, err = pr.db.Exec(ctx, sql, args...)
+	result, err := pr.db.Exec(ctx, sql, args...)
 	if err != nil {
 		return err
 	}
 
+	if result.RowsAffected() == 0 {
+		return notChanged(ctx, pr.db, "products", id, version, domain.ErrVersionMismatch)
+	}
+
 	return nil
 }
+
+// RestoreProduct restores a soft deleted product record in the database by id, as long as its category is not soft deleted
+func (pr *ProductRepository) RestoreProduct(ctx context.Context, id uint64) (*domain.Product, error) {
+	var product domain.Product
+
+	query := pr.db.QueryBuilder.Update("products").
+		Set("deleted_at", nil).
+		Set("updated_at", time.Now()).
+		Set("version", bumpVersion).
+		Where(sq.Eq{"id": id}).
+		Where(sq.NotEq{"deleted_at": nil}).
+		Where("EXISTS (SELECT 1 FROM categories c WHERE c.id = products.category_id AND c.deleted_at IS NULL)").
+		Suffix("RETURNING *")
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = pr.db.QueryRow(ctx, sql, args...).Scan(
+		&product.ID,
+		&product.CategoryID,
+		&product.SKU,
+		&product.Name,
+		&product.Stock,
+		&product.Price,
+		&product.Image,
+		&product.CreatedAt,
+		&product.UpdatedAt,
+		&product.Barcode,
+		&product.Thumbnail,
+		&product.DeletedAt,
+		&product.Version,
+	)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, notFoundOrConflict(ctx, pr.db, pr.db.QueryBuilder.Select().
+				From("products").
+				Where(sq.Eq{"id": id}).
+				Where(sq.NotEq{"deleted_at": nil}))
+		}
+		if errCode := pr.db.ErrorCode(err); errCode == "23505" {
+			return nil, domain.ErrConflictingData
+		}
+		return nil, err
+	}
+
+	return &product, nil
+}
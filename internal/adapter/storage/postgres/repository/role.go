@@ -0,0 +1,226 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/nikhil-shrestha/go-pos/internal/adapter/storage/postgres"
+	"github.com/nikhil-shrestha/go-pos/internal/core/domain"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+/**
+ * RoleRepository implements port.RoleRepository interface
+ * and provides an access to the postgres database
+ */
+type RoleRepository struct {
+	db *postgres.DB
+}
+
+// NewRoleRepository creates a new role repository instance
+func NewRoleRepository(db *postgres.DB) *RoleRepository {
+	return &RoleRepository{
+		db,
+	}
+}
+
+// CreateRole creates a new role in the database
+func (rr *RoleRepository) CreateRole(ctx context.Context, role *domain.Role) (*domain.Role, error) {
+	query := rr.db.QueryBuilder.Insert("roles").
+		Columns("name", "permissions").
+		Values(role.Name, permissionsToStrings(role.Permissions)).
+		Suffix("RETURNING *")
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = scanRole(rr.db.QueryRow(ctx, sql, args...), role)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return nil, domain.ErrConflictingData
+		}
+		return nil, err
+	}
+
+	return role, nil
+}
+
+// GetRoleByID retrieves a role by id from the database
+func (rr *RoleRepository) GetRoleByID(ctx context.Context, id uint64) (*domain.Role, error) {
+	return rr.getRole(ctx, sq.Eq{"id": id})
+}
+
+// GetRoleByName retrieves a role by name from the database
+func (rr *RoleRepository) GetRoleByName(ctx context.Context, name domain.UserRole) (*domain.Role, error) {
+	return rr.getRole(ctx, sq.Eq{"name": name})
+}
+
+// getRole retrieves the role matching the condition from the database
+func (rr *RoleRepository) getRole(ctx context.Context, where sq.Eq) (*domain.Role, error) {
+	var role domain.Role
+
+	query := rr.db.QueryBuilder.Select("*").
+		From("roles").
+		Where(where).
+		Limit(1)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = scanRole(rr.db.QueryRow(ctx, sql, args...), &role)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, domain.ErrDataNotFound
+		}
+		return nil, err
+	}
+
+	return &role, nil
+}
+
+// roleSortColumns are the columns roles can be sorted by
+var roleSortColumns = map[string]string{
+	"id":         "id",
+	"name":       "name",
+	"created_at": "created_at",
+}
+
+// ListRoles retrieves a list of roles from the database
+func (rr *RoleRepository) ListRoles(ctx context.Context, params *domain.ListParams) ([]domain.Role, uint64, error) {
+	var roles []domain.Role
+
+	total, err := countRows(ctx, rr.db, rr.db.QueryBuilder.Select().From("roles"))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	query := rr.db.QueryBuilder.Select("*").
+		From("roles").
+		OrderBy(orderBy(params, roleSortColumns)).
+		Limit(params.Limit).
+		Offset((params.Skip - 1) * params.Limit)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	rows, err := rr.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var role domain.Role
+
+		err := scanRole(rows, &role)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		roles = append(roles, role)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	return roles, total, nil
+}
+
+// UpdateRole updates a role in the database, renaming it for its users too
+func (rr *RoleRepository) UpdateRole(ctx context.Context, role *domain.Role) (*domain.Role, error) {
+	name := nullString(string(role.Name))
+
+	var permissions []string
+	if role.Permissions != nil {
+		permissions = permissionsToStrings(role.Permissions)
+	}
+
+	query := rr.db.QueryBuilder.Update("roles").
+		Set("name", sq.Expr("COALESCE(?, name)", name)).
+		Set("permissions", sq.Expr("COALESCE(?::varchar[], permissions)", permissions)).
+		Set("updated_at", time.Now()).
+		Where(sq.Eq{"id": role.ID}).
+		Suffix("RETURNING *")
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = scanRole(rr.db.QueryRow(ctx, sql, args...), role)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return nil, domain.ErrConflictingData
+		}
+		return nil, err
+	}
+
+	return role, nil
+}
+
+// DeleteRole deletes a role by id from the database
+func (rr *RoleRepository) DeleteRole(ctx context.Context, id uint64) error {
+	query := rr.db.QueryBuilder.Delete("roles").
+		Where(sq.Eq{"id": id})
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = rr.db.Exec(ctx, sql, args...)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			return domain.ErrConflictingData
+		}
+		return err
+	}
+
+	return nil
+}
+
+// scanRole scans a role row, converting the permissions array
+func scanRole(row pgx.Row, role *domain.Role) error {
+	var permissions []string
+
+	err := row.Scan(
+		&role.ID,
+		&role.Name,
+		&permissions,
+		&role.CreatedAt,
+		&role.UpdatedAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	role.Permissions = make([]domain.Permission, len(permissions))
+	for i, permission := range permissions {
+		role.Permissions[i] = domain.Permission(permission)
+	}
+
+	return nil
+}
+
+// permissionsToStrings converts permissions into the values of a varchar array
+func permissionsToStrings(permissions []domain.Permission) []string {
+	values := make([]string, len(permissions))
+	for i, permission := range permissions {
+		values[i] = string(permission)
+	}
+
+	return values
+}
@@ -0,0 +1,158 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/nikhil-shrestha/go-pos/internal/adapter/storage/postgres"
+	"github.com/nikhil-shrestha/go-pos/internal/core/domain"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+/**
+ * TerminalRepository implements port.TerminalRepository interface
+ * and provides an access to the postgres database
+ */
+type TerminalRepository struct {
+	db *postgres.DB
+}
+
+// NewTerminalRepository creates a new terminal repository instance
+func NewTerminalRepository(db *postgres.DB) *TerminalRepository {
+	return &TerminalRepository{
+		db,
+	}
+}
+
+// CreateTerminal creates a new terminal in the database
+func (tr *TerminalRepository) CreateTerminal(ctx context.Context, terminal *domain.Terminal) (*domain.Terminal, error) {
+	query := tr.db.QueryBuilder.Insert("terminals").
+		Columns("name", "key_hash").
+		Values(terminal.Name, terminal.KeyHash).
+		Suffix("RETURNING *")
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = tr.db.QueryRow(ctx, sql, args...).Scan(
+		&terminal.ID,
+		&terminal.Name,
+		&terminal.KeyHash,
+		&terminal.CreatedAt,
+		&terminal.UpdatedAt,
+	)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return nil, domain.ErrConflictingData
+		}
+		return nil, err
+	}
+
+	return terminal, nil
+}
+
+// GetTerminalByID retrieves a terminal by id from the database
+func (tr *TerminalRepository) GetTerminalByID(ctx context.Context, id uint64) (*domain.Terminal, error) {
+	var terminal domain.Terminal
+
+	query := tr.db.QueryBuilder.Select("*").
+		From("terminals").
+		Where(sq.Eq{"id": id}).
+		Limit(1)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = tr.db.QueryRow(ctx, sql, args...).Scan(
+		&terminal.ID,
+		&terminal.Name,
+		&terminal.KeyHash,
+		&terminal.CreatedAt,
+		&terminal.UpdatedAt,
+	)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, domain.ErrDataNotFound
+		}
+		return nil, err
+	}
+
+	return &terminal, nil
+}
+
+// terminalSortColumns are the columns terminals can be sorted by
+var terminalSortColumns = map[string]string{
+	"id":         "id",
+	"name":       "name",
+	"created_at": "created_at",
+}
+
+// ListTerminals retrieves a list of terminals from the database
+func (tr *TerminalRepository) ListTerminals(ctx context.Context, params *domain.ListParams) ([]domain.Terminal, uint64, error) {
+	var terminal domain.Terminal
+	var terminals []domain.Terminal
+
+	total, err := countRows(ctx, tr.db, tr.db.QueryBuilder.Select().From("terminals"))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	query := tr.db.QueryBuilder.Select("*").
+		From("terminals").
+		OrderBy(orderBy(params, terminalSortColumns)).
+		Limit(params.Limit).
+		Offset((params.Skip - 1) * params.Limit)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	rows, err := tr.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		err := rows.Scan(
+			&terminal.ID,
+			&terminal.Name,
+			&terminal.KeyHash,
+			&terminal.CreatedAt,
+			&terminal.UpdatedAt,
+		)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		terminals = append(terminals, terminal)
+	}
+
+	return terminals, total, nil
+}
+
+// DeleteTerminal deletes a terminal by id from the database
+func (tr *TerminalRepository) DeleteTerminal(ctx context.Context, id uint64) error {
+	query := tr.db.QueryBuilder.Delete("terminals").
+		Where(sq.Eq{"id": id})
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = tr.db.Exec(ctx, sql, args...)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,184 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/nikhil-shrestha/go-pos/internal/adapter/storage/postgres"
+	"github.com/nikhil-shrestha/go-pos/internal/core/domain"
+	"github.com/jackc/pgx/v5"
+)
+
+/**
+ * TwoFactorRepository implements port.TwoFactorRepository interface
+ * and provides an access to the postgres database
+ */
+type TwoFactorRepository struct {
+	db *postgres.DB
+}
+
+// NewTwoFactorRepository creates a new two-factor repository instance
+func NewTwoFactorRepository(db *postgres.DB) *TwoFactorRepository {
+	return &TwoFactorRepository{
+		db,
+	}
+}
+
+// SaveTwoFactor inserts a pending enrolment in the database, replacing the previous one of the user if it is still pending
+func (tfr *TwoFactorRepository) SaveTwoFactor(ctx context.Context, twoFactor *domain.TwoFactor) (*domain.TwoFactor, error) {
+	query := tfr.db.QueryBuilder.Insert("two_factors").
+		Columns("user_id", "secret").
+		Values(twoFactor.UserID, twoFactor.Secret).
+		Suffix(`ON CONFLICT ("user_id") DO UPDATE SET "secret" = EXCLUDED."secret", "recovery_codes" = '{}', "updated_at" = now() WHERE "two_factors"."enabled_at" IS NULL`).
+		Suffix("RETURNING *")
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = scanTwoFactor(tfr.db.QueryRow(ctx, sql, args...), twoFactor)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, domain.ErrTwoFactorEnabled
+		}
+		return nil, err
+	}
+
+	return twoFactor, nil
+}
+
+// GetTwoFactor retrieves the enrolment of a user from the database
+func (tfr *TwoFactorRepository) GetTwoFactor(ctx context.Context, userID uint64) (*domain.TwoFactor, error) {
+	var twoFactor domain.TwoFactor
+
+	query := tfr.db.QueryBuilder.Select("*").
+		From("two_factors").
+		Where(sq.Eq{"user_id": userID}).
+		Limit(1)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = scanTwoFactor(tfr.db.QueryRow(ctx, sql, args...), &twoFactor)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, domain.ErrDataNotFound
+		}
+		return nil, err
+	}
+
+	return &twoFactor, nil
+}
+
+// EnableTwoFactor confirms the pending enrolment of a user in the database
+func (tfr *TwoFactorRepository) EnableTwoFactor(ctx context.Context, userID uint64, recoveryCodes []string) (*domain.TwoFactor, error) {
+	var twoFactor domain.TwoFactor
+
+	now := time.Now()
+
+	query := tfr.db.QueryBuilder.Update("two_factors").
+		Set("recovery_codes", recoveryCodes).
+		Set("enabled_at", now).
+		Set("updated_at", now).
+		Where(sq.Eq{"user_id": userID, "enabled_at": nil}).
+		Suffix("RETURNING *")
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = scanTwoFactor(tfr.db.QueryRow(ctx, sql, args...), &twoFactor)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, domain.ErrTwoFactorEnabled
+		}
+		return nil, err
+	}
+
+	return &twoFactor, nil
+}
+
+// UpdateRecoveryCodes replaces the recovery codes of an enrolment in the database
+func (tfr *TwoFactorRepository) UpdateRecoveryCodes(ctx context.Context, userID uint64, recoveryCodes []string) error {
+	query := tfr.db.QueryBuilder.Update("two_factors").
+		Set("recovery_codes", recoveryCodes).
+		Set("updated_at", time.Now()).
+		Where(sq.Eq{"user_id": userID})
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return err
+	}
+
+	tag, err := tfr.db.Exec(ctx, sql, args...)
+	if err != nil {
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return domain.ErrDataNotFound
+	}
+
+	return nil
+}
+
+// UseRecoveryCode removes a recovery code from an enrolment in the database, in one statement so it cannot be used twice
+func (tfr *TwoFactorRepository) UseRecoveryCode(ctx context.Context, userID uint64, recoveryCode string) error {
+	query := tfr.db.QueryBuilder.Update("two_factors").
+		Set("recovery_codes", sq.Expr("array_remove(recovery_codes, ?)", recoveryCode)).
+		Set("updated_at", time.Now()).
+		Where(sq.Eq{"user_id": userID}).
+		Where(sq.NotEq{"enabled_at": nil}).
+		Where(sq.Expr("? = ANY(recovery_codes)", recoveryCode))
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return err
+	}
+
+	tag, err := tfr.db.Exec(ctx, sql, args...)
+	if err != nil {
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return domain.ErrDataNotFound
+	}
+
+	return nil
+}
+
+// DeleteTwoFactor deletes the enrolment of a user from the database
+func (tfr *TwoFactorRepository) DeleteTwoFactor(ctx context.Context, userID uint64) error {
+	query := tfr.db.QueryBuilder.Delete("two_factors").
+		Where(sq.Eq{"user_id": userID})
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = tfr.db.Exec(ctx, sql, args...)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// scanTwoFactor scans an enrolment row
+func scanTwoFactor(row pgx.Row, twoFactor *domain.TwoFactor) error {
+	return row.Scan(
+		&twoFactor.UserID,
+		&twoFactor.Secret,
+		&twoFactor.RecoveryCodes,
+		&twoFactor.EnabledAt,
+		&twoFactor.CreatedAt,
+		&twoFactor.UpdatedAt,
+	)
+}
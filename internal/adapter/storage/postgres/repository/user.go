@@ -45,6 +45,9 @@ func (ur *UserRepository) CreateUser(ctx context.Context, user *domain.User) (*d
 		&user.Role,
 		&user.CreatedAt,
 		&user.UpdatedAt,
+		&user.Pin,
+		&user.DeletedAt,
+		&user.Version,
 	)
 	if err != nil {
 		if errCode := ur.db.ErrorCode(err); errCode == "23505" {
@@ -56,13 +59,14 @@ func (ur *UserRepository) CreateUser(ctx context.Context, user *domain.User) (*d
 	return user, nil
 }
 
-// GetUserByID gets a user by ID from the database
+// GetUserByID gets a user that is not soft deleted by ID from the database
 func (ur *UserRepository) GetUserByID(ctx context.Context, id uint64) (*domain.User, error) {
 	var user domain.User
 
 	query := ur.db.QueryBuilder.Select("*").
 		From("users").
 		Where(sq.Eq{"id": id}).
+		Where(notDeleted).
 		Limit(1)
 
 	sql, args, err := query.ToSql()
@@ -78,6 +82,9 @@ func (ur *UserRepository) GetUserByID(ctx context.Context, id uint64) (*domain.U
 		&user.Role,
 		&user.CreatedAt,
 		&user.UpdatedAt,
+		&user.Pin,
+		&user.DeletedAt,
+		&user.Version,
 	)
 	if err != nil {
 		if err == pgx.ErrNoRows {
@@ -89,13 +96,57 @@ func (ur *UserRepository) GetUserByID(ctx context.Context, id uint64) (*domain.U
 	return &user, nil
 }
 
-// GetUserByEmailAndPassword gets a user by email from the database
+// GetUsersByIDs gets the users with the given IDs from the database, soft deleted or not, skipping the IDs that do not exist
+func (ur *UserRepository) GetUsersByIDs(ctx context.Context, ids []uint64) ([]domain.User, error) {
+	var user domain.User
+	var users []domain.User
+
+	query := ur.db.QueryBuilder.Select("*").
+		From("users").
+		Where(sq.Eq{"id": ids})
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := ur.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		err := rows.Scan(
+			&user.ID,
+			&user.Name,
+			&user.Email,
+			&user.Password,
+			&user.Role,
+			&user.CreatedAt,
+			&user.UpdatedAt,
+			&user.Pin,
+			&user.DeletedAt,
+			&user.Version,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		users = append(users, user)
+	}
+
+	return users, rows.Err()
+}
+
+// GetUserByEmailAndPassword gets a user that is not soft deleted by email from the database
 func (ur *UserRepository) GetUserByEmail(ctx context.Context, email string) (*domain.User, error) {
 	var user domain.User
 
 	query := ur.db.QueryBuilder.Select("*").
 		From("users").
 		Where(sq.Eq{"email": email}).
+		Where(notDeleted).
 		Limit(1)
 
 	sql, args, err := query.ToSql()
@@ -111,6 +162,9 @@ func (ur *UserRepository) GetUserByEmail(ctx context.Context, email string) (*do
 		&user.Role,
 		&user.CreatedAt,
 		&user.UpdatedAt,
+		&user.Pin,
+		&user.DeletedAt,
+		&user.Version,
 	)
 	if err != nil {
 		if err == pgx.ErrNoRows {
@@ -122,25 +176,39 @@ func (ur *UserRepository) GetUserByEmail(ctx context.Context, email string) (*do
 	return &user, nil
 }
 
+// userSortColumns are the columns users can be sorted by
+var userSortColumns = map[string]string{
+	"id":         "id",
+	"name":       "name",
+	"email":      "email",
+	"created_at": "created_at",
+}
+
 // ListUsers lists all users from the database
-func (ur *UserRepository) ListUsers(ctx context.Context, skip, limit uint64) ([]domain.User, error) {
+func (ur *UserRepository) ListUsers(ctx context.Context, includeDeleted bool, params *domain.ListParams) ([]domain.User, uint64, error) {
 	var user domain.User
 	var users []domain.User
 
-	query := ur.db.QueryBuilder.Select("*").
-		From("users").
-		OrderBy("id").
-		Limit(limit).
-		Offset((skip - 1) * limit)
+	filtered := withDeleted(ur.db.QueryBuilder.Select().From("users"), includeDeleted)
+
+	total, err := countRows(ctx, ur.db, filtered)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	query := filtered.Columns("*").
+		OrderBy(orderBy(params, userSortColumns)).
+		Limit(params.Limit).
+		Offset((params.Skip - 1) * params.Limit)
 
 	sql, args, err := query.ToSql()
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 
 	rows, err := ur.db.Query(ctx, sql, args...)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 	defer rows.Close()
 
@@ -153,31 +221,39 @@ func (ur *UserRepository) ListUsers(ctx context.Context, skip, limit uint64) ([]
 			&user.Role,
 			&user.CreatedAt,
 			&user.UpdatedAt,
+			&user.Pin,
+			&user.DeletedAt,
+			&user.Version,
 		)
 		if err != nil {
-			return nil, err
+			return nil, 0, err
 		}
 
 		users = append(users, user)
 	}
 
-	return users, nil
+	return users, total, nil
 }
 
-// UpdateUser updates a user by ID in the database
+// UpdateUser updates a user by ID in the database, as long as it is at the given version unless none is given
 func (ur *UserRepository) UpdateUser(ctx context.Context, user *domain.User) (*domain.User, error) {
 	name := nullString(user.Name)
 	email := nullString(user.Email)
 	password := nullString(user.Password)
+	pin := nullString(user.Pin)
 	role := nullString(string(user.Role))
 
 	query := ur.db.QueryBuilder.Update("users").
 		Set("name", sq.Expr("COALESCE(?, name)", name)).
 		Set("email", sq.Expr("COALESCE(?, email)", email)).
 		Set("password", sq.Expr("COALESCE(?, password)", password)).
+		Set("pin", sq.Expr("COALESCE(?, pin)", pin)).
 		Set("role", sq.Expr("COALESCE(?, role)", role)).
 		Set("updated_at", time.Now()).
+		Set("version", bumpVersion).
 		Where(sq.Eq{"id": user.ID}).
+		Where(notDeleted).
+		Where(atVersion(user.Version)).
 		Suffix("RETURNING *")
 
 	sql, args, err := query.ToSql()
@@ -193,31 +269,91 @@ func (ur *UserRepository) UpdateUser(ctx context.Context, user *domain.User) (*d
 		&user.Role,
 		&user.CreatedAt,
 		&user.UpdatedAt,
+		&user.Pin,
+		&user.DeletedAt,
+		&user.Version,
 	)
 	if err != nil {
-		if errCode := ur.db.ErrorCode(err); errCode == "23505" {
+		if err == pgx.ErrNoRows {
+			return nil, notChanged(ctx, ur.db, "users", user.ID, user.Version, domain.ErrVersionMismatch)
+		}
+		errCode := ur.db.ErrorCode(err)
+		if errCode == "23505" {
 			return nil, domain.ErrConflictingData
 		}
+		if errCode == "23503" {
+			return nil, domain.ErrDataNotFound
+		}
 		return nil, err
 	}
 
 	return user, nil
 }
 
-// DeleteUser deletes a user by ID from the database
-func (ur *UserRepository) DeleteUser(ctx context.Context, id uint64) error {
-	query := ur.db.QueryBuilder.Delete("users").
-		Where(sq.Eq{"id": id})
+// DeleteUser soft deletes a user by ID in the database, as long as it is at the given version unless none is given
+func (ur *UserRepository) DeleteUser(ctx context.Context, id, version uint64) error {
+	query := ur.db.QueryBuilder.Update("users").
+		Set("deleted_at", time.Now()).
+		Set("version", bumpVersion).
+		Where(sq.Eq{"id": id}).
+		Where(notDeleted).
+		Where(atVersion(version))
 
 	sql, args, err := query.ToSql()
 	if err != nil {
 		return err
 	}
 
-	_, err = ur.db.Exec(ctx, sql, args...)
+	result, err := ur.db.Exec(ctx, sql, args...)
 	if err != nil {
 		return err
 	}
 
+	if result.RowsAffected() == 0 {
+		return notChanged(ctx, ur.db, "users", id, version, domain.ErrVersionMismatch)
+	}
+
 	return nil
 }
+
+// RestoreUser restores a soft deleted user by ID in the database
+func (ur *UserRepository) RestoreUser(ctx context.Context, id uint64) (*domain.User, error) {
+	var user domain.User
+
+	query := ur.db.QueryBuilder.Update("users").
+		Set("deleted_at", nil).
+		Set("updated_at", time.Now()).
+		Set("version", bumpVersion).
+		Where(sq.Eq{"id": id}).
+		Where(sq.NotEq{"deleted_at": nil}).
+		Suffix("RETURNING *")
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = ur.db.QueryRow(ctx, sql, args...).Scan(
+		&user.ID,
+		&user.Name,
+		&user.Email,
+		&user.Password,
+		&user.Role,
+		&user.CreatedAt,
+		&user.UpdatedAt,
+		&user.Pin,
+		&user.DeletedAt,
+		&user.Version,
+	)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, domain.ErrDataNotFound
+		}
+		if errCode := ur.db.ErrorCode(err); errCode == "23505" {
+			return nil, domain.ErrConflictingData
+		}
+		return nil, err
+	}
+
+	return &user, nil
+}
@@ -5,10 +5,21 @@ import (
 	"time"
 
 	"github.com/nikhil-shrestha/go-pos/internal/adapter/config"
+	"github.com/nikhil-shrestha/go-pos/internal/core/domain"
 	"github.com/nikhil-shrestha/go-pos/internal/core/port"
 	"github.com/redis/go-redis/v9"
 )
 
+// compareAndSwap sets the key to the new value only if it still holds the old one,
+// checking and setting in a single step so no other client can write in between
+var compareAndSwap = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	redis.call("SET", KEYS[1], ARGV[2], "PX", ARGV[3])
+	return 1
+end
+return 0
+`)
+
 /**
  * Redis implements port.CacheRepository interface
  * and provides an access to the redis library
@@ -38,13 +49,54 @@ func (r *Redis) Set(ctx context.Context, key string, value []byte, ttl time.Dura
 	return r.client.Set(ctx, key, value, ttl).Err()
 }
 
-// Get retrieves the value from the redis database
+// SetIfNotExists stores the value in the redis database only if the key does not exist, reporting whether it was stored
+func (r *Redis) SetIfNotExists(ctx context.Context, key string, value []byte, ttl time.Duration) (bool, error) {
+	return r.client.SetNX(ctx, key, value, ttl).Result()
+}
+
+// CompareAndSwap stores the value in the redis database only if the key still holds the old value, reporting whether it was stored
+func (r *Redis) CompareAndSwap(ctx context.Context, key string, old, value []byte, ttl time.Duration) (bool, error) {
+	return compareAndSwap.Run(ctx, r.client, []string{key}, old, value, ttl.Milliseconds()).Bool()
+}
+
+// Get retrieves the value from the redis database, returning domain.ErrDataNotFound if the key does not exist
 func (r *Redis) Get(ctx context.Context, key string) ([]byte, error) {
 	res, err := r.client.Get(ctx, key).Result()
+	if err == redis.Nil {
+		return nil, domain.ErrDataNotFound
+	}
+	bytes := []byte(res)
+	return bytes, err
+}
+
+// GetAndDelete retrieves the value and removes it from the redis database in a single step,
+// returning domain.ErrDataNotFound if the key does not exist
+func (r *Redis) GetAndDelete(ctx context.Context, key string) ([]byte, error) {
+	res, err := r.client.GetDel(ctx, key).Result()
+	if err == redis.Nil {
+		return nil, domain.ErrDataNotFound
+	}
 	bytes := []byte(res)
 	return bytes, err
 }
 
+// Increment increments the counter in the redis database and sets its ttl when it is created
+func (r *Redis) Increment(ctx context.Context, key string, ttl time.Duration) (int64, error) {
+	count, err := r.client.Incr(ctx, key).Result()
+	if err != nil {
+		return 0, err
+	}
+
+	if count == 1 && ttl > 0 {
+		err = r.client.Expire(ctx, key, ttl).Err()
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	return count, nil
+}
+
 // Delete removes the value from the redis database
 func (r *Redis) Delete(ctx context.Context, key string) error {
 	return r.client.Del(ctx, key).Err()
@@ -0,0 +1,103 @@
+package s3
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/nikhil-shrestha/go-pos/internal/adapter/config"
+	"github.com/nikhil-shrestha/go-pos/internal/core/domain"
+	"github.com/nikhil-shrestha/go-pos/internal/core/port"
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+// publicReadPolicy is the policy of a new bucket, which lets clients load uploaded images by their URL
+const publicReadPolicy = `{
+	"Version": "2012-10-17",
+	"Statement": [{
+		"Effect": "Allow",
+		"Principal": {"AWS": ["*"]},
+		"Action": ["s3:GetObject"],
+		"Resource": ["arn:aws:s3:::%s/*"]
+	}]
+}`
+
+/**
+ * S3 implements port.FileStorage interface
+ * and provides an access to an S3-compatible object storage
+ */
+type S3 struct {
+	client *minio.Client
+	bucket string
+	url    string
+}
+
+// New creates a new S3 instance, creating the bucket if it does not exist
+func New(ctx context.Context, config *config.Storage) (port.FileStorage, error) {
+	useSSL := config.UseSSL == "true"
+
+	client, err := minio.New(config.Endpoint, &minio.Options{
+		Creds:  credentials.NewStaticV4(config.AccessKey, config.SecretKey, ""),
+		Secure: useSSL,
+		Region: config.Region,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	exists, err := client.BucketExists(ctx, config.Bucket)
+	if err != nil {
+		return nil, err
+	}
+
+	if !exists {
+		err = client.MakeBucket(ctx, config.Bucket, minio.MakeBucketOptions{Region: config.Region})
+		if err != nil {
+			return nil, err
+		}
+
+		err = client.SetBucketPolicy(ctx, config.Bucket, fmt.Sprintf(publicReadPolicy, config.Bucket))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	url := config.URL
+	if url == "" {
+		scheme := "http"
+		if useSSL {
+			scheme = "https"
+		}
+		url = fmt.Sprintf("%s://%s/%s", scheme, config.Endpoint, config.Bucket)
+	}
+
+	return &S3{
+		client,
+		config.Bucket,
+		strings.TrimSuffix(url, "/"),
+	}, nil
+}
+
+// Upload puts the file into the bucket
+func (s *S3) Upload(ctx context.Context, key string, file *domain.File) (string, error) {
+	_, err := s.client.PutObject(ctx, s.bucket, key, bytes.NewReader(file.Content), int64(len(file.Content)), minio.PutObjectOptions{
+		ContentType: file.ContentType,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return s.url + "/" + key, nil
+}
+
+// Delete removes the object of the URL from the bucket
+func (s *S3) Delete(ctx context.Context, url string) error {
+	key, ok := strings.CutPrefix(url, s.url+"/")
+	if !ok {
+		return nil
+	}
+
+	return s.client.RemoveObject(ctx, s.bucket, key, minio.RemoveObjectOptions{})
+}
@@ -0,0 +1,116 @@
+package s3_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/nikhil-shrestha/go-pos/internal/adapter/config"
+	"github.com/nikhil-shrestha/go-pos/internal/adapter/storage/s3"
+	"github.com/nikhil-shrestha/go-pos/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+// objectStore is a minimal in-memory stand-in for an S3-compatible server such as MinIO
+type objectStore struct {
+	mu      sync.Mutex
+	buckets map[string]bool
+	objects map[string][]byte
+}
+
+func (os *objectStore) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	os.mu.Lock()
+	defer os.mu.Unlock()
+
+	path := strings.TrimPrefix(r.URL.Path, "/")
+	bucket, key, _ := strings.Cut(path, "/")
+
+	switch {
+	case key == "" && r.Method == http.MethodHead:
+		if !os.buckets[bucket] {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	case key == "" && r.Method == http.MethodPut:
+		os.buckets[bucket] = true
+	case r.Method == http.MethodPut:
+		content, _ := io.ReadAll(r.Body)
+		if strings.HasPrefix(r.Header.Get("X-Amz-Content-Sha256"), "STREAMING-") {
+			content = decodeChunks(content)
+		}
+		os.objects[path] = content
+		w.Header().Set("ETag", `"etag"`)
+	case r.Method == http.MethodDelete:
+		delete(os.objects, path)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusNotImplemented)
+	}
+}
+
+// decodeChunks extracts the payload of an aws-chunked request body, which is sent over plain HTTP
+func decodeChunks(body []byte) []byte {
+	var content []byte
+
+	for {
+		header, rest, ok := bytes.Cut(body, []byte("\r\n"))
+		if !ok {
+			return content
+		}
+
+		sizeHex, _, _ := bytes.Cut(header, []byte(";"))
+		size, err := strconv.ParseInt(string(sizeHex), 16, 64)
+		if err != nil || size == 0 || int64(len(rest)) < size {
+			return content
+		}
+
+		content = append(content, rest[:size]...)
+		body = bytes.TrimPrefix(rest[size:], []byte("\r\n"))
+	}
+}
+
+func TestS3(t *testing.T) {
+	ctx := context.Background()
+	store := &objectStore{
+		buckets: map[string]bool{},
+		objects: map[string][]byte{},
+	}
+
+	server := httptest.NewServer(store)
+	defer server.Close()
+
+	endpoint := strings.TrimPrefix(server.URL, "http://")
+	storage, err := s3.New(ctx, &config.Storage{
+		Endpoint:  endpoint,
+		Region:    "us-east-1",
+		Bucket:    "gopos",
+		AccessKey: "minioadmin",
+		SecretKey: "minioadmin",
+	})
+	assert.NoError(t, err, "Error mismatch")
+	assert.True(t, store.buckets["gopos"], "Bucket was not created")
+
+	file := &domain.File{
+		Name:        "logo.png",
+		ContentType: "image/png",
+		Content:     []byte("image"),
+	}
+
+	url, err := storage.Upload(ctx, "payments/1/logo.png", file)
+	assert.NoError(t, err, "Error mismatch")
+	assert.Equal(t, server.URL+"/gopos/payments/1/logo.png", url, "URL mismatch")
+	assert.Equal(t, file.Content, store.objects["gopos/payments/1/logo.png"], "Content mismatch")
+
+	err = storage.Delete(ctx, "https://example.com/logo.png")
+	assert.NoError(t, err, "Error mismatch")
+	assert.Len(t, store.objects, 1, "Unmanaged URL was deleted")
+
+	err = storage.Delete(ctx, url)
+	assert.NoError(t, err, "Error mismatch")
+	assert.Empty(t, store.objects, "Object was not deleted")
+}
@@ -0,0 +1,48 @@
+package domain
+
+import "time"
+
+// ReportInterval is an enum for the time bucket of a sales report
+type ReportInterval string
+
+// ReportInterval enum values
+const (
+	Hourly ReportInterval = "hour"
+	Daily  ReportInterval = "day"
+)
+
+// ReportFilter is an entity that represents the parameters of a sales report
+type ReportFilter struct {
+	StartDate time.Time
+	EndDate   time.Time
+	TimeZone  string
+	Interval  ReportInterval
+	Limit     uint64
+}
+
+// SalesSummary is an entity that represents the aggregated sales within a date range
+type SalesSummary struct {
+	TotalOrders       uint64
+	TotalItems        int64
+	TotalRevenue      float64
+	AverageOrderValue float64
+	AverageBasketSize float64
+}
+
+// SalesPeriod is an entity that represents the aggregated sales within a time bucket
+type SalesPeriod struct {
+	Period       time.Time
+	TotalOrders  uint64
+	TotalItems   int64
+	TotalRevenue float64
+}
+
+// SalesGroup is an entity that represents the aggregated sales of a category, product, cashier, or payment.
+// Categories are grouped by the name their products were sold under, so their groups have no ID
+type SalesGroup struct {
+	ID           uint64
+	Name         string
+	TotalOrders  uint64
+	TotalItems   int64
+	TotalRevenue float64
+}
@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"slices"
+	"time"
+)
+
+// APIKeyPermissions lists the permissions an API key can be granted, which leave out
+// managing users, roles, terminals and API keys and the actions that need a supervisor
+var APIKeyPermissions = []Permission{
+	CategoriesWrite,
+	ProductsWrite,
+	ProductsExport,
+	OrdersCreate,
+	OrdersExport,
+	ReportsView,
+}
+
+// IsAPIKeyPermission reports whether the permission can be granted to an API key
+func (p Permission) IsAPIKeyPermission() bool {
+	return slices.Contains(APIKeyPermissions, p)
+}
+
+// APIKey is an entity that represents a key an integration authenticates with instead of a user.
+// The key is only known when it is created, and is identified by its prefix afterwards
+type APIKey struct {
+	ID          uint64
+	Name        string
+	Prefix      string
+	Key         string
+	KeyHash     string
+	Permissions []Permission
+	CreatedBy   uint64
+	ExpiresAt   *time.Time
+	LastUsedAt  *time.Time
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// IsExpired reports whether the key has expired
+func (ak *APIKey) IsExpired() bool {
+	return ak.ExpiresAt != nil && !time.Now().Before(*ak.ExpiresAt)
+}
@@ -0,0 +1,101 @@
+package domain
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+)
+
+// AuditLog is an entity that represents an action recorded in the audit trail,
+// with the supervisor who approved it if the user could not take it alone.
+// Each log is chained to the previous one by including its hash in its own,
+// so that editing or removing a log breaks the chain from that log on
+type AuditLog struct {
+	ID         uint64
+	UserID     uint64
+	APIKeyID   uint64
+	ApproverID uint64
+	Action     string
+	Entity     string
+	EntityID   uint64
+	Changes    map[string]AuditChange
+	IP         string
+	RequestID  string
+	PrevHash   string
+	Hash       string
+	CreatedAt  time.Time
+}
+
+// AuditChange is an entity that represents the values of a field before and after an action
+type AuditChange struct {
+	Before any `json:"before"`
+	After  any `json:"after"`
+}
+
+// ComputeHash hashes the previous hash with the fields of the log using SHA-256
+func (al *AuditLog) ComputeHash() string {
+	data, _ := json.Marshal([]any{
+		al.PrevHash,
+		al.UserID,
+		al.APIKeyID,
+		al.ApproverID,
+		al.Action,
+		al.Entity,
+		al.EntityID,
+		al.Changes,
+		al.IP,
+		al.RequestID,
+		al.CreatedAt.UnixMicro(),
+	})
+	hash := sha256.Sum256(data)
+
+	return hex.EncodeToString(hash[:])
+}
+
+// AuditLogFilter is an entity that represents the filters of the audit trail
+type AuditLogFilter struct {
+	UserID    uint64
+	Action    string
+	Entity    string
+	EntityID  uint64
+	RequestID string
+	StartDate *time.Time
+	EndDate   *time.Time
+}
+
+// AuditVerification is an entity that represents the result of checking the hash chain of the audit trail.
+// BrokenID is the first log whose hash does not match, or 0 if the chain is intact
+type AuditVerification struct {
+	Checked  uint64
+	BrokenID uint64
+}
+
+// IsValid reports whether the hash chain is intact
+func (av *AuditVerification) IsValid() bool {
+	return av.BrokenID == 0
+}
+
+// Actor is an entity that represents who made a request and from where, which is recorded with its actions
+type Actor struct {
+	UserID     uint64
+	APIKeyID   uint64
+	ApproverID uint64
+	IP         string
+	RequestID  string
+}
+
+// actorContextKey is the key of the actor in a context
+type actorContextKey struct{}
+
+// NewActorContext returns a copy of the context that carries the actor
+func NewActorContext(ctx context.Context, actor *Actor) context.Context {
+	return context.WithValue(ctx, actorContextKey{}, actor)
+}
+
+// ActorFromContext returns the actor the context carries, if any
+func ActorFromContext(ctx context.Context) (*Actor, bool) {
+	actor, ok := ctx.Value(actorContextKey{}).(*Actor)
+	return actor, ok
+}
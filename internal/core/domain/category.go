@@ -2,10 +2,15 @@ package domain
 
 import "time"
 
-// Category is an entity that represents a category of product
+// Category is an entity that represents a category of product,
+// which can be nested under a parent category
 type Category struct {
 	ID        uint64
+	ParentID  uint64
 	Name      string
 	CreatedAt time.Time
 	UpdatedAt time.Time
+	DeletedAt *time.Time
+	Version   uint64
+	Children  []Category
 }
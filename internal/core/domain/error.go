@@ -13,26 +13,105 @@ var (
 	ErrNoUpdatedData = errors.New("no data to update")
 	// ErrConflictingData is an error for when data conflicts with existing data
 	ErrConflictingData = errors.New("data conflicts with existing data in unique column")
+	// ErrVersionMismatch is an error for when the data has been changed since the version the client sent in If-Match
+	ErrVersionMismatch = errors.New("data has been modified since it was retrieved")
+	// ErrPreconditionRequired is an error for when a change is requested without the If-Match header
+	ErrPreconditionRequired = errors.New("If-Match header with the ETag of the data is required")
 	// ErrInsufficientStock is an error for when product stock is not enough
 	ErrInsufficientStock = errors.New("product stock is not enough")
+	// ErrInvalidQuantity is an error for when an ordered quantity is not positive
+	ErrInvalidQuantity = errors.New("quantity must be at least 1")
 	// ErrInsufficientPayment is an error for when total paid is less than total price
 	ErrInsufficientPayment = errors.New("total paid is less than total price")
+	// ErrCategoryCycle is an error for when a category is moved under itself or one of its descendants
+	ErrCategoryCycle = errors.New("category cannot be nested under itself or one of its descendants")
+	// ErrImageTooLarge is an error for when an uploaded image exceeds MaxImageSize
+	ErrImageTooLarge = errors.New("image is too large")
+	// ErrUnsupportedImage is an error for when an uploaded file is not a JPEG, PNG, or WebP image
+	ErrUnsupportedImage = errors.New("image must be a JPEG, PNG, or WebP file")
+	// ErrInvalidDateRange is an error for when the start date is not before the end date
+	ErrInvalidDateRange = errors.New("start date must be before end date")
+	// ErrInvalidTotalRange is an error for when the minimum total is greater than the maximum total
+	ErrInvalidTotalRange = errors.New("minimum total must not be greater than maximum total")
+	// ErrInvalidCursor is an error for when the pagination cursor is malformed
+	ErrInvalidCursor = errors.New("cursor is invalid")
+	// ErrInvalidImportFile is an error for when an import file cannot be read or misses required columns
+	ErrInvalidImportFile = errors.New("import file is invalid or misses required columns")
+	// ErrInvalidTimeZone is an error for when the time zone is not recognized
+	ErrInvalidTimeZone = errors.New("time zone is invalid")
+	// ErrInvalidStoragePath is an error for when the local file storage would serve the root or working directory,
+	// or be mounted at the root of the server
+	ErrInvalidStoragePath = errors.New("storage path and URL path must not be empty, the root or the working directory")
 	// ErrTokenDuration is an error for when the token duration format is invalid
 	ErrTokenDuration = errors.New("invalid token duration format")
+	// ErrTokenMode is an error for when the token mode is neither local nor public
+	ErrTokenMode = errors.New("invalid token mode")
+	// ErrTokenKey is an error for when the token key ring is malformed or misses the active key
+	ErrTokenKey = errors.New("invalid token key ring")
 	// ErrTokenCreation is an error for when the token creation fails
 	ErrTokenCreation = errors.New("error creating token")
 	// ErrExpiredToken is an error for when the access token is expired
 	ErrExpiredToken = errors.New("access token has expired")
 	// ErrInvalidToken is an error for when the access token is invalid
 	ErrInvalidToken = errors.New("access token is invalid")
+	// ErrRevokedToken is an error for when the access token has been revoked by a logout
+	ErrRevokedToken = errors.New("access token has been revoked")
+	// ErrInvalidRefreshToken is an error for when the refresh token is invalid, expired, or revoked
+	ErrInvalidRefreshToken = errors.New("refresh token is invalid")
+	// ErrRefreshTokenReused is an error for when a rotated refresh token is used again, which revokes its session
+	ErrRefreshTokenReused = errors.New("refresh token has already been used")
+	// ErrInvalidResetToken is an error for when the password reset token is invalid, expired, or already used
+	ErrInvalidResetToken = errors.New("password reset token is invalid")
+	// ErrInvalidChallenge is an error for when the login challenge is invalid, expired, or already answered
+	ErrInvalidChallenge = errors.New("login challenge is invalid or expired")
+	// ErrInvalidTwoFactorCode is an error for when the TOTP or recovery code is wrong
+	ErrInvalidTwoFactorCode = errors.New("invalid two-factor code")
+	// ErrTwoFactorLocked is an error for when two-factor codes are locked after too many failed attempts
+	ErrTwoFactorLocked = errors.New("too many failed two-factor attempts, try again later")
+	// ErrTwoFactorEnabled is an error for when the user has already enrolled in two-factor authentication
+	ErrTwoFactorEnabled = errors.New("two-factor authentication is already enabled")
+	// ErrTwoFactorNotEnabled is an error for when the user has not enrolled in two-factor authentication
+	ErrTwoFactorNotEnabled = errors.New("two-factor authentication is not enabled")
+	// ErrTwoFactorRequired is an error for when two-factor authentication cannot be turned off for the role of the user
+	ErrTwoFactorRequired = errors.New("two-factor authentication is required for the role")
+	// ErrInvalidAPIKey is an error for when the API key is unknown, expired, or revoked
+	ErrInvalidAPIKey = errors.New("API key is invalid")
+	// ErrInvalidAPIKeyExpiry is an error for when an API key is created with an expiry in the past
+	ErrInvalidAPIKeyExpiry = errors.New("API key must expire in the future")
 	// ErrInvalidCredentials is an error for when the credentials are invalid
 	ErrInvalidCredentials = errors.New("invalid email or password")
+	// ErrInvalidPin is an error for when the user has no PIN or the PIN is wrong
+	ErrInvalidPin = errors.New("invalid user or PIN")
+	// ErrPinLoginNotAllowed is an error for when the role of the user requires two-factor authentication, which PIN login skips
+	ErrPinLoginNotAllowed = errors.New("PIN login is not allowed for the role, log in with a password")
+	// ErrPinLocked is an error for when PIN login is locked after too many failed attempts
+	ErrPinLocked = errors.New("too many failed PIN attempts, try again later or log in with a password")
+	// ErrAccountLocked is an error for when login is locked for the account or the client after too many failed attempts
+	ErrAccountLocked = errors.New("too many failed login attempts, try again later")
+	// ErrInvalidTerminal is an error for when the terminal does not exist or its key does not match
+	ErrInvalidTerminal = errors.New("terminal is not registered or its key is invalid")
 	// ErrEmptyAuthorizationHeader is an error for when the authorization header is empty
 	ErrEmptyAuthorizationHeader = errors.New("authorization header is not provided")
 	// ErrInvalidAuthorizationHeader is an error for when the authorization header is invalid
 	ErrInvalidAuthorizationHeader = errors.New("authorization header format is invalid")
 	// ErrInvalidAuthorizationType is an error for when the authorization type is invalid
 	ErrInvalidAuthorizationType = errors.New("authorization type is not supported")
+	// ErrBuiltInRole is an error for when a change would break a built-in role
+	ErrBuiltInRole = errors.New("built-in roles cannot be renamed or deleted, and the admin role cannot be changed")
+	// ErrOverrideRequired is an error for when a restricted action needs a supervisor's approval token
+	ErrOverrideRequired = errors.New("action requires a supervisor's approval")
+	// ErrInvalidOverride is an error for when the approval token is unknown, expired, used, or issued for another action or user
+	ErrInvalidOverride = errors.New("approval token is invalid")
+	// ErrSelfApproval is an error for when a user tries to approve their own restricted action
+	ErrSelfApproval = errors.New("restricted actions must be approved by another user")
+	// ErrInvalidIdempotencyKey is an error for when the idempotency key is too long
+	ErrInvalidIdempotencyKey = errors.New("idempotency key must be at most 255 characters")
+	// ErrIdempotencyKeyReused is an error for when the idempotency key has already been used for a different request
+	ErrIdempotencyKeyReused = errors.New("idempotency key has already been used for a different request")
+	// ErrIdempotencyKeyInUse is an error for when the request with the idempotency key is still running
+	ErrIdempotencyKeyInUse = errors.New("request with the idempotency key is still being processed, try again later")
+	// ErrOrderVoided is an error for when the order has already been voided
+	ErrOrderVoided = errors.New("order has already been voided")
 	// ErrUnauthorized is an error for when the user is unauthorized
 	ErrUnauthorized = errors.New("user is unauthorized to access the resource")
 	// ErrForbidden is an error for when the user is forbidden to access the resource
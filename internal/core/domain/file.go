@@ -0,0 +1,11 @@
+package domain
+
+// MaxImageSize is the maximum size in bytes of an uploaded image
+const MaxImageSize = 5 << 20
+
+// File is an entity that represents an uploaded file
+type File struct {
+	Name        string
+	ContentType string
+	Content     []byte
+}
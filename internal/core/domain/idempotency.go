@@ -0,0 +1,19 @@
+package domain
+
+import "time"
+
+// IdempotentRequest is an entity that represents a request made with an idempotency key,
+// which keeps the response of the request so that its retries get the response back instead of running it again
+type IdempotentRequest struct {
+	Key            string
+	RequestHash    string
+	ResponseStatus int
+	ResponseBody   []byte
+	CreatedAt      time.Time
+	ExpiresAt      time.Time
+}
+
+// IsCompleted reports whether the request has completed and kept its response, or is still running
+func (ir *IdempotentRequest) IsCompleted() bool {
+	return ir.ResponseStatus != 0
+}
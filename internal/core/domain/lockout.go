@@ -0,0 +1,22 @@
+package domain
+
+import (
+	"time"
+)
+
+// LockoutError is an error for when an action is locked after too many failed attempts,
+// which tells how long until it can be tried again
+type LockoutError struct {
+	Err        error
+	RetryAfter time.Duration
+}
+
+// Error returns the message of the wrapped error
+func (le *LockoutError) Error() string {
+	return le.Err.Error()
+}
+
+// Unwrap returns the wrapped error, so errors.Is matches the kind of lockout
+func (le *LockoutError) Unwrap() error {
+	return le.Err
+}
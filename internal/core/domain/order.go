@@ -1,11 +1,21 @@
 package domain
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+// OrderStatus is an enum for order's status
+type OrderStatus string
+
+// OrderStatus enum values
+const (
+	OrderCompleted OrderStatus = "completed"
+	OrderVoided    OrderStatus = "voided"
+)
+
 // Order is an entity that represents an order
 type Order struct {
 	ID           uint64
@@ -16,9 +26,38 @@ type Order struct {
 	TotalPaid    float64
 	TotalReturn  float64
 	ReceiptCode  uuid.UUID
+	Status       OrderStatus
+	VoidedAt     *time.Time
 	CreatedAt    time.Time
 	UpdatedAt    time.Time
 	User         *User
 	Payment      *Payment
 	Products     []OrderProduct
 }
+
+// OrderFilter is an entity that represents the filters of an order list, where zero values match every order.
+// The customer name matches as a case-insensitive substring and the end date is exclusive
+type OrderFilter struct {
+	StartDate    *time.Time
+	EndDate      *time.Time
+	UserID       uint64
+	PaymentID    uint64
+	CustomerName string
+	ReceiptCode  uuid.UUID
+	MinTotal     float64
+	MaxTotal     float64
+	Status       OrderStatus
+}
+
+// String returns the filters as a string that is unique to them
+func (f *OrderFilter) String() string {
+	var startDate, endDate int64
+	if f.StartDate != nil {
+		startDate = f.StartDate.UnixMicro()
+	}
+	if f.EndDate != nil {
+		endDate = f.EndDate.UnixMicro()
+	}
+
+	return fmt.Sprintf("%d-%d-%d-%d-%s-%g-%g-%s-%q", startDate, endDate, f.UserID, f.PaymentID, f.ReceiptCode, f.MinTotal, f.MaxTotal, f.Status, f.CustomerName)
+}
@@ -1,16 +1,25 @@
 package domain
 
-import "time"
+import (
+	"time"
 
-// OrderProduct is an entity that represents pivot table between order and product
+	"github.com/google/uuid"
+)
+
+// OrderProduct is an entity that represents pivot table between order and product.
+// The product name, SKU, price and category name are a snapshot taken at the time of the sale
 type OrderProduct struct {
-	ID         uint64
-	OrderID    uint64
-	ProductID  uint64
-	Quantity   int64
-	TotalPrice float64
-	CreatedAt  time.Time
-	UpdatedAt  time.Time
-	Order      *Order
-	Product    *Product
+	ID           uint64
+	OrderID      uint64
+	ProductID    uint64
+	ProductName  string
+	ProductSKU   uuid.UUID
+	ProductPrice float64
+	CategoryName string
+	Quantity     int64
+	TotalPrice   float64
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+	Order        *Order
+	Product      *Product
 }
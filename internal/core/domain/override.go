@@ -0,0 +1,38 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OverrideAction is an enum for a restricted action, which a user without its permission
+// can only take with the approval of a user who has it
+type OverrideAction string
+
+// OverrideAction enum values
+const (
+	VoidOrderAction  OverrideAction = "order.void"
+	OpenDrawerAction OverrideAction = "drawer.open"
+)
+
+// overridePermissions maps each restricted action to the permission that allows it
+var overridePermissions = map[OverrideAction]Permission{
+	VoidOrderAction:  OrdersVoid,
+	OpenDrawerAction: DrawerOpen,
+}
+
+// Permission returns the permission that allows the action, or false if the action is unknown
+func (oa OverrideAction) Permission() (Permission, bool) {
+	permission, ok := overridePermissions[oa]
+	return permission, ok
+}
+
+// Override is an entity that represents a supervisor's approval of a single restricted action on a user's session
+type Override struct {
+	ID          uuid.UUID
+	Action      OverrideAction
+	RequestedBy uint64
+	ApprovedBy  uint64
+	ExpiresAt   time.Time
+}
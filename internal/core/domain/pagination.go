@@ -0,0 +1,61 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// SortOrder is an enum for the direction a list is sorted in
+type SortOrder string
+
+// SortOrder enum values
+const (
+	Ascending  SortOrder = "asc"
+	Descending SortOrder = "desc"
+)
+
+// ListParams is an entity that represents the page of a list to select and the field it is sorted by.
+// Lists are sorted by id in ascending order unless told otherwise
+type ListParams struct {
+	Skip  uint64
+	Limit uint64
+	Sort  string
+	Order SortOrder
+}
+
+// Page is an entity that represents a page of a list with the total number of items of the list, as it is cached
+type Page[T any] struct {
+	Items []T
+	Total uint64
+}
+
+// Cursor is an entity that represents the position of an item in a list sorted by creation time,
+// with the id breaking ties between items created at the same time
+type Cursor struct {
+	CreatedAt time.Time `json:"created_at"`
+	ID        uint64    `json:"id"`
+}
+
+// String returns the position of the cursor as a string, or an empty string if there is no cursor
+func (c *Cursor) String() string {
+	if c == nil {
+		return ""
+	}
+
+	return fmt.Sprintf("%d-%d", c.CreatedAt.UnixMicro(), c.ID)
+}
+
+// CursorParams is an entity that represents the page of a list sorted by creation time following the cursor,
+// or the first page if there is no cursor
+type CursorParams struct {
+	Cursor *Cursor
+	Limit  uint64
+	Order  SortOrder
+}
+
+// CursorPage is an entity that represents a page of a list with the cursor of the next page, as it is cached.
+// The next cursor is nil on the last page
+type CursorPage[T any] struct {
+	Items []T
+	Next  *Cursor
+}
@@ -14,10 +14,13 @@ const (
 
 // Payment is an entity that represents a payment
 type Payment struct {
-	ID        uint64
-	Name      string
-	Type      PaymentType
-	Logo      string
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	ID            uint64
+	Name          string
+	Type          PaymentType
+	Logo          string
+	LogoThumbnail string
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+	DeletedAt     *time.Time
+	Version       uint64
 }
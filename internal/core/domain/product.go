@@ -1,6 +1,8 @@
 package domain
 
 import (
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -11,11 +13,36 @@ type Product struct {
 	ID         uint64
 	CategoryID uint64
 	SKU        uuid.UUID
+	Barcode    string
 	Name       string
 	Stock      int64
 	Price      float64
 	Image      string
+	Thumbnail  string
 	CreatedAt  time.Time
 	UpdatedAt  time.Time
+	DeletedAt  *time.Time
+	Version    uint64
 	Category   *Category
 }
+
+// InsufficientStockError is an error for when the stock of products is not enough for an order,
+// which tells the products that are short of stock
+type InsufficientStockError struct {
+	ProductIDs []uint64
+}
+
+// Error returns the message of ErrInsufficientStock with the ids of the products
+func (ise *InsufficientStockError) Error() string {
+	ids := make([]string, len(ise.ProductIDs))
+	for i, id := range ise.ProductIDs {
+		ids[i] = fmt.Sprint(id)
+	}
+
+	return fmt.Sprintf("%s for products %s", ErrInsufficientStock, strings.Join(ids, ", "))
+}
+
+// Unwrap returns ErrInsufficientStock, so errors.Is matches the error
+func (ise *InsufficientStockError) Unwrap() error {
+	return ErrInsufficientStock
+}
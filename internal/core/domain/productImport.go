@@ -0,0 +1,38 @@
+package domain
+
+// ProductImportAction is an enum for the change an imported row makes
+type ProductImportAction string
+
+// ProductImportAction enum values
+const (
+	ProductCreated ProductImportAction = "create"
+	ProductUpdated ProductImportAction = "update"
+)
+
+// ProductImportRow is an entity that represents a single row of a product import file,
+// with the product it replaced if the row updates one
+type ProductImportRow struct {
+	Line     int
+	Product  *Product
+	Previous *Product
+	Action   ProductImportAction
+	Errors   []string
+}
+
+// ProductImport is an entity that represents a bulk product import
+type ProductImport struct {
+	DryRun  bool
+	Applied bool
+	Rows    []ProductImportRow
+}
+
+// Failed reports whether any row of the import has an error
+func (pi *ProductImport) Failed() bool {
+	for _, row := range pi.Rows {
+		if len(row.Errors) > 0 {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,69 @@
+package domain
+
+import (
+	"slices"
+	"time"
+)
+
+// Permission is an enum for an action that a role allows its users to take
+type Permission string
+
+// Permission enum values
+const (
+	UsersWrite      Permission = "users.write"
+	RolesWrite      Permission = "roles.write"
+	PaymentsWrite   Permission = "payments.write"
+	CategoriesWrite Permission = "categories.write"
+	ProductsWrite   Permission = "products.write"
+	ProductsExport  Permission = "products.export"
+	OrdersCreate    Permission = "orders.create"
+	OrdersExport    Permission = "orders.export"
+	OrdersVoid      Permission = "orders.void"
+	DrawerOpen      Permission = "drawer.open"
+	ReportsView     Permission = "reports.view"
+	TerminalsWrite  Permission = "terminals.write"
+	APIKeysWrite    Permission = "api_keys.write"
+	AuditLogsView   Permission = "audit_logs.view"
+)
+
+// Permissions lists every permission a role can be granted
+var Permissions = []Permission{
+	UsersWrite,
+	RolesWrite,
+	PaymentsWrite,
+	CategoriesWrite,
+	ProductsWrite,
+	ProductsExport,
+	OrdersCreate,
+	OrdersExport,
+	OrdersVoid,
+	DrawerOpen,
+	ReportsView,
+	TerminalsWrite,
+	APIKeysWrite,
+	AuditLogsView,
+}
+
+// IsValid reports whether the permission is a known permission
+func (p Permission) IsValid() bool {
+	return slices.Contains(Permissions, p)
+}
+
+// Role is an entity that represents a named set of permissions given to users
+type Role struct {
+	ID          uint64
+	Name        UserRole
+	Permissions []Permission
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// IsBuiltIn reports whether the role is created by the migrations, which the application relies on
+func (r *Role) IsBuiltIn() bool {
+	return r.Name == Admin || r.Name == Cashier
+}
+
+// HasPermission reports whether the role grants the permission
+func (r *Role) HasPermission(permission Permission) bool {
+	return slices.Contains(r.Permissions, permission)
+}
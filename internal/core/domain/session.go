@@ -0,0 +1,29 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AuthToken is an entity that represents the tokens given to an authenticated user.
+// A user with two-factor authentication gets a challenge instead of the tokens, and the recovery codes once enrolled during login
+type AuthToken struct {
+	AccessToken   string
+	RefreshToken  string
+	Challenge     *LoginChallenge
+	RecoveryCodes []string
+}
+
+// Session is an entity that represents a login, which lasts until its refresh token expires or is revoked.
+// The refresh token rotates on every use, so only the last issued one is valid
+type Session struct {
+	ID              uuid.UUID
+	UserID          uint64
+	TerminalID      uint64
+	RefreshTokenID  uuid.UUID
+	AccessTokenID   uuid.UUID
+	AccessExpiresAt time.Time
+	CreatedAt       time.Time
+	ExpiresAt       time.Time
+}
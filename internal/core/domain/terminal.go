@@ -0,0 +1,16 @@
+package domain
+
+import (
+	"time"
+)
+
+// Terminal is an entity that represents a registered till, on which cashiers can log in with their PIN.
+// The key is only known when the terminal is registered, afterwards only its hash is kept
+type Terminal struct {
+	ID        uint64
+	Name      string
+	Key       string
+	KeyHash   string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
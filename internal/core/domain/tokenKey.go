@@ -0,0 +1,11 @@
+package domain
+
+// TokenKey is an entity that represents a public key that verifies signed tokens
+type TokenKey struct {
+	ID        string
+	Version   string
+	Purpose   string
+	PublicKey string
+	PASERK    string
+	Active    bool
+}
@@ -1,12 +1,25 @@
 package domain
 
 import (
+	"slices"
+	"time"
+
 	"github.com/google/uuid"
 )
 
-// TokenPayload is an entity that represents the payload of the token
+// TokenPayload is an entity that represents the payload of the token, or of the API key a request is authenticated with
 type TokenPayload struct {
-	ID     uuid.UUID
-	UserID uint64
-	Role   UserRole
+	ID          uuid.UUID
+	UserID      uint64
+	Role        UserRole
+	Permissions []Permission
+	TerminalID  uint64
+	APIKeyID    uint64
+	IssuedAt    time.Time
+	ExpiresAt   time.Time
+}
+
+// HasPermission reports whether the role of the user had the permission when the token was issued
+func (tp *TokenPayload) HasPermission(permission Permission) bool {
+	return slices.Contains(tp.Permissions, permission)
 }
@@ -0,0 +1,50 @@
+package domain
+
+import (
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TwoFactor is an entity that represents the TOTP enrolment of a user, which is pending until the first code is verified
+type TwoFactor struct {
+	UserID        uint64
+	Secret        string
+	RecoveryCodes []string
+	EnabledAt     *time.Time
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// IsEnabled reports whether the enrolment has been confirmed with a code
+func (tf *TwoFactor) IsEnabled() bool {
+	return tf.EnabledAt != nil
+}
+
+// TwoFactorSetup is an entity that represents the secret an authenticator app is provisioned with
+type TwoFactorSetup struct {
+	Secret string
+	URI    string
+	QRCode []byte
+}
+
+// TwoFactorPolicy is an entity that represents how TOTP codes are issued and which roles must use them
+type TwoFactorPolicy struct {
+	Issuer        string
+	RequiredRoles []UserRole
+}
+
+// IsRequired reports whether users of the role must enrol before they can log in
+func (tp *TwoFactorPolicy) IsRequired(role UserRole) bool {
+	return slices.Contains(tp.RequiredRoles, role)
+}
+
+// LoginChallenge is an entity that represents a login waiting for a TOTP or recovery code.
+// A setup challenge is given to a user who must enrol first, and is answered with the code of the new enrolment
+type LoginChallenge struct {
+	ID        uuid.UUID
+	UserID    uint64
+	Setup     bool
+	ExpiresAt time.Time
+}
@@ -4,10 +4,10 @@ import (
 	"time"
 )
 
-// UserRole is an enum for user's role
+// UserRole is the name of a user's role
 type UserRole string
 
-// UserRole enum values
+// UserRole values of the built-in roles
 const (
 	Admin   UserRole = "admin"
 	Cashier UserRole = "cashier"
@@ -15,11 +15,16 @@ const (
 
 // User is an entity that represents a user
 type User struct {
-	ID        uint64
-	Name      string
-	Email     string
-	Password  string
-	Role      UserRole
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	ID          uint64
+	Name        string
+	Email       string
+	Password    string
+	Pin         string
+	Role        UserRole
+	Permissions []Permission
+	TerminalID  uint64
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	DeletedAt   *time.Time
+	Version     uint64
 }
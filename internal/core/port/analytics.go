@@ -0,0 +1,41 @@
+package port
+
+import (
+	"context"
+
+	"github.com/nikhil-shrestha/go-pos/internal/core/domain"
+)
+
+//go:generate mockgen -source=analytics.go -destination=mock/analytics.go -package=mock
+
+// AnalyticsRepository is an interface for interacting with sales aggregation data
+type AnalyticsRepository interface {
+	// GetSalesSummary aggregates orders within the date range
+	GetSalesSummary(ctx context.Context, filter *domain.ReportFilter) (*domain.SalesSummary, error)
+	// ListSalesByPeriod aggregates orders by hour or day within the date range
+	ListSalesByPeriod(ctx context.Context, filter *domain.ReportFilter) ([]domain.SalesPeriod, error)
+	// ListSalesByCategory aggregates order products by category within the date range
+	ListSalesByCategory(ctx context.Context, filter *domain.ReportFilter) ([]domain.SalesGroup, error)
+	// ListSalesByProduct aggregates order products by product within the date range
+	ListSalesByProduct(ctx context.Context, filter *domain.ReportFilter) ([]domain.SalesGroup, error)
+	// ListSalesByCashier aggregates orders by user within the date range
+	ListSalesByCashier(ctx context.Context, filter *domain.ReportFilter) ([]domain.SalesGroup, error)
+	// ListSalesByPayment aggregates orders by payment within the date range
+	ListSalesByPayment(ctx context.Context, filter *domain.ReportFilter) ([]domain.SalesGroup, error)
+}
+
+// AnalyticsService is an interface for interacting with sales reporting business logic
+type AnalyticsService interface {
+	// GetSalesSummary returns the total orders, revenue, and basket averages
+	GetSalesSummary(ctx context.Context, filter *domain.ReportFilter) (*domain.SalesSummary, error)
+	// ListSalesByPeriod returns the revenue grouped by hour or day
+	ListSalesByPeriod(ctx context.Context, filter *domain.ReportFilter) ([]domain.SalesPeriod, error)
+	// ListSalesByCategory returns the revenue grouped by category
+	ListSalesByCategory(ctx context.Context, filter *domain.ReportFilter) ([]domain.SalesGroup, error)
+	// ListSalesByProduct returns the best selling products
+	ListSalesByProduct(ctx context.Context, filter *domain.ReportFilter) ([]domain.SalesGroup, error)
+	// ListSalesByCashier returns the revenue grouped by cashier
+	ListSalesByCashier(ctx context.Context, filter *domain.ReportFilter) ([]domain.SalesGroup, error)
+	// ListSalesByPayment returns the revenue grouped by payment
+	ListSalesByPayment(ctx context.Context, filter *domain.ReportFilter) ([]domain.SalesGroup, error)
+}
@@ -0,0 +1,36 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"github.com/nikhil-shrestha/go-pos/internal/core/domain"
+)
+
+//go:generate mockgen -source=api_key.go -destination=mock/api_key.go -package=mock
+
+// APIKeyRepository is an interface for interacting with API key-related data
+type APIKeyRepository interface {
+	// CreateAPIKey inserts a new API key into the database
+	CreateAPIKey(ctx context.Context, apiKey *domain.APIKey) (*domain.APIKey, error)
+	// GetAPIKeyByPrefix selects an API key by its prefix, unless its creator has been deleted
+	GetAPIKeyByPrefix(ctx context.Context, prefix string) (*domain.APIKey, error)
+	// ListAPIKeys selects a sorted page of API keys with their total count
+	ListAPIKeys(ctx context.Context, params *domain.ListParams) ([]domain.APIKey, uint64, error)
+	// TouchAPIKey records when an API key was last used
+	TouchAPIKey(ctx context.Context, id uint64, lastUsedAt time.Time) error
+	// DeleteAPIKey deletes an API key and returns it
+	DeleteAPIKey(ctx context.Context, id uint64) (*domain.APIKey, error)
+}
+
+// APIKeyService is an interface for interacting with API key-related business logic
+type APIKeyService interface {
+	// CreateAPIKey creates a new API key with some of the permissions of its creator and returns it with its key, which is not stored
+	CreateAPIKey(ctx context.Context, creator *domain.TokenPayload, apiKey *domain.APIKey) (*domain.APIKey, error)
+	// ListAPIKeys returns a sorted page of API keys with their total count
+	ListAPIKeys(ctx context.Context, params *domain.ListParams) ([]domain.APIKey, uint64, error)
+	// RevokeAPIKey deletes an API key, which is rejected from then on
+	RevokeAPIKey(ctx context.Context, id uint64) error
+	// VerifyAPIKey verifies the key and returns the payload requests made with it act with
+	VerifyAPIKey(ctx context.Context, key string) (*domain.TokenPayload, error)
+}
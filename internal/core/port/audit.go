@@ -0,0 +1,33 @@
+package port
+
+import (
+	"context"
+
+	"github.com/nikhil-shrestha/go-pos/internal/core/domain"
+)
+
+//go:generate mockgen -source=audit.go -destination=mock/audit.go -package=mock
+
+// AuditRepository is an interface for interacting with audit log-related data
+type AuditRepository interface {
+	// CreateAuditLog chains a new audit log to the last one and inserts it into the database
+	CreateAuditLog(ctx context.Context, auditLog *domain.AuditLog) (*domain.AuditLog, error)
+	// ListAuditLogs selects a sorted page of audit logs matching the filter with their total count
+	ListAuditLogs(ctx context.Context, filter *domain.AuditLogFilter, params *domain.ListParams) ([]domain.AuditLog, uint64, error)
+	// ListAuditLogsAfter selects the audit logs following the given id in the order they were chained
+	ListAuditLogsAfter(ctx context.Context, id, limit uint64) ([]domain.AuditLog, error)
+}
+
+// AuditService is an interface for interacting with audit log-related business logic
+type AuditService interface {
+	// RecordAuditLog records an action taken by the actor of the request with the changes between
+	// the entity before and after it, either of which is nil when the entity is created or deleted
+	RecordAuditLog(ctx context.Context, auditLog *domain.AuditLog, before, after any) error
+	// WithinTransaction runs fn in a database transaction, so that the changes fn makes and the audit logs it records
+	// are committed together, or not at all
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+	// ListAuditLogs returns a sorted page of audit logs matching the filter with their total count
+	ListAuditLogs(ctx context.Context, filter *domain.AuditLogFilter, params *domain.ListParams) ([]domain.AuditLog, uint64, error)
+	// VerifyAuditLogs checks the hash chain of the whole audit trail
+	VerifyAuditLogs(ctx context.Context) (*domain.AuditVerification, error)
+}
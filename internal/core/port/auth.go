@@ -10,14 +10,42 @@ import (
 
 // TokenService is an interface for interacting with token-related business logic
 type TokenService interface {
-	// CreateToken creates a new token for a given user
-	CreateToken(user *domain.User) (string, error)
+	// CreateToken creates a new token for a given user and returns it with its payload
+	CreateToken(user *domain.User) (string, *domain.TokenPayload, error)
 	// VerifyToken verifies the token and returns the payload
 	VerifyToken(token string) (*domain.TokenPayload, error)
 }
 
-// UserService is an interface for interacting with user authentication-related business logic
+// TokenKeyService is an interface for publishing the keys that third parties need to verify tokens
+type TokenKeyService interface {
+	// ListPublicKeys returns the public keys that verify tokens, which is empty when tokens are encrypted with a shared secret
+	ListPublicKeys() []domain.TokenKey
+}
+
+// AuthService is an interface for interacting with user authentication-related business logic
 type AuthService interface {
-	// Login authenticates a user by email and password and returns a token
-	Login(ctx context.Context, email, password string) (string, error)
+	// Login authenticates a user by email and password and returns an access token and a refresh token,
+	// or a challenge if the user has to give a two-factor code, locking logins to the account or from the client IP after too many failed attempts
+	Login(ctx context.Context, email, password, clientIP string) (*domain.AuthToken, error)
+	// VerifyChallenge answers the login challenge with a TOTP or recovery code and returns an access token and a refresh token
+	VerifyChallenge(ctx context.Context, challengeID, code string) (*domain.AuthToken, error)
+	// PinLogin authenticates a user by PIN on a registered terminal and returns tokens that are only valid on that terminal
+	PinLogin(ctx context.Context, terminalID uint64, terminalKey string, userID uint64, pin string) (*domain.AuthToken, error)
+	// UnlockUser lifts the login and PIN lockouts of a user
+	UnlockUser(ctx context.Context, id uint64) error
+	// Refresh exchanges a refresh token for a new access token and a new refresh token
+	Refresh(ctx context.Context, refreshToken string) (*domain.AuthToken, error)
+	// Logout revokes the access token and, if given, the session of the refresh token
+	Logout(ctx context.Context, payload *domain.TokenPayload, refreshToken string) error
+	// LogoutAll revokes every token issued to the user so far
+	LogoutAll(ctx context.Context, payload *domain.TokenPayload) error
+	// ChangePassword changes the password of the user after checking the current one, revoking every token issued to the user so far
+	ChangePassword(ctx context.Context, userID uint64, currentPassword, newPassword string) error
+	// RequestPasswordReset sends a password reset token to the user with the email, if there is one
+	RequestPasswordReset(ctx context.Context, email string) error
+	// ResetPassword sets a new password with a password reset token, revoking every token issued to the user so far
+	ResetPassword(ctx context.Context, token, password string) error
+	// VerifyToken verifies the access token, checks that it has not been revoked and,
+	// for a token bound to a terminal, that the request comes from the terminal, and returns the payload
+	VerifyToken(ctx context.Context, token, terminalKey string) (*domain.TokenPayload, error)
 }
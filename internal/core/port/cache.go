@@ -11,8 +11,17 @@ import (
 type CacheRepository interface {
 	// Set stores the value in the cache
 	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
-	// Get retrieves the value from the cache
+	// SetIfNotExists stores the value in the cache only if the key does not exist, reporting whether it was stored
+	SetIfNotExists(ctx context.Context, key string, value []byte, ttl time.Duration) (bool, error)
+	// CompareAndSwap stores the value in the cache only if the key still holds the old value, reporting whether it was stored
+	CompareAndSwap(ctx context.Context, key string, old, value []byte, ttl time.Duration) (bool, error)
+	// Get retrieves the value from the cache, returning domain.ErrDataNotFound if the key does not exist
 	Get(ctx context.Context, key string) ([]byte, error)
+	// GetAndDelete retrieves the value and removes it from the cache in a single step, so only one caller gets it,
+	// returning domain.ErrDataNotFound if the key does not exist
+	GetAndDelete(ctx context.Context, key string) ([]byte, error)
+	// Increment adds one to the counter and returns its new value, the ttl being set when the counter is created
+	Increment(ctx context.Context, key string, ttl time.Duration) (int64, error)
 	// Delete removes the value from the cache
 	Delete(ctx context.Context, key string) error
 	// DeleteByPrefix removes the value from the cache with the given prefix
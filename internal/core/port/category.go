@@ -12,14 +12,23 @@ import (
 type CategoryRepository interface {
 	// CreateCategory inserts a new category into the database
 	CreateCategory(ctx context.Context, category *domain.Category) (*domain.Category, error)
-	// GetCategoryByID selects a category by id
+	// GetCategoryByID selects a category that is not soft deleted by id
 	GetCategoryByID(ctx context.Context, id uint64) (*domain.Category, error)
-	// ListCategories selects a list of categories with pagination
-	ListCategories(ctx context.Context, skip, limit uint64) ([]domain.Category, error)
-	// UpdateCategory updates a category
+	// GetCategoriesByIDs selects the categories with the given ids, soft deleted or not, skipping the ids that do not exist
+	GetCategoriesByIDs(ctx context.Context, ids []uint64) ([]domain.Category, error)
+	// ListCategories selects a sorted page of root categories with their total count, each with its tree of descendants
+	ListCategories(ctx context.Context, includeDeleted bool, params *domain.ListParams) ([]domain.Category, uint64, error)
+	// ListCategoryAncestors selects a category followed by its ancestors up to the root category
+	ListCategoryAncestors(ctx context.Context, id uint64) ([]domain.Category, error)
+	// LockCategoryTree locks the category tree against other moves until the transaction ends
+	LockCategoryTree(ctx context.Context) error
+	// UpdateCategory updates the name of a category unless it is empty, and its parent, which is cleared when it is 0,
+	// as long as it is at the version of the given category
 	UpdateCategory(ctx context.Context, category *domain.Category) (*domain.Category, error)
-	// DeleteCategory deletes a category
-	DeleteCategory(ctx context.Context, id uint64) error
+	// DeleteCategory soft deletes a category, as long as it is at the given version
+	DeleteCategory(ctx context.Context, id, version uint64) error
+	// RestoreCategory restores a soft deleted category
+	RestoreCategory(ctx context.Context, id uint64) (*domain.Category, error)
 }
 
 // CategoryService is an interface for interacting with category-related business logic
@@ -28,10 +37,13 @@ type CategoryService interface {
 	CreateCategory(ctx context.Context, category *domain.Category) (*domain.Category, error)
 	// GetCategory returns a category by id
 	GetCategory(ctx context.Context, id uint64) (*domain.Category, error)
-	// ListCategories returns a list of categories with pagination
-	ListCategories(ctx context.Context, skip, limit uint64) ([]domain.Category, error)
-	// UpdateCategory updates a category
-	UpdateCategory(ctx context.Context, category *domain.Category) (*domain.Category, error)
-	// DeleteCategory deletes a category
-	DeleteCategory(ctx context.Context, id uint64) error
+	// ListCategories returns a sorted page of category trees with their total count
+	ListCategories(ctx context.Context, includeDeleted bool, params *domain.ListParams) ([]domain.Category, uint64, error)
+	// UpdateCategory updates a category, as long as it is at the version of the given category. The category is moved
+	// under its ParentID, or to the top level when it is 0, only when reparent is set
+	UpdateCategory(ctx context.Context, category *domain.Category, reparent bool) (*domain.Category, error)
+	// DeleteCategory soft deletes a category, as long as it is at the given version
+	DeleteCategory(ctx context.Context, id, version uint64) error
+	// RestoreCategory restores a soft deleted category
+	RestoreCategory(ctx context.Context, id uint64) (*domain.Category, error)
 }
@@ -0,0 +1,34 @@
+package port
+
+import (
+	"context"
+
+	"github.com/nikhil-shrestha/go-pos/internal/core/domain"
+)
+
+//go:generate mockgen -source=idempotency.go -destination=mock/idempotency.go -package=mock
+
+// IdempotencyRepository is an interface for interacting with idempotent request-related data
+type IdempotencyRepository interface {
+	// CreateIdempotentRequest inserts a new running request into the database,
+	// returning domain.ErrConflictingData if an unexpired request has the same key
+	CreateIdempotentRequest(ctx context.Context, request *domain.IdempotentRequest) error
+	// GetIdempotentRequest selects an unexpired request by its key
+	GetIdempotentRequest(ctx context.Context, key string) (*domain.IdempotentRequest, error)
+	// CompleteIdempotentRequest stores the response of the request, inserting the request if it is not there
+	CompleteIdempotentRequest(ctx context.Context, request *domain.IdempotentRequest) error
+	// DeleteIdempotentRequest deletes a request by its key
+	DeleteIdempotentRequest(ctx context.Context, key string) error
+	// DeleteExpiredIdempotentRequests deletes the requests whose idempotency window has passed
+	DeleteExpiredIdempotentRequests(ctx context.Context) error
+}
+
+// IdempotencyService is an interface for interacting with idempotent request-related business logic
+type IdempotencyService interface {
+	// BeginRequest claims the key for the request, or returns the completed request with the same key to replay its response
+	BeginRequest(ctx context.Context, key string, request []byte) (*domain.IdempotentRequest, error)
+	// CompleteRequest keeps the response of the claimed request for its retries
+	CompleteRequest(ctx context.Context, request *domain.IdempotentRequest) error
+	// ReleaseRequest frees the key of the claimed request, so that a retry runs the request again
+	ReleaseRequest(ctx context.Context, request *domain.IdempotentRequest) error
+}
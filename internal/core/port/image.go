@@ -0,0 +1,17 @@
+package port
+
+import (
+	"context"
+
+	"github.com/nikhil-shrestha/go-pos/internal/core/domain"
+)
+
+//go:generate mockgen -source=image.go -destination=mock/image.go -package=mock
+
+// ImageService is an interface for interacting with image-related business logic
+type ImageService interface {
+	// UploadProductImage stores a product image with its thumbnail
+	UploadProductImage(ctx context.Context, id uint64, image *domain.File) (*domain.Product, error)
+	// UploadPaymentLogo stores a payment logo with its thumbnail
+	UploadPaymentLogo(ctx context.Context, id uint64, image *domain.File) (*domain.Payment, error)
+}
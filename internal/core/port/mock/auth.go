@@ -41,12 +41,13 @@ func (m *MockTokenService) EXPECT() *MockTokenServiceMockRecorder {
 }
 
 // CreateToken mocks base method.
-func (m *MockTokenService) CreateToken(user *domain.User) (string, error) {
+func (m *MockTokenService) CreateToken(user *domain.User) (string, *domain.TokenPayload, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "CreateToken", user)
 	ret0, _ := ret[0].(string)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret1, _ := ret[1].(*domain.TokenPayload)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
 // CreateToken indicates an expected call of CreateToken.
@@ -70,6 +71,43 @@ func (mr *MockTokenServiceMockRecorder) VerifyToken(token any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyToken", reflect.TypeOf((*MockTokenService)(nil).VerifyToken), token)
 }
 
+// MockTokenKeyService is a mock of TokenKeyService interface.
+type MockTokenKeyService struct {
+	ctrl     *gomock.Controller
+	recorder *MockTokenKeyServiceMockRecorder
+}
+
+// MockTokenKeyServiceMockRecorder is the mock recorder for MockTokenKeyService.
+type MockTokenKeyServiceMockRecorder struct {
+	mock *MockTokenKeyService
+}
+
+// NewMockTokenKeyService creates a new mock instance.
+func NewMockTokenKeyService(ctrl *gomock.Controller) *MockTokenKeyService {
+	mock := &MockTokenKeyService{ctrl: ctrl}
+	mock.recorder = &MockTokenKeyServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTokenKeyService) EXPECT() *MockTokenKeyServiceMockRecorder {
+	return m.recorder
+}
+
+// ListPublicKeys mocks base method.
+func (m *MockTokenKeyService) ListPublicKeys() []domain.TokenKey {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPublicKeys")
+	ret0, _ := ret[0].([]domain.TokenKey)
+	return ret0
+}
+
+// ListPublicKeys indicates an expected call of ListPublicKeys.
+func (mr *MockTokenKeyServiceMockRecorder) ListPublicKeys() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPublicKeys", reflect.TypeOf((*MockTokenKeyService)(nil).ListPublicKeys))
+}
+
 // MockAuthService is a mock of AuthService interface.
 type MockAuthService struct {
 	ctrl     *gomock.Controller
@@ -93,17 +131,161 @@ func (m *MockAuthService) EXPECT() *MockAuthServiceMockRecorder {
 	return m.recorder
 }
 
+// ChangePassword mocks base method.
+func (m *MockAuthService) ChangePassword(ctx context.Context, userID uint64, currentPassword, newPassword string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangePassword", ctx, userID, currentPassword, newPassword)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ChangePassword indicates an expected call of ChangePassword.
+func (mr *MockAuthServiceMockRecorder) ChangePassword(ctx, userID, currentPassword, newPassword any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangePassword", reflect.TypeOf((*MockAuthService)(nil).ChangePassword), ctx, userID, currentPassword, newPassword)
+}
+
 // Login mocks base method.
-func (m *MockAuthService) Login(ctx context.Context, email, password string) (string, error) {
+func (m *MockAuthService) Login(ctx context.Context, email, password, clientIP string) (*domain.AuthToken, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Login", ctx, email, password)
-	ret0, _ := ret[0].(string)
+	ret := m.ctrl.Call(m, "Login", ctx, email, password, clientIP)
+	ret0, _ := ret[0].(*domain.AuthToken)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Login indicates an expected call of Login.
-func (mr *MockAuthServiceMockRecorder) Login(ctx, email, password any) *gomock.Call {
+func (mr *MockAuthServiceMockRecorder) Login(ctx, email, password, clientIP any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Login", reflect.TypeOf((*MockAuthService)(nil).Login), ctx, email, password, clientIP)
+}
+
+// Logout mocks base method.
+func (m *MockAuthService) Logout(ctx context.Context, payload *domain.TokenPayload, refreshToken string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Logout", ctx, payload, refreshToken)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Logout indicates an expected call of Logout.
+func (mr *MockAuthServiceMockRecorder) Logout(ctx, payload, refreshToken any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Logout", reflect.TypeOf((*MockAuthService)(nil).Logout), ctx, payload, refreshToken)
+}
+
+// LogoutAll mocks base method.
+func (m *MockAuthService) LogoutAll(ctx context.Context, payload *domain.TokenPayload) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LogoutAll", ctx, payload)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LogoutAll indicates an expected call of LogoutAll.
+func (mr *MockAuthServiceMockRecorder) LogoutAll(ctx, payload any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LogoutAll", reflect.TypeOf((*MockAuthService)(nil).LogoutAll), ctx, payload)
+}
+
+// PinLogin mocks base method.
+func (m *MockAuthService) PinLogin(ctx context.Context, terminalID uint64, terminalKey string, userID uint64, pin string) (*domain.AuthToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PinLogin", ctx, terminalID, terminalKey, userID, pin)
+	ret0, _ := ret[0].(*domain.AuthToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PinLogin indicates an expected call of PinLogin.
+func (mr *MockAuthServiceMockRecorder) PinLogin(ctx, terminalID, terminalKey, userID, pin any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PinLogin", reflect.TypeOf((*MockAuthService)(nil).PinLogin), ctx, terminalID, terminalKey, userID, pin)
+}
+
+// Refresh mocks base method.
+func (m *MockAuthService) Refresh(ctx context.Context, refreshToken string) (*domain.AuthToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Refresh", ctx, refreshToken)
+	ret0, _ := ret[0].(*domain.AuthToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Refresh indicates an expected call of Refresh.
+func (mr *MockAuthServiceMockRecorder) Refresh(ctx, refreshToken any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Refresh", reflect.TypeOf((*MockAuthService)(nil).Refresh), ctx, refreshToken)
+}
+
+// RequestPasswordReset mocks base method.
+func (m *MockAuthService) RequestPasswordReset(ctx context.Context, email string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestPasswordReset", ctx, email)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RequestPasswordReset indicates an expected call of RequestPasswordReset.
+func (mr *MockAuthServiceMockRecorder) RequestPasswordReset(ctx, email any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestPasswordReset", reflect.TypeOf((*MockAuthService)(nil).RequestPasswordReset), ctx, email)
+}
+
+// ResetPassword mocks base method.
+func (m *MockAuthService) ResetPassword(ctx context.Context, token, password string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetPassword", ctx, token, password)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetPassword indicates an expected call of ResetPassword.
+func (mr *MockAuthServiceMockRecorder) ResetPassword(ctx, token, password any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetPassword", reflect.TypeOf((*MockAuthService)(nil).ResetPassword), ctx, token, password)
+}
+
+// UnlockUser mocks base method.
+func (m *MockAuthService) UnlockUser(ctx context.Context, id uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnlockUser", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnlockUser indicates an expected call of UnlockUser.
+func (mr *MockAuthServiceMockRecorder) UnlockUser(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnlockUser", reflect.TypeOf((*MockAuthService)(nil).UnlockUser), ctx, id)
+}
+
+// VerifyChallenge mocks base method.
+func (m *MockAuthService) VerifyChallenge(ctx context.Context, challengeID, code string) (*domain.AuthToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyChallenge", ctx, challengeID, code)
+	ret0, _ := ret[0].(*domain.AuthToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyChallenge indicates an expected call of VerifyChallenge.
+func (mr *MockAuthServiceMockRecorder) VerifyChallenge(ctx, challengeID, code any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyChallenge", reflect.TypeOf((*MockAuthService)(nil).VerifyChallenge), ctx, challengeID, code)
+}
+
+// VerifyToken mocks base method.
+func (m *MockAuthService) VerifyToken(ctx context.Context, token, terminalKey string) (*domain.TokenPayload, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyToken", ctx, token, terminalKey)
+	ret0, _ := ret[0].(*domain.TokenPayload)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyToken indicates an expected call of VerifyToken.
+func (mr *MockAuthServiceMockRecorder) VerifyToken(ctx, token, terminalKey any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Login", reflect.TypeOf((*MockAuthService)(nil).Login), ctx, email, password)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyToken", reflect.TypeOf((*MockAuthService)(nil).VerifyToken), ctx, token, terminalKey)
 }
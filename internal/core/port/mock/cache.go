@@ -54,6 +54,21 @@ func (mr *MockCacheRepositoryMockRecorder) Close() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockCacheRepository)(nil).Close))
 }
 
+// CompareAndSwap mocks base method.
+func (m *MockCacheRepository) CompareAndSwap(ctx context.Context, key string, old, value []byte, ttl time.Duration) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompareAndSwap", ctx, key, old, value, ttl)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CompareAndSwap indicates an expected call of CompareAndSwap.
+func (mr *MockCacheRepositoryMockRecorder) CompareAndSwap(ctx, key, old, value, ttl any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompareAndSwap", reflect.TypeOf((*MockCacheRepository)(nil).CompareAndSwap), ctx, key, old, value, ttl)
+}
+
 // Delete mocks base method.
 func (m *MockCacheRepository) Delete(ctx context.Context, key string) error {
 	m.ctrl.T.Helper()
@@ -97,6 +112,36 @@ func (mr *MockCacheRepositoryMockRecorder) Get(ctx, key any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockCacheRepository)(nil).Get), ctx, key)
 }
 
+// GetAndDelete mocks base method.
+func (m *MockCacheRepository) GetAndDelete(ctx context.Context, key string) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAndDelete", ctx, key)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAndDelete indicates an expected call of GetAndDelete.
+func (mr *MockCacheRepositoryMockRecorder) GetAndDelete(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAndDelete", reflect.TypeOf((*MockCacheRepository)(nil).GetAndDelete), ctx, key)
+}
+
+// Increment mocks base method.
+func (m *MockCacheRepository) Increment(ctx context.Context, key string, ttl time.Duration) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Increment", ctx, key, ttl)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Increment indicates an expected call of Increment.
+func (mr *MockCacheRepositoryMockRecorder) Increment(ctx, key, ttl any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Increment", reflect.TypeOf((*MockCacheRepository)(nil).Increment), ctx, key, ttl)
+}
+
 // Set mocks base method.
 func (m *MockCacheRepository) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
 	m.ctrl.T.Helper()
@@ -110,3 +155,18 @@ func (mr *MockCacheRepositoryMockRecorder) Set(ctx, key, value, ttl any) *gomock
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockCacheRepository)(nil).Set), ctx, key, value, ttl)
 }
+
+// SetIfNotExists mocks base method.
+func (m *MockCacheRepository) SetIfNotExists(ctx context.Context, key string, value []byte, ttl time.Duration) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetIfNotExists", ctx, key, value, ttl)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetIfNotExists indicates an expected call of SetIfNotExists.
+func (mr *MockCacheRepositoryMockRecorder) SetIfNotExists(ctx, key, value, ttl any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetIfNotExists", reflect.TypeOf((*MockCacheRepository)(nil).SetIfNotExists), ctx, key, value, ttl)
+}
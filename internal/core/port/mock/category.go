@@ -56,17 +56,32 @@ func (mr *MockCategoryRepositoryMockRecorder) CreateCategory(ctx, category any)
 }
 
 // DeleteCategory mocks base method.
-func (m *MockCategoryRepository) DeleteCategory(ctx context.Context, id uint64) error {
+func (m *MockCategoryRepository) DeleteCategory(ctx context.Context, id, version uint64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteCategory", ctx, id)
+	ret := m.ctrl.Call(m, "DeleteCategory", ctx, id, version)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteCategory indicates an expected call of DeleteCategory.
-func (mr *MockCategoryRepositoryMockRecorder) DeleteCategory(ctx, id any) *gomock.Call {
+func (mr *MockCategoryRepositoryMockRecorder) DeleteCategory(ctx, id, version any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCategory", reflect.TypeOf((*MockCategoryRepository)(nil).DeleteCategory), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCategory", reflect.TypeOf((*MockCategoryRepository)(nil).DeleteCategory), ctx, id, version)
+}
+
+// GetCategoriesByIDs mocks base method.
+func (m *MockCategoryRepository) GetCategoriesByIDs(ctx context.Context, ids []uint64) ([]domain.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCategoriesByIDs", ctx, ids)
+	ret0, _ := ret[0].([]domain.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCategoriesByIDs indicates an expected call of GetCategoriesByIDs.
+func (mr *MockCategoryRepositoryMockRecorder) GetCategoriesByIDs(ctx, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategoriesByIDs", reflect.TypeOf((*MockCategoryRepository)(nil).GetCategoriesByIDs), ctx, ids)
 }
 
 // GetCategoryByID mocks base method.
@@ -85,18 +100,63 @@ func (mr *MockCategoryRepositoryMockRecorder) GetCategoryByID(ctx, id any) *gomo
 }
 
 // ListCategories mocks base method.
-func (m *MockCategoryRepository) ListCategories(ctx context.Context, skip, limit uint64) ([]domain.Category, error) {
+func (m *MockCategoryRepository) ListCategories(ctx context.Context, includeDeleted bool, params *domain.ListParams) ([]domain.Category, uint64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListCategories", ctx, skip, limit)
+	ret := m.ctrl.Call(m, "ListCategories", ctx, includeDeleted, params)
+	ret0, _ := ret[0].([]domain.Category)
+	ret1, _ := ret[1].(uint64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListCategories indicates an expected call of ListCategories.
+func (mr *MockCategoryRepositoryMockRecorder) ListCategories(ctx, includeDeleted, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCategories", reflect.TypeOf((*MockCategoryRepository)(nil).ListCategories), ctx, includeDeleted, params)
+}
+
+// ListCategoryAncestors mocks base method.
+func (m *MockCategoryRepository) ListCategoryAncestors(ctx context.Context, id uint64) ([]domain.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCategoryAncestors", ctx, id)
 	ret0, _ := ret[0].([]domain.Category)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListCategories indicates an expected call of ListCategories.
-func (mr *MockCategoryRepositoryMockRecorder) ListCategories(ctx, skip, limit any) *gomock.Call {
+// ListCategoryAncestors indicates an expected call of ListCategoryAncestors.
+func (mr *MockCategoryRepositoryMockRecorder) ListCategoryAncestors(ctx, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCategories", reflect.TypeOf((*MockCategoryRepository)(nil).ListCategories), ctx, skip, limit)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCategoryAncestors", reflect.TypeOf((*MockCategoryRepository)(nil).ListCategoryAncestors), ctx, id)
+}
+
+// LockCategoryTree mocks base method.
+func (m *MockCategoryRepository) LockCategoryTree(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockCategoryTree", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LockCategoryTree indicates an expected call of LockCategoryTree.
+func (mr *MockCategoryRepositoryMockRecorder) LockCategoryTree(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockCategoryTree", reflect.TypeOf((*MockCategoryRepository)(nil).LockCategoryTree), ctx)
+}
+
+// RestoreCategory mocks base method.
+func (m *MockCategoryRepository) RestoreCategory(ctx context.Context, id uint64) (*domain.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreCategory", ctx, id)
+	ret0, _ := ret[0].(*domain.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreCategory indicates an expected call of RestoreCategory.
+func (mr *MockCategoryRepositoryMockRecorder) RestoreCategory(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreCategory", reflect.TypeOf((*MockCategoryRepository)(nil).RestoreCategory), ctx, id)
 }
 
 // UpdateCategory mocks base method.
@@ -153,17 +213,17 @@ func (mr *MockCategoryServiceMockRecorder) CreateCategory(ctx, category any) *go
 }
 
 // DeleteCategory mocks base method.
-func (m *MockCategoryService) DeleteCategory(ctx context.Context, id uint64) error {
+func (m *MockCategoryService) DeleteCategory(ctx context.Context, id, version uint64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteCategory", ctx, id)
+	ret := m.ctrl.Call(m, "DeleteCategory", ctx, id, version)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteCategory indicates an expected call of DeleteCategory.
-func (mr *MockCategoryServiceMockRecorder) DeleteCategory(ctx, id any) *gomock.Call {
+func (mr *MockCategoryServiceMockRecorder) DeleteCategory(ctx, id, version any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCategory", reflect.TypeOf((*MockCategoryService)(nil).DeleteCategory), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCategory", reflect.TypeOf((*MockCategoryService)(nil).DeleteCategory), ctx, id, version)
 }
 
 // GetCategory mocks base method.
@@ -182,31 +242,47 @@ func (mr *MockCategoryServiceMockRecorder) GetCategory(ctx, id any) *gomock.Call
 }
 
 // ListCategories mocks base method.
-func (m *MockCategoryService) ListCategories(ctx context.Context, skip, limit uint64) ([]domain.Category, error) {
+func (m *MockCategoryService) ListCategories(ctx context.Context, includeDeleted bool, params *domain.ListParams) ([]domain.Category, uint64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListCategories", ctx, skip, limit)
+	ret := m.ctrl.Call(m, "ListCategories", ctx, includeDeleted, params)
 	ret0, _ := ret[0].([]domain.Category)
+	ret1, _ := ret[1].(uint64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListCategories indicates an expected call of ListCategories.
+func (mr *MockCategoryServiceMockRecorder) ListCategories(ctx, includeDeleted, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCategories", reflect.TypeOf((*MockCategoryService)(nil).ListCategories), ctx, includeDeleted, params)
+}
+
+// RestoreCategory mocks base method.
+func (m *MockCategoryService) RestoreCategory(ctx context.Context, id uint64) (*domain.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreCategory", ctx, id)
+	ret0, _ := ret[0].(*domain.Category)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListCategories indicates an expected call of ListCategories.
-func (mr *MockCategoryServiceMockRecorder) ListCategories(ctx, skip, limit any) *gomock.Call {
+// RestoreCategory indicates an expected call of RestoreCategory.
+func (mr *MockCategoryServiceMockRecorder) RestoreCategory(ctx, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCategories", reflect.TypeOf((*MockCategoryService)(nil).ListCategories), ctx, skip, limit)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreCategory", reflect.TypeOf((*MockCategoryService)(nil).RestoreCategory), ctx, id)
 }
 
 // UpdateCategory mocks base method.
-func (m *MockCategoryService) UpdateCategory(ctx context.Context, category *domain.Category) (*domain.Category, error) {
+func (m *MockCategoryService) UpdateCategory(ctx context.Context, category *domain.Category, reparent bool) (*domain.Category, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateCategory", ctx, category)
+	ret := m.ctrl.Call(m, "UpdateCategory", ctx, category, reparent)
 	ret0, _ := ret[0].(*domain.Category)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UpdateCategory indicates an expected call of UpdateCategory.
-func (mr *MockCategoryServiceMockRecorder) UpdateCategory(ctx, category any) *gomock.Call {
+func (mr *MockCategoryServiceMockRecorder) UpdateCategory(ctx, category, reparent any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCategory", reflect.TypeOf((*MockCategoryService)(nil).UpdateCategory), ctx, category)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCategory", reflect.TypeOf((*MockCategoryService)(nil).UpdateCategory), ctx, category, reparent)
 }
@@ -71,18 +71,64 @@ func (mr *MockOrderRepositoryMockRecorder) GetOrderByID(ctx, id any) *gomock.Cal
 }
 
 // ListOrders mocks base method.
-func (m *MockOrderRepository) ListOrders(ctx context.Context, skip, limit uint64) ([]domain.Order, error) {
+func (m *MockOrderRepository) ListOrders(ctx context.Context, filter *domain.OrderFilter, params *domain.ListParams) ([]domain.Order, uint64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListOrders", ctx, skip, limit)
+	ret := m.ctrl.Call(m, "ListOrders", ctx, filter, params)
 	ret0, _ := ret[0].([]domain.Order)
+	ret1, _ := ret[1].(uint64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListOrders indicates an expected call of ListOrders.
+func (mr *MockOrderRepositoryMockRecorder) ListOrders(ctx, filter, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOrders", reflect.TypeOf((*MockOrderRepository)(nil).ListOrders), ctx, filter, params)
+}
+
+// ListOrdersByCursor mocks base method.
+func (m *MockOrderRepository) ListOrdersByCursor(ctx context.Context, filter *domain.OrderFilter, params *domain.CursorParams) ([]domain.Order, *domain.Cursor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOrdersByCursor", ctx, filter, params)
+	ret0, _ := ret[0].([]domain.Order)
+	ret1, _ := ret[1].(*domain.Cursor)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListOrdersByCursor indicates an expected call of ListOrdersByCursor.
+func (mr *MockOrderRepositoryMockRecorder) ListOrdersByCursor(ctx, filter, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOrdersByCursor", reflect.TypeOf((*MockOrderRepository)(nil).ListOrdersByCursor), ctx, filter, params)
+}
+
+// StreamOrders mocks base method.
+func (m *MockOrderRepository) StreamOrders(ctx context.Context, filter *domain.OrderFilter, fn func(*domain.Order) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StreamOrders", ctx, filter, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StreamOrders indicates an expected call of StreamOrders.
+func (mr *MockOrderRepositoryMockRecorder) StreamOrders(ctx, filter, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamOrders", reflect.TypeOf((*MockOrderRepository)(nil).StreamOrders), ctx, filter, fn)
+}
+
+// VoidOrder mocks base method.
+func (m *MockOrderRepository) VoidOrder(ctx context.Context, id uint64) (*domain.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VoidOrder", ctx, id)
+	ret0, _ := ret[0].(*domain.Order)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListOrders indicates an expected call of ListOrders.
-func (mr *MockOrderRepositoryMockRecorder) ListOrders(ctx, skip, limit any) *gomock.Call {
+// VoidOrder indicates an expected call of VoidOrder.
+func (mr *MockOrderRepositoryMockRecorder) VoidOrder(ctx, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOrders", reflect.TypeOf((*MockOrderRepository)(nil).ListOrders), ctx, skip, limit)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VoidOrder", reflect.TypeOf((*MockOrderRepository)(nil).VoidOrder), ctx, id)
 }
 
 // MockOrderService is a mock of OrderService interface.
@@ -123,6 +169,20 @@ func (mr *MockOrderServiceMockRecorder) CreateOrder(ctx, order any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrder", reflect.TypeOf((*MockOrderService)(nil).CreateOrder), ctx, order)
 }
 
+// ExportOrders mocks base method.
+func (m *MockOrderService) ExportOrders(ctx context.Context, filter *domain.OrderFilter, fn func(*domain.Order) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportOrders", ctx, filter, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportOrders indicates an expected call of ExportOrders.
+func (mr *MockOrderServiceMockRecorder) ExportOrders(ctx, filter, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportOrders", reflect.TypeOf((*MockOrderService)(nil).ExportOrders), ctx, filter, fn)
+}
+
 // GetOrder mocks base method.
 func (m *MockOrderService) GetOrder(ctx context.Context, id uint64) (*domain.Order, error) {
 	m.ctrl.T.Helper()
@@ -139,16 +199,62 @@ func (mr *MockOrderServiceMockRecorder) GetOrder(ctx, id any) *gomock.Call {
 }
 
 // ListOrders mocks base method.
-func (m *MockOrderService) ListOrders(ctx context.Context, skip, limit uint64) ([]domain.Order, error) {
+func (m *MockOrderService) ListOrders(ctx context.Context, filter *domain.OrderFilter, params *domain.ListParams) ([]domain.Order, uint64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListOrders", ctx, skip, limit)
+	ret := m.ctrl.Call(m, "ListOrders", ctx, filter, params)
 	ret0, _ := ret[0].([]domain.Order)
+	ret1, _ := ret[1].(uint64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListOrders indicates an expected call of ListOrders.
+func (mr *MockOrderServiceMockRecorder) ListOrders(ctx, filter, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOrders", reflect.TypeOf((*MockOrderService)(nil).ListOrders), ctx, filter, params)
+}
+
+// ListOrdersByCursor mocks base method.
+func (m *MockOrderService) ListOrdersByCursor(ctx context.Context, filter *domain.OrderFilter, params *domain.CursorParams) ([]domain.Order, *domain.Cursor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOrdersByCursor", ctx, filter, params)
+	ret0, _ := ret[0].([]domain.Order)
+	ret1, _ := ret[1].(*domain.Cursor)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListOrdersByCursor indicates an expected call of ListOrdersByCursor.
+func (mr *MockOrderServiceMockRecorder) ListOrdersByCursor(ctx, filter, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOrdersByCursor", reflect.TypeOf((*MockOrderService)(nil).ListOrdersByCursor), ctx, filter, params)
+}
+
+// OpenDrawer mocks base method.
+func (m *MockOrderService) OpenDrawer(ctx context.Context, userID, approverID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OpenDrawer", ctx, userID, approverID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// OpenDrawer indicates an expected call of OpenDrawer.
+func (mr *MockOrderServiceMockRecorder) OpenDrawer(ctx, userID, approverID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OpenDrawer", reflect.TypeOf((*MockOrderService)(nil).OpenDrawer), ctx, userID, approverID)
+}
+
+// VoidOrder mocks base method.
+func (m *MockOrderService) VoidOrder(ctx context.Context, id, userID, approverID uint64) (*domain.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VoidOrder", ctx, id, userID, approverID)
+	ret0, _ := ret[0].(*domain.Order)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListOrders indicates an expected call of ListOrders.
-func (mr *MockOrderServiceMockRecorder) ListOrders(ctx, skip, limit any) *gomock.Call {
+// VoidOrder indicates an expected call of VoidOrder.
+func (mr *MockOrderServiceMockRecorder) VoidOrder(ctx, id, userID, approverID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOrders", reflect.TypeOf((*MockOrderService)(nil).ListOrders), ctx, skip, limit)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VoidOrder", reflect.TypeOf((*MockOrderService)(nil).VoidOrder), ctx, id, userID, approverID)
 }
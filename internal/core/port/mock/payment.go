@@ -56,17 +56,17 @@ func (mr *MockPaymentRepositoryMockRecorder) CreatePayment(ctx, payment any) *go
 }
 
 // DeletePayment mocks base method.
-func (m *MockPaymentRepository) DeletePayment(ctx context.Context, id uint64) error {
+func (m *MockPaymentRepository) DeletePayment(ctx context.Context, id, version uint64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeletePayment", ctx, id)
+	ret := m.ctrl.Call(m, "DeletePayment", ctx, id, version)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeletePayment indicates an expected call of DeletePayment.
-func (mr *MockPaymentRepositoryMockRecorder) DeletePayment(ctx, id any) *gomock.Call {
+func (mr *MockPaymentRepositoryMockRecorder) DeletePayment(ctx, id, version any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePayment", reflect.TypeOf((*MockPaymentRepository)(nil).DeletePayment), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePayment", reflect.TypeOf((*MockPaymentRepository)(nil).DeletePayment), ctx, id, version)
 }
 
 // GetPaymentByID mocks base method.
@@ -84,19 +84,50 @@ func (mr *MockPaymentRepositoryMockRecorder) GetPaymentByID(ctx, id any) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPaymentByID", reflect.TypeOf((*MockPaymentRepository)(nil).GetPaymentByID), ctx, id)
 }
 
-// ListPayments mocks base method.
-func (m *MockPaymentRepository) ListPayments(ctx context.Context, skip, limit uint64) ([]domain.Payment, error) {
+// GetPaymentsByIDs mocks base method.
+func (m *MockPaymentRepository) GetPaymentsByIDs(ctx context.Context, ids []uint64) ([]domain.Payment, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListPayments", ctx, skip, limit)
+	ret := m.ctrl.Call(m, "GetPaymentsByIDs", ctx, ids)
 	ret0, _ := ret[0].([]domain.Payment)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
+// GetPaymentsByIDs indicates an expected call of GetPaymentsByIDs.
+func (mr *MockPaymentRepositoryMockRecorder) GetPaymentsByIDs(ctx, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPaymentsByIDs", reflect.TypeOf((*MockPaymentRepository)(nil).GetPaymentsByIDs), ctx, ids)
+}
+
+// ListPayments mocks base method.
+func (m *MockPaymentRepository) ListPayments(ctx context.Context, includeDeleted bool, params *domain.ListParams) ([]domain.Payment, uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPayments", ctx, includeDeleted, params)
+	ret0, _ := ret[0].([]domain.Payment)
+	ret1, _ := ret[1].(uint64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
 // ListPayments indicates an expected call of ListPayments.
-func (mr *MockPaymentRepositoryMockRecorder) ListPayments(ctx, skip, limit any) *gomock.Call {
+func (mr *MockPaymentRepositoryMockRecorder) ListPayments(ctx, includeDeleted, params any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPayments", reflect.TypeOf((*MockPaymentRepository)(nil).ListPayments), ctx, skip, limit)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPayments", reflect.TypeOf((*MockPaymentRepository)(nil).ListPayments), ctx, includeDeleted, params)
+}
+
+// RestorePayment mocks base method.
+func (m *MockPaymentRepository) RestorePayment(ctx context.Context, id uint64) (*domain.Payment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestorePayment", ctx, id)
+	ret0, _ := ret[0].(*domain.Payment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestorePayment indicates an expected call of RestorePayment.
+func (mr *MockPaymentRepositoryMockRecorder) RestorePayment(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestorePayment", reflect.TypeOf((*MockPaymentRepository)(nil).RestorePayment), ctx, id)
 }
 
 // UpdatePayment mocks base method.
@@ -153,17 +184,17 @@ func (mr *MockPaymentServiceMockRecorder) CreatePayment(ctx, payment any) *gomoc
 }
 
 // DeletePayment mocks base method.
-func (m *MockPaymentService) DeletePayment(ctx context.Context, id uint64) error {
+func (m *MockPaymentService) DeletePayment(ctx context.Context, id, version uint64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeletePayment", ctx, id)
+	ret := m.ctrl.Call(m, "DeletePayment", ctx, id, version)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeletePayment indicates an expected call of DeletePayment.
-func (mr *MockPaymentServiceMockRecorder) DeletePayment(ctx, id any) *gomock.Call {
+func (mr *MockPaymentServiceMockRecorder) DeletePayment(ctx, id, version any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePayment", reflect.TypeOf((*MockPaymentService)(nil).DeletePayment), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePayment", reflect.TypeOf((*MockPaymentService)(nil).DeletePayment), ctx, id, version)
 }
 
 // GetPayment mocks base method.
@@ -182,18 +213,34 @@ func (mr *MockPaymentServiceMockRecorder) GetPayment(ctx, id any) *gomock.Call {
 }
 
 // ListPayments mocks base method.
-func (m *MockPaymentService) ListPayments(ctx context.Context, skip, limit uint64) ([]domain.Payment, error) {
+func (m *MockPaymentService) ListPayments(ctx context.Context, includeDeleted bool, params *domain.ListParams) ([]domain.Payment, uint64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListPayments", ctx, skip, limit)
+	ret := m.ctrl.Call(m, "ListPayments", ctx, includeDeleted, params)
 	ret0, _ := ret[0].([]domain.Payment)
+	ret1, _ := ret[1].(uint64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListPayments indicates an expected call of ListPayments.
+func (mr *MockPaymentServiceMockRecorder) ListPayments(ctx, includeDeleted, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPayments", reflect.TypeOf((*MockPaymentService)(nil).ListPayments), ctx, includeDeleted, params)
+}
+
+// RestorePayment mocks base method.
+func (m *MockPaymentService) RestorePayment(ctx context.Context, id uint64) (*domain.Payment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestorePayment", ctx, id)
+	ret0, _ := ret[0].(*domain.Payment)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListPayments indicates an expected call of ListPayments.
-func (mr *MockPaymentServiceMockRecorder) ListPayments(ctx, skip, limit any) *gomock.Call {
+// RestorePayment indicates an expected call of RestorePayment.
+func (mr *MockPaymentServiceMockRecorder) RestorePayment(ctx, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPayments", reflect.TypeOf((*MockPaymentService)(nil).ListPayments), ctx, skip, limit)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestorePayment", reflect.TypeOf((*MockPaymentService)(nil).RestorePayment), ctx, id)
 }
 
 // UpdatePayment mocks base method.
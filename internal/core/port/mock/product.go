@@ -56,17 +56,17 @@ func (mr *MockProductRepositoryMockRecorder) CreateProduct(ctx, product any) *go
 }
 
 // DeleteProduct mocks base method.
-func (m *MockProductRepository) DeleteProduct(ctx context.Context, id uint64) error {
+func (m *MockProductRepository) DeleteProduct(ctx context.Context, id, version uint64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteProduct", ctx, id)
+	ret := m.ctrl.Call(m, "DeleteProduct", ctx, id, version)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteProduct indicates an expected call of DeleteProduct.
-func (mr *MockProductRepositoryMockRecorder) DeleteProduct(ctx, id any) *gomock.Call {
+func (mr *MockProductRepositoryMockRecorder) DeleteProduct(ctx, id, version any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProduct", reflect.TypeOf((*MockProductRepository)(nil).DeleteProduct), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProduct", reflect.TypeOf((*MockProductRepository)(nil).DeleteProduct), ctx, id, version)
 }
 
 // GetProductByID mocks base method.
@@ -84,19 +84,94 @@ func (mr *MockProductRepositoryMockRecorder) GetProductByID(ctx, id any) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductByID", reflect.TypeOf((*MockProductRepository)(nil).GetProductByID), ctx, id)
 }
 
-// ListProducts mocks base method.
-func (m *MockProductRepository) ListProducts(ctx context.Context, search string, categoryId, skip, limit uint64) ([]domain.Product, error) {
+// GetProductsByIDs mocks base method.
+func (m *MockProductRepository) GetProductsByIDs(ctx context.Context, ids []uint64) ([]domain.Product, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListProducts", ctx, search, categoryId, skip, limit)
+	ret := m.ctrl.Call(m, "GetProductsByIDs", ctx, ids)
 	ret0, _ := ret[0].([]domain.Product)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
+// GetProductsByIDs indicates an expected call of GetProductsByIDs.
+func (mr *MockProductRepositoryMockRecorder) GetProductsByIDs(ctx, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductsByIDs", reflect.TypeOf((*MockProductRepository)(nil).GetProductsByIDs), ctx, ids)
+}
+
+// ImportProducts mocks base method.
+func (m *MockProductRepository) ImportProducts(ctx context.Context, productImport *domain.ProductImport) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportProducts", ctx, productImport)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ImportProducts indicates an expected call of ImportProducts.
+func (mr *MockProductRepositoryMockRecorder) ImportProducts(ctx, productImport any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportProducts", reflect.TypeOf((*MockProductRepository)(nil).ImportProducts), ctx, productImport)
+}
+
+// ListProducts mocks base method.
+func (m *MockProductRepository) ListProducts(ctx context.Context, search string, categoryId uint64, includeDeleted bool, params *domain.ListParams) ([]domain.Product, uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListProducts", ctx, search, categoryId, includeDeleted, params)
+	ret0, _ := ret[0].([]domain.Product)
+	ret1, _ := ret[1].(uint64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
 // ListProducts indicates an expected call of ListProducts.
-func (mr *MockProductRepositoryMockRecorder) ListProducts(ctx, search, categoryId, skip, limit any) *gomock.Call {
+func (mr *MockProductRepositoryMockRecorder) ListProducts(ctx, search, categoryId, includeDeleted, params any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProducts", reflect.TypeOf((*MockProductRepository)(nil).ListProducts), ctx, search, categoryId, skip, limit)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProducts", reflect.TypeOf((*MockProductRepository)(nil).ListProducts), ctx, search, categoryId, includeDeleted, params)
+}
+
+// ListProductsByCursor mocks base method.
+func (m *MockProductRepository) ListProductsByCursor(ctx context.Context, search string, categoryId uint64, includeDeleted bool, params *domain.CursorParams) ([]domain.Product, *domain.Cursor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListProductsByCursor", ctx, search, categoryId, includeDeleted, params)
+	ret0, _ := ret[0].([]domain.Product)
+	ret1, _ := ret[1].(*domain.Cursor)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListProductsByCursor indicates an expected call of ListProductsByCursor.
+func (mr *MockProductRepositoryMockRecorder) ListProductsByCursor(ctx, search, categoryId, includeDeleted, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProductsByCursor", reflect.TypeOf((*MockProductRepository)(nil).ListProductsByCursor), ctx, search, categoryId, includeDeleted, params)
+}
+
+// RestoreProduct mocks base method.
+func (m *MockProductRepository) RestoreProduct(ctx context.Context, id uint64) (*domain.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreProduct", ctx, id)
+	ret0, _ := ret[0].(*domain.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreProduct indicates an expected call of RestoreProduct.
+func (mr *MockProductRepositoryMockRecorder) RestoreProduct(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreProduct", reflect.TypeOf((*MockProductRepository)(nil).RestoreProduct), ctx, id)
+}
+
+// StreamProducts mocks base method.
+func (m *MockProductRepository) StreamProducts(ctx context.Context, search string, categoryId uint64, fn func(*domain.Product) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StreamProducts", ctx, search, categoryId, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StreamProducts indicates an expected call of StreamProducts.
+func (mr *MockProductRepositoryMockRecorder) StreamProducts(ctx, search, categoryId, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamProducts", reflect.TypeOf((*MockProductRepository)(nil).StreamProducts), ctx, search, categoryId, fn)
 }
 
 // UpdateProduct mocks base method.
@@ -153,17 +228,31 @@ func (mr *MockProductServiceMockRecorder) CreateProduct(ctx, product any) *gomoc
 }
 
 // DeleteProduct mocks base method.
-func (m *MockProductService) DeleteProduct(ctx context.Context, id uint64) error {
+func (m *MockProductService) DeleteProduct(ctx context.Context, id, version uint64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteProduct", ctx, id)
+	ret := m.ctrl.Call(m, "DeleteProduct", ctx, id, version)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteProduct indicates an expected call of DeleteProduct.
-func (mr *MockProductServiceMockRecorder) DeleteProduct(ctx, id any) *gomock.Call {
+func (mr *MockProductServiceMockRecorder) DeleteProduct(ctx, id, version any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProduct", reflect.TypeOf((*MockProductService)(nil).DeleteProduct), ctx, id, version)
+}
+
+// ExportProducts mocks base method.
+func (m *MockProductService) ExportProducts(ctx context.Context, search string, categoryId uint64, fn func(*domain.Product) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportProducts", ctx, search, categoryId, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportProducts indicates an expected call of ExportProducts.
+func (mr *MockProductServiceMockRecorder) ExportProducts(ctx, search, categoryId, fn any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProduct", reflect.TypeOf((*MockProductService)(nil).DeleteProduct), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportProducts", reflect.TypeOf((*MockProductService)(nil).ExportProducts), ctx, search, categoryId, fn)
 }
 
 // GetProduct mocks base method.
@@ -181,19 +270,66 @@ func (mr *MockProductServiceMockRecorder) GetProduct(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProduct", reflect.TypeOf((*MockProductService)(nil).GetProduct), ctx, id)
 }
 
+// ImportProducts mocks base method.
+func (m *MockProductService) ImportProducts(ctx context.Context, productImport *domain.ProductImport) (*domain.ProductImport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportProducts", ctx, productImport)
+	ret0, _ := ret[0].(*domain.ProductImport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportProducts indicates an expected call of ImportProducts.
+func (mr *MockProductServiceMockRecorder) ImportProducts(ctx, productImport any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportProducts", reflect.TypeOf((*MockProductService)(nil).ImportProducts), ctx, productImport)
+}
+
 // ListProducts mocks base method.
-func (m *MockProductService) ListProducts(ctx context.Context, search string, categoryId, skip, limit uint64) ([]domain.Product, error) {
+func (m *MockProductService) ListProducts(ctx context.Context, search string, categoryId uint64, includeDeleted bool, params *domain.ListParams) ([]domain.Product, uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListProducts", ctx, search, categoryId, includeDeleted, params)
+	ret0, _ := ret[0].([]domain.Product)
+	ret1, _ := ret[1].(uint64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListProducts indicates an expected call of ListProducts.
+func (mr *MockProductServiceMockRecorder) ListProducts(ctx, search, categoryId, includeDeleted, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProducts", reflect.TypeOf((*MockProductService)(nil).ListProducts), ctx, search, categoryId, includeDeleted, params)
+}
+
+// ListProductsByCursor mocks base method.
+func (m *MockProductService) ListProductsByCursor(ctx context.Context, search string, categoryId uint64, includeDeleted bool, params *domain.CursorParams) ([]domain.Product, *domain.Cursor, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListProducts", ctx, search, categoryId, skip, limit)
+	ret := m.ctrl.Call(m, "ListProductsByCursor", ctx, search, categoryId, includeDeleted, params)
 	ret0, _ := ret[0].([]domain.Product)
+	ret1, _ := ret[1].(*domain.Cursor)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListProductsByCursor indicates an expected call of ListProductsByCursor.
+func (mr *MockProductServiceMockRecorder) ListProductsByCursor(ctx, search, categoryId, includeDeleted, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProductsByCursor", reflect.TypeOf((*MockProductService)(nil).ListProductsByCursor), ctx, search, categoryId, includeDeleted, params)
+}
+
+// RestoreProduct mocks base method.
+func (m *MockProductService) RestoreProduct(ctx context.Context, id uint64) (*domain.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreProduct", ctx, id)
+	ret0, _ := ret[0].(*domain.Product)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListProducts indicates an expected call of ListProducts.
-func (mr *MockProductServiceMockRecorder) ListProducts(ctx, search, categoryId, skip, limit any) *gomock.Call {
+// RestoreProduct indicates an expected call of RestoreProduct.
+func (mr *MockProductServiceMockRecorder) RestoreProduct(ctx, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProducts", reflect.TypeOf((*MockProductService)(nil).ListProducts), ctx, search, categoryId, skip, limit)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreProduct", reflect.TypeOf((*MockProductService)(nil).RestoreProduct), ctx, id)
 }
 
 // UpdateProduct mocks base method.
@@ -56,17 +56,17 @@ func (mr *MockUserRepositoryMockRecorder) CreateUser(ctx, user any) *gomock.Call
 }
 
 // DeleteUser mocks base method.
-func (m *MockUserRepository) DeleteUser(ctx context.Context, id uint64) error {
+func (m *MockUserRepository) DeleteUser(ctx context.Context, id, version uint64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteUser", ctx, id)
+	ret := m.ctrl.Call(m, "DeleteUser", ctx, id, version)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteUser indicates an expected call of DeleteUser.
-func (mr *MockUserRepositoryMockRecorder) DeleteUser(ctx, id any) *gomock.Call {
+func (mr *MockUserRepositoryMockRecorder) DeleteUser(ctx, id, version any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockUserRepository)(nil).DeleteUser), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockUserRepository)(nil).DeleteUser), ctx, id, version)
 }
 
 // GetUserByEmail mocks base method.
@@ -99,19 +99,50 @@ func (mr *MockUserRepositoryMockRecorder) GetUserByID(ctx, id any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByID", reflect.TypeOf((*MockUserRepository)(nil).GetUserByID), ctx, id)
 }
 
-// ListUsers mocks base method.
-func (m *MockUserRepository) ListUsers(ctx context.Context, skip, limit uint64) ([]domain.User, error) {
+// GetUsersByIDs mocks base method.
+func (m *MockUserRepository) GetUsersByIDs(ctx context.Context, ids []uint64) ([]domain.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListUsers", ctx, skip, limit)
+	ret := m.ctrl.Call(m, "GetUsersByIDs", ctx, ids)
 	ret0, _ := ret[0].([]domain.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
+// GetUsersByIDs indicates an expected call of GetUsersByIDs.
+func (mr *MockUserRepositoryMockRecorder) GetUsersByIDs(ctx, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsersByIDs", reflect.TypeOf((*MockUserRepository)(nil).GetUsersByIDs), ctx, ids)
+}
+
+// ListUsers mocks base method.
+func (m *MockUserRepository) ListUsers(ctx context.Context, includeDeleted bool, params *domain.ListParams) ([]domain.User, uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUsers", ctx, includeDeleted, params)
+	ret0, _ := ret[0].([]domain.User)
+	ret1, _ := ret[1].(uint64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
 // ListUsers indicates an expected call of ListUsers.
-func (mr *MockUserRepositoryMockRecorder) ListUsers(ctx, skip, limit any) *gomock.Call {
+func (mr *MockUserRepositoryMockRecorder) ListUsers(ctx, includeDeleted, params any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsers", reflect.TypeOf((*MockUserRepository)(nil).ListUsers), ctx, skip, limit)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsers", reflect.TypeOf((*MockUserRepository)(nil).ListUsers), ctx, includeDeleted, params)
+}
+
+// RestoreUser mocks base method.
+func (m *MockUserRepository) RestoreUser(ctx context.Context, id uint64) (*domain.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreUser", ctx, id)
+	ret0, _ := ret[0].(*domain.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreUser indicates an expected call of RestoreUser.
+func (mr *MockUserRepositoryMockRecorder) RestoreUser(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreUser", reflect.TypeOf((*MockUserRepository)(nil).RestoreUser), ctx, id)
 }
 
 // UpdateUser mocks base method.
@@ -153,17 +184,17 @@ func (m *MockUserService) EXPECT() *MockUserServiceMockRecorder {
 }
 
 // DeleteUser mocks base method.
-func (m *MockUserService) DeleteUser(ctx context.Context, id uint64) error {
+func (m *MockUserService) DeleteUser(ctx context.Context, id, version uint64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteUser", ctx, id)
+	ret := m.ctrl.Call(m, "DeleteUser", ctx, id, version)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteUser indicates an expected call of DeleteUser.
-func (mr *MockUserServiceMockRecorder) DeleteUser(ctx, id any) *gomock.Call {
+func (mr *MockUserServiceMockRecorder) DeleteUser(ctx, id, version any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockUserService)(nil).DeleteUser), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockUserService)(nil).DeleteUser), ctx, id, version)
 }
 
 // GetUser mocks base method.
@@ -182,18 +213,19 @@ func (mr *MockUserServiceMockRecorder) GetUser(ctx, id any) *gomock.Call {
 }
 
 // ListUsers mocks base method.
-func (m *MockUserService) ListUsers(ctx context.Context, skip, limit uint64) ([]domain.User, error) {
+func (m *MockUserService) ListUsers(ctx context.Context, includeDeleted bool, params *domain.ListParams) ([]domain.User, uint64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListUsers", ctx, skip, limit)
+	ret := m.ctrl.Call(m, "ListUsers", ctx, includeDeleted, params)
 	ret0, _ := ret[0].([]domain.User)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret1, _ := ret[1].(uint64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
 // ListUsers indicates an expected call of ListUsers.
-func (mr *MockUserServiceMockRecorder) ListUsers(ctx, skip, limit any) *gomock.Call {
+func (mr *MockUserServiceMockRecorder) ListUsers(ctx, includeDeleted, params any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsers", reflect.TypeOf((*MockUserService)(nil).ListUsers), ctx, skip, limit)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsers", reflect.TypeOf((*MockUserService)(nil).ListUsers), ctx, includeDeleted, params)
 }
 
 // Register mocks base method.
@@ -211,6 +243,21 @@ func (mr *MockUserServiceMockRecorder) Register(ctx, user any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Register", reflect.TypeOf((*MockUserService)(nil).Register), ctx, user)
 }
 
+// RestoreUser mocks base method.
+func (m *MockUserService) RestoreUser(ctx context.Context, id uint64) (*domain.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreUser", ctx, id)
+	ret0, _ := ret[0].(*domain.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreUser indicates an expected call of RestoreUser.
+func (mr *MockUserServiceMockRecorder) RestoreUser(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreUser", reflect.TypeOf((*MockUserService)(nil).RestoreUser), ctx, id)
+}
+
 // UpdateUser mocks base method.
 func (m *MockUserService) UpdateUser(ctx context.Context, user *domain.User) (*domain.User, error) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,15 @@
+package port
+
+import (
+	"context"
+
+	"github.com/nikhil-shrestha/go-pos/internal/core/domain"
+)
+
+//go:generate mockgen -source=notifier.go -destination=mock/notifier.go -package=mock
+
+// Notifier is an interface for sending messages to users
+type Notifier interface {
+	// SendPasswordReset sends the user the token that resets their password
+	SendPasswordReset(ctx context.Context, user *domain.User, token string) error
+}
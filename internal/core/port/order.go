@@ -10,12 +10,19 @@ import (
 
 // OrderRepository is an interface for interacting with order-related data
 type OrderRepository interface {
-	// CreateOrder inserts a new order into the database
+	// CreateOrder inserts a new order into the database and takes its products out of stock,
+	// returning domain.InsufficientStockError if the stock of any of them is not enough
 	CreateOrder(ctx context.Context, order *domain.Order) (*domain.Order, error)
 	// GetOrderByID selects an order by id
 	GetOrderByID(ctx context.Context, id uint64) (*domain.Order, error)
-	// ListOrders selects a list of orders with pagination
-	ListOrders(ctx context.Context, skip, limit uint64) ([]domain.Order, error)
+	// ListOrders selects a sorted page of the orders matching the filter with their total count
+	ListOrders(ctx context.Context, filter *domain.OrderFilter, params *domain.ListParams) ([]domain.Order, uint64, error)
+	// ListOrdersByCursor selects a page of the orders matching the filter following the cursor and the cursor of the next page, if any
+	ListOrdersByCursor(ctx context.Context, filter *domain.OrderFilter, params *domain.CursorParams) ([]domain.Order, *domain.Cursor, error)
+	// StreamOrders calls fn for every order matching the filter with its order products
+	StreamOrders(ctx context.Context, filter *domain.OrderFilter, fn func(order *domain.Order) error) error
+	// VoidOrder marks a completed order as voided and restocks its products
+	VoidOrder(ctx context.Context, id uint64) (*domain.Order, error)
 }
 
 // OrderService is an interface for interacting with order-related business logic
@@ -24,6 +31,14 @@ type OrderService interface {
 	CreateOrder(ctx context.Context, order *domain.Order) (*domain.Order, error)
 	// GetOrder returns an order by id
 	GetOrder(ctx context.Context, id uint64) (*domain.Order, error)
-	// ListOrders returns a list of orders with pagination
-	ListOrders(ctx context.Context, skip, limit uint64) ([]domain.Order, error)
+	// ListOrders returns a sorted page of the orders matching the filter with their total count
+	ListOrders(ctx context.Context, filter *domain.OrderFilter, params *domain.ListParams) ([]domain.Order, uint64, error)
+	// ListOrdersByCursor returns a page of the orders matching the filter following the cursor and the cursor of the next page, if any
+	ListOrdersByCursor(ctx context.Context, filter *domain.OrderFilter, params *domain.CursorParams) ([]domain.Order, *domain.Cursor, error)
+	// ExportOrders calls fn for every order matching the filter with its order products without loading them all into memory
+	ExportOrders(ctx context.Context, filter *domain.OrderFilter, fn func(order *domain.Order) error) error
+	// VoidOrder voids an order and records the user and the supervisor who approved it, if any, in the audit trail
+	VoidOrder(ctx context.Context, id, userID, approverID uint64) (*domain.Order, error)
+	// OpenDrawer records a no-sale opening of the cash drawer in the audit trail
+	OpenDrawer(ctx context.Context, userID, approverID uint64) error
 }
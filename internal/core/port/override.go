@@ -0,0 +1,19 @@
+package port
+
+import (
+	"context"
+
+	"github.com/nikhil-shrestha/go-pos/internal/core/domain"
+)
+
+//go:generate mockgen -source=override.go -destination=mock/override.go -package=mock
+
+// OverrideService is an interface for interacting with supervisor override-related business logic
+type OverrideService interface {
+	// RequestOverride authenticates a supervisor who has the permission of the action and gives the requester a short-lived approval for it
+	RequestOverride(ctx context.Context, requester *domain.TokenPayload, action domain.OverrideAction, email, password string) (*domain.Override, error)
+	// RequestPinOverride is like RequestOverride, but the supervisor authenticates with their PIN
+	RequestPinOverride(ctx context.Context, requester *domain.TokenPayload, action domain.OverrideAction, approverID uint64, pin string) (*domain.Override, error)
+	// UseOverride consumes the approval, which must have been given to the requester for the action
+	UseOverride(ctx context.Context, requester *domain.TokenPayload, action domain.OverrideAction, id string) (*domain.Override, error)
+}
@@ -12,14 +12,18 @@ import (
 type PaymentRepository interface {
 	// CreatePayment inserts a new payment into the database
 	CreatePayment(ctx context.Context, payment *domain.Payment) (*domain.Payment, error)
-	// GetPaymentByID selects a payment by id
+	// GetPaymentByID selects a payment that is not soft deleted by id
 	GetPaymentByID(ctx context.Context, id uint64) (*domain.Payment, error)
-	// ListPayments selects a list of payments with pagination
-	ListPayments(ctx context.Context, skip, limit uint64) ([]domain.Payment, error)
-	// UpdatePayment updates a payment
+	// GetPaymentsByIDs selects the payments with the given ids, soft deleted or not, skipping the ids that do not exist
+	GetPaymentsByIDs(ctx context.Context, ids []uint64) ([]domain.Payment, error)
+	// ListPayments selects a sorted page of payments with their total count
+	ListPayments(ctx context.Context, includeDeleted bool, params *domain.ListParams) ([]domain.Payment, uint64, error)
+	// UpdatePayment updates a payment, as long as it is at the version of the given payment
 	UpdatePayment(ctx context.Context, payment *domain.Payment) (*domain.Payment, error)
-	// DeletePayment deletes a payment
-	DeletePayment(ctx context.Context, id uint64) error
+	// DeletePayment soft deletes a payment, as long as it is at the given version
+	DeletePayment(ctx context.Context, id, version uint64) error
+	// RestorePayment restores a soft deleted payment
+	RestorePayment(ctx context.Context, id uint64) (*domain.Payment, error)
 }
 
 // PaymentService is an interface for interacting with payment-related business logic
@@ -28,10 +32,12 @@ type PaymentService interface {
 	CreatePayment(ctx context.Context, payment *domain.Payment) (*domain.Payment, error)
 	// GetPayment returns a payment by id
 	GetPayment(ctx context.Context, id uint64) (*domain.Payment, error)
-	// ListPayments returns a list of payments with pagination
-	ListPayments(ctx context.Context, skip, limit uint64) ([]domain.Payment, error)
-	// UpdatePayment updates a payment
+	// ListPayments returns a sorted page of payments with their total count
+	ListPayments(ctx context.Context, includeDeleted bool, params *domain.ListParams) ([]domain.Payment, uint64, error)
+	// UpdatePayment updates a payment, as long as it is at the version of the given payment
 	UpdatePayment(ctx context.Context, payment *domain.Payment) (*domain.Payment, error)
-	// DeletePayment deletes a payment
-	DeletePayment(ctx context.Context, id uint64) error
+	// DeletePayment soft deletes a payment, as long as it is at the given version
+	DeletePayment(ctx context.Context, id, version uint64) error
+	// RestorePayment restores a soft deleted payment
+	RestorePayment(ctx context.Context, id uint64) (*domain.Payment, error)
 }
@@ -12,14 +12,24 @@ import (
 type ProductRepository interface {
 	// CreateProduct inserts a new product into the database
 	CreateProduct(ctx context.Context, product *domain.Product) (*domain.Product, error)
-	// GetProductByID selects a product by id
+	// GetProductByID selects a product that is not soft deleted by id
 	GetProductByID(ctx context.Context, id uint64) (*domain.Product, error)
-	// ListProducts selects a list of products with pagination
-	ListProducts(ctx context.Context, search string, categoryId, skip, limit uint64) ([]domain.Product, error)
-	// UpdateProduct updates a product
+	// GetProductsByIDs selects the products with the given ids, soft deleted or not, skipping the ids that do not exist
+	GetProductsByIDs(ctx context.Context, ids []uint64) ([]domain.Product, error)
+	// ListProducts selects a sorted page of products with their total count, including the products of descendant categories
+	ListProducts(ctx context.Context, search string, categoryId uint64, includeDeleted bool, params *domain.ListParams) ([]domain.Product, uint64, error)
+	// ListProductsByCursor selects a page of products following the cursor and the cursor of the next page, if any, with the filters of ListProducts
+	ListProductsByCursor(ctx context.Context, search string, categoryId uint64, includeDeleted bool, params *domain.CursorParams) ([]domain.Product, *domain.Cursor, error)
+	// StreamProducts calls fn for every product matching the filters with its category
+	StreamProducts(ctx context.Context, search string, categoryId uint64, fn func(product *domain.Product) error) error
+	// ImportProducts creates or updates the products of an import in a single transaction
+	ImportProducts(ctx context.Context, productImport *domain.ProductImport) error
+	// UpdateProduct updates a product, as long as it is at the version of the given product
 	UpdateProduct(ctx context.Context, product *domain.Product) (*domain.Product, error)
-	// DeleteProduct deletes a product
-	DeleteProduct(ctx context.Context, id uint64) error
+	// DeleteProduct soft deletes a product, as long as it is at the given version
+	DeleteProduct(ctx context.Context, id, version uint64) error
+	// RestoreProduct restores a soft deleted product
+	RestoreProduct(ctx context.Context, id uint64) (*domain.Product, error)
 }
 
 // ProductService is an interface for interacting with product-related business logic
@@ -28,10 +38,18 @@ type ProductService interface {
 	CreateProduct(ctx context.Context, product *domain.Product) (*domain.Product, error)
 	// GetProduct returns a product by id
 	GetProduct(ctx context.Context, id uint64) (*domain.Product, error)
-	// ListProducts returns a list of products with pagination
-	ListProducts(ctx context.Context, search string, categoryId, skip, limit uint64) ([]domain.Product, error)
-	// UpdateProduct updates a product
+	// ListProducts returns a sorted page of products with their total count
+	ListProducts(ctx context.Context, search string, categoryId uint64, includeDeleted bool, params *domain.ListParams) ([]domain.Product, uint64, error)
+	// ListProductsByCursor returns a page of products following the cursor and the cursor of the next page, if any
+	ListProductsByCursor(ctx context.Context, search string, categoryId uint64, includeDeleted bool, params *domain.CursorParams) ([]domain.Product, *domain.Cursor, error)
+	// ExportProducts calls fn for every product matching the filters without loading them all into memory
+	ExportProducts(ctx context.Context, search string, categoryId uint64, fn func(product *domain.Product) error) error
+	// ImportProducts creates or updates products by SKU or barcode and reports the errors of every row
+	ImportProducts(ctx context.Context, productImport *domain.ProductImport) (*domain.ProductImport, error)
+	// UpdateProduct updates a product, as long as it is at the version of the given product
 	UpdateProduct(ctx context.Context, product *domain.Product) (*domain.Product, error)
-	// DeleteProduct deletes a product
-	DeleteProduct(ctx context.Context, id uint64) error
+	// DeleteProduct soft deletes a product, as long as it is at the given version
+	DeleteProduct(ctx context.Context, id, version uint64) error
+	// RestoreProduct restores a soft deleted product
+	RestoreProduct(ctx context.Context, id uint64) (*domain.Product, error)
 }
@@ -0,0 +1,39 @@
+package port
+
+import (
+	"context"
+
+	"github.com/nikhil-shrestha/go-pos/internal/core/domain"
+)
+
+//go:generate mockgen -source=role.go -destination=mock/role.go -package=mock
+
+// RoleRepository is an interface for interacting with role-related data
+type RoleRepository interface {
+	// CreateRole inserts a new role into the database
+	CreateRole(ctx context.Context, role *domain.Role) (*domain.Role, error)
+	// GetRoleByID selects a role by id
+	GetRoleByID(ctx context.Context, id uint64) (*domain.Role, error)
+	// GetRoleByName selects a role by name
+	GetRoleByName(ctx context.Context, name domain.UserRole) (*domain.Role, error)
+	// ListRoles selects a sorted page of roles with their total count
+	ListRoles(ctx context.Context, params *domain.ListParams) ([]domain.Role, uint64, error)
+	// UpdateRole updates a role
+	UpdateRole(ctx context.Context, role *domain.Role) (*domain.Role, error)
+	// DeleteRole deletes a role
+	DeleteRole(ctx context.Context, id uint64) error
+}
+
+// RoleService is an interface for interacting with role-related business logic
+type RoleService interface {
+	// CreateRole creates a new role
+	CreateRole(ctx context.Context, role *domain.Role) (*domain.Role, error)
+	// GetRole returns a role by id
+	GetRole(ctx context.Context, id uint64) (*domain.Role, error)
+	// ListRoles returns a sorted page of roles with their total count
+	ListRoles(ctx context.Context, params *domain.ListParams) ([]domain.Role, uint64, error)
+	// UpdateRole updates a role
+	UpdateRole(ctx context.Context, role *domain.Role) (*domain.Role, error)
+	// DeleteRole deletes a role
+	DeleteRole(ctx context.Context, id uint64) error
+}
@@ -0,0 +1,17 @@
+package port
+
+import (
+	"context"
+
+	"github.com/nikhil-shrestha/go-pos/internal/core/domain"
+)
+
+//go:generate mockgen -source=storage.go -destination=mock/storage.go -package=mock
+
+// FileStorage is an interface for storing uploaded files
+type FileStorage interface {
+	// Upload stores a file under the key and returns its public URL
+	Upload(ctx context.Context, key string, file *domain.File) (string, error)
+	// Delete removes a file by the URL returned from Upload, ignoring URLs that are not managed by the storage
+	Delete(ctx context.Context, url string) error
+}
@@ -0,0 +1,31 @@
+package port
+
+import (
+	"context"
+
+	"github.com/nikhil-shrestha/go-pos/internal/core/domain"
+)
+
+//go:generate mockgen -source=terminal.go -destination=mock/terminal.go -package=mock
+
+// TerminalRepository is an interface for interacting with terminal-related data
+type TerminalRepository interface {
+	// CreateTerminal inserts a new terminal into the database
+	CreateTerminal(ctx context.Context, terminal *domain.Terminal) (*domain.Terminal, error)
+	// GetTerminalByID selects a terminal by id
+	GetTerminalByID(ctx context.Context, id uint64) (*domain.Terminal, error)
+	// ListTerminals selects a sorted page of terminals with their total count
+	ListTerminals(ctx context.Context, params *domain.ListParams) ([]domain.Terminal, uint64, error)
+	// DeleteTerminal deletes a terminal
+	DeleteTerminal(ctx context.Context, id uint64) error
+}
+
+// TerminalService is an interface for interacting with terminal-related business logic
+type TerminalService interface {
+	// RegisterTerminal registers a new terminal and returns it with its key, which is not stored
+	RegisterTerminal(ctx context.Context, terminal *domain.Terminal) (*domain.Terminal, error)
+	// ListTerminals returns a sorted page of terminals with their total count
+	ListTerminals(ctx context.Context, params *domain.ListParams) ([]domain.Terminal, uint64, error)
+	// DeleteTerminal deletes a terminal, which ends the PIN logins made on it
+	DeleteTerminal(ctx context.Context, id uint64) error
+}
@@ -0,0 +1,11 @@
+package port
+
+import "context"
+
+//go:generate mockgen -source=transaction.go -destination=mock/transaction.go -package=mock
+
+// Transactor is an interface for running changes to the database in a single transaction
+type Transactor interface {
+	// WithinTransaction runs fn in a transaction carried by the context passed to it, committing it if fn returns nil
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
@@ -0,0 +1,47 @@
+package port
+
+import (
+	"context"
+
+	"github.com/nikhil-shrestha/go-pos/internal/core/domain"
+)
+
+//go:generate mockgen -source=two_factor.go -destination=mock/two_factor.go -package=mock
+
+// TwoFactorRepository is an interface for interacting with two-factor enrolment-related data
+type TwoFactorRepository interface {
+	// SaveTwoFactor inserts or replaces the pending enrolment of a user
+	SaveTwoFactor(ctx context.Context, twoFactor *domain.TwoFactor) (*domain.TwoFactor, error)
+	// GetTwoFactor selects the enrolment of a user
+	GetTwoFactor(ctx context.Context, userID uint64) (*domain.TwoFactor, error)
+	// EnableTwoFactor confirms the pending enrolment of a user with the hashes of its recovery codes
+	EnableTwoFactor(ctx context.Context, userID uint64, recoveryCodes []string) (*domain.TwoFactor, error)
+	// UpdateRecoveryCodes replaces the hashes of the recovery codes of an enrolment
+	UpdateRecoveryCodes(ctx context.Context, userID uint64, recoveryCodes []string) error
+	// UseRecoveryCode removes the hash of a recovery code from an enrolment, so it cannot be used again
+	UseRecoveryCode(ctx context.Context, userID uint64, recoveryCode string) error
+	// DeleteTwoFactor deletes the enrolment of a user
+	DeleteTwoFactor(ctx context.Context, userID uint64) error
+}
+
+// TwoFactorService is an interface for interacting with two-factor authentication-related business logic
+type TwoFactorService interface {
+	// SetupTwoFactor provisions a new secret for the user, which is pending until a code of it is verified
+	SetupTwoFactor(ctx context.Context, userID uint64) (*domain.TwoFactorSetup, error)
+	// EnableTwoFactor confirms the pending secret with a code and returns the recovery codes
+	EnableTwoFactor(ctx context.Context, userID uint64, code string) ([]string, error)
+	// RegenerateRecoveryCodes replaces the recovery codes after checking a code
+	RegenerateRecoveryCodes(ctx context.Context, userID uint64, code string) ([]string, error)
+	// DisableTwoFactor turns off two-factor authentication after checking a code
+	DisableTwoFactor(ctx context.Context, userID uint64, code string) error
+	// ResetTwoFactor turns off two-factor authentication of a user who lost both the authenticator and the recovery codes
+	ResetTwoFactor(ctx context.Context, userID uint64) error
+	// IsRequired reports whether users of the role must answer a two-factor challenge to log in
+	IsRequired(role domain.UserRole) bool
+	// CreateChallenge starts a login challenge for the user if they are enrolled or their role requires it
+	CreateChallenge(ctx context.Context, user *domain.User) (*domain.LoginChallenge, error)
+	// SetupChallenge provisions a new secret for the user of a setup challenge
+	SetupChallenge(ctx context.Context, challengeID string) (*domain.TwoFactorSetup, error)
+	// VerifyChallenge answers a login challenge with a code and returns the user id, with the recovery codes if the challenge enrolled the user
+	VerifyChallenge(ctx context.Context, challengeID, code string) (uint64, []string, error)
+}
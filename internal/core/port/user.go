@@ -12,16 +12,20 @@ import (
 type UserRepository interface {
 	// CreateUser inserts a new user into the database
 	CreateUser(ctx context.Context, user *domain.User) (*domain.User, error)
-	// GetUserByID selects a user by id
+	// GetUserByID selects a user that is not soft deleted by id
 	GetUserByID(ctx context.Context, id uint64) (*domain.User, error)
-	// GetUserByEmail selects a user by email
+	// GetUsersByIDs selects the users with the given ids, soft deleted or not, skipping the ids that do not exist
+	GetUsersByIDs(ctx context.Context, ids []uint64) ([]domain.User, error)
+	// GetUserByEmail selects a user that is not soft deleted by email
 	GetUserByEmail(ctx context.Context, email string) (*domain.User, error)
-	// ListUsers selects a list of users with pagination
-	ListUsers(ctx context.Context, skip, limit uint64) ([]domain.User, error)
-	// UpdateUser updates a user
+	// ListUsers selects a sorted page of users with their total count
+	ListUsers(ctx context.Context, includeDeleted bool, params *domain.ListParams) ([]domain.User, uint64, error)
+	// UpdateUser updates a user, as long as it is at the version of the given user
 	UpdateUser(ctx context.Context, user *domain.User) (*domain.User, error)
-	// DeleteUser deletes a user
-	DeleteUser(ctx context.Context, id uint64) error
+	// DeleteUser soft deletes a user, as long as it is at the given version
+	DeleteUser(ctx context.Context, id, version uint64) error
+	// RestoreUser restores a soft deleted user
+	RestoreUser(ctx context.Context, id uint64) (*domain.User, error)
 }
 
 // UserService is an interface for interacting with user-related business logic
@@ -30,10 +34,12 @@ type UserService interface {
 	Register(ctx context.Context, user *domain.User) (*domain.User, error)
 	// GetUser returns a user by id
 	GetUser(ctx context.Context, id uint64) (*domain.User, error)
-	// ListUsers returns a list of users with pagination
-	ListUsers(ctx context.Context, skip, limit uint64) ([]domain.User, error)
-	// UpdateUser updates a user
+	// ListUsers returns a sorted page of users with their total count
+	ListUsers(ctx context.Context, includeDeleted bool, params *domain.ListParams) ([]domain.User, uint64, error)
+	// UpdateUser updates a user, as long as it is at the version of the given user
 	UpdateUser(ctx context.Context, user *domain.User) (*domain.User, error)
-	// DeleteUser deletes a user
-	DeleteUser(ctx context.Context, id uint64) error
+	// DeleteUser soft deletes a user, as long as it is at the given version, and revokes its access tokens and API keys
+	DeleteUser(ctx context.Context, id, version uint64) error
+	// RestoreUser restores a soft deleted user
+	RestoreUser(ctx context.Context, id uint64) (*domain.User, error)
 }
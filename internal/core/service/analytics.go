@@ -0,0 +1,140 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/nikhil-shrestha/go-pos/internal/core/domain"
+	"github.com/nikhil-shrestha/go-pos/internal/core/port"
+)
+
+/**
+ * AnalyticsService implements port.AnalyticsService interface
+ * and provides an access to the analytics repository
+ */
+type AnalyticsService struct {
+	repo port.AnalyticsRepository
+}
+
+// NewAnalyticsService creates a new analytics service instance
+func NewAnalyticsService(repo port.AnalyticsRepository) *AnalyticsService {
+	return &AnalyticsService{
+		repo,
+	}
+}
+
+// GetSalesSummary returns the total orders, revenue, and basket averages within the date range
+func (as *AnalyticsService) GetSalesSummary(ctx context.Context, filter *domain.ReportFilter) (*domain.SalesSummary, error) {
+	_, err := validateReportFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	summary, err := as.repo.GetSalesSummary(ctx, filter)
+	if err != nil {
+		return nil, domain.ErrInternal
+	}
+
+	return summary, nil
+}
+
+// ListSalesByPeriod returns the revenue grouped by hour or day in the filter's time zone
+func (as *AnalyticsService) ListSalesByPeriod(ctx context.Context, filter *domain.ReportFilter) ([]domain.SalesPeriod, error) {
+	location, err := validateReportFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	if filter.Interval == "" {
+		filter.Interval = domain.Daily
+	}
+
+	periods, err := as.repo.ListSalesByPeriod(ctx, filter)
+	if err != nil {
+		return nil, domain.ErrInternal
+	}
+
+	for i, period := range periods {
+		periods[i].Period = period.Period.In(location)
+	}
+
+	return periods, nil
+}
+
+// ListSalesByCategory returns the revenue grouped by category
+func (as *AnalyticsService) ListSalesByCategory(ctx context.Context, filter *domain.ReportFilter) ([]domain.SalesGroup, error) {
+	_, err := validateReportFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	categories, err := as.repo.ListSalesByCategory(ctx, filter)
+	if err != nil {
+		return nil, domain.ErrInternal
+	}
+
+	return categories, nil
+}
+
+// ListSalesByProduct returns the best selling products by quantity
+func (as *AnalyticsService) ListSalesByProduct(ctx context.Context, filter *domain.ReportFilter) ([]domain.SalesGroup, error) {
+	_, err := validateReportFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	products, err := as.repo.ListSalesByProduct(ctx, filter)
+	if err != nil {
+		return nil, domain.ErrInternal
+	}
+
+	return products, nil
+}
+
+// ListSalesByCashier returns the revenue grouped by the user who created the orders
+func (as *AnalyticsService) ListSalesByCashier(ctx context.Context, filter *domain.ReportFilter) ([]domain.SalesGroup, error) {
+	_, err := validateReportFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	cashiers, err := as.repo.ListSalesByCashier(ctx, filter)
+	if err != nil {
+		return nil, domain.ErrInternal
+	}
+
+	return cashiers, nil
+}
+
+// ListSalesByPayment returns the revenue grouped by payment
+func (as *AnalyticsService) ListSalesByPayment(ctx context.Context, filter *domain.ReportFilter) ([]domain.SalesGroup, error) {
+	_, err := validateReportFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	payments, err := as.repo.ListSalesByPayment(ctx, filter)
+	if err != nil {
+		return nil, domain.ErrInternal
+	}
+
+	return payments, nil
+}
+
+// validateReportFilter checks the date range and returns the location of the filter's time zone
+func validateReportFilter(filter *domain.ReportFilter) (*time.Location, error) {
+	if filter.TimeZone == "" {
+		filter.TimeZone = time.UTC.String()
+	}
+
+	location, err := time.LoadLocation(filter.TimeZone)
+	if err != nil {
+		return nil, domain.ErrInvalidTimeZone
+	}
+
+	if !filter.StartDate.Before(filter.EndDate) {
+		return nil, domain.ErrInvalidDateRange
+	}
+
+	return location, nil
+}
@@ -0,0 +1,243 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/nikhil-shrestha/go-pos/internal/core/domain"
+	"github.com/nikhil-shrestha/go-pos/internal/core/port/mock"
+	"github.com/nikhil-shrestha/go-pos/internal/core/service"
+	"github.com/brianvoe/gofakeit/v6"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+type getSalesSummaryTestedInput struct {
+	filter *domain.ReportFilter
+}
+
+type getSalesSummaryExpectedOutput struct {
+	summary *domain.SalesSummary
+	err     error
+}
+
+func TestAnalyticsService_GetSalesSummary(t *testing.T) {
+	ctx := context.Background()
+	startDate := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	endDate := startDate.AddDate(0, 1, 0)
+
+	summary := &domain.SalesSummary{
+		TotalOrders:       gofakeit.Uint64(),
+		TotalItems:        gofakeit.Int64(),
+		TotalRevenue:      gofakeit.Float64(),
+		AverageOrderValue: gofakeit.Float64(),
+		AverageBasketSize: gofakeit.Float64(),
+	}
+
+	testCases := []struct {
+		desc     string
+		mocks    func(analyticsRepo *mock.MockAnalyticsRepository)
+		input    getSalesSummaryTestedInput
+		expected getSalesSummaryExpectedOutput
+	}{
+		{
+			desc: "Success",
+			mocks: func(analyticsRepo *mock.MockAnalyticsRepository) {
+				analyticsRepo.EXPECT().
+					GetSalesSummary(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(summary, nil)
+			},
+			input: getSalesSummaryTestedInput{
+				filter: &domain.ReportFilter{
+					StartDate: startDate,
+					EndDate:   endDate,
+					TimeZone:  "Asia/Kathmandu",
+				},
+			},
+			expected: getSalesSummaryExpectedOutput{
+				summary: summary,
+				err:     nil,
+			},
+		},
+		{
+			desc:  "Fail_InvalidDateRange",
+			mocks: func(analyticsRepo *mock.MockAnalyticsRepository) {},
+			input: getSalesSummaryTestedInput{
+				filter: &domain.ReportFilter{
+					StartDate: endDate,
+					EndDate:   startDate,
+				},
+			},
+			expected: getSalesSummaryExpectedOutput{
+				summary: nil,
+				err:     domain.ErrInvalidDateRange,
+			},
+		},
+		{
+			desc:  "Fail_InvalidTimeZone",
+			mocks: func(analyticsRepo *mock.MockAnalyticsRepository) {},
+			input: getSalesSummaryTestedInput{
+				filter: &domain.ReportFilter{
+					StartDate: startDate,
+					EndDate:   endDate,
+					TimeZone:  "Mars/Olympus_Mons",
+				},
+			},
+			expected: getSalesSummaryExpectedOutput{
+				summary: nil,
+				err:     domain.ErrInvalidTimeZone,
+			},
+		},
+		{
+			desc: "Fail_InternalError",
+			mocks: func(analyticsRepo *mock.MockAnalyticsRepository) {
+				analyticsRepo.EXPECT().
+					GetSalesSummary(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, domain.ErrInternal)
+			},
+			input: getSalesSummaryTestedInput{
+				filter: &domain.ReportFilter{
+					StartDate: startDate,
+					EndDate:   endDate,
+				},
+			},
+			expected: getSalesSummaryExpectedOutput{
+				summary: nil,
+				err:     domain.ErrInternal,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			analyticsRepo := mock.NewMockAnalyticsRepository(ctrl)
+
+			tc.mocks(analyticsRepo)
+
+			analyticsService := service.NewAnalyticsService(analyticsRepo)
+
+			summary, err := analyticsService.GetSalesSummary(ctx, tc.input.filter)
+			assert.Equal(t, tc.expected.err, err, "Error mismatch")
+			assert.Equal(t, tc.expected.summary, summary, "Summary mismatch")
+		})
+	}
+}
+
+func TestAnalyticsService_ListSalesByPeriod(t *testing.T) {
+	ctx := context.Background()
+	location, _ := time.LoadLocation("Asia/Kathmandu")
+	startDate := time.Date(2024, time.January, 1, 0, 0, 0, 0, location)
+	endDate := startDate.AddDate(0, 0, 2)
+
+	filter := &domain.ReportFilter{
+		StartDate: startDate,
+		EndDate:   endDate,
+		TimeZone:  location.String(),
+	}
+	expectedFilter := &domain.ReportFilter{
+		StartDate: startDate,
+		EndDate:   endDate,
+		TimeZone:  location.String(),
+		Interval:  domain.Daily,
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	analyticsRepo := mock.NewMockAnalyticsRepository(ctrl)
+	analyticsRepo.EXPECT().
+		ListSalesByPeriod(gomock.Any(), gomock.Eq(expectedFilter)).
+		Times(1).
+		Return([]domain.SalesPeriod{
+			{Period: startDate.UTC(), TotalOrders: 1},
+			{Period: startDate.AddDate(0, 0, 1).UTC(), TotalOrders: 2},
+		}, nil)
+
+	analyticsService := service.NewAnalyticsService(analyticsRepo)
+
+	periods, err := analyticsService.ListSalesByPeriod(ctx, filter)
+	assert.NoError(t, err, "Error mismatch")
+	assert.Len(t, periods, 2, "Periods length mismatch")
+
+	for _, period := range periods {
+		assert.Equal(t, location, period.Period.Location(), "Location mismatch")
+		assert.Zero(t, period.Period.Hour(), "Period is not aligned to the local day")
+	}
+}
+
+func TestAnalyticsService_ListSalesByProduct(t *testing.T) {
+	ctx := context.Background()
+	startDate := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	filter := &domain.ReportFilter{
+		StartDate: startDate,
+		EndDate:   startDate.AddDate(0, 0, 7),
+		Limit:     5,
+	}
+
+	var products []domain.SalesGroup
+	for i := 0; i < 5; i++ {
+		products = append(products, domain.SalesGroup{
+			ID:           gofakeit.Uint64(),
+			Name:         gofakeit.ProductName(),
+			TotalOrders:  gofakeit.Uint64(),
+			TotalItems:   gofakeit.Int64(),
+			TotalRevenue: gofakeit.Float64(),
+		})
+	}
+
+	testCases := []struct {
+		desc     string
+		mocks    func(analyticsRepo *mock.MockAnalyticsRepository)
+		products []domain.SalesGroup
+		err      error
+	}{
+		{
+			desc: "Success",
+			mocks: func(analyticsRepo *mock.MockAnalyticsRepository) {
+				analyticsRepo.EXPECT().
+					ListSalesByProduct(gomock.Any(), gomock.Eq(filter)).
+					Times(1).
+					Return(products, nil)
+			},
+			products: products,
+			err:      nil,
+		},
+		{
+			desc: "Fail_InternalError",
+			mocks: func(analyticsRepo *mock.MockAnalyticsRepository) {
+				analyticsRepo.EXPECT().
+					ListSalesByProduct(gomock.Any(), gomock.Eq(filter)).
+					Times(1).
+					Return(nil, domain.ErrInternal)
+			},
+			products: nil,
+			err:      domain.ErrInternal,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			analyticsRepo := mock.NewMockAnalyticsRepository(ctrl)
+
+			tc.mocks(analyticsRepo)
+
+			analyticsService := service.NewAnalyticsService(analyticsRepo)
+
+			products, err := analyticsService.ListSalesByProduct(ctx, filter)
+			assert.Equal(t, tc.err, err, "Error mismatch")
+			assert.Equal(t, tc.products, products, "Products mismatch")
+		})
+	}
+}
@@ -0,0 +1,211 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/nikhil-shrestha/go-pos/internal/core/domain"
+	"github.com/nikhil-shrestha/go-pos/internal/core/port"
+	"github.com/nikhil-shrestha/go-pos/internal/core/util"
+)
+
+const (
+	// apiKeyScheme is the leading part of every API key, which makes leaked keys easy to recognize
+	apiKeyScheme = "gopos"
+	// apiKeyPrefixSize is the number of random bytes of the prefix that identifies an API key
+	apiKeyPrefixSize = 4
+	// apiKeySecretSize is the number of random bytes of the secret part of an API key
+	apiKeySecretSize = 32
+	// apiKeyCacheDuration is how long a verified API key is cached, which is also
+	// how often its last use is recorded and how late a revoked user's key may still be accepted
+	apiKeyCacheDuration = time.Minute
+)
+
+/**
+ * APIKeyService implements port.APIKeyService interface
+ * and provides an access to the API key repository,
+ * cache service and audit service
+ */
+type APIKeyService struct {
+	repo  port.APIKeyRepository
+	cache port.CacheRepository
+	audit port.AuditService
+}
+
+// NewAPIKeyService creates a new API key service instance
+func NewAPIKeyService(repo port.APIKeyRepository, cache port.CacheRepository, audit port.AuditService) *APIKeyService {
+	return &APIKeyService{
+		repo,
+		cache,
+		audit,
+	}
+}
+
+// CreateAPIKey generates a key of the form gopos_<prefix>_<secret> and stores only the hash of its secret.
+// The key can only be granted permissions its creator has and that are allowed for API keys
+func (aks *APIKeyService) CreateAPIKey(ctx context.Context, creator *domain.TokenPayload, apiKey *domain.APIKey) (*domain.APIKey, error) {
+	for _, permission := range apiKey.Permissions {
+		if !permission.IsAPIKeyPermission() || !creator.HasPermission(permission) {
+			return nil, domain.ErrForbidden
+		}
+	}
+
+	if apiKey.ExpiresAt != nil && !apiKey.ExpiresAt.After(time.Now()) {
+		return nil, domain.ErrInvalidAPIKeyExpiry
+	}
+
+	prefix, err := util.GenerateKey(apiKeyPrefixSize)
+	if err != nil {
+		return nil, domain.ErrInternal
+	}
+
+	secret, err := util.GenerateKey(apiKeySecretSize)
+	if err != nil {
+		return nil, domain.ErrInternal
+	}
+
+	apiKey.Prefix = prefix
+	apiKey.KeyHash = util.HashKey(secret)
+	apiKey.CreatedBy = creator.UserID
+
+	err = aks.audit.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+
+		apiKey, err = aks.repo.CreateAPIKey(ctx, apiKey)
+		if err != nil {
+			if err == domain.ErrConflictingData {
+				return err
+			}
+			return domain.ErrInternal
+		}
+
+		return aks.audit.RecordAuditLog(ctx, &domain.AuditLog{Action: "api_key.create", Entity: "api_key", EntityID: apiKey.ID}, nil, apiKey)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	apiKey.Key = strings.Join([]string{apiKeyScheme, prefix, secret}, "_")
+
+	return apiKey, nil
+}
+
+// ListAPIKeys lists all API keys, which are not cached so that their last use is current
+func (aks *APIKeyService) ListAPIKeys(ctx context.Context, params *domain.ListParams) ([]domain.APIKey, uint64, error) {
+	apiKeys, total, err := aks.repo.ListAPIKeys(ctx, params)
+	if err != nil {
+		return nil, 0, domain.ErrInternal
+	}
+
+	return apiKeys, total, nil
+}
+
+// RevokeAPIKey deletes an API key, after which requests made with it are rejected
+func (aks *APIKeyService) RevokeAPIKey(ctx context.Context, id uint64) error {
+	var apiKey *domain.APIKey
+
+	err := aks.audit.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+
+		apiKey, err = aks.repo.DeleteAPIKey(ctx, id)
+		if err != nil {
+			if err == domain.ErrDataNotFound {
+				return err
+			}
+			return domain.ErrInternal
+		}
+
+		return aks.audit.RecordAuditLog(ctx, &domain.AuditLog{Action: "api_key.delete", Entity: "api_key", EntityID: id}, apiKey, nil)
+	})
+	if err != nil {
+		return err
+	}
+
+	cacheKey := util.GenerateCacheKey("api_key", apiKey.Prefix)
+
+	err = aks.cache.Delete(ctx, cacheKey)
+	if err != nil {
+		return domain.ErrInternal
+	}
+
+	return nil
+}
+
+// VerifyAPIKey looks the key up by its prefix, checks its secret and expiry,
+// and returns a payload that acts as its creator with the permissions of the key
+func (aks *APIKeyService) VerifyAPIKey(ctx context.Context, key string) (*domain.TokenPayload, error) {
+	parts := strings.Split(key, "_")
+	if len(parts) != 3 || parts[0] != apiKeyScheme {
+		return nil, domain.ErrInvalidAPIKey
+	}
+	prefix, secret := parts[1], parts[2]
+
+	apiKey, err := aks.getAPIKey(ctx, prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	if !util.CompareKey(secret, apiKey.KeyHash) || apiKey.IsExpired() {
+		return nil, domain.ErrInvalidAPIKey
+	}
+
+	payload := &domain.TokenPayload{
+		UserID:      apiKey.CreatedBy,
+		Permissions: apiKey.Permissions,
+		APIKeyID:    apiKey.ID,
+		IssuedAt:    apiKey.CreatedAt,
+	}
+	if apiKey.ExpiresAt != nil {
+		payload.ExpiresAt = *apiKey.ExpiresAt
+	}
+
+	return payload, nil
+}
+
+// getAPIKey returns the API key with the prefix from the cache, or from the
+// database, in which case its last use is recorded before it is cached
+func (aks *APIKeyService) getAPIKey(ctx context.Context, prefix string) (*domain.APIKey, error) {
+	var apiKey *domain.APIKey
+
+	cacheKey := util.GenerateCacheKey("api_key", prefix)
+
+	cachedAPIKey, err := aks.cache.Get(ctx, cacheKey)
+	if err == nil {
+		err = util.Deserialize(cachedAPIKey, &apiKey)
+		if err != nil {
+			return nil, domain.ErrInternal
+		}
+
+		return apiKey, nil
+	}
+
+	apiKey, err = aks.repo.GetAPIKeyByPrefix(ctx, prefix)
+	if err != nil {
+		if err == domain.ErrDataNotFound {
+			return nil, domain.ErrInvalidAPIKey
+		}
+		return nil, domain.ErrInternal
+	}
+
+	now := time.Now()
+
+	err = aks.repo.TouchAPIKey(ctx, apiKey.ID, now)
+	if err != nil {
+		return nil, domain.ErrInternal
+	}
+
+	apiKey.LastUsedAt = &now
+
+	apiKeySerialized, err := util.Serialize(apiKey)
+	if err != nil {
+		return nil, domain.ErrInternal
+	}
+
+	err = aks.cache.Set(ctx, cacheKey, apiKeySerialized, apiKeyCacheDuration)
+	if err != nil {
+		return nil, domain.ErrInternal
+	}
+
+	return apiKey, nil
+}
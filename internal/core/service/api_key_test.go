@@ -0,0 +1,248 @@
+package service_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nikhil-shrestha/go-pos/internal/core/domain"
+	"github.com/nikhil-shrestha/go-pos/internal/core/port/mock"
+	"github.com/nikhil-shrestha/go-pos/internal/core/service"
+	"github.com/nikhil-shrestha/go-pos/internal/core/util"
+	"github.com/brianvoe/gofakeit/v6"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+type createAPIKeyTestedInput struct {
+	permissions []domain.Permission
+	expiresAt   *time.Time
+}
+
+func TestAPIKeyService_CreateAPIKey(t *testing.T) {
+	ctx := context.Background()
+	creator := &domain.TokenPayload{
+		UserID:      gofakeit.Uint64(),
+		Permissions: []domain.Permission{domain.ProductsWrite, domain.OrdersCreate, domain.UsersWrite, domain.APIKeysWrite},
+	}
+	past := time.Now().Add(-time.Hour)
+
+	testCases := []struct {
+		desc     string
+		mocks    func(apiKeyRepo *mock.MockAPIKeyRepository)
+		input    createAPIKeyTestedInput
+		expected error
+	}{
+		{
+			desc: "Success",
+			mocks: func(apiKeyRepo *mock.MockAPIKeyRepository) {
+				apiKeyRepo.EXPECT().
+					CreateAPIKey(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, apiKey *domain.APIKey) (*domain.APIKey, error) {
+						assert.Equal(t, creator.UserID, apiKey.CreatedBy, "Creator mismatch")
+						assert.Len(t, apiKey.Prefix, 8, "Prefix length mismatch")
+						assert.NotEmpty(t, apiKey.KeyHash, "Key hash must be stored")
+						assert.Empty(t, apiKey.Key, "Key must not be stored")
+						return apiKey, nil
+					})
+			},
+			input: createAPIKeyTestedInput{
+				permissions: []domain.Permission{domain.ProductsWrite, domain.OrdersCreate},
+			},
+			expected: nil,
+		},
+		{
+			desc:  "Fail_PermissionNotAllowedForAPIKeys",
+			mocks: func(apiKeyRepo *mock.MockAPIKeyRepository) {},
+			input: createAPIKeyTestedInput{
+				permissions: []domain.Permission{domain.UsersWrite},
+			},
+			expected: domain.ErrForbidden,
+		},
+		{
+			desc:  "Fail_PermissionNotHeldByCreator",
+			mocks: func(apiKeyRepo *mock.MockAPIKeyRepository) {},
+			input: createAPIKeyTestedInput{
+				permissions: []domain.Permission{domain.ReportsView},
+			},
+			expected: domain.ErrForbidden,
+		},
+		{
+			desc:  "Fail_ExpiryInPast",
+			mocks: func(apiKeyRepo *mock.MockAPIKeyRepository) {},
+			input: createAPIKeyTestedInput{
+				permissions: []domain.Permission{domain.ProductsWrite},
+				expiresAt:   &past,
+			},
+			expected: domain.ErrInvalidAPIKeyExpiry,
+		},
+		{
+			desc: "Fail_InternalError",
+			mocks: func(apiKeyRepo *mock.MockAPIKeyRepository) {
+				apiKeyRepo.EXPECT().CreateAPIKey(gomock.Any(), gomock.Any()).Return(nil, domain.ErrInternal)
+			},
+			input: createAPIKeyTestedInput{
+				permissions: []domain.Permission{domain.ProductsWrite},
+			},
+			expected: domain.ErrInternal,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			apiKeyRepo := mock.NewMockAPIKeyRepository(ctrl)
+			cache := mock.NewMockCacheRepository(ctrl)
+
+			tc.mocks(apiKeyRepo)
+
+			apiKeyService := service.NewAPIKeyService(apiKeyRepo, cache, newMockAuditService(ctrl))
+
+			apiKey, err := apiKeyService.CreateAPIKey(ctx, creator, &domain.APIKey{
+				Name:        gofakeit.Company(),
+				Permissions: tc.input.permissions,
+				ExpiresAt:   tc.input.expiresAt,
+			})
+			assert.ErrorIs(t, err, tc.expected, "Error mismatch")
+
+			if err == nil {
+				parts := strings.Split(apiKey.Key, "_")
+				assert.Len(t, parts, 3, "Key format mismatch")
+				assert.Equal(t, apiKey.Prefix, parts[1], "Prefix mismatch")
+				assert.True(t, util.CompareKey(parts[2], apiKey.KeyHash), "Key hash mismatch")
+			}
+		})
+	}
+}
+
+func TestAPIKeyService_VerifyAPIKey(t *testing.T) {
+	ctx := context.Background()
+	prefix, _ := util.GenerateKey(4)
+	secret, _ := util.GenerateKey(32)
+	key := "gopos_" + prefix + "_" + secret
+	expired := time.Now().Add(-time.Minute)
+	apiKey := &domain.APIKey{
+		ID:          gofakeit.Uint64(),
+		Prefix:      prefix,
+		KeyHash:     util.HashKey(secret),
+		Permissions: []domain.Permission{domain.ProductsWrite},
+		CreatedBy:   gofakeit.Uint64(),
+	}
+	expiredAPIKey := *apiKey
+	expiredAPIKey.ExpiresAt = &expired
+	apiKeySerialized, _ := util.Serialize(apiKey)
+	cacheKey := util.GenerateCacheKey("api_key", prefix)
+
+	testCases := []struct {
+		desc  string
+		mocks func(
+			apiKeyRepo *mock.MockAPIKeyRepository,
+			cache *mock.MockCacheRepository,
+		)
+		input    string
+		expected error
+	}{
+		{
+			desc: "Success",
+			mocks: func(
+				apiKeyRepo *mock.MockAPIKeyRepository,
+				cache *mock.MockCacheRepository,
+			) {
+				cache.EXPECT().Get(gomock.Any(), gomock.Eq(cacheKey)).Return(nil, domain.ErrDataNotFound)
+				apiKeyRepo.EXPECT().GetAPIKeyByPrefix(gomock.Any(), gomock.Eq(prefix)).Return(apiKey, nil)
+				apiKeyRepo.EXPECT().TouchAPIKey(gomock.Any(), gomock.Eq(apiKey.ID), gomock.Any()).Return(nil)
+				cache.EXPECT().Set(gomock.Any(), gomock.Eq(cacheKey), gomock.Any(), gomock.Eq(time.Minute)).Return(nil)
+			},
+			input:    key,
+			expected: nil,
+		},
+		{
+			desc: "Success_FromCache",
+			mocks: func(
+				apiKeyRepo *mock.MockAPIKeyRepository,
+				cache *mock.MockCacheRepository,
+			) {
+				cache.EXPECT().Get(gomock.Any(), gomock.Eq(cacheKey)).Return(apiKeySerialized, nil)
+			},
+			input:    key,
+			expected: nil,
+		},
+		{
+			desc: "Fail_Malformed",
+			mocks: func(
+				apiKeyRepo *mock.MockAPIKeyRepository,
+				cache *mock.MockCacheRepository,
+			) {
+			},
+			input:    secret,
+			expected: domain.ErrInvalidAPIKey,
+		},
+		{
+			desc: "Fail_UnknownPrefix",
+			mocks: func(
+				apiKeyRepo *mock.MockAPIKeyRepository,
+				cache *mock.MockCacheRepository,
+			) {
+				cache.EXPECT().Get(gomock.Any(), gomock.Eq(cacheKey)).Return(nil, domain.ErrDataNotFound)
+				apiKeyRepo.EXPECT().GetAPIKeyByPrefix(gomock.Any(), gomock.Eq(prefix)).Return(nil, domain.ErrDataNotFound)
+			},
+			input:    key,
+			expected: domain.ErrInvalidAPIKey,
+		},
+		{
+			desc: "Fail_WrongSecret",
+			mocks: func(
+				apiKeyRepo *mock.MockAPIKeyRepository,
+				cache *mock.MockCacheRepository,
+			) {
+				cache.EXPECT().Get(gomock.Any(), gomock.Eq(cacheKey)).Return(apiKeySerialized, nil)
+			},
+			input:    "gopos_" + prefix + "_" + strings.Repeat("0", 64),
+			expected: domain.ErrInvalidAPIKey,
+		},
+		{
+			desc: "Fail_Expired",
+			mocks: func(
+				apiKeyRepo *mock.MockAPIKeyRepository,
+				cache *mock.MockCacheRepository,
+			) {
+				cache.EXPECT().Get(gomock.Any(), gomock.Eq(cacheKey)).Return(nil, domain.ErrDataNotFound)
+				apiKeyRepo.EXPECT().GetAPIKeyByPrefix(gomock.Any(), gomock.Eq(prefix)).Return(&expiredAPIKey, nil)
+				apiKeyRepo.EXPECT().TouchAPIKey(gomock.Any(), gomock.Eq(apiKey.ID), gomock.Any()).Return(nil)
+				cache.EXPECT().Set(gomock.Any(), gomock.Eq(cacheKey), gomock.Any(), gomock.Eq(time.Minute)).Return(nil)
+			},
+			input:    key,
+			expected: domain.ErrInvalidAPIKey,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			apiKeyRepo := mock.NewMockAPIKeyRepository(ctrl)
+			cache := mock.NewMockCacheRepository(ctrl)
+
+			tc.mocks(apiKeyRepo, cache)
+
+			apiKeyService := service.NewAPIKeyService(apiKeyRepo, cache, newMockAuditService(ctrl))
+
+			payload, err := apiKeyService.VerifyAPIKey(ctx, tc.input)
+			assert.ErrorIs(t, err, tc.expected, "Error mismatch")
+
+			if err == nil {
+				assert.Equal(t, apiKey.ID, payload.APIKeyID, "API key mismatch")
+				assert.Equal(t, apiKey.CreatedBy, payload.UserID, "User mismatch")
+				assert.Equal(t, apiKey.Permissions, payload.Permissions, "Permissions mismatch")
+			}
+		})
+	}
+}
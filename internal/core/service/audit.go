@@ -0,0 +1,259 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"slices"
+
+	"github.com/nikhil-shrestha/go-pos/internal/core/domain"
+	"github.com/nikhil-shrestha/go-pos/internal/core/port"
+)
+
+// auditVerifyBatchSize is the number of audit logs read at a time when verifying the hash chain
+const auditVerifyBatchSize = 1000
+
+// auditRedactedValue replaces the values of secret fields in the changes of an audit log
+const auditRedactedValue = "[REDACTED]"
+
+var (
+	// auditIgnoredFields are the fields left out of the changes of an audit log
+	auditIgnoredFields = []string{"CreatedAt", "UpdatedAt"}
+	// auditRedactedFields are the fields whose values are redacted in the changes of an audit log
+	auditRedactedFields = []string{"Password", "Pin", "Key", "KeyHash", "Secret", "RecoveryCodes"}
+)
+
+// pendingAuditLogsKey is the key for the audit logs recorded in a transaction in the context
+type pendingAuditLogsKey struct{}
+
+// pendingAuditLogs are the audit logs recorded in a transaction, which are chained when it is about to commit
+type pendingAuditLogs struct {
+	auditLogs []*domain.AuditLog
+}
+
+/**
+ * AuditService implements port.AuditService interface
+ * and provides an access to the audit repository
+ * and the database transactions
+ */
+type AuditService struct {
+	repo port.AuditRepository
+	tx   port.Transactor
+}
+
+// NewAuditService creates a new audit service instance
+func NewAuditService(repo port.AuditRepository, tx port.Transactor) *AuditService {
+	return &AuditService{
+		repo,
+		tx,
+	}
+}
+
+// RecordAuditLog records an action with the actor of the request the context carries,
+// unless the log already names the user, and the fields that differ between before and after.
+// In a transaction the log is only chained when the transaction is about to commit
+func (as *AuditService) RecordAuditLog(ctx context.Context, auditLog *domain.AuditLog, before, after any) error {
+	actor, ok := domain.ActorFromContext(ctx)
+	if ok {
+		if auditLog.UserID == 0 {
+			auditLog.UserID = actor.UserID
+			auditLog.ApproverID = actor.ApproverID
+		}
+		auditLog.APIKeyID = actor.APIKeyID
+		auditLog.IP = actor.IP
+		auditLog.RequestID = actor.RequestID
+	}
+
+	changes, err := auditChanges(before, after)
+	if err != nil {
+		return domain.ErrInternal
+	}
+
+	auditLog.Changes = changes
+
+	pending, ok := ctx.Value(pendingAuditLogsKey{}).(*pendingAuditLogs)
+	if ok {
+		pending.auditLogs = append(pending.auditLogs, auditLog)
+		return nil
+	}
+
+	return as.createAuditLogs(ctx, []*domain.AuditLog{auditLog})
+}
+
+// WithinTransaction runs fn in a database transaction and returns the error fn returns,
+// or domain.ErrInternal if the transaction cannot be started or committed. The audit logs fn records
+// are chained as the last step of the outermost transaction, so that the lock serializing the chain
+// is only held from then until the commit
+func (as *AuditService) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	var fnErr error
+
+	parent, nested := ctx.Value(pendingAuditLogsKey{}).(*pendingAuditLogs)
+	pending := &pendingAuditLogs{}
+
+	err := as.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		fnErr = fn(context.WithValue(ctx, pendingAuditLogsKey{}, pending))
+		if fnErr != nil || nested {
+			return fnErr
+		}
+
+		fnErr = as.createAuditLogs(ctx, pending.auditLogs)
+		return fnErr
+	})
+	if fnErr != nil {
+		return fnErr
+	}
+	if err != nil {
+		return domain.ErrInternal
+	}
+
+	// A nested transaction is a savepoint, whose logs are chained with the transaction it is part of
+	if nested {
+		parent.auditLogs = append(parent.auditLogs, pending.auditLogs...)
+	}
+
+	return nil
+}
+
+// createAuditLogs chains the audit logs to the audit trail in the order they were recorded
+func (as *AuditService) createAuditLogs(ctx context.Context, auditLogs []*domain.AuditLog) error {
+	for _, auditLog := range auditLogs {
+		_, err := as.repo.CreateAuditLog(ctx, auditLog)
+		if err != nil {
+			return domain.ErrInternal
+		}
+	}
+
+	return nil
+}
+
+// ListAuditLogs lists the audit logs matching the filter
+func (as *AuditService) ListAuditLogs(ctx context.Context, filter *domain.AuditLogFilter, params *domain.ListParams) ([]domain.AuditLog, uint64, error) {
+	if filter.StartDate != nil && filter.EndDate != nil && !filter.StartDate.Before(*filter.EndDate) {
+		return nil, 0, domain.ErrInvalidDateRange
+	}
+
+	auditLogs, total, err := as.repo.ListAuditLogs(ctx, filter, params)
+	if err != nil {
+		return nil, 0, domain.ErrInternal
+	}
+
+	return auditLogs, total, nil
+}
+
+// VerifyAuditLogs walks the audit trail in the order it was chained and recomputes the hash of every log,
+// stopping at the first log that was edited or that follows a removed log. Logs recorded before the
+// hash chain was introduced have no hash and are skipped
+func (as *AuditService) VerifyAuditLogs(ctx context.Context) (*domain.AuditVerification, error) {
+	verification := &domain.AuditVerification{}
+
+	var lastID uint64
+	var prevHash string
+	chained := false
+
+	for {
+		auditLogs, err := as.repo.ListAuditLogsAfter(ctx, lastID, auditVerifyBatchSize)
+		if err != nil {
+			return nil, domain.ErrInternal
+		}
+
+		for _, auditLog := range auditLogs {
+			lastID = auditLog.ID
+			verification.Checked++
+
+			if !chained && auditLog.Hash == "" {
+				continue
+			}
+			chained = true
+
+			if auditLog.PrevHash != prevHash || auditLog.ComputeHash() != auditLog.Hash {
+				verification.BrokenID = auditLog.ID
+				return verification, nil
+			}
+
+			prevHash = auditLog.Hash
+		}
+
+		if len(auditLogs) < auditVerifyBatchSize {
+			return verification, nil
+		}
+	}
+}
+
+// auditChanges returns the fields that differ between the entity before and after an action,
+// with the values of secret fields redacted
+func auditChanges(before, after any) (map[string]domain.AuditChange, error) {
+	beforeFields, err := auditFields(before)
+	if err != nil {
+		return nil, err
+	}
+
+	afterFields, err := auditFields(after)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make(map[string]domain.AuditChange)
+
+	for field, value := range beforeFields {
+		afterValue, ok := afterFields[field]
+		if !ok || !reflect.DeepEqual(value, afterValue) {
+			changes[field] = domain.AuditChange{Before: value, After: afterValue}
+		}
+	}
+
+	for field, value := range afterFields {
+		if _, ok := beforeFields[field]; !ok {
+			changes[field] = domain.AuditChange{After: value}
+		}
+	}
+
+	for field, change := range changes {
+		if slices.Contains(auditIgnoredFields, field) {
+			delete(changes, field)
+			continue
+		}
+
+		if slices.Contains(auditRedactedFields, field) {
+			changes[field] = domain.AuditChange{
+				Before: redactAuditValue(change.Before),
+				After:  redactAuditValue(change.After),
+			}
+		}
+	}
+
+	if len(changes) == 0 {
+		return nil, nil
+	}
+
+	return changes, nil
+}
+
+// auditFields converts an entity into its fields as they are encoded in JSON, or nil if there is no entity
+func auditFields(entity any) (map[string]any, error) {
+	var fields map[string]any
+
+	if entity == nil {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(entity)
+	if err != nil {
+		return nil, err
+	}
+
+	err = json.Unmarshal(data, &fields)
+	if err != nil {
+		return nil, err
+	}
+
+	return fields, nil
+}
+
+// redactAuditValue hides a secret value, keeping whether it was set
+func redactAuditValue(value any) any {
+	if value == nil || value == "" {
+		return value
+	}
+
+	return auditRedactedValue
+}
@@ -0,0 +1,415 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/nikhil-shrestha/go-pos/internal/core/domain"
+	"github.com/nikhil-shrestha/go-pos/internal/core/port/mock"
+	"github.com/nikhil-shrestha/go-pos/internal/core/service"
+	"github.com/brianvoe/gofakeit/v6"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+// newMockAuditService creates an audit service mock that accepts every audit log and runs transactions in place
+func newMockAuditService(ctrl *gomock.Controller) *mock.MockAuditService {
+	audit := mock.NewMockAuditService(ctrl)
+	audit.EXPECT().RecordAuditLog(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	audit.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).DoAndReturn(runInPlace).AnyTimes()
+
+	return audit
+}
+
+// newAuditService creates an audit service that records to the repository mock and runs transactions in place
+func newAuditService(ctrl *gomock.Controller, auditRepo *mock.MockAuditRepository) *service.AuditService {
+	tx := mock.NewMockTransactor(ctrl)
+	tx.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).DoAndReturn(runInPlace).AnyTimes()
+
+	return service.NewAuditService(auditRepo, tx)
+}
+
+// runInPlace stands in for a database transaction by running the function with the context it is given
+func runInPlace(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+type recordAuditLogTestedInput struct {
+	ctx      context.Context
+	auditLog *domain.AuditLog
+	before   any
+	after    any
+}
+
+func TestAuditService_RecordAuditLog(t *testing.T) {
+	actor := &domain.Actor{
+		UserID:     gofakeit.Uint64(),
+		APIKeyID:   gofakeit.Uint64(),
+		ApproverID: gofakeit.Uint64(),
+		IP:         gofakeit.IPv4Address(),
+		RequestID:  gofakeit.UUID(),
+	}
+	actorCtx := domain.NewActorContext(context.Background(), actor)
+	product := &domain.Product{
+		ID:        gofakeit.Uint64(),
+		Name:      gofakeit.ProductName(),
+		Price:     gofakeit.Price(1000, 2000),
+		Stock:     100,
+		UpdatedAt: time.Now(),
+	}
+	updatedProduct := *product
+	updatedProduct.Price = product.Price + 100
+	updatedProduct.UpdatedAt = product.UpdatedAt.Add(time.Minute)
+	user := &domain.User{
+		ID:       gofakeit.Uint64(),
+		Name:     gofakeit.Name(),
+		Password: "hashed password",
+	}
+	updatedUser := *user
+	updatedUser.Password = "new hashed password"
+	userID := gofakeit.Uint64()
+
+	testCases := []struct {
+		desc     string
+		mocks    func(auditRepo *mock.MockAuditRepository)
+		input    recordAuditLogTestedInput
+		expected error
+	}{
+		{
+			desc: "Success_Update",
+			mocks: func(auditRepo *mock.MockAuditRepository) {
+				auditRepo.EXPECT().
+					CreateAuditLog(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, auditLog *domain.AuditLog) (*domain.AuditLog, error) {
+						assert.Equal(t, actor.UserID, auditLog.UserID, "User mismatch")
+						assert.Equal(t, actor.APIKeyID, auditLog.APIKeyID, "API key mismatch")
+						assert.Equal(t, actor.ApproverID, auditLog.ApproverID, "Approver mismatch")
+						assert.Equal(t, actor.IP, auditLog.IP, "IP mismatch")
+						assert.Equal(t, actor.RequestID, auditLog.RequestID, "Request ID mismatch")
+						assert.Equal(t, map[string]domain.AuditChange{
+							"Price": {Before: product.Price, After: updatedProduct.Price},
+						}, auditLog.Changes, "Changes mismatch")
+						return auditLog, nil
+					})
+			},
+			input: recordAuditLogTestedInput{
+				ctx:      actorCtx,
+				auditLog: &domain.AuditLog{Action: "product.update", Entity: "product", EntityID: product.ID},
+				before:   product,
+				after:    &updatedProduct,
+			},
+			expected: nil,
+		},
+		{
+			desc: "Success_RedactedSecret",
+			mocks: func(auditRepo *mock.MockAuditRepository) {
+				auditRepo.EXPECT().
+					CreateAuditLog(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, auditLog *domain.AuditLog) (*domain.AuditLog, error) {
+						assert.Equal(t, map[string]domain.AuditChange{
+							"Password": {Before: "[REDACTED]", After: "[REDACTED]"},
+						}, auditLog.Changes, "Changes mismatch")
+						return auditLog, nil
+					})
+			},
+			input: recordAuditLogTestedInput{
+				ctx:      context.Background(),
+				auditLog: &domain.AuditLog{Action: "user.update", Entity: "user", EntityID: user.ID},
+				before:   user,
+				after:    &updatedUser,
+			},
+			expected: nil,
+		},
+		{
+			desc: "Success_ExplicitUser",
+			mocks: func(auditRepo *mock.MockAuditRepository) {
+				auditRepo.EXPECT().
+					CreateAuditLog(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, auditLog *domain.AuditLog) (*domain.AuditLog, error) {
+						assert.Equal(t, userID, auditLog.UserID, "User mismatch")
+						assert.Zero(t, auditLog.ApproverID, "Approver mismatch")
+						assert.Equal(t, actor.RequestID, auditLog.RequestID, "Request ID mismatch")
+						assert.Nil(t, auditLog.Changes, "Changes mismatch")
+						return auditLog, nil
+					})
+			},
+			input: recordAuditLogTestedInput{
+				ctx:      actorCtx,
+				auditLog: &domain.AuditLog{UserID: userID, Action: "drawer.open", Entity: "drawer"},
+			},
+			expected: nil,
+		},
+		{
+			desc: "Fail_InternalError",
+			mocks: func(auditRepo *mock.MockAuditRepository) {
+				auditRepo.EXPECT().CreateAuditLog(gomock.Any(), gomock.Any()).Return(nil, domain.ErrInternal)
+			},
+			input: recordAuditLogTestedInput{
+				ctx:      actorCtx,
+				auditLog: &domain.AuditLog{Action: "product.delete", Entity: "product", EntityID: product.ID},
+				before:   product,
+			},
+			expected: domain.ErrInternal,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			auditRepo := mock.NewMockAuditRepository(ctrl)
+
+			tc.mocks(auditRepo)
+
+			auditService := service.NewAuditService(auditRepo, mock.NewMockTransactor(ctrl))
+
+			err := auditService.RecordAuditLog(tc.input.ctx, tc.input.auditLog, tc.input.before, tc.input.after)
+			assert.ErrorIs(t, err, tc.expected, "Error mismatch")
+		})
+	}
+}
+
+// newAuditChain creates a chain of audit logs following the given number of logs recorded before the chain
+func newAuditChain(legacy, chained int) []domain.AuditLog {
+	var auditLogs []domain.AuditLog
+	var prevHash string
+
+	for i := 0; i < legacy+chained; i++ {
+		auditLog := domain.AuditLog{
+			ID:        uint64(i + 1),
+			UserID:    gofakeit.Uint64(),
+			Action:    "product.update",
+			Entity:    "product",
+			EntityID:  gofakeit.Uint64(),
+			Changes:   map[string]domain.AuditChange{"Price": {Before: 1000.0, After: 1200.0}},
+			CreatedAt: time.Now().UTC().Truncate(time.Microsecond),
+		}
+
+		if i >= legacy {
+			auditLog.PrevHash = prevHash
+			auditLog.Hash = auditLog.ComputeHash()
+			prevHash = auditLog.Hash
+		}
+
+		auditLogs = append(auditLogs, auditLog)
+	}
+
+	return auditLogs
+}
+
+func TestAuditService_VerifyAuditLogs(t *testing.T) {
+	ctx := context.Background()
+
+	intact := newAuditChain(2, 5)
+
+	edited := newAuditChain(0, 5)
+	edited[2].Changes["Price"] = domain.AuditChange{Before: 1000.0, After: 1.0}
+
+	removed := newAuditChain(0, 5)
+	removed = append(removed[:3], removed[4:]...)
+
+	testCases := []struct {
+		desc     string
+		mocks    func(auditRepo *mock.MockAuditRepository)
+		expected *domain.AuditVerification
+		err      error
+	}{
+		{
+			desc: "Success_Intact",
+			mocks: func(auditRepo *mock.MockAuditRepository) {
+				auditRepo.EXPECT().ListAuditLogsAfter(gomock.Any(), gomock.Eq(uint64(0)), gomock.Any()).Return(intact, nil)
+			},
+			expected: &domain.AuditVerification{Checked: 7},
+		},
+		{
+			desc: "Success_Edited",
+			mocks: func(auditRepo *mock.MockAuditRepository) {
+				auditRepo.EXPECT().ListAuditLogsAfter(gomock.Any(), gomock.Eq(uint64(0)), gomock.Any()).Return(edited, nil)
+			},
+			expected: &domain.AuditVerification{Checked: 3, BrokenID: edited[2].ID},
+		},
+		{
+			desc: "Success_Removed",
+			mocks: func(auditRepo *mock.MockAuditRepository) {
+				auditRepo.EXPECT().ListAuditLogsAfter(gomock.Any(), gomock.Eq(uint64(0)), gomock.Any()).Return(removed, nil)
+			},
+			expected: &domain.AuditVerification{Checked: 4, BrokenID: removed[3].ID},
+		},
+		{
+			desc: "Fail_InternalError",
+			mocks: func(auditRepo *mock.MockAuditRepository) {
+				auditRepo.EXPECT().ListAuditLogsAfter(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, domain.ErrInternal)
+			},
+			err: domain.ErrInternal,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			auditRepo := mock.NewMockAuditRepository(ctrl)
+
+			tc.mocks(auditRepo)
+
+			auditService := service.NewAuditService(auditRepo, mock.NewMockTransactor(ctrl))
+
+			verification, err := auditService.VerifyAuditLogs(ctx)
+			assert.ErrorIs(t, err, tc.err, "Error mismatch")
+			assert.Equal(t, tc.expected, verification, "Verification mismatch")
+		})
+	}
+}
+
+func TestAuditService_WithinTransaction(t *testing.T) {
+	ctx := context.Background()
+	errCommit := errors.New("commit failed")
+
+	testCases := []struct {
+		desc     string
+		mocks    func(tx *mock.MockTransactor)
+		fnErr    error
+		expected error
+	}{
+		{
+			desc: "Success",
+			mocks: func(tx *mock.MockTransactor) {
+				tx.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).DoAndReturn(runInPlace)
+			},
+			expected: nil,
+		},
+		{
+			desc: "Fail_FunctionError",
+			mocks: func(tx *mock.MockTransactor) {
+				tx.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).DoAndReturn(runInPlace)
+			},
+			fnErr:    domain.ErrDataNotFound,
+			expected: domain.ErrDataNotFound,
+		},
+		{
+			desc: "Fail_CommitError",
+			mocks: func(tx *mock.MockTransactor) {
+				tx.EXPECT().
+					WithinTransaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+						if err := fn(ctx); err != nil {
+							return err
+						}
+						return errCommit
+					})
+			},
+			expected: domain.ErrInternal,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			tx := mock.NewMockTransactor(ctrl)
+
+			tc.mocks(tx)
+
+			auditService := service.NewAuditService(mock.NewMockAuditRepository(ctrl), tx)
+
+			called := false
+			err := auditService.WithinTransaction(ctx, func(ctx context.Context) error {
+				called = true
+				return tc.fnErr
+			})
+			assert.True(t, called, "Function not called")
+			assert.ErrorIs(t, err, tc.expected, "Error mismatch")
+		})
+	}
+}
+
+func TestAuditService_WithinTransaction_AuditLogs(t *testing.T) {
+	ctx := context.Background()
+	outerLog := &domain.AuditLog{Action: "order.void", Entity: "order", EntityID: gofakeit.Uint64()}
+	innerLog := &domain.AuditLog{Action: "override.use", Entity: "override", EntityID: gofakeit.Uint64()}
+
+	testCases := []struct {
+		desc     string
+		mocks    func(auditRepo *mock.MockAuditRepository)
+		nested   bool
+		fnErr    error
+		expected error
+	}{
+		{
+			desc: "Success_ChainedOnCommit",
+			mocks: func(auditRepo *mock.MockAuditRepository) {
+				auditRepo.EXPECT().CreateAuditLog(gomock.Any(), gomock.Eq(outerLog)).Return(outerLog, nil)
+			},
+			expected: nil,
+		},
+		{
+			desc: "Success_Nested",
+			mocks: func(auditRepo *mock.MockAuditRepository) {
+				gomock.InOrder(
+					auditRepo.EXPECT().CreateAuditLog(gomock.Any(), gomock.Eq(outerLog)).Return(outerLog, nil),
+					auditRepo.EXPECT().CreateAuditLog(gomock.Any(), gomock.Eq(innerLog)).Return(innerLog, nil),
+				)
+			},
+			nested:   true,
+			expected: nil,
+		},
+		{
+			desc:     "Fail_FunctionError",
+			mocks:    func(auditRepo *mock.MockAuditRepository) {},
+			fnErr:    domain.ErrDataNotFound,
+			expected: domain.ErrDataNotFound,
+		},
+		{
+			desc: "Fail_InternalError",
+			mocks: func(auditRepo *mock.MockAuditRepository) {
+				auditRepo.EXPECT().CreateAuditLog(gomock.Any(), gomock.Any()).Return(nil, domain.ErrInternal)
+			},
+			expected: domain.ErrInternal,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			auditRepo := mock.NewMockAuditRepository(ctrl)
+
+			tc.mocks(auditRepo)
+
+			auditService := newAuditService(ctrl, auditRepo)
+
+			err := auditService.WithinTransaction(ctx, func(ctx context.Context) error {
+				err := auditService.RecordAuditLog(ctx, outerLog, nil, nil)
+				if err != nil {
+					return err
+				}
+
+				if tc.nested {
+					err = auditService.WithinTransaction(ctx, func(ctx context.Context) error {
+						return auditService.RecordAuditLog(ctx, innerLog, nil, nil)
+					})
+					if err != nil {
+						return err
+					}
+				}
+
+				return tc.fnErr
+			})
+			assert.ErrorIs(t, err, tc.expected, "Error mismatch")
+		})
+	}
+}
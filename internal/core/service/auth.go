@@ -2,49 +2,612 @@ package service
 
 import (
 	"context"
+	"fmt"
+	"strings"
+	"time"
 
 	"github.com/nikhil-shrestha/go-pos/internal/core/domain"
 	"github.com/nikhil-shrestha/go-pos/internal/core/port"
 	"github.com/nikhil-shrestha/go-pos/internal/core/util"
+	"github.com/google/uuid"
 )
 
 /**
  * AuthService implements port.AuthService interface
- * and provides an access to the user repository
- * and token service
+ * and provides an access to the user, role and terminal repositories,
+ * token service, two-factor service, cache service, notifier and audit service
  */
 type AuthService struct {
-	repo port.UserRepository
-	ts   port.TokenService
+	repo            port.UserRepository
+	roleRepo        port.RoleRepository
+	terminalRepo    port.TerminalRepository
+	ts              port.TokenService
+	twoFactor       port.TwoFactorService
+	cache           port.CacheRepository
+	notifier        port.Notifier
+	audit           port.AuditService
+	refreshDuration time.Duration
 }
 
 // NewAuthService creates a new auth service instance
-func NewAuthService(repo port.UserRepository, ts port.TokenService) *AuthService {
+func NewAuthService(repo port.UserRepository, roleRepo port.RoleRepository, terminalRepo port.TerminalRepository, ts port.TokenService, twoFactor port.TwoFactorService, cache port.CacheRepository, notifier port.Notifier, audit port.AuditService, refreshDuration time.Duration) *AuthService {
 	return &AuthService{
 		repo,
+		roleRepo,
+		terminalRepo,
 		ts,
+		twoFactor,
+		cache,
+		notifier,
+		audit,
+		refreshDuration,
 	}
 }
 
-// Login gives a registered user an access token if the credentials are valid
-func (as *AuthService) Login(ctx context.Context, email, password string) (string, error) {
+// Login gives a registered user an access token and a refresh token if the credentials are valid,
+// or a challenge to answer with a two-factor code first if the user is enrolled or their role requires it.
+// Failed logins are counted per account and per client IP, and lock logins with a growing lockout once there are too many
+func (as *AuthService) Login(ctx context.Context, email, password, clientIP string) (*domain.AuthToken, error) {
+	account := strings.ToLower(email)
+
+	err := loginAccountLockout.check(ctx, as.cache, account)
+	if err != nil {
+		return nil, err
+	}
+
+	err = loginClientLockout.check(ctx, as.cache, clientIP)
+	if err != nil {
+		return nil, err
+	}
+
 	user, err := as.repo.GetUserByEmail(ctx, email)
 	if err != nil {
 		if err == domain.ErrDataNotFound {
-			return "", domain.ErrInvalidCredentials
+			return nil, as.failLogin(ctx, account, clientIP)
 		}
-		return "", domain.ErrInternal
+		return nil, domain.ErrInternal
 	}
 
 	err = util.ComparePassword(password, user.Password)
 	if err != nil {
-		return "", domain.ErrInvalidCredentials
+		return nil, as.failLogin(ctx, account, clientIP)
+	}
+
+	err = loginAccountLockout.reset(ctx, as.cache, account)
+	if err != nil {
+		return nil, err
+	}
+
+	challenge, err := as.twoFactor.CreateChallenge(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	if challenge != nil {
+		return &domain.AuthToken{
+			Challenge: challenge,
+		}, nil
+	}
+
+	return as.startSession(ctx, user)
+}
+
+// VerifyChallenge completes a login with the two-factor code of its challenge.
+// The tokens come with the recovery codes if the user enrolled while answering the challenge
+func (as *AuthService) VerifyChallenge(ctx context.Context, challengeID, code string) (*domain.AuthToken, error) {
+	userID, recoveryCodes, err := as.twoFactor.VerifyChallenge(ctx, challengeID, code)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := as.repo.GetUserByID(ctx, userID)
+	if err != nil {
+		if err == domain.ErrDataNotFound {
+			return nil, domain.ErrInvalidChallenge
+		}
+		return nil, domain.ErrInternal
+	}
+
+	token, err := as.startSession(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	token.RecoveryCodes = recoveryCodes
+
+	return token, nil
+}
+
+// PinLogin gives a user tokens bound to a registered terminal if the PIN is valid.
+// The terminal key stands in for the second factor, so there is no two-factor challenge,
+// and users whose role requires two-factor authentication cannot log in with a PIN.
+// PIN login is locked for the user after too many failed attempts, while login with a password keeps working
+func (as *AuthService) PinLogin(ctx context.Context, terminalID uint64, terminalKey string, userID uint64, pin string) (*domain.AuthToken, error) {
+	err := as.verifyTerminal(ctx, terminalID, terminalKey)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := verifyPin(ctx, as.repo, as.cache, userID, pin)
+	if err != nil {
+		return nil, err
+	}
+
+	if as.twoFactor.IsRequired(user.Role) {
+		return nil, domain.ErrPinLoginNotAllowed
+	}
+
+	user.TerminalID = terminalID
+
+	return as.startSession(ctx, user)
+}
+
+// UnlockUser lifts the login and PIN lockouts of a user before they expire
+func (as *AuthService) UnlockUser(ctx context.Context, id uint64) error {
+	user, err := as.repo.GetUserByID(ctx, id)
+	if err != nil {
+		if err == domain.ErrDataNotFound {
+			return err
+		}
+		return domain.ErrInternal
+	}
+
+	err = loginAccountLockout.reset(ctx, as.cache, strings.ToLower(user.Email))
+	if err != nil {
+		return err
+	}
+
+	err = pinLockout.reset(ctx, as.cache, user.ID)
+	if err != nil {
+		return err
+	}
+
+	return as.audit.RecordAuditLog(ctx, &domain.AuditLog{Action: "user.unlock", Entity: "user", EntityID: user.ID}, nil, nil)
+}
+
+// Refresh rotates the refresh token of a session and gives a new access token.
+// Using a refresh token that has already been rotated revokes the whole session,
+// since either the user or whoever stole the token is replaying it. The rotation
+// only succeeds if the session is unchanged since it was read, so two requests
+// racing with the same refresh token are caught as reuse too
+func (as *AuthService) Refresh(ctx context.Context, refreshToken string) (*domain.AuthToken, error) {
+	session, refreshTokenID, sessionSerialized, err := as.getSession(ctx, refreshToken)
+	if err != nil {
+		return nil, err
+	}
+
+	if session.RefreshTokenID != refreshTokenID {
+		err = as.revokeSession(ctx, session)
+		if err != nil {
+			return nil, err
+		}
+		return nil, domain.ErrRefreshTokenReused
+	}
+
+	revoked, err := as.isRevokedByUser(ctx, session.UserID, session.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	if revoked {
+		err = as.deleteSession(ctx, session)
+		if err != nil {
+			return nil, err
+		}
+		return nil, domain.ErrInvalidRefreshToken
+	}
+
+	user, err := as.repo.GetUserByID(ctx, session.UserID)
+	if err != nil {
+		if err == domain.ErrDataNotFound {
+			return nil, domain.ErrInvalidRefreshToken
+		}
+		return nil, domain.ErrInternal
+	}
+
+	user.TerminalID = session.TerminalID
+
+	err = as.loadPermissions(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	accessToken, payload, err := as.ts.CreateToken(user)
+	if err != nil {
+		return nil, domain.ErrTokenCreation
+	}
+
+	session.RefreshTokenID = uuid.New()
+	session.AccessTokenID = payload.ID
+	session.AccessExpiresAt = payload.ExpiresAt
+
+	swapped, err := as.swapSession(ctx, sessionSerialized, session)
+	if err != nil {
+		return nil, err
+	}
+	if !swapped {
+		// another request rotated or ended the session after it was read
+		session, _, _, err = as.getSession(ctx, refreshToken)
+		if err != nil {
+			return nil, err
+		}
+
+		err = as.revokeSession(ctx, session)
+		if err != nil {
+			return nil, err
+		}
+		return nil, domain.ErrRefreshTokenReused
+	}
+
+	return &domain.AuthToken{
+		AccessToken:  accessToken,
+		RefreshToken: newRefreshToken(session),
+	}, nil
+}
+
+// Logout revokes the access token and ends the session of the refresh token, if it belongs to the same user
+func (as *AuthService) Logout(ctx context.Context, payload *domain.TokenPayload, refreshToken string) error {
+	err := as.revokeAccessToken(ctx, payload.ID, payload.ExpiresAt)
+	if err != nil {
+		return err
+	}
+
+	if refreshToken == "" {
+		return nil
+	}
+
+	session, _, _, err := as.getSession(ctx, refreshToken)
+	if err != nil {
+		if err == domain.ErrInvalidRefreshToken {
+			return nil
+		}
+		return err
+	}
+
+	if session.UserID != payload.UserID {
+		return nil
+	}
+
+	return as.deleteSession(ctx, session)
+}
+
+// LogoutAll revokes every access token and session of the user issued up to now
+func (as *AuthService) LogoutAll(ctx context.Context, payload *domain.TokenPayload) error {
+	return as.revokeUser(ctx, payload.UserID)
+}
+
+// revokeUser revokes every access token and session of the user issued up to now
+func (as *AuthService) revokeUser(ctx context.Context, userID uint64) error {
+	return revokeUser(ctx, as.cache, userID, as.refreshDuration)
+}
+
+// revokeUser records when the user was revoked, rejecting every access token and session issued up to then
+func revokeUser(ctx context.Context, cache port.CacheRepository, userID uint64, refreshDuration time.Duration) error {
+	return revokeNow(ctx, cache, util.GenerateCacheKey("revoked_user", userID), refreshDuration)
+}
+
+// revokeRole records when the permissions of the role changed, rejecting every access token issued with the old ones.
+// Sessions are kept, since refreshing them issues access tokens with the permissions the role has then
+func revokeRole(ctx context.Context, cache port.CacheRepository, role domain.UserRole, refreshDuration time.Duration) error {
+	return revokeNow(ctx, cache, util.GenerateCacheKey("revoked_role", role), refreshDuration)
+}
+
+// revokeNow stores the time of a revocation under the cache key
+func revokeNow(ctx context.Context, cache port.CacheRepository, cacheKey string, refreshDuration time.Duration) error {
+	revokedAtSerialized, err := util.Serialize(time.Now())
+	if err != nil {
+		return domain.ErrInternal
+	}
+
+	// Sessions outlive access tokens, so the revocation only has to last as long as a session
+	err = cache.Set(ctx, cacheKey, revokedAtSerialized, refreshDuration)
+	if err != nil {
+		return domain.ErrInternal
+	}
+
+	return nil
+}
+
+// VerifyToken verifies the access token and rejects it if it has been revoked by a logout or a change of the
+// permissions of its role, or if it was issued by a PIN login and the request does not carry the key of its terminal
+func (as *AuthService) VerifyToken(ctx context.Context, token, terminalKey string) (*domain.TokenPayload, error) {
+	payload, err := as.ts.VerifyToken(token)
+	if err != nil {
+		return nil, err
+	}
+
+	cacheKey := util.GenerateCacheKey("revoked_token", payload.ID)
+
+	_, err = as.cache.Get(ctx, cacheKey)
+	if err == nil {
+		return nil, domain.ErrRevokedToken
+	}
+	if err != domain.ErrDataNotFound {
+		return nil, domain.ErrInternal
+	}
+
+	revoked, err := as.isRevokedByUser(ctx, payload.UserID, payload.IssuedAt)
+	if err != nil {
+		return nil, err
+	}
+	if revoked {
+		return nil, domain.ErrRevokedToken
+	}
+
+	revoked, err = as.isRevoked(ctx, util.GenerateCacheKey("revoked_role", payload.Role), payload.IssuedAt)
+	if err != nil {
+		return nil, err
+	}
+	if revoked {
+		return nil, domain.ErrRevokedToken
+	}
+
+	if payload.TerminalID != 0 {
+		err = as.verifyTerminal(ctx, payload.TerminalID, terminalKey)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return payload, nil
+}
+
+// verifyTerminal checks that the terminal is registered and that the key is its key
+func (as *AuthService) verifyTerminal(ctx context.Context, id uint64, key string) error {
+	var terminal *domain.Terminal
+
+	cacheKey := util.GenerateCacheKey("terminal", id)
+
+	cachedTerminal, err := as.cache.Get(ctx, cacheKey)
+	if err == nil {
+		err = util.Deserialize(cachedTerminal, &terminal)
+		if err != nil {
+			return domain.ErrInternal
+		}
+	} else {
+		terminal, err = as.terminalRepo.GetTerminalByID(ctx, id)
+		if err != nil {
+			if err == domain.ErrDataNotFound {
+				return domain.ErrInvalidTerminal
+			}
+			return domain.ErrInternal
+		}
+
+		terminalSerialized, err := util.Serialize(terminal)
+		if err != nil {
+			return domain.ErrInternal
+		}
+
+		err = as.cache.Set(ctx, cacheKey, terminalSerialized, 0)
+		if err != nil {
+			return domain.ErrInternal
+		}
+	}
+
+	if !util.CompareKey(key, terminal.KeyHash) {
+		return domain.ErrInvalidTerminal
+	}
+
+	return nil
+}
+
+// failLogin counts a failed login for the account and the client and returns the error to report
+func (as *AuthService) failLogin(ctx context.Context, account, clientIP string) error {
+	accountErr := loginAccountLockout.fail(ctx, as.cache, account)
+	clientErr := loginClientLockout.fail(ctx, as.cache, clientIP)
+
+	if accountErr != nil {
+		return accountErr
+	}
+	if clientErr != nil {
+		return clientErr
+	}
+
+	return domain.ErrInvalidCredentials
+}
+
+// startSession gives the user an access token and starts a session for its refresh token
+func (as *AuthService) startSession(ctx context.Context, user *domain.User) (*domain.AuthToken, error) {
+	err := as.loadPermissions(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	accessToken, payload, err := as.ts.CreateToken(user)
+	if err != nil {
+		return nil, domain.ErrTokenCreation
+	}
+
+	session := &domain.Session{
+		ID:              uuid.New(),
+		UserID:          user.ID,
+		TerminalID:      user.TerminalID,
+		RefreshTokenID:  uuid.New(),
+		AccessTokenID:   payload.ID,
+		AccessExpiresAt: payload.ExpiresAt,
+		CreatedAt:       payload.IssuedAt,
+		ExpiresAt:       payload.IssuedAt.Add(as.refreshDuration),
+	}
+
+	err = as.saveSession(ctx, session)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.AuthToken{
+		AccessToken:  accessToken,
+		RefreshToken: newRefreshToken(session),
+	}, nil
+}
+
+// loadPermissions gives the user the permissions of its role, which are embedded in the access token
+func (as *AuthService) loadPermissions(ctx context.Context, user *domain.User) error {
+	role, err := as.roleRepo.GetRoleByName(ctx, user.Role)
+	if err != nil {
+		return domain.ErrInternal
+	}
+
+	user.Permissions = role.Permissions
+
+	return nil
+}
+
+// getSession parses the refresh token and retrieves its session, returning the id of the refresh token
+// and the session as it is stored
+func (as *AuthService) getSession(ctx context.Context, refreshToken string) (*domain.Session, uuid.UUID, []byte, error) {
+	var session *domain.Session
+
+	sessionID, refreshTokenID, ok := parseRefreshToken(refreshToken)
+	if !ok {
+		return nil, uuid.Nil, nil, domain.ErrInvalidRefreshToken
+	}
+
+	cacheKey := util.GenerateCacheKey("session", sessionID)
+
+	sessionSerialized, err := as.cache.Get(ctx, cacheKey)
+	if err != nil {
+		if err == domain.ErrDataNotFound {
+			return nil, uuid.Nil, nil, domain.ErrInvalidRefreshToken
+		}
+		return nil, uuid.Nil, nil, domain.ErrInternal
+	}
+
+	err = util.Deserialize(sessionSerialized, &session)
+	if err != nil {
+		return nil, uuid.Nil, nil, domain.ErrInternal
+	}
+
+	return session, refreshTokenID, sessionSerialized, nil
+}
+
+// saveSession stores the session until it expires
+func (as *AuthService) saveSession(ctx context.Context, session *domain.Session) error {
+	ttl := time.Until(session.ExpiresAt)
+	if ttl <= 0 {
+		return domain.ErrInvalidRefreshToken
+	}
+
+	sessionSerialized, err := util.Serialize(session)
+	if err != nil {
+		return domain.ErrInternal
+	}
+
+	cacheKey := util.GenerateCacheKey("session", session.ID)
+
+	err = as.cache.Set(ctx, cacheKey, sessionSerialized, ttl)
+	if err != nil {
+		return domain.ErrInternal
+	}
+
+	return nil
+}
+
+// swapSession stores the session only if it is still stored as previous, reporting whether it was stored
+func (as *AuthService) swapSession(ctx context.Context, previous []byte, session *domain.Session) (bool, error) {
+	ttl := time.Until(session.ExpiresAt)
+	if ttl <= 0 {
+		return false, domain.ErrInvalidRefreshToken
+	}
+
+	sessionSerialized, err := util.Serialize(session)
+	if err != nil {
+		return false, domain.ErrInternal
+	}
+
+	cacheKey := util.GenerateCacheKey("session", session.ID)
+
+	swapped, err := as.cache.CompareAndSwap(ctx, cacheKey, previous, sessionSerialized, ttl)
+	if err != nil {
+		return false, domain.ErrInternal
+	}
+
+	return swapped, nil
+}
+
+// deleteSession ends the session, so its refresh token can no longer be used
+func (as *AuthService) deleteSession(ctx context.Context, session *domain.Session) error {
+	cacheKey := util.GenerateCacheKey("session", session.ID)
+
+	err := as.cache.Delete(ctx, cacheKey)
+	if err != nil {
+		return domain.ErrInternal
+	}
+
+	return nil
+}
+
+// revokeSession ends the session and revokes the last access token it issued
+func (as *AuthService) revokeSession(ctx context.Context, session *domain.Session) error {
+	err := as.revokeAccessToken(ctx, session.AccessTokenID, session.AccessExpiresAt)
+	if err != nil {
+		return err
+	}
+
+	return as.deleteSession(ctx, session)
+}
+
+// revokeAccessToken adds the access token to the revocation list until it expires
+func (as *AuthService) revokeAccessToken(ctx context.Context, id uuid.UUID, expiresAt time.Time) error {
+	ttl := time.Until(expiresAt)
+	if ttl <= 0 {
+		return nil
+	}
+
+	cacheKey := util.GenerateCacheKey("revoked_token", id)
+
+	err := as.cache.Set(ctx, cacheKey, []byte{1}, ttl)
+	if err != nil {
+		return domain.ErrInternal
+	}
+
+	return nil
+}
+
+// isRevokedByUser reports whether the user logged out of all sessions after the token was issued
+func (as *AuthService) isRevokedByUser(ctx context.Context, userID uint64, issuedAt time.Time) (bool, error) {
+	return as.isRevoked(ctx, util.GenerateCacheKey("revoked_user", userID), issuedAt)
+}
+
+// isRevoked reports whether the revocation stored under the cache key was recorded after the token was issued
+func (as *AuthService) isRevoked(ctx context.Context, cacheKey string, issuedAt time.Time) (bool, error) {
+	var revokedAt time.Time
+
+	revokedAtSerialized, err := as.cache.Get(ctx, cacheKey)
+	if err != nil {
+		if err == domain.ErrDataNotFound {
+			return false, nil
+		}
+		return false, domain.ErrInternal
+	}
+
+	err = util.Deserialize(revokedAtSerialized, &revokedAt)
+	if err != nil {
+		return false, domain.ErrInternal
+	}
+
+	return !issuedAt.After(revokedAt), nil
+}
+
+// newRefreshToken creates the refresh token of a session, which is the session id followed by the id of its current refresh token
+func newRefreshToken(session *domain.Session) string {
+	return fmt.Sprintf("%s.%s", session.ID, session.RefreshTokenID)
+}
+
+// parseRefreshToken splits a refresh token into the session id and the refresh token id
+func parseRefreshToken(refreshToken string) (uuid.UUID, uuid.UUID, bool) {
+	sessionPart, refreshTokenPart, ok := strings.Cut(refreshToken, ".")
+	if !ok {
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	sessionID, err := uuid.Parse(sessionPart)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, false
 	}
 
-	accessToken, err := as.ts.CreateToken(user)
+	refreshTokenID, err := uuid.Parse(refreshTokenPart)
 	if err != nil {
-		return "", domain.ErrTokenCreation
+		return uuid.Nil, uuid.Nil, false
 	}
 
-	return accessToken, nil
+	return sessionID, refreshTokenID, true
 }
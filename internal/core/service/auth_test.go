@@ -2,13 +2,18 @@ package service_test
 
 import (
 	"context"
+	"errors"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/nikhil-shrestha/go-pos/internal/core/domain"
 	"github.com/nikhil-shrestha/go-pos/internal/core/port/mock"
 	"github.com/nikhil-shrestha/go-pos/internal/core/service"
 	"github.com/nikhil-shrestha/go-pos/internal/core/util"
 	"github.com/brianvoe/gofakeit/v6"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
 )
 
@@ -31,17 +36,52 @@ func TestAuthService_Login(t *testing.T) {
 		Email:    email,
 		Password: hashedPassword,
 	}
+	role := &domain.Role{
+		Name:        domain.Cashier,
+		Permissions: []domain.Permission{domain.OrdersCreate},
+	}
 	failUser := &domain.User{
 		Email:    email,
 		Password: "wrong password",
 	}
 	token := gofakeit.UUID()
+	payload := &domain.TokenPayload{
+		ID:        uuid.New(),
+		UserID:    user.ID,
+		IssuedAt:  time.Now(),
+		ExpiresAt: time.Now().Add(15 * time.Minute),
+	}
+	challenge := &domain.LoginChallenge{
+		ID:        uuid.New(),
+		UserID:    user.ID,
+		ExpiresAt: time.Now().Add(5 * time.Minute),
+	}
+	clientIP := "127.0.0.1"
+	accountLockKey := util.GenerateCacheKey("login_account_lock", strings.ToLower(email))
+	accountAttemptsKey := util.GenerateCacheKey("login_account_attempts", strings.ToLower(email))
+	clientLockKey := util.GenerateCacheKey("login_client_lock", clientIP)
+	clientAttemptsKey := util.GenerateCacheKey("login_client_attempts", clientIP)
+	lockedUntil, _ := util.Serialize(time.Now().Add(time.Minute))
+	unlocked := func(cache *mock.MockCacheRepository) {
+		cache.EXPECT().Get(gomock.Any(), gomock.Eq(accountLockKey)).Return(nil, domain.ErrDataNotFound)
+		cache.EXPECT().Get(gomock.Any(), gomock.Eq(clientLockKey)).Return(nil, domain.ErrDataNotFound)
+	}
+	failed := func(cache *mock.MockCacheRepository, accountAttempts int64) {
+		cache.EXPECT().Increment(gomock.Any(), gomock.Eq(accountAttemptsKey), gomock.Eq(time.Hour)).Return(accountAttempts, nil)
+		cache.EXPECT().Increment(gomock.Any(), gomock.Eq(clientAttemptsKey), gomock.Eq(time.Hour)).Return(int64(1), nil)
+	}
+	succeeded := func(cache *mock.MockCacheRepository) {
+		cache.EXPECT().Delete(gomock.Any(), gomock.Eq(accountAttemptsKey)).Return(nil)
+		cache.EXPECT().Delete(gomock.Any(), gomock.Eq(accountLockKey)).Return(nil)
+	}
 
 	testCases := []struct {
 		desc  string
 		mocks func(
 			userRepo *mock.MockUserRepository,
 			tokenService *mock.MockTokenService,
+			twoFactor *mock.MockTwoFactorService,
+			cache *mock.MockCacheRepository,
 		)
 		input    loginTestedInput
 		expected loginExpectedOutput
@@ -51,15 +91,27 @@ func TestAuthService_Login(t *testing.T) {
 			mocks: func(
 				userRepo *mock.MockUserRepository,
 				tokenService *mock.MockTokenService,
+				twoFactor *mock.MockTwoFactorService,
+				cache *mock.MockCacheRepository,
 			) {
+				unlocked(cache)
 				userRepo.EXPECT().
 					GetUserByEmail(gomock.Any(), gomock.Eq(email)).
 					Times(1).
 					Return(user, nil)
+				succeeded(cache)
+				twoFactor.EXPECT().
+					CreateChallenge(gomock.Any(), gomock.Eq(user)).
+					Times(1).
+					Return(nil, nil)
 				tokenService.EXPECT().
 					CreateToken(gomock.Eq(user)).
 					Times(1).
-					Return(token, nil)
+					Return(token, payload, nil)
+				cache.EXPECT().
+					Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil)
 			},
 			input: loginTestedInput{
 				email:    email,
@@ -70,16 +122,48 @@ func TestAuthService_Login(t *testing.T) {
 				err:   nil,
 			},
 		},
+		{
+			desc: "Success_Challenge",
+			mocks: func(
+				userRepo *mock.MockUserRepository,
+				tokenService *mock.MockTokenService,
+				twoFactor *mock.MockTwoFactorService,
+				cache *mock.MockCacheRepository,
+			) {
+				unlocked(cache)
+				userRepo.EXPECT().
+					GetUserByEmail(gomock.Any(), gomock.Eq(email)).
+					Times(1).
+					Return(user, nil)
+				succeeded(cache)
+				twoFactor.EXPECT().
+					CreateChallenge(gomock.Any(), gomock.Eq(user)).
+					Times(1).
+					Return(challenge, nil)
+			},
+			input: loginTestedInput{
+				email:    email,
+				password: password,
+			},
+			expected: loginExpectedOutput{
+				token: "",
+				err:   nil,
+			},
+		},
 		{
 			desc: "Fail_UserNotFound",
 			mocks: func(
 				userRepo *mock.MockUserRepository,
 				tokenService *mock.MockTokenService,
+				twoFactor *mock.MockTwoFactorService,
+				cache *mock.MockCacheRepository,
 			) {
+				unlocked(cache)
 				userRepo.EXPECT().
 					GetUserByEmail(gomock.Any(), gomock.Eq(email)).
 					Times(1).
 					Return(nil, domain.ErrDataNotFound)
+				failed(cache, 1)
 			},
 			input: loginTestedInput{
 				email:    email,
@@ -95,11 +179,15 @@ func TestAuthService_Login(t *testing.T) {
 			mocks: func(
 				userRepo *mock.MockUserRepository,
 				tokenService *mock.MockTokenService,
+				twoFactor *mock.MockTwoFactorService,
+				cache *mock.MockCacheRepository,
 			) {
+				unlocked(cache)
 				userRepo.EXPECT().
 					GetUserByEmail(gomock.Any(), gomock.Eq(email)).
 					Times(1).
 					Return(failUser, nil)
+				failed(cache, 1)
 			},
 			input: loginTestedInput{
 				email:    email,
@@ -110,20 +198,78 @@ func TestAuthService_Login(t *testing.T) {
 				err:   domain.ErrInvalidCredentials,
 			},
 		},
+		{
+			desc: "Fail_PasswordMismatchLocks",
+			mocks: func(
+				userRepo *mock.MockUserRepository,
+				tokenService *mock.MockTokenService,
+				twoFactor *mock.MockTwoFactorService,
+				cache *mock.MockCacheRepository,
+			) {
+				unlocked(cache)
+				userRepo.EXPECT().
+					GetUserByEmail(gomock.Any(), gomock.Eq(email)).
+					Times(1).
+					Return(failUser, nil)
+				failed(cache, 5)
+				cache.EXPECT().
+					Set(gomock.Any(), gomock.Eq(accountLockKey), gomock.Any(), gomock.Eq(30*time.Second)).
+					Times(1).
+					Return(nil)
+			},
+			input: loginTestedInput{
+				email:    email,
+				password: password,
+			},
+			expected: loginExpectedOutput{
+				token: "",
+				err:   domain.ErrAccountLocked,
+			},
+		},
+		{
+			desc: "Fail_Locked",
+			mocks: func(
+				userRepo *mock.MockUserRepository,
+				tokenService *mock.MockTokenService,
+				twoFactor *mock.MockTwoFactorService,
+				cache *mock.MockCacheRepository,
+			) {
+				cache.EXPECT().
+					Get(gomock.Any(), gomock.Eq(accountLockKey)).
+					Times(1).
+					Return(lockedUntil, nil)
+			},
+			input: loginTestedInput{
+				email:    email,
+				password: password,
+			},
+			expected: loginExpectedOutput{
+				token: "",
+				err:   domain.ErrAccountLocked,
+			},
+		},
 		{
 			desc: "Fail_TokenCreation",
 			mocks: func(
 				userRepo *mock.MockUserRepository,
 				tokenService *mock.MockTokenService,
+				twoFactor *mock.MockTwoFactorService,
+				cache *mock.MockCacheRepository,
 			) {
+				unlocked(cache)
 				userRepo.EXPECT().
 					GetUserByEmail(gomock.Any(), gomock.Eq(email)).
 					Times(1).
 					Return(user, nil)
+				succeeded(cache)
+				twoFactor.EXPECT().
+					CreateChallenge(gomock.Any(), gomock.Eq(user)).
+					Times(1).
+					Return(nil, nil)
 				tokenService.EXPECT().
 					CreateToken(gomock.Eq(user)).
 					Times(1).
-					Return("", domain.ErrTokenCreation)
+					Return("", nil, domain.ErrTokenCreation)
 			},
 			input: loginTestedInput{
 				email:    email,
@@ -139,7 +285,10 @@ func TestAuthService_Login(t *testing.T) {
 			mocks: func(
 				userRepo *mock.MockUserRepository,
 				tokenService *mock.MockTokenService,
+				twoFactor *mock.MockTwoFactorService,
+				cache *mock.MockCacheRepository,
 			) {
+				unlocked(cache)
 				userRepo.EXPECT().
 					GetUserByEmail(gomock.Any(), gomock.Eq(email)).
 					Times(1).
@@ -165,17 +314,758 @@ func TestAuthService_Login(t *testing.T) {
 
 			userRepo := mock.NewMockUserRepository(ctrl)
 			tokenService := mock.NewMockTokenService(ctrl)
+			roleRepo := mock.NewMockRoleRepository(ctrl)
+			terminalRepo := mock.NewMockTerminalRepository(ctrl)
+			twoFactor := mock.NewMockTwoFactorService(ctrl)
+			cache := mock.NewMockCacheRepository(ctrl)
+			notifier := mock.NewMockNotifier(ctrl)
 
-			tc.mocks(userRepo, tokenService)
+			roleRepo.EXPECT().
+				GetRoleByName(gomock.Any(), gomock.Any()).
+				AnyTimes().
+				Return(role, nil)
 
-			authService := service.NewAuthService(userRepo, tokenService)
+			tc.mocks(userRepo, tokenService, twoFactor, cache)
 
-			token, err := authService.Login(ctx, tc.input.email, tc.input.password)
-			if err != tc.expected.err {
+			authService := service.NewAuthService(userRepo, roleRepo, terminalRepo, tokenService, twoFactor, cache, notifier, newMockAuditService(ctrl), time.Hour)
+
+			token, err := authService.Login(ctx, tc.input.email, tc.input.password, clientIP)
+			if !errors.Is(err, tc.expected.err) {
 				t.Errorf("[case: %s] expected to get %q; got %q", tc.desc, tc.expected.err, err)
 			}
-			if token != tc.expected.token {
-				t.Errorf("[case: %s] expected to get %q; got %q", tc.desc, tc.expected.token, token)
+			if token != nil && token.AccessToken != tc.expected.token {
+				t.Errorf("[case: %s] expected to get %q; got %q", tc.desc, tc.expected.token, token.AccessToken)
+			}
+			if token == nil && tc.expected.token != "" {
+				t.Errorf("[case: %s] expected to get %q; got no token", tc.desc, tc.expected.token)
+			}
+			if token != nil && token.AccessToken == "" && token.Challenge != challenge {
+				t.Errorf("[case: %s] expected to get a challenge; got none", tc.desc)
+			}
+		})
+	}
+}
+
+type refreshTestedInput struct {
+	refreshToken string
+}
+
+type refreshExpectedOutput struct {
+	token string
+	err   error
+}
+
+func TestAuthService_Refresh(t *testing.T) {
+	ctx := context.Background()
+	user := &domain.User{
+		ID:   gofakeit.Uint64(),
+		Role: domain.Cashier,
+	}
+	role := &domain.Role{
+		Name:        domain.Cashier,
+		Permissions: []domain.Permission{domain.OrdersCreate},
+	}
+	session := &domain.Session{
+		ID:              uuid.New(),
+		UserID:          user.ID,
+		RefreshTokenID:  uuid.New(),
+		AccessTokenID:   uuid.New(),
+		AccessExpiresAt: time.Now().Add(15 * time.Minute),
+		CreatedAt:       time.Now().Add(-time.Minute),
+		ExpiresAt:       time.Now().Add(time.Hour),
+	}
+	sessionSerialized, _ := util.Serialize(session)
+	rotatedSession := *session
+	rotatedSession.RefreshTokenID = uuid.New()
+	rotatedSession.AccessTokenID = uuid.New()
+	rotatedSessionSerialized, _ := util.Serialize(rotatedSession)
+	sessionCacheKey := util.GenerateCacheKey("session", session.ID)
+	userCacheKey := util.GenerateCacheKey("revoked_user", user.ID)
+	refreshToken := session.ID.String() + "." + session.RefreshTokenID.String()
+	reusedRefreshToken := session.ID.String() + "." + uuid.NewString()
+	revokedAtSerialized, _ := util.Serialize(time.Now())
+	token := gofakeit.UUID()
+	payload := &domain.TokenPayload{
+		ID:        uuid.New(),
+		UserID:    user.ID,
+		IssuedAt:  time.Now(),
+		ExpiresAt: time.Now().Add(15 * time.Minute),
+	}
+
+	testCases := []struct {
+		desc  string
+		mocks func(
+			userRepo *mock.MockUserRepository,
+			tokenService *mock.MockTokenService,
+			cache *mock.MockCacheRepository,
+		)
+		input    refreshTestedInput
+		expected refreshExpectedOutput
+	}{
+		{
+			desc: "Success",
+			mocks: func(
+				userRepo *mock.MockUserRepository,
+				tokenService *mock.MockTokenService,
+				cache *mock.MockCacheRepository,
+			) {
+				cache.EXPECT().
+					Get(gomock.Any(), gomock.Eq(sessionCacheKey)).
+					Times(1).
+					Return(sessionSerialized, nil)
+				cache.EXPECT().
+					Get(gomock.Any(), gomock.Eq(userCacheKey)).
+					Times(1).
+					Return(nil, domain.ErrDataNotFound)
+				userRepo.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+				tokenService.EXPECT().
+					CreateToken(gomock.Eq(user)).
+					Times(1).
+					Return(token, payload, nil)
+				cache.EXPECT().
+					CompareAndSwap(gomock.Any(), gomock.Eq(sessionCacheKey), gomock.Eq(sessionSerialized), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(true, nil)
+			},
+			input: refreshTestedInput{
+				refreshToken: refreshToken,
+			},
+			expected: refreshExpectedOutput{
+				token: token,
+				err:   nil,
+			},
+		},
+		{
+			desc: "Fail_ConcurrentReuse",
+			mocks: func(
+				userRepo *mock.MockUserRepository,
+				tokenService *mock.MockTokenService,
+				cache *mock.MockCacheRepository,
+			) {
+				cache.EXPECT().
+					Get(gomock.Any(), gomock.Eq(sessionCacheKey)).
+					Times(1).
+					Return(sessionSerialized, nil)
+				cache.EXPECT().
+					Get(gomock.Any(), gomock.Eq(userCacheKey)).
+					Times(1).
+					Return(nil, domain.ErrDataNotFound)
+				userRepo.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+				tokenService.EXPECT().
+					CreateToken(gomock.Eq(user)).
+					Times(1).
+					Return(token, payload, nil)
+				cache.EXPECT().
+					CompareAndSwap(gomock.Any(), gomock.Eq(sessionCacheKey), gomock.Eq(sessionSerialized), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(false, nil)
+				cache.EXPECT().
+					Get(gomock.Any(), gomock.Eq(sessionCacheKey)).
+					Times(1).
+					Return(rotatedSessionSerialized, nil)
+				cache.EXPECT().
+					Set(gomock.Any(), gomock.Eq(util.GenerateCacheKey("revoked_token", rotatedSession.AccessTokenID)), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil)
+				cache.EXPECT().
+					Delete(gomock.Any(), gomock.Eq(sessionCacheKey)).
+					Times(1).
+					Return(nil)
+			},
+			input: refreshTestedInput{
+				refreshToken: refreshToken,
+			},
+			expected: refreshExpectedOutput{
+				err: domain.ErrRefreshTokenReused,
+			},
+		},
+		{
+			desc: "Fail_SwapError",
+			mocks: func(
+				userRepo *mock.MockUserRepository,
+				tokenService *mock.MockTokenService,
+				cache *mock.MockCacheRepository,
+			) {
+				cache.EXPECT().
+					Get(gomock.Any(), gomock.Eq(sessionCacheKey)).
+					Times(1).
+					Return(sessionSerialized, nil)
+				cache.EXPECT().
+					Get(gomock.Any(), gomock.Eq(userCacheKey)).
+					Times(1).
+					Return(nil, domain.ErrDataNotFound)
+				userRepo.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+				tokenService.EXPECT().
+					CreateToken(gomock.Eq(user)).
+					Times(1).
+					Return(token, payload, nil)
+				cache.EXPECT().
+					CompareAndSwap(gomock.Any(), gomock.Eq(sessionCacheKey), gomock.Eq(sessionSerialized), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(false, errors.New("redis down"))
+			},
+			input: refreshTestedInput{
+				refreshToken: refreshToken,
+			},
+			expected: refreshExpectedOutput{
+				err: domain.ErrInternal,
+			},
+		},
+		{
+			desc: "Fail_MalformedToken",
+			mocks: func(
+				userRepo *mock.MockUserRepository,
+				tokenService *mock.MockTokenService,
+				cache *mock.MockCacheRepository,
+			) {
+			},
+			input: refreshTestedInput{
+				refreshToken: "invalid",
+			},
+			expected: refreshExpectedOutput{
+				err: domain.ErrInvalidRefreshToken,
+			},
+		},
+		{
+			desc: "Fail_SessionNotFound",
+			mocks: func(
+				userRepo *mock.MockUserRepository,
+				tokenService *mock.MockTokenService,
+				cache *mock.MockCacheRepository,
+			) {
+				cache.EXPECT().
+					Get(gomock.Any(), gomock.Eq(sessionCacheKey)).
+					Times(1).
+					Return(nil, domain.ErrDataNotFound)
+			},
+			input: refreshTestedInput{
+				refreshToken: refreshToken,
+			},
+			expected: refreshExpectedOutput{
+				err: domain.ErrInvalidRefreshToken,
+			},
+		},
+		{
+			desc: "Fail_Reused",
+			mocks: func(
+				userRepo *mock.MockUserRepository,
+				tokenService *mock.MockTokenService,
+				cache *mock.MockCacheRepository,
+			) {
+				cache.EXPECT().
+					Get(gomock.Any(), gomock.Eq(sessionCacheKey)).
+					Times(1).
+					Return(sessionSerialized, nil)
+				cache.EXPECT().
+					Set(gomock.Any(), gomock.Eq(util.GenerateCacheKey("revoked_token", session.AccessTokenID)), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil)
+				cache.EXPECT().
+					Delete(gomock.Any(), gomock.Eq(sessionCacheKey)).
+					Times(1).
+					Return(nil)
+			},
+			input: refreshTestedInput{
+				refreshToken: reusedRefreshToken,
+			},
+			expected: refreshExpectedOutput{
+				err: domain.ErrRefreshTokenReused,
+			},
+		},
+		{
+			desc: "Fail_LoggedOutFromAllSessions",
+			mocks: func(
+				userRepo *mock.MockUserRepository,
+				tokenService *mock.MockTokenService,
+				cache *mock.MockCacheRepository,
+			) {
+				cache.EXPECT().
+					Get(gomock.Any(), gomock.Eq(sessionCacheKey)).
+					Times(1).
+					Return(sessionSerialized, nil)
+				cache.EXPECT().
+					Get(gomock.Any(), gomock.Eq(userCacheKey)).
+					Times(1).
+					Return(revokedAtSerialized, nil)
+				cache.EXPECT().
+					Delete(gomock.Any(), gomock.Eq(sessionCacheKey)).
+					Times(1).
+					Return(nil)
+			},
+			input: refreshTestedInput{
+				refreshToken: refreshToken,
+			},
+			expected: refreshExpectedOutput{
+				err: domain.ErrInvalidRefreshToken,
+			},
+		},
+		{
+			desc: "Fail_UserNotFound",
+			mocks: func(
+				userRepo *mock.MockUserRepository,
+				tokenService *mock.MockTokenService,
+				cache *mock.MockCacheRepository,
+			) {
+				cache.EXPECT().
+					Get(gomock.Any(), gomock.Eq(sessionCacheKey)).
+					Times(1).
+					Return(sessionSerialized, nil)
+				cache.EXPECT().
+					Get(gomock.Any(), gomock.Eq(userCacheKey)).
+					Times(1).
+					Return(nil, domain.ErrDataNotFound)
+				userRepo.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(nil, domain.ErrDataNotFound)
+			},
+			input: refreshTestedInput{
+				refreshToken: refreshToken,
+			},
+			expected: refreshExpectedOutput{
+				err: domain.ErrInvalidRefreshToken,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			userRepo := mock.NewMockUserRepository(ctrl)
+			tokenService := mock.NewMockTokenService(ctrl)
+			roleRepo := mock.NewMockRoleRepository(ctrl)
+			terminalRepo := mock.NewMockTerminalRepository(ctrl)
+			cache := mock.NewMockCacheRepository(ctrl)
+			notifier := mock.NewMockNotifier(ctrl)
+
+			roleRepo.EXPECT().
+				GetRoleByName(gomock.Any(), gomock.Any()).
+				AnyTimes().
+				Return(role, nil)
+
+			tc.mocks(userRepo, tokenService, cache)
+
+			authService := service.NewAuthService(userRepo, roleRepo, terminalRepo, tokenService, mock.NewMockTwoFactorService(ctrl), cache, notifier, newMockAuditService(ctrl), time.Hour)
+
+			authToken, err := authService.Refresh(ctx, tc.input.refreshToken)
+			assert.Equal(t, tc.expected.err, err, "Error mismatch")
+
+			if tc.expected.err == nil {
+				assert.Equal(t, tc.expected.token, authToken.AccessToken, "Access token mismatch")
+				assert.NotEqual(t, tc.input.refreshToken, authToken.RefreshToken, "Refresh token must rotate")
+			}
+		})
+	}
+}
+
+type verifyTokenExpectedOutput struct {
+	payload *domain.TokenPayload
+	err     error
+}
+
+func TestAuthService_VerifyToken(t *testing.T) {
+	ctx := context.Background()
+	token := gofakeit.UUID()
+	payload := &domain.TokenPayload{
+		ID:        uuid.New(),
+		UserID:    gofakeit.Uint64(),
+		Role:      domain.Cashier,
+		IssuedAt:  time.Now().Add(-time.Minute),
+		ExpiresAt: time.Now().Add(15 * time.Minute),
+	}
+	tokenCacheKey := util.GenerateCacheKey("revoked_token", payload.ID)
+	userCacheKey := util.GenerateCacheKey("revoked_user", payload.UserID)
+	roleCacheKey := util.GenerateCacheKey("revoked_role", payload.Role)
+	revokedBeforeSerialized, _ := util.Serialize(payload.IssuedAt.Add(-time.Minute))
+	revokedAfterSerialized, _ := util.Serialize(time.Now())
+
+	testCases := []struct {
+		desc     string
+		mocks    func(tokenService *mock.MockTokenService, cache *mock.MockCacheRepository)
+		expected verifyTokenExpectedOutput
+	}{
+		{
+			desc: "Success",
+			mocks: func(tokenService *mock.MockTokenService, cache *mock.MockCacheRepository) {
+				tokenService.EXPECT().VerifyToken(gomock.Eq(token)).Return(payload, nil)
+				cache.EXPECT().Get(gomock.Any(), gomock.Eq(tokenCacheKey)).Return(nil, domain.ErrDataNotFound)
+				cache.EXPECT().Get(gomock.Any(), gomock.Eq(userCacheKey)).Return(nil, domain.ErrDataNotFound)
+				cache.EXPECT().Get(gomock.Any(), gomock.Eq(roleCacheKey)).Return(nil, domain.ErrDataNotFound)
+			},
+			expected: verifyTokenExpectedOutput{
+				payload: payload,
+			},
+		},
+		{
+			desc: "Success_LoggedOutBeforeIssued",
+			mocks: func(tokenService *mock.MockTokenService, cache *mock.MockCacheRepository) {
+				tokenService.EXPECT().VerifyToken(gomock.Eq(token)).Return(payload, nil)
+				cache.EXPECT().Get(gomock.Any(), gomock.Eq(tokenCacheKey)).Return(nil, domain.ErrDataNotFound)
+				cache.EXPECT().Get(gomock.Any(), gomock.Eq(userCacheKey)).Return(revokedBeforeSerialized, nil)
+				cache.EXPECT().Get(gomock.Any(), gomock.Eq(roleCacheKey)).Return(revokedBeforeSerialized, nil)
+			},
+			expected: verifyTokenExpectedOutput{
+				payload: payload,
+			},
+		},
+		{
+			desc: "Fail_InvalidToken",
+			mocks: func(tokenService *mock.MockTokenService, cache *mock.MockCacheRepository) {
+				tokenService.EXPECT().VerifyToken(gomock.Eq(token)).Return(nil, domain.ErrInvalidToken)
+			},
+			expected: verifyTokenExpectedOutput{
+				err: domain.ErrInvalidToken,
+			},
+		},
+		{
+			desc: "Fail_LoggedOut",
+			mocks: func(tokenService *mock.MockTokenService, cache *mock.MockCacheRepository) {
+				tokenService.EXPECT().VerifyToken(gomock.Eq(token)).Return(payload, nil)
+				cache.EXPECT().Get(gomock.Any(), gomock.Eq(tokenCacheKey)).Return([]byte{1}, nil)
+			},
+			expected: verifyTokenExpectedOutput{
+				err: domain.ErrRevokedToken,
+			},
+		},
+		{
+			desc: "Fail_LoggedOutFromAllSessions",
+			mocks: func(tokenService *mock.MockTokenService, cache *mock.MockCacheRepository) {
+				tokenService.EXPECT().VerifyToken(gomock.Eq(token)).Return(payload, nil)
+				cache.EXPECT().Get(gomock.Any(), gomock.Eq(tokenCacheKey)).Return(nil, domain.ErrDataNotFound)
+				cache.EXPECT().Get(gomock.Any(), gomock.Eq(userCacheKey)).Return(revokedAfterSerialized, nil)
+			},
+			expected: verifyTokenExpectedOutput{
+				err: domain.ErrRevokedToken,
+			},
+		},
+		{
+			desc: "Fail_RolePermissionsChanged",
+			mocks: func(tokenService *mock.MockTokenService, cache *mock.MockCacheRepository) {
+				tokenService.EXPECT().VerifyToken(gomock.Eq(token)).Return(payload, nil)
+				cache.EXPECT().Get(gomock.Any(), gomock.Eq(tokenCacheKey)).Return(nil, domain.ErrDataNotFound)
+				cache.EXPECT().Get(gomock.Any(), gomock.Eq(userCacheKey)).Return(nil, domain.ErrDataNotFound)
+				cache.EXPECT().Get(gomock.Any(), gomock.Eq(roleCacheKey)).Return(revokedAfterSerialized, nil)
+			},
+			expected: verifyTokenExpectedOutput{
+				err: domain.ErrRevokedToken,
+			},
+		},
+		{
+			desc: "Fail_CacheUnavailable",
+			mocks: func(tokenService *mock.MockTokenService, cache *mock.MockCacheRepository) {
+				tokenService.EXPECT().VerifyToken(gomock.Eq(token)).Return(payload, nil)
+				cache.EXPECT().Get(gomock.Any(), gomock.Eq(tokenCacheKey)).Return(nil, domain.ErrInternal)
+			},
+			expected: verifyTokenExpectedOutput{
+				err: domain.ErrInternal,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			userRepo := mock.NewMockUserRepository(ctrl)
+			tokenService := mock.NewMockTokenService(ctrl)
+			cache := mock.NewMockCacheRepository(ctrl)
+			notifier := mock.NewMockNotifier(ctrl)
+
+			tc.mocks(tokenService, cache)
+
+			authService := service.NewAuthService(userRepo, mock.NewMockRoleRepository(ctrl), mock.NewMockTerminalRepository(ctrl), tokenService, mock.NewMockTwoFactorService(ctrl), cache, notifier, newMockAuditService(ctrl), time.Hour)
+
+			verifiedPayload, err := authService.VerifyToken(ctx, token, "")
+			assert.Equal(t, tc.expected.err, err, "Error mismatch")
+			assert.Equal(t, tc.expected.payload, verifiedPayload, "Payload mismatch")
+		})
+	}
+}
+
+type pinLoginTestedInput struct {
+	terminalKey string
+	userID      uint64
+	pin         string
+}
+
+type pinLoginExpectedOutput struct {
+	token string
+	err   error
+}
+
+func TestAuthService_PinLogin(t *testing.T) {
+	ctx := context.Background()
+	pin := "4821"
+	hashedPin, _ := util.HashPassword(pin)
+	terminalKey := gofakeit.UUID()
+	terminal := &domain.Terminal{
+		ID:      gofakeit.Uint64(),
+		Name:    gofakeit.Word(),
+		KeyHash: util.HashKey(terminalKey),
+	}
+	user := &domain.User{
+		ID:   gofakeit.Uint64(),
+		Pin:  hashedPin,
+		Role: domain.Cashier,
+	}
+	userWithoutPin := &domain.User{
+		ID:   user.ID,
+		Role: domain.Cashier,
+	}
+	admin := &domain.User{
+		ID:   user.ID,
+		Pin:  hashedPin,
+		Role: domain.Admin,
+	}
+	twoFactorPolicy := &domain.TwoFactorPolicy{
+		RequiredRoles: []domain.UserRole{domain.Admin},
+	}
+	role := &domain.Role{
+		Name:        domain.Cashier,
+		Permissions: []domain.Permission{domain.OrdersCreate},
+	}
+	token := gofakeit.UUID()
+	payload := &domain.TokenPayload{
+		ID:         uuid.New(),
+		UserID:     user.ID,
+		TerminalID: terminal.ID,
+		IssuedAt:   time.Now(),
+		ExpiresAt:  time.Now().Add(15 * time.Minute),
+	}
+	terminalCacheKey := util.GenerateCacheKey("terminal", terminal.ID)
+	lockCacheKey := util.GenerateCacheKey("pin_lock", user.ID)
+	attemptsCacheKey := util.GenerateCacheKey("pin_attempts", user.ID)
+	lockedUntil, _ := util.Serialize(time.Now().Add(10 * time.Minute))
+
+	testCases := []struct {
+		desc  string
+		mocks func(
+			userRepo *mock.MockUserRepository,
+			terminalRepo *mock.MockTerminalRepository,
+			tokenService *mock.MockTokenService,
+			cache *mock.MockCacheRepository,
+		)
+		input    pinLoginTestedInput
+		expected pinLoginExpectedOutput
+	}{
+		{
+			desc: "Success",
+			mocks: func(
+				userRepo *mock.MockUserRepository,
+				terminalRepo *mock.MockTerminalRepository,
+				tokenService *mock.MockTokenService,
+				cache *mock.MockCacheRepository,
+			) {
+				cache.EXPECT().Get(gomock.Any(), gomock.Eq(terminalCacheKey)).Return(nil, domain.ErrDataNotFound)
+				terminalRepo.EXPECT().GetTerminalByID(gomock.Any(), gomock.Eq(terminal.ID)).Return(terminal, nil)
+				cache.EXPECT().Set(gomock.Any(), gomock.Eq(terminalCacheKey), gomock.Any(), gomock.Any()).Return(nil)
+				cache.EXPECT().Get(gomock.Any(), gomock.Eq(lockCacheKey)).Return(nil, domain.ErrDataNotFound)
+				userRepo.EXPECT().GetUserByID(gomock.Any(), gomock.Eq(user.ID)).Return(user, nil)
+				cache.EXPECT().Delete(gomock.Any(), gomock.Eq(attemptsCacheKey)).Return(nil)
+				cache.EXPECT().Delete(gomock.Any(), gomock.Eq(lockCacheKey)).Return(nil)
+				tokenService.EXPECT().
+					CreateToken(gomock.Any()).
+					DoAndReturn(func(user *domain.User) (string, *domain.TokenPayload, error) {
+						assert.Equal(t, terminal.ID, user.TerminalID, "Token must be bound to the terminal")
+						return token, payload, nil
+					})
+				cache.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			},
+			input: pinLoginTestedInput{
+				terminalKey: terminalKey,
+				userID:      user.ID,
+				pin:         pin,
+			},
+			expected: pinLoginExpectedOutput{
+				token: token,
+			},
+		},
+		{
+			desc: "Fail_InvalidTerminalKey",
+			mocks: func(
+				userRepo *mock.MockUserRepository,
+				terminalRepo *mock.MockTerminalRepository,
+				tokenService *mock.MockTokenService,
+				cache *mock.MockCacheRepository,
+			) {
+				cache.EXPECT().Get(gomock.Any(), gomock.Eq(terminalCacheKey)).Return(nil, domain.ErrDataNotFound)
+				terminalRepo.EXPECT().GetTerminalByID(gomock.Any(), gomock.Eq(terminal.ID)).Return(terminal, nil)
+				cache.EXPECT().Set(gomock.Any(), gomock.Eq(terminalCacheKey), gomock.Any(), gomock.Any()).Return(nil)
+			},
+			input: pinLoginTestedInput{
+				terminalKey: gofakeit.UUID(),
+				userID:      user.ID,
+				pin:         pin,
+			},
+			expected: pinLoginExpectedOutput{
+				err: domain.ErrInvalidTerminal,
+			},
+		},
+		{
+			desc: "Fail_TwoFactorRequired",
+			mocks: func(
+				userRepo *mock.MockUserRepository,
+				terminalRepo *mock.MockTerminalRepository,
+				tokenService *mock.MockTokenService,
+				cache *mock.MockCacheRepository,
+			) {
+				terminalSerialized, _ := util.Serialize(terminal)
+				cache.EXPECT().Get(gomock.Any(), gomock.Eq(terminalCacheKey)).Return(terminalSerialized, nil)
+				cache.EXPECT().Get(gomock.Any(), gomock.Eq(lockCacheKey)).Return(nil, domain.ErrDataNotFound)
+				userRepo.EXPECT().GetUserByID(gomock.Any(), gomock.Eq(user.ID)).Return(admin, nil)
+				cache.EXPECT().Delete(gomock.Any(), gomock.Eq(attemptsCacheKey)).Return(nil)
+				cache.EXPECT().Delete(gomock.Any(), gomock.Eq(lockCacheKey)).Return(nil)
+			},
+			input: pinLoginTestedInput{
+				terminalKey: terminalKey,
+				userID:      user.ID,
+				pin:         pin,
+			},
+			expected: pinLoginExpectedOutput{
+				err: domain.ErrPinLoginNotAllowed,
+			},
+		},
+		{
+			desc: "Fail_Locked",
+			mocks: func(
+				userRepo *mock.MockUserRepository,
+				terminalRepo *mock.MockTerminalRepository,
+				tokenService *mock.MockTokenService,
+				cache *mock.MockCacheRepository,
+			) {
+				terminalSerialized, _ := util.Serialize(terminal)
+				cache.EXPECT().Get(gomock.Any(), gomock.Eq(terminalCacheKey)).Return(terminalSerialized, nil)
+				cache.EXPECT().Get(gomock.Any(), gomock.Eq(lockCacheKey)).Return(lockedUntil, nil)
+			},
+			input: pinLoginTestedInput{
+				terminalKey: terminalKey,
+				userID:      user.ID,
+				pin:         pin,
+			},
+			expected: pinLoginExpectedOutput{
+				err: domain.ErrPinLocked,
+			},
+		},
+		{
+			desc: "Fail_WrongPin",
+			mocks: func(
+				userRepo *mock.MockUserRepository,
+				terminalRepo *mock.MockTerminalRepository,
+				tokenService *mock.MockTokenService,
+				cache *mock.MockCacheRepository,
+			) {
+				terminalSerialized, _ := util.Serialize(terminal)
+				cache.EXPECT().Get(gomock.Any(), gomock.Eq(terminalCacheKey)).Return(terminalSerialized, nil)
+				cache.EXPECT().Get(gomock.Any(), gomock.Eq(lockCacheKey)).Return(nil, domain.ErrDataNotFound)
+				userRepo.EXPECT().GetUserByID(gomock.Any(), gomock.Eq(user.ID)).Return(user, nil)
+				cache.EXPECT().Increment(gomock.Any(), gomock.Eq(attemptsCacheKey), gomock.Eq(15*time.Minute)).Return(int64(1), nil)
+			},
+			input: pinLoginTestedInput{
+				terminalKey: terminalKey,
+				userID:      user.ID,
+				pin:         "0000",
+			},
+			expected: pinLoginExpectedOutput{
+				err: domain.ErrInvalidPin,
+			},
+		},
+		{
+			desc: "Fail_WrongPinLocks",
+			mocks: func(
+				userRepo *mock.MockUserRepository,
+				terminalRepo *mock.MockTerminalRepository,
+				tokenService *mock.MockTokenService,
+				cache *mock.MockCacheRepository,
+			) {
+				terminalSerialized, _ := util.Serialize(terminal)
+				cache.EXPECT().Get(gomock.Any(), gomock.Eq(terminalCacheKey)).Return(terminalSerialized, nil)
+				cache.EXPECT().Get(gomock.Any(), gomock.Eq(lockCacheKey)).Return(nil, domain.ErrDataNotFound)
+				userRepo.EXPECT().GetUserByID(gomock.Any(), gomock.Eq(user.ID)).Return(user, nil)
+				cache.EXPECT().Increment(gomock.Any(), gomock.Eq(attemptsCacheKey), gomock.Any()).Return(int64(5), nil)
+				cache.EXPECT().Set(gomock.Any(), gomock.Eq(lockCacheKey), gomock.Any(), gomock.Eq(15*time.Minute)).Return(nil)
+			},
+			input: pinLoginTestedInput{
+				terminalKey: terminalKey,
+				userID:      user.ID,
+				pin:         "0000",
+			},
+			expected: pinLoginExpectedOutput{
+				err: domain.ErrPinLocked,
+			},
+		},
+		{
+			desc: "Fail_NoPin",
+			mocks: func(
+				userRepo *mock.MockUserRepository,
+				terminalRepo *mock.MockTerminalRepository,
+				tokenService *mock.MockTokenService,
+				cache *mock.MockCacheRepository,
+			) {
+				terminalSerialized, _ := util.Serialize(terminal)
+				cache.EXPECT().Get(gomock.Any(), gomock.Eq(terminalCacheKey)).Return(terminalSerialized, nil)
+				cache.EXPECT().Get(gomock.Any(), gomock.Eq(lockCacheKey)).Return(nil, domain.ErrDataNotFound)
+				userRepo.EXPECT().GetUserByID(gomock.Any(), gomock.Eq(user.ID)).Return(userWithoutPin, nil)
+				cache.EXPECT().Increment(gomock.Any(), gomock.Eq(attemptsCacheKey), gomock.Any()).Return(int64(1), nil)
+			},
+			input: pinLoginTestedInput{
+				terminalKey: terminalKey,
+				userID:      user.ID,
+				pin:         "",
+			},
+			expected: pinLoginExpectedOutput{
+				err: domain.ErrInvalidPin,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			userRepo := mock.NewMockUserRepository(ctrl)
+			roleRepo := mock.NewMockRoleRepository(ctrl)
+			terminalRepo := mock.NewMockTerminalRepository(ctrl)
+			tokenService := mock.NewMockTokenService(ctrl)
+			cache := mock.NewMockCacheRepository(ctrl)
+			twoFactorService := mock.NewMockTwoFactorService(ctrl)
+			notifier := mock.NewMockNotifier(ctrl)
+
+			roleRepo.EXPECT().
+				GetRoleByName(gomock.Any(), gomock.Any()).
+				AnyTimes().
+				Return(role, nil)
+			twoFactorService.EXPECT().
+				IsRequired(gomock.Any()).
+				AnyTimes().
+				DoAndReturn(twoFactorPolicy.IsRequired)
+
+			tc.mocks(userRepo, terminalRepo, tokenService, cache)
+
+			authService := service.NewAuthService(userRepo, roleRepo, terminalRepo, tokenService, twoFactorService, cache, notifier, newMockAuditService(ctrl), time.Hour)
+
+			authToken, err := authService.PinLogin(ctx, terminal.ID, tc.input.terminalKey, tc.input.userID, tc.input.pin)
+			assert.ErrorIs(t, err, tc.expected.err, "Error mismatch")
+			if tc.expected.err == nil {
+				assert.Equal(t, tc.expected.token, authToken.AccessToken, "Token mismatch")
 			}
 		})
 	}
@@ -10,30 +10,51 @@ import (
 
 /**
  * CategoryService implements port.CategoryService interface
- * and provides an access to the category repository
- * and cache service
+ * and provides an access to the category repository,
+ * cache service and audit service
  */
 type CategoryService struct {
 	repo  port.CategoryRepository
 	cache port.CacheRepository
+	audit port.AuditService
 }
 
 // NewCategoryService creates a new category service instance
-func NewCategoryService(repo port.CategoryRepository, cache port.CacheRepository) *CategoryService {
+func NewCategoryService(repo port.CategoryRepository, cache port.CacheRepository, audit port.AuditService) *CategoryService {
 	return &CategoryService{
 		repo,
 		cache,
+		audit,
 	}
 }
 
 // CreateCategory creates a new category
 func (cs *CategoryService) CreateCategory(ctx context.Context, category *domain.Category) (*domain.Category, error) {
-	category, err := cs.repo.CreateCategory(ctx, category)
-	if err != nil {
-		if err == domain.ErrConflictingData {
-			return nil, err
+	if category.ParentID != 0 {
+		_, err := cs.repo.GetCategoryByID(ctx, category.ParentID)
+		if err != nil {
+			if err == domain.ErrDataNotFound {
+				return nil, err
+			}
+			return nil, domain.ErrInternal
 		}
-		return nil, domain.ErrInternal
+	}
+
+	err := cs.audit.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+
+		category, err = cs.repo.CreateCategory(ctx, category)
+		if err != nil {
+			if err == domain.ErrConflictingData {
+				return err
+			}
+			return domain.ErrInternal
+		}
+
+		return cs.audit.RecordAuditLog(ctx, &domain.AuditLog{Action: "category.create", Entity: "category", EntityID: category.ID}, nil, category)
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	cacheKey := util.GenerateCacheKey("category", category.ID)
@@ -90,43 +111,44 @@ func (cs *CategoryService) GetCategory(ctx context.Context, id uint64) (*domain.
 	return category, nil
 }
 
-// ListCategories retrieves a list of categories
-func (cs *CategoryService) ListCategories(ctx context.Context, skip, limit uint64) ([]domain.Category, error) {
-	var categories []domain.Category
+// ListCategories retrieves a list of root categories with their descendants
+func (cs *CategoryService) ListCategories(ctx context.Context, includeDeleted bool, params *domain.ListParams) ([]domain.Category, uint64, error) {
+	var page domain.Page[domain.Category]
 
-	params := util.GenerateCacheKeyParams(skip, limit)
-	cacheKey := util.GenerateCacheKey("categories", params)
+	cacheParams := util.GenerateCacheKeyParams(params.Skip, params.Limit, params.Sort, params.Order, includeDeleted)
+	cacheKey := util.GenerateCacheKey("categories", cacheParams)
 
 	cachedCategories, err := cs.cache.Get(ctx, cacheKey)
 	if err == nil {
-		err := util.Deserialize(cachedCategories, &categories)
+		err := util.Deserialize(cachedCategories, &page)
 		if err != nil {
-			return nil, domain.ErrInternal
+			return nil, 0, domain.ErrInternal
 		}
 
-		return categories, nil
+		return page.Items, page.Total, nil
 	}
 
-	categories, err = cs.repo.ListCategories(ctx, skip, limit)
+	categories, total, err := cs.repo.ListCategories(ctx, includeDeleted, params)
 	if err != nil {
-		return nil, domain.ErrInternal
+		return nil, 0, domain.ErrInternal
 	}
 
-	categoriesSerialized, err := util.Serialize(categories)
+	categoriesSerialized, err := util.Serialize(domain.Page[domain.Category]{Items: categories, Total: total})
 	if err != nil {
-		return nil, domain.ErrInternal
+		return nil, 0, domain.ErrInternal
 	}
 
 	err = cs.cache.Set(ctx, cacheKey, categoriesSerialized, 0)
 	if err != nil {
-		return nil, domain.ErrInternal
+		return nil, 0, domain.ErrInternal
 	}
 
-	return categories, nil
+	return categories, total, nil
 }
 
-// UpdateCategory updates a category
-func (cs *CategoryService) UpdateCategory(ctx context.Context, category *domain.Category) (*domain.Category, error) {
+// UpdateCategory updates a category, as long as it is still at the version the client read. Unless reparent is set,
+// the category keeps its parent
+func (cs *CategoryService) UpdateCategory(ctx context.Context, category *domain.Category, reparent bool) (*domain.Category, error) {
 	existingCategory, err := cs.repo.GetCategoryByID(ctx, category.ID)
 	if err != nil {
 		if err == domain.ErrDataNotFound {
@@ -135,18 +157,53 @@ func (cs *CategoryService) UpdateCategory(ctx context.Context, category *domain.
 		return nil, domain.ErrInternal
 	}
 
-	emptyData := category.Name == ""
-	sameData := existingCategory.Name == category.Name
+	if existingCategory.Version != category.Version {
+		return nil, domain.ErrVersionMismatch
+	}
+
+	if !reparent {
+		category.ParentID = existingCategory.ParentID
+	}
+
+	emptyData := category.Name == "" && !reparent
+	sameName := category.Name == "" || existingCategory.Name == category.Name
+	sameParent := existingCategory.ParentID == category.ParentID
+	sameData := sameName && sameParent
 	if emptyData || sameData {
 		return nil, domain.ErrNoUpdatedData
 	}
 
-	_, err = cs.repo.UpdateCategory(ctx, category)
-	if err != nil {
-		if err == domain.ErrConflictingData {
-			return nil, err
+	var updatedCategory *domain.Category
+
+	err = cs.audit.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+
+		// The parent is checked under the lock of the tree, or two categories moved under each other
+		// at the same time would both pass the check and form a cycle
+		if !sameParent && category.ParentID != 0 {
+			err = cs.repo.LockCategoryTree(ctx)
+			if err != nil {
+				return domain.ErrInternal
+			}
+
+			err = cs.checkCategoryParent(ctx, category)
+			if err != nil {
+				return err
+			}
 		}
-		return nil, domain.ErrInternal
+
+		updatedCategory, err = cs.repo.UpdateCategory(ctx, category)
+		if err != nil {
+			if err == domain.ErrConflictingData || err == domain.ErrDataNotFound || err == domain.ErrVersionMismatch {
+				return err
+			}
+			return domain.ErrInternal
+		}
+
+		return cs.audit.RecordAuditLog(ctx, &domain.AuditLog{Action: "category.update", Entity: "category", EntityID: category.ID}, existingCategory, updatedCategory)
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	cacheKey := util.GenerateCacheKey("category", category.ID)
@@ -171,12 +228,43 @@ func (cs *CategoryService) UpdateCategory(ctx context.Context, category *domain.
 		return nil, domain.ErrInternal
 	}
 
+	if !sameParent {
+		err = cs.cache.DeleteByPrefix(ctx, "products:*")
+		if err != nil {
+			return nil, domain.ErrInternal
+		}
+	}
+
 	return category, nil
 }
 
-// DeleteCategory deletes a category
-func (cs *CategoryService) DeleteCategory(ctx context.Context, id uint64) error {
-	_, err := cs.repo.GetCategoryByID(ctx, id)
+// checkCategoryParent ensures that the new parent of a category exists, is not deleted and is not the category itself or one of its descendants
+func (cs *CategoryService) checkCategoryParent(ctx context.Context, category *domain.Category) error {
+	if category.ParentID == category.ID {
+		return domain.ErrCategoryCycle
+	}
+
+	ancestors, err := cs.repo.ListCategoryAncestors(ctx, category.ParentID)
+	if err != nil {
+		return domain.ErrInternal
+	}
+
+	if len(ancestors) == 0 || ancestors[0].DeletedAt != nil {
+		return domain.ErrDataNotFound
+	}
+
+	for _, ancestor := range ancestors {
+		if ancestor.ID == category.ID {
+			return domain.ErrCategoryCycle
+		}
+	}
+
+	return nil
+}
+
+// DeleteCategory soft deletes a category at the version the client read, which must not have any subcategories or products left
+func (cs *CategoryService) DeleteCategory(ctx context.Context, id, version uint64) error {
+	existingCategory, err := cs.repo.GetCategoryByID(ctx, id)
 	if err != nil {
 		if err == domain.ErrDataNotFound {
 			return err
@@ -184,6 +272,10 @@ func (cs *CategoryService) DeleteCategory(ctx context.Context, id uint64) error
 		return domain.ErrInternal
 	}
 
+	if existingCategory.Version != version {
+		return domain.ErrVersionMismatch
+	}
+
 	cacheKey := util.GenerateCacheKey("category", id)
 
 	err = cs.cache.Delete(ctx, cacheKey)
@@ -196,5 +288,97 @@ func (cs *CategoryService) DeleteCategory(ctx context.Context, id uint64) error
 		return domain.ErrInternal
 	}
 
-	return cs.repo.DeleteCategory(ctx, id)
+	return cs.audit.WithinTransaction(ctx, func(ctx context.Context) error {
+		err := cs.repo.DeleteCategory(ctx, id, version)
+		if err != nil {
+			return err
+		}
+
+		return cs.audit.RecordAuditLog(ctx, &domain.AuditLog{Action: "category.delete", Entity: "category", EntityID: id}, existingCategory, nil)
+	})
+}
+
+// RestoreCategory restores a soft deleted category
+func (cs *CategoryService) RestoreCategory(ctx context.Context, id uint64) (*domain.Category, error) {
+	var category *domain.Category
+
+	err := cs.audit.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+
+		category, err = cs.repo.RestoreCategory(ctx, id)
+		if err != nil {
+			if err == domain.ErrDataNotFound || err == domain.ErrConflictingData {
+				return err
+			}
+			return domain.ErrInternal
+		}
+
+		return cs.audit.RecordAuditLog(ctx, &domain.AuditLog{Action: "category.restore", Entity: "category", EntityID: id}, nil, category)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = cs.cache.DeleteByPrefix(ctx, "categories:*")
+	if err != nil {
+		return nil, domain.ErrInternal
+	}
+
+	return category, nil
+}
+
+// loadCategories sets the category of the products, selecting the categories in a single query
+func loadCategories(ctx context.Context, repo port.CategoryRepository, products []domain.Product) error {
+	if len(products) == 0 {
+		return nil
+	}
+
+	categoryIDs := make([]uint64, len(products))
+	for i, product := range products {
+		categoryIDs[i] = product.CategoryID
+	}
+
+	categories, err := repo.GetCategoriesByIDs(ctx, uniqueIDs(categoryIDs))
+	if err != nil {
+		return domain.ErrInternal
+	}
+
+	categoriesByID := mapByID(categories, func(category *domain.Category) uint64 { return category.ID })
+
+	for i, product := range products {
+		category, ok := categoriesByID[product.CategoryID]
+		if !ok {
+			return domain.ErrDataNotFound
+		}
+
+		products[i].Category = category
+	}
+
+	return nil
+}
+
+// uniqueIDs returns the ids without duplicates, in the order they first appear
+func uniqueIDs(ids []uint64) []uint64 {
+	seen := make(map[uint64]bool, len(ids))
+	unique := make([]uint64, 0, len(ids))
+
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+
+	return unique
+}
+
+// mapByID indexes the items by their id
+func mapByID[T any](items []T, id func(item *T) uint64) map[uint64]*T {
+	itemsByID := make(map[uint64]*T, len(items))
+
+	for i := range items {
+		itemsByID[id(&items[i])] = &items[i]
+	}
+
+	return itemsByID
 }
@@ -27,6 +27,7 @@ func TestCategoryService_CreateCategory(t *testing.T) {
 	ctx := context.Background()
 	categoryID := gofakeit.Uint64()
 	categoryName := gofakeit.ProductCategory()
+	parentID := gofakeit.Uint64()
 	categoryInput := &domain.Category{
 		Name: categoryName,
 	}
@@ -77,6 +78,28 @@ func TestCategoryService_CreateCategory(t *testing.T) {
 				err:      nil,
 			},
 		},
+		{
+			desc: "Fail_ParentNotFound",
+			mocks: func(
+				categoryRepo *mock.MockCategoryRepository,
+				cache *mock.MockCacheRepository,
+			) {
+				categoryRepo.EXPECT().
+					GetCategoryByID(gomock.Any(), gomock.Eq(parentID)).
+					Times(1).
+					Return(nil, domain.ErrDataNotFound)
+			},
+			input: createCategoryTestedInput{
+				category: &domain.Category{
+					ParentID: parentID,
+					Name:     categoryName,
+				},
+			},
+			expected: createCategoryExpectedOutput{
+				category: nil,
+				err:      domain.ErrDataNotFound,
+			},
+		},
 		{
 			desc: "Fail_DuplicateData",
 			mocks: func(
@@ -179,7 +202,7 @@ func TestCategoryService_CreateCategory(t *testing.T) {
 
 			tc.mocks(categoryRepo, cache)
 
-			categoryService := service.NewCategoryService(categoryRepo, cache)
+			categoryService := service.NewCategoryService(categoryRepo, cache, newMockAuditService(ctrl))
 
 			category, err := categoryService.CreateCategory(ctx, tc.input.category)
 			assert.Equal(t, tc.expected.err, err, "Error mismatch")
@@ -370,7 +393,7 @@ func TestCategoryService_GetCategory(t *testing.T) {
 
 			tc.mocks(categoryRepo, cache)
 
-			categoryService := service.NewCategoryService(categoryRepo, cache)
+			categoryService := service.NewCategoryService(categoryRepo, cache, newMockAuditService(ctrl))
 
 			category, err := categoryService.GetCategory(ctx, tc.input.id)
 			assert.Equal(t, tc.expected.err, err, "Error mismatch")
@@ -380,12 +403,12 @@ func TestCategoryService_GetCategory(t *testing.T) {
 }
 
 type listCategoriesTestedInput struct {
-	skip  uint64
-	limit uint64
+	params *domain.ListParams
 }
 
 type listCategoriesExpectedOutput struct {
 	categories []domain.Category
+	total      uint64
 	err        error
 }
 
@@ -402,10 +425,18 @@ func TestCategoryService_ListCategories(t *testing.T) {
 	ctx := context.Background()
 	skip := gofakeit.Uint64()
 	limit := gofakeit.Uint64()
+	total := gofakeit.Uint64()
+	listParams := &domain.ListParams{
+		Skip:  skip,
+		Limit: limit,
+		Sort:  "created_at",
+		Order: domain.Descending,
+	}
+	includeDeleted := false
 
-	params := util.GenerateCacheKeyParams(skip, limit)
+	params := util.GenerateCacheKeyParams(skip, limit, listParams.Sort, listParams.Order, includeDeleted)
 	cacheKey := util.GenerateCacheKey("categories", params)
-	categoriesSerialized, _ := util.Serialize(categories)
+	categoriesSerialized, _ := util.Serialize(domain.Page[domain.Category]{Items: categories, Total: total})
 
 	testCases := []struct {
 		desc  string
@@ -428,11 +459,11 @@ func TestCategoryService_ListCategories(t *testing.T) {
 					Return(categoriesSerialized, nil)
 			},
 			input: listCategoriesTestedInput{
-				skip:  skip,
-				limit: limit,
+				params: listParams,
 			},
 			expected: listCategoriesExpectedOutput{
 				categories: categories,
+				total:      total,
 				err:        nil,
 			},
 		},
@@ -447,20 +478,20 @@ func TestCategoryService_ListCategories(t *testing.T) {
 					Times(1).
 					Return(nil, domain.ErrInternal)
 				categoryRepo.EXPECT().
-					ListCategories(gomock.Any(), gomock.Eq(skip), gomock.Eq(limit)).
+					ListCategories(gomock.Any(), gomock.Eq(includeDeleted), gomock.Eq(listParams)).
 					Times(1).
-					Return(categories, nil)
+					Return(categories, total, nil)
 				cache.EXPECT().
 					Set(gomock.Any(), gomock.Eq(cacheKey), gomock.Eq(categoriesSerialized), gomock.Eq(time.Duration(0))).
 					Times(1).
 					Return(nil)
 			},
 			input: listCategoriesTestedInput{
-				skip:  skip,
-				limit: limit,
+				params: listParams,
 			},
 			expected: listCategoriesExpectedOutput{
 				categories: categories,
+				total:      total,
 				err:        nil,
 			},
 		},
@@ -475,13 +506,12 @@ func TestCategoryService_ListCategories(t *testing.T) {
 					Times(1).
 					Return(nil, domain.ErrInternal)
 				categoryRepo.EXPECT().
-					ListCategories(gomock.Any(), gomock.Eq(skip), gomock.Eq(limit)).
+					ListCategories(gomock.Any(), gomock.Eq(includeDeleted), gomock.Eq(listParams)).
 					Times(1).
-					Return(nil, domain.ErrInternal)
+					Return(nil, uint64(0), domain.ErrInternal)
 			},
 			input: listCategoriesTestedInput{
-				skip:  skip,
-				limit: limit,
+				params: listParams,
 			},
 			expected: listCategoriesExpectedOutput{
 				categories: nil,
@@ -499,17 +529,16 @@ func TestCategoryService_ListCategories(t *testing.T) {
 					Times(1).
 					Return(nil, domain.ErrInternal)
 				categoryRepo.EXPECT().
-					ListCategories(gomock.Any(), gomock.Eq(skip), gomock.Eq(limit)).
+					ListCategories(gomock.Any(), gomock.Eq(includeDeleted), gomock.Eq(listParams)).
 					Times(1).
-					Return(categories, nil)
+					Return(categories, total, nil)
 				cache.EXPECT().
 					Set(gomock.Any(), gomock.Eq(cacheKey), gomock.Eq(categoriesSerialized), gomock.Eq(time.Duration(0))).
 					Times(1).
 					Return(domain.ErrInternal)
 			},
 			input: listCategoriesTestedInput{
-				skip:  skip,
-				limit: limit,
+				params: listParams,
 			},
 			expected: listCategoriesExpectedOutput{
 				categories: nil,
@@ -528,8 +557,7 @@ func TestCategoryService_ListCategories(t *testing.T) {
 					Return([]byte("invalid"), nil)
 			},
 			input: listCategoriesTestedInput{
-				skip:  skip,
-				limit: limit,
+				params: listParams,
 			},
 			expected: listCategoriesExpectedOutput{
 				categories: nil,
@@ -550,11 +578,12 @@ func TestCategoryService_ListCategories(t *testing.T) {
 
 			tc.mocks(categoryRepo, cache)
 
-			categoryService := service.NewCategoryService(categoryRepo, cache)
+			categoryService := service.NewCategoryService(categoryRepo, cache, newMockAuditService(ctrl))
 
-			categories, err := categoryService.ListCategories(ctx, tc.input.skip, tc.input.limit)
+			categories, total, err := categoryService.ListCategories(ctx, includeDeleted, tc.input.params)
 			assert.Equal(t, tc.expected.err, err, "Error mismatch")
 			assert.Equal(t, tc.expected.categories, categories, "Categories mismatch")
+			assert.Equal(t, tc.expected.total, total, "Total mismatch")
 		})
 	}
 }
@@ -651,6 +680,27 @@ func TestCategoryService_UpdateCategory(t *testing.T) {
 				err:      domain.ErrDataNotFound,
 			},
 		},
+		{
+			desc: "Fail_VersionMismatch",
+			mocks: func(
+				categoryRepo *mock.MockCategoryRepository,
+				cache *mock.MockCacheRepository,
+			) {
+				categoryRepo.EXPECT().
+					GetCategoryByID(gomock.Any(), gomock.Eq(categoryInput.ID)).
+					Times(1).
+					Return(&domain.Category{
+						Version: categoryInput.Version + 1,
+					}, nil)
+			},
+			input: updateCategoryTestedInput{
+				category: categoryInput,
+			},
+			expected: updateCategoryExpectedOutput{
+				category: nil,
+				err:      domain.ErrVersionMismatch,
+			},
+		},
 		{
 			desc: "Fail_InternalErrorGetByID",
 			mocks: func(
@@ -863,9 +913,9 @@ func TestCategoryService_UpdateCategory(t *testing.T) {
 
 			tc.mocks(categoryRepo, cache)
 
-			categoryService := service.NewCategoryService(categoryRepo, cache)
+			categoryService := service.NewCategoryService(categoryRepo, cache, newMockAuditService(ctrl))
 
-			category, err := categoryService.UpdateCategory(ctx, tc.input.category)
+			category, err := categoryService.UpdateCategory(ctx, tc.input.category, false)
 			assert.Equal(t, tc.expected.err, err, "Error mismatch")
 			assert.Equal(t, tc.expected.category, category, "Category mismatch")
 		})
@@ -873,16 +923,248 @@ func TestCategoryService_UpdateCategory(t *testing.T) {
 }
 
 type deleteCategoryTestedInput struct {
-	id uint64
+	id      uint64
+	version uint64
 }
 
 type deleteCategoryExpectedOutput struct {
 	err error
 }
 
+func TestCategoryService_UpdateCategoryParent(t *testing.T) {
+	ctx := context.Background()
+	categoryID := gofakeit.Uint64()
+	parentID := gofakeit.Uint64()
+	childID := gofakeit.Uint64()
+	existingCategory := &domain.Category{
+		ID:   categoryID,
+		Name: gofakeit.ProductCategory(),
+	}
+
+	testCases := []struct {
+		desc  string
+		mocks func(
+			categoryRepo *mock.MockCategoryRepository,
+			cache *mock.MockCacheRepository,
+		)
+		parentID uint64
+		err      error
+	}{
+		{
+			desc: "Success",
+			mocks: func(
+				categoryRepo *mock.MockCategoryRepository,
+				cache *mock.MockCacheRepository,
+			) {
+				categoryRepo.EXPECT().
+					GetCategoryByID(gomock.Any(), gomock.Eq(categoryID)).
+					Times(1).
+					Return(existingCategory, nil)
+				categoryRepo.EXPECT().
+					LockCategoryTree(gomock.Any()).
+					Times(1).
+					Return(nil)
+				categoryRepo.EXPECT().
+					ListCategoryAncestors(gomock.Any(), gomock.Eq(parentID)).
+					Times(1).
+					Return([]domain.Category{{ID: parentID}}, nil)
+				categoryRepo.EXPECT().
+					UpdateCategory(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(&domain.Category{ID: categoryID, ParentID: parentID}, nil)
+				cache.EXPECT().
+					Delete(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil)
+				cache.EXPECT().
+					Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil)
+				cache.EXPECT().
+					DeleteByPrefix(gomock.Any(), gomock.Eq("categories:*")).
+					Times(1).
+					Return(nil)
+				cache.EXPECT().
+					DeleteByPrefix(gomock.Any(), gomock.Eq("products:*")).
+					Times(1).
+					Return(nil)
+			},
+			parentID: parentID,
+			err:      nil,
+		},
+		{
+			desc: "Success_ToRoot",
+			mocks: func(
+				categoryRepo *mock.MockCategoryRepository,
+				cache *mock.MockCacheRepository,
+			) {
+				categoryRepo.EXPECT().
+					GetCategoryByID(gomock.Any(), gomock.Eq(categoryID)).
+					Times(1).
+					Return(&domain.Category{ID: categoryID, ParentID: parentID}, nil)
+				categoryRepo.EXPECT().
+					UpdateCategory(gomock.Any(), gomock.Eq(&domain.Category{ID: categoryID})).
+					Times(1).
+					Return(&domain.Category{ID: categoryID}, nil)
+				cache.EXPECT().
+					Delete(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil)
+				cache.EXPECT().
+					Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil)
+				cache.EXPECT().
+					DeleteByPrefix(gomock.Any(), gomock.Eq("categories:*")).
+					Times(1).
+					Return(nil)
+				cache.EXPECT().
+					DeleteByPrefix(gomock.Any(), gomock.Eq("products:*")).
+					Times(1).
+					Return(nil)
+			},
+			parentID: 0,
+			err:      nil,
+		},
+		{
+			desc: "Fail_LockTree",
+			mocks: func(
+				categoryRepo *mock.MockCategoryRepository,
+				cache *mock.MockCacheRepository,
+			) {
+				categoryRepo.EXPECT().
+					GetCategoryByID(gomock.Any(), gomock.Eq(categoryID)).
+					Times(1).
+					Return(existingCategory, nil)
+				categoryRepo.EXPECT().
+					LockCategoryTree(gomock.Any()).
+					Times(1).
+					Return(domain.ErrInternal)
+			},
+			parentID: parentID,
+			err:      domain.ErrInternal,
+		},
+		{
+			desc: "Fail_ParentNotFound",
+			mocks: func(
+				categoryRepo *mock.MockCategoryRepository,
+				cache *mock.MockCacheRepository,
+			) {
+				categoryRepo.EXPECT().
+					GetCategoryByID(gomock.Any(), gomock.Eq(categoryID)).
+					Times(1).
+					Return(existingCategory, nil)
+				categoryRepo.EXPECT().
+					LockCategoryTree(gomock.Any()).
+					Times(1).
+					Return(nil)
+				categoryRepo.EXPECT().
+					ListCategoryAncestors(gomock.Any(), gomock.Eq(parentID)).
+					Times(1).
+					Return(nil, nil)
+			},
+			parentID: parentID,
+			err:      domain.ErrDataNotFound,
+		},
+		{
+			desc: "Fail_ParentDeleted",
+			mocks: func(
+				categoryRepo *mock.MockCategoryRepository,
+				cache *mock.MockCacheRepository,
+			) {
+				deletedAt := time.Now()
+				categoryRepo.EXPECT().
+					GetCategoryByID(gomock.Any(), gomock.Eq(categoryID)).
+					Times(1).
+					Return(existingCategory, nil)
+				categoryRepo.EXPECT().
+					LockCategoryTree(gomock.Any()).
+					Times(1).
+					Return(nil)
+				categoryRepo.EXPECT().
+					ListCategoryAncestors(gomock.Any(), gomock.Eq(parentID)).
+					Times(1).
+					Return([]domain.Category{{ID: parentID, DeletedAt: &deletedAt}}, nil)
+			},
+			parentID: parentID,
+			err:      domain.ErrDataNotFound,
+		},
+		{
+			desc: "Fail_Itself",
+			mocks: func(
+				categoryRepo *mock.MockCategoryRepository,
+				cache *mock.MockCacheRepository,
+			) {
+				categoryRepo.EXPECT().
+					GetCategoryByID(gomock.Any(), gomock.Eq(categoryID)).
+					Times(1).
+					Return(existingCategory, nil)
+				categoryRepo.EXPECT().
+					LockCategoryTree(gomock.Any()).
+					Times(1).
+					Return(nil)
+			},
+			parentID: categoryID,
+			err:      domain.ErrCategoryCycle,
+		},
+		{
+			desc: "Fail_Descendant",
+			mocks: func(
+				categoryRepo *mock.MockCategoryRepository,
+				cache *mock.MockCacheRepository,
+			) {
+				categoryRepo.EXPECT().
+					GetCategoryByID(gomock.Any(), gomock.Eq(categoryID)).
+					Times(1).
+					Return(existingCategory, nil)
+				categoryRepo.EXPECT().
+					LockCategoryTree(gomock.Any()).
+					Times(1).
+					Return(nil)
+				categoryRepo.EXPECT().
+					ListCategoryAncestors(gomock.Any(), gomock.Eq(childID)).
+					Times(1).
+					Return([]domain.Category{
+						{ID: childID, ParentID: categoryID},
+						{ID: categoryID},
+					}, nil)
+			},
+			parentID: childID,
+			err:      domain.ErrCategoryCycle,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			categoryRepo := mock.NewMockCategoryRepository(ctrl)
+			cache := mock.NewMockCacheRepository(ctrl)
+
+			tc.mocks(categoryRepo, cache)
+
+			categoryService := service.NewCategoryService(categoryRepo, cache, newMockAuditService(ctrl))
+
+			_, err := categoryService.UpdateCategory(ctx, &domain.Category{
+				ID:       categoryID,
+				ParentID: tc.parentID,
+			}, true)
+			assert.Equal(t, tc.err, err, "Error mismatch")
+		})
+	}
+}
+
 func TestCategoryService_DeleteCategory(t *testing.T) {
 	ctx := context.Background()
 	categoryID := gofakeit.Uint64()
+	version := gofakeit.Uint64()
+	existingCategory := &domain.Category{
+		ID:      categoryID,
+		Version: version,
+	}
 
 	cacheKey := util.GenerateCacheKey("category", categoryID)
 
@@ -904,7 +1186,7 @@ func TestCategoryService_DeleteCategory(t *testing.T) {
 				categoryRepo.EXPECT().
 					GetCategoryByID(gomock.Any(), gomock.Eq(categoryID)).
 					Times(1).
-					Return(&domain.Category{}, nil)
+					Return(existingCategory, nil)
 				cache.EXPECT().
 					Delete(gomock.Any(), gomock.Eq(cacheKey)).
 					Times(1).
@@ -914,12 +1196,13 @@ func TestCategoryService_DeleteCategory(t *testing.T) {
 					Times(1).
 					Return(nil)
 				categoryRepo.EXPECT().
-					DeleteCategory(gomock.Any(), gomock.Eq(categoryID)).
+					DeleteCategory(gomock.Any(), gomock.Eq(categoryID), gomock.Eq(version)).
 					Times(1).
 					Return(nil)
 			},
 			input: deleteCategoryTestedInput{
-				id: categoryID,
+				id:      categoryID,
+				version: version,
 			},
 			expected: deleteCategoryExpectedOutput{
 				err: nil,
@@ -937,12 +1220,32 @@ func TestCategoryService_DeleteCategory(t *testing.T) {
 					Return(nil, domain.ErrDataNotFound)
 			},
 			input: deleteCategoryTestedInput{
-				id: categoryID,
+				id:      categoryID,
+				version: version,
 			},
 			expected: deleteCategoryExpectedOutput{
 				err: domain.ErrDataNotFound,
 			},
 		},
+		{
+			desc: "Fail_VersionMismatch",
+			mocks: func(
+				categoryRepo *mock.MockCategoryRepository,
+				cache *mock.MockCacheRepository,
+			) {
+				categoryRepo.EXPECT().
+					GetCategoryByID(gomock.Any(), gomock.Eq(categoryID)).
+					Times(1).
+					Return(existingCategory, nil)
+			},
+			input: deleteCategoryTestedInput{
+				id:      categoryID,
+				version: version + 1,
+			},
+			expected: deleteCategoryExpectedOutput{
+				err: domain.ErrVersionMismatch,
+			},
+		},
 		{
 			desc: "Fail_InternalErrorGetByID",
 			mocks: func(
@@ -955,7 +1258,8 @@ func TestCategoryService_DeleteCategory(t *testing.T) {
 					Return(nil, domain.ErrInternal)
 			},
 			input: deleteCategoryTestedInput{
-				id: categoryID,
+				id:      categoryID,
+				version: version,
 			},
 			expected: deleteCategoryExpectedOutput{
 				err: domain.ErrInternal,
@@ -970,14 +1274,15 @@ func TestCategoryService_DeleteCategory(t *testing.T) {
 				categoryRepo.EXPECT().
 					GetCategoryByID(gomock.Any(), gomock.Eq(categoryID)).
 					Times(1).
-					Return(&domain.Category{}, nil)
+					Return(existingCategory, nil)
 				cache.EXPECT().
 					Delete(gomock.Any(), gomock.Eq(cacheKey)).
 					Times(1).
 					Return(domain.ErrInternal)
 			},
 			input: deleteCategoryTestedInput{
-				id: categoryID,
+				id:      categoryID,
+				version: version,
 			},
 			expected: deleteCategoryExpectedOutput{
 				err: domain.ErrInternal,
@@ -992,7 +1297,7 @@ func TestCategoryService_DeleteCategory(t *testing.T) {
 				categoryRepo.EXPECT().
 					GetCategoryByID(gomock.Any(), gomock.Eq(categoryID)).
 					Times(1).
-					Return(&domain.Category{}, nil)
+					Return(existingCategory, nil)
 				cache.EXPECT().
 					Delete(gomock.Any(), gomock.Eq(cacheKey)).
 					Times(1).
@@ -1003,7 +1308,8 @@ func TestCategoryService_DeleteCategory(t *testing.T) {
 					Return(domain.ErrInternal)
 			},
 			input: deleteCategoryTestedInput{
-				id: categoryID,
+				id:      categoryID,
+				version: version,
 			},
 			expected: deleteCategoryExpectedOutput{
 				err: domain.ErrInternal,
@@ -1018,7 +1324,7 @@ func TestCategoryService_DeleteCategory(t *testing.T) {
 				categoryRepo.EXPECT().
 					GetCategoryByID(gomock.Any(), gomock.Eq(categoryID)).
 					Times(1).
-					Return(&domain.Category{}, nil)
+					Return(existingCategory, nil)
 				cache.EXPECT().
 					Delete(gomock.Any(), gomock.Eq(cacheKey)).
 					Times(1).
@@ -1028,12 +1334,13 @@ func TestCategoryService_DeleteCategory(t *testing.T) {
 					Times(1).
 					Return(nil)
 				categoryRepo.EXPECT().
-					DeleteCategory(gomock.Any(), gomock.Eq(categoryID)).
+					DeleteCategory(gomock.Any(), gomock.Eq(categoryID), gomock.Eq(version)).
 					Times(1).
 					Return(domain.ErrInternal)
 			},
 			input: deleteCategoryTestedInput{
-				id: categoryID,
+				id:      categoryID,
+				version: version,
 			},
 			expected: deleteCategoryExpectedOutput{
 				err: domain.ErrInternal,
@@ -1053,10 +1360,162 @@ func TestCategoryService_DeleteCategory(t *testing.T) {
 
 			tc.mocks(categoryRepo, cache)
 
-			categoryService := service.NewCategoryService(categoryRepo, cache)
+			categoryService := service.NewCategoryService(categoryRepo, cache, newMockAuditService(ctrl))
 
-			err := categoryService.DeleteCategory(ctx, tc.input.id)
+			err := categoryService.DeleteCategory(ctx, tc.input.id, tc.input.version)
 			assert.Equal(t, tc.expected.err, err, "Error mismatch")
 		})
 	}
 }
+
+type restoreCategoryTestedInput struct {
+	id uint64
+}
+
+type restoreCategoryExpectedOutput struct {
+	category *domain.Category
+	err      error
+}
+
+func TestCategoryService_RestoreCategory(t *testing.T) {
+	ctx := context.Background()
+	categoryID := gofakeit.Uint64()
+	categoryOutput := &domain.Category{
+		ID:   categoryID,
+		Name: gofakeit.ProductCategory(),
+	}
+
+	testCases := []struct {
+		desc  string
+		mocks func(
+			categoryRepo *mock.MockCategoryRepository,
+			cache *mock.MockCacheRepository,
+		)
+		input    restoreCategoryTestedInput
+		expected restoreCategoryExpectedOutput
+	}{
+		{
+			desc: "Success",
+			mocks: func(
+				categoryRepo *mock.MockCategoryRepository,
+				cache *mock.MockCacheRepository,
+			) {
+				categoryRepo.EXPECT().
+					RestoreCategory(gomock.Any(), gomock.Eq(categoryID)).
+					Times(1).
+					Return(categoryOutput, nil)
+				cache.EXPECT().
+					DeleteByPrefix(gomock.Any(), gomock.Eq("categories:*")).
+					Times(1).
+					Return(nil)
+			},
+			input: restoreCategoryTestedInput{
+				id: categoryID,
+			},
+			expected: restoreCategoryExpectedOutput{
+				category: categoryOutput,
+				err:      nil,
+			},
+		},
+		{
+			desc: "Fail_NotFound",
+			mocks: func(
+				categoryRepo *mock.MockCategoryRepository,
+				cache *mock.MockCacheRepository,
+			) {
+				categoryRepo.EXPECT().
+					RestoreCategory(gomock.Any(), gomock.Eq(categoryID)).
+					Times(1).
+					Return(nil, domain.ErrDataNotFound)
+			},
+			input: restoreCategoryTestedInput{
+				id: categoryID,
+			},
+			expected: restoreCategoryExpectedOutput{
+				category: nil,
+				err:      domain.ErrDataNotFound,
+			},
+		},
+		{
+			desc: "Fail_Conflict",
+			mocks: func(
+				categoryRepo *mock.MockCategoryRepository,
+				cache *mock.MockCacheRepository,
+			) {
+				categoryRepo.EXPECT().
+					RestoreCategory(gomock.Any(), gomock.Eq(categoryID)).
+					Times(1).
+					Return(nil, domain.ErrConflictingData)
+			},
+			input: restoreCategoryTestedInput{
+				id: categoryID,
+			},
+			expected: restoreCategoryExpectedOutput{
+				category: nil,
+				err:      domain.ErrConflictingData,
+			},
+		},
+		{
+			desc: "Fail_InternalError",
+			mocks: func(
+				categoryRepo *mock.MockCategoryRepository,
+				cache *mock.MockCacheRepository,
+			) {
+				categoryRepo.EXPECT().
+					RestoreCategory(gomock.Any(), gomock.Eq(categoryID)).
+					Times(1).
+					Return(nil, domain.ErrInternal)
+			},
+			input: restoreCategoryTestedInput{
+				id: categoryID,
+			},
+			expected: restoreCategoryExpectedOutput{
+				category: nil,
+				err:      domain.ErrInternal,
+			},
+		},
+		{
+			desc: "Fail_DeleteCacheByPrefix",
+			mocks: func(
+				categoryRepo *mock.MockCategoryRepository,
+				cache *mock.MockCacheRepository,
+			) {
+				categoryRepo.EXPECT().
+					RestoreCategory(gomock.Any(), gomock.Eq(categoryID)).
+					Times(1).
+					Return(categoryOutput, nil)
+				cache.EXPECT().
+					DeleteByPrefix(gomock.Any(), gomock.Eq("categories:*")).
+					Times(1).
+					Return(domain.ErrInternal)
+			},
+			input: restoreCategoryTestedInput{
+				id: categoryID,
+			},
+			expected: restoreCategoryExpectedOutput{
+				category: nil,
+				err:      domain.ErrInternal,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			categoryRepo := mock.NewMockCategoryRepository(ctrl)
+			cache := mock.NewMockCacheRepository(ctrl)
+
+			tc.mocks(categoryRepo, cache)
+
+			categoryService := service.NewCategoryService(categoryRepo, cache, newMockAuditService(ctrl))
+
+			category, err := categoryService.RestoreCategory(ctx, tc.input.id)
+			assert.Equal(t, tc.expected.err, err, "Error mismatch")
+			assert.Equal(t, tc.expected.category, category, "Category mismatch")
+		})
+	}
+}
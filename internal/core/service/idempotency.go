@@ -0,0 +1,233 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"log/slog"
+	"time"
+
+	"github.com/nikhil-shrestha/go-pos/internal/core/domain"
+	"github.com/nikhil-shrestha/go-pos/internal/core/port"
+	"github.com/nikhil-shrestha/go-pos/internal/core/util"
+)
+
+const (
+	// idempotencyWindow is how long the response of a request is kept for its retries with the same idempotency key
+	idempotencyWindow = 24 * time.Hour
+	// idempotencyLease is how long a running request holds its idempotency key, so that the key is freed if it never completes
+	idempotencyLease = time.Minute
+	// idempotencyWait is how long a retry waits for the running request with the same idempotency key to complete
+	idempotencyWait = 10 * time.Second
+	// idempotencyPollInterval is how often a waiting retry checks whether the running request has completed
+	idempotencyPollInterval = 100 * time.Millisecond
+)
+
+/**
+ * IdempotencyService implements port.IdempotencyService interface
+ * and provides an access to the idempotency repository
+ * and cache service
+ */
+type IdempotencyService struct {
+	repo  port.IdempotencyRepository
+	cache port.CacheRepository
+}
+
+// NewIdempotencyService creates a new idempotency service instance
+func NewIdempotencyService(repo port.IdempotencyRepository, cache port.CacheRepository) *IdempotencyService {
+	return &IdempotencyService{
+		repo,
+		cache,
+	}
+}
+
+// BeginRequest claims the idempotency key for the request and returns the running request to be completed or released.
+// If the same request holds the key, it returns the request once it has completed, waiting for it while it is running.
+// Running requests hold their key in the cache, or in the database while the cache is unavailable, and completed requests
+// are kept in both. A key is only claimed in the cache if the database does not hold it, so a retry made across a cache
+// outage, or after the cache lost the key, does not run the request again
+func (is *IdempotencyService) BeginRequest(ctx context.Context, key string, request []byte) (*domain.IdempotentRequest, error) {
+	hash := sha256.Sum256(request)
+	requestHash := hex.EncodeToString(hash[:])
+	deadline := time.Now().Add(idempotencyWait)
+
+	for {
+		now := time.Now()
+		claimed := &domain.IdempotentRequest{
+			Key:         key,
+			RequestHash: requestHash,
+			CreatedAt:   now,
+			ExpiresAt:   now.Add(idempotencyLease),
+		}
+
+		existing, err := is.claim(ctx, claimed)
+		if err != nil {
+			return nil, err
+		}
+
+		if existing == nil {
+			return claimed, nil
+		}
+
+		if existing.RequestHash != requestHash {
+			return nil, domain.ErrIdempotencyKeyReused
+		}
+
+		if existing.IsCompleted() {
+			return existing, nil
+		}
+
+		if time.Now().After(deadline) {
+			return nil, domain.ErrIdempotencyKeyInUse
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, domain.ErrIdempotencyKeyInUse
+		case <-time.After(idempotencyPollInterval):
+		}
+	}
+}
+
+// CompleteRequest keeps the response of the request for its retries during the idempotency window,
+// in the database and in the cache, and only fails if neither can keep it
+func (is *IdempotencyService) CompleteRequest(ctx context.Context, request *domain.IdempotentRequest) error {
+	request.ExpiresAt = time.Now().Add(idempotencyWindow)
+
+	requestSerialized, err := util.Serialize(request)
+	if err != nil {
+		return domain.ErrInternal
+	}
+
+	repoErr := is.repo.CompleteIdempotentRequest(ctx, request)
+
+	cacheKey := util.GenerateCacheKey("idempotency", request.Key)
+	cacheErr := is.cache.Set(ctx, cacheKey, requestSerialized, idempotencyWindow)
+
+	if repoErr != nil && cacheErr != nil {
+		return domain.ErrInternal
+	}
+
+	return nil
+}
+
+// ReleaseRequest frees the idempotency key of the request in the cache and in the database,
+// so that a retry runs the request again, and only fails if neither can free it
+func (is *IdempotencyService) ReleaseRequest(ctx context.Context, request *domain.IdempotentRequest) error {
+	cacheKey := util.GenerateCacheKey("idempotency", request.Key)
+	cacheErr := is.cache.Delete(ctx, cacheKey)
+
+	repoErr := is.repo.DeleteIdempotentRequest(ctx, request.Key)
+
+	if cacheErr != nil && repoErr != nil {
+		return domain.ErrInternal
+	}
+
+	return nil
+}
+
+// claim stores the running request, falling back to the database when the cache is unavailable,
+// and returns nil if it has claimed the key or the request which already holds it
+func (is *IdempotencyService) claim(ctx context.Context, request *domain.IdempotentRequest) (*domain.IdempotentRequest, error) {
+	requestSerialized, err := util.Serialize(request)
+	if err != nil {
+		return nil, domain.ErrInternal
+	}
+
+	cacheKey := util.GenerateCacheKey("idempotency", request.Key)
+	claimed, err := is.cache.SetIfNotExists(ctx, cacheKey, requestSerialized, idempotencyLease)
+	if err != nil {
+		return is.claimInDB(ctx, request)
+	}
+
+	if claimed {
+		return is.claimChecked(ctx, request)
+	}
+
+	existingSerialized, err := is.cache.Get(ctx, cacheKey)
+	if err != nil {
+		if err == domain.ErrDataNotFound {
+			return released(request), nil
+		}
+		return nil, domain.ErrInternal
+	}
+
+	var existing domain.IdempotentRequest
+	err = util.Deserialize(existingSerialized, &existing)
+	if err != nil {
+		return nil, domain.ErrInternal
+	}
+
+	return &existing, nil
+}
+
+// claimChecked keeps the key claimed in the cache only if the database holds no request with it, which it does if the
+// request completed while the cache lost the key or ran while the cache was unavailable, and returns that request otherwise.
+// A claimed key is also the moment to delete the expired requests from the database
+func (is *IdempotencyService) claimChecked(ctx context.Context, request *domain.IdempotentRequest) (*domain.IdempotentRequest, error) {
+	cacheKey := util.GenerateCacheKey("idempotency", request.Key)
+
+	existing, err := is.repo.GetIdempotentRequest(ctx, request.Key)
+	if err != nil {
+		if err == domain.ErrDataNotFound {
+			is.deleteExpired(ctx)
+			return nil, nil
+		}
+		_ = is.cache.Delete(ctx, cacheKey)
+		return nil, domain.ErrInternal
+	}
+
+	if !existing.IsCompleted() {
+		_ = is.cache.Delete(ctx, cacheKey)
+		return existing, nil
+	}
+
+	existingSerialized, err := util.Serialize(existing)
+	if err == nil {
+		err = is.cache.Set(ctx, cacheKey, existingSerialized, time.Until(existing.ExpiresAt))
+	}
+	if err != nil {
+		_ = is.cache.Delete(ctx, cacheKey)
+	}
+
+	return existing, nil
+}
+
+// deleteExpired deletes the expired requests from the database, which only logs a failure since the next claim tries again
+func (is *IdempotencyService) deleteExpired(ctx context.Context) {
+	err := is.repo.DeleteExpiredIdempotentRequests(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "Error deleting the expired idempotent requests", "error", err)
+	}
+}
+
+// claimInDB is like claim, but stores the running request in the database
+func (is *IdempotencyService) claimInDB(ctx context.Context, request *domain.IdempotentRequest) (*domain.IdempotentRequest, error) {
+	err := is.repo.CreateIdempotentRequest(ctx, request)
+	if err == nil {
+		return nil, nil
+	}
+
+	if err != domain.ErrConflictingData {
+		return nil, domain.ErrInternal
+	}
+
+	existing, err := is.repo.GetIdempotentRequest(ctx, request.Key)
+	if err != nil {
+		if err == domain.ErrDataNotFound {
+			return released(request), nil
+		}
+		return nil, domain.ErrInternal
+	}
+
+	return existing, nil
+}
+
+// released stands in for a request which freed its key after the key was found taken,
+// so that the retry waits a moment as if the request were running and then claims the key again
+func released(request *domain.IdempotentRequest) *domain.IdempotentRequest {
+	return &domain.IdempotentRequest{
+		Key:         request.Key,
+		RequestHash: request.RequestHash,
+	}
+}
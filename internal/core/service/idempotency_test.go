@@ -0,0 +1,686 @@
+package service_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/nikhil-shrestha/go-pos/internal/core/domain"
+	"github.com/nikhil-shrestha/go-pos/internal/core/port/mock"
+	"github.com/nikhil-shrestha/go-pos/internal/core/service"
+	"github.com/nikhil-shrestha/go-pos/internal/core/util"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+type beginRequestTestedInput struct {
+	key     string
+	request []byte
+}
+
+type beginRequestExpectedOutput struct {
+	completed bool
+	err       error
+}
+
+func TestIdempotencyService_BeginRequest(t *testing.T) {
+	ctx := context.Background()
+	key := "1:4f1c7a2e-checkout"
+	request := []byte(`POST /v1/orders {"payment_id":1}`)
+	hash := sha256.Sum256(request)
+	requestHash := hex.EncodeToString(hash[:])
+	cacheKey := util.GenerateCacheKey("idempotency", key)
+	runningRequest := &domain.IdempotentRequest{
+		Key:         key,
+		RequestHash: requestHash,
+	}
+	runningRequestSerialized, _ := util.Serialize(runningRequest)
+	completedRequest := &domain.IdempotentRequest{
+		Key:            key,
+		RequestHash:    requestHash,
+		ResponseStatus: http.StatusOK,
+		ResponseBody:   []byte(`{"success":true}`),
+	}
+	completedRequestSerialized, _ := util.Serialize(completedRequest)
+	storedRequest := &domain.IdempotentRequest{
+		Key:            key,
+		RequestHash:    requestHash,
+		ResponseStatus: http.StatusOK,
+		ResponseBody:   []byte(`{"success":true}`),
+		ExpiresAt:      time.Now().Add(time.Hour),
+	}
+	otherRequest := &domain.IdempotentRequest{
+		Key:         key,
+		RequestHash: "other",
+	}
+	otherRequestSerialized, _ := util.Serialize(otherRequest)
+	cacheErr := errors.New("redis: connection refused")
+
+	testCases := []struct {
+		desc  string
+		mocks func(
+			repo *mock.MockIdempotencyRepository,
+			cache *mock.MockCacheRepository,
+		)
+		input    beginRequestTestedInput
+		expected beginRequestExpectedOutput
+	}{
+		{
+			desc: "Success_Claimed",
+			mocks: func(
+				repo *mock.MockIdempotencyRepository,
+				cache *mock.MockCacheRepository,
+			) {
+				cache.EXPECT().
+					SetIfNotExists(gomock.Any(), gomock.Eq(cacheKey), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(true, nil)
+				repo.EXPECT().
+					GetIdempotentRequest(gomock.Any(), gomock.Eq(key)).
+					Times(1).
+					Return(nil, domain.ErrDataNotFound)
+				repo.EXPECT().
+					DeleteExpiredIdempotentRequests(gomock.Any()).
+					Times(1).
+					Return(nil)
+			},
+			input: beginRequestTestedInput{
+				key:     key,
+				request: request,
+			},
+			expected: beginRequestExpectedOutput{
+				completed: false,
+				err:       nil,
+			},
+		},
+		{
+			desc: "Success_Replayed",
+			mocks: func(
+				repo *mock.MockIdempotencyRepository,
+				cache *mock.MockCacheRepository,
+			) {
+				cache.EXPECT().
+					SetIfNotExists(gomock.Any(), gomock.Eq(cacheKey), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(false, nil)
+				cache.EXPECT().
+					Get(gomock.Any(), gomock.Eq(cacheKey)).
+					Times(1).
+					Return(completedRequestSerialized, nil)
+			},
+			input: beginRequestTestedInput{
+				key:     key,
+				request: request,
+			},
+			expected: beginRequestExpectedOutput{
+				completed: true,
+				err:       nil,
+			},
+		},
+		{
+			desc: "Success_WaitedForRunning",
+			mocks: func(
+				repo *mock.MockIdempotencyRepository,
+				cache *mock.MockCacheRepository,
+			) {
+				cache.EXPECT().
+					SetIfNotExists(gomock.Any(), gomock.Eq(cacheKey), gomock.Any(), gomock.Any()).
+					Times(2).
+					Return(false, nil)
+				gomock.InOrder(
+					cache.EXPECT().
+						Get(gomock.Any(), gomock.Eq(cacheKey)).
+						Times(1).
+						Return(runningRequestSerialized, nil),
+					cache.EXPECT().
+						Get(gomock.Any(), gomock.Eq(cacheKey)).
+						Times(1).
+						Return(completedRequestSerialized, nil),
+				)
+			},
+			input: beginRequestTestedInput{
+				key:     key,
+				request: request,
+			},
+			expected: beginRequestExpectedOutput{
+				completed: true,
+				err:       nil,
+			},
+		},
+		{
+			desc: "Success_ClaimedAfterRelease",
+			mocks: func(
+				repo *mock.MockIdempotencyRepository,
+				cache *mock.MockCacheRepository,
+			) {
+				gomock.InOrder(
+					cache.EXPECT().
+						SetIfNotExists(gomock.Any(), gomock.Eq(cacheKey), gomock.Any(), gomock.Any()).
+						Times(1).
+						Return(false, nil),
+					cache.EXPECT().
+						Get(gomock.Any(), gomock.Eq(cacheKey)).
+						Times(1).
+						Return(nil, domain.ErrDataNotFound),
+					cache.EXPECT().
+						SetIfNotExists(gomock.Any(), gomock.Eq(cacheKey), gomock.Any(), gomock.Any()).
+						Times(1).
+						Return(true, nil),
+					repo.EXPECT().
+						GetIdempotentRequest(gomock.Any(), gomock.Eq(key)).
+						Times(1).
+						Return(nil, domain.ErrDataNotFound),
+					repo.EXPECT().
+						DeleteExpiredIdempotentRequests(gomock.Any()).
+						Times(1).
+						Return(domain.ErrInternal),
+				)
+			},
+			input: beginRequestTestedInput{
+				key:     key,
+				request: request,
+			},
+			expected: beginRequestExpectedOutput{
+				completed: false,
+				err:       nil,
+			},
+		},
+		{
+			desc: "Success_ReplayedAfterCacheLoss",
+			mocks: func(
+				repo *mock.MockIdempotencyRepository,
+				cache *mock.MockCacheRepository,
+			) {
+				cache.EXPECT().
+					SetIfNotExists(gomock.Any(), gomock.Eq(cacheKey), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(true, nil)
+				repo.EXPECT().
+					GetIdempotentRequest(gomock.Any(), gomock.Eq(key)).
+					Times(1).
+					Return(storedRequest, nil)
+				cache.EXPECT().
+					Set(gomock.Any(), gomock.Eq(cacheKey), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil)
+			},
+			input: beginRequestTestedInput{
+				key:     key,
+				request: request,
+			},
+			expected: beginRequestExpectedOutput{
+				completed: true,
+				err:       nil,
+			},
+		},
+		{
+			desc: "Success_WaitedForRunningInDB",
+			mocks: func(
+				repo *mock.MockIdempotencyRepository,
+				cache *mock.MockCacheRepository,
+			) {
+				cache.EXPECT().
+					SetIfNotExists(gomock.Any(), gomock.Eq(cacheKey), gomock.Any(), gomock.Any()).
+					Times(2).
+					Return(true, nil)
+				gomock.InOrder(
+					repo.EXPECT().
+						GetIdempotentRequest(gomock.Any(), gomock.Eq(key)).
+						Times(1).
+						Return(runningRequest, nil),
+					cache.EXPECT().
+						Delete(gomock.Any(), gomock.Eq(cacheKey)).
+						Times(1).
+						Return(nil),
+					repo.EXPECT().
+						GetIdempotentRequest(gomock.Any(), gomock.Eq(key)).
+						Times(1).
+						Return(storedRequest, nil),
+					cache.EXPECT().
+						Set(gomock.Any(), gomock.Eq(cacheKey), gomock.Any(), gomock.Any()).
+						Times(1).
+						Return(nil),
+				)
+			},
+			input: beginRequestTestedInput{
+				key:     key,
+				request: request,
+			},
+			expected: beginRequestExpectedOutput{
+				completed: true,
+				err:       nil,
+			},
+		},
+		{
+			desc: "Fail_CheckInDBError",
+			mocks: func(
+				repo *mock.MockIdempotencyRepository,
+				cache *mock.MockCacheRepository,
+			) {
+				cache.EXPECT().
+					SetIfNotExists(gomock.Any(), gomock.Eq(cacheKey), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(true, nil)
+				repo.EXPECT().
+					GetIdempotentRequest(gomock.Any(), gomock.Eq(key)).
+					Times(1).
+					Return(nil, domain.ErrInternal)
+				cache.EXPECT().
+					Delete(gomock.Any(), gomock.Eq(cacheKey)).
+					Times(1).
+					Return(nil)
+			},
+			input: beginRequestTestedInput{
+				key:     key,
+				request: request,
+			},
+			expected: beginRequestExpectedOutput{
+				err: domain.ErrInternal,
+			},
+		},
+		{
+			desc: "Fail_KeyReused",
+			mocks: func(
+				repo *mock.MockIdempotencyRepository,
+				cache *mock.MockCacheRepository,
+			) {
+				cache.EXPECT().
+					SetIfNotExists(gomock.Any(), gomock.Eq(cacheKey), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(false, nil)
+				cache.EXPECT().
+					Get(gomock.Any(), gomock.Eq(cacheKey)).
+					Times(1).
+					Return(otherRequestSerialized, nil)
+			},
+			input: beginRequestTestedInput{
+				key:     key,
+				request: request,
+			},
+			expected: beginRequestExpectedOutput{
+				err: domain.ErrIdempotencyKeyReused,
+			},
+		},
+		{
+			desc: "Fail_CacheGetError",
+			mocks: func(
+				repo *mock.MockIdempotencyRepository,
+				cache *mock.MockCacheRepository,
+			) {
+				cache.EXPECT().
+					SetIfNotExists(gomock.Any(), gomock.Eq(cacheKey), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(false, nil)
+				cache.EXPECT().
+					Get(gomock.Any(), gomock.Eq(cacheKey)).
+					Times(1).
+					Return(nil, cacheErr)
+			},
+			input: beginRequestTestedInput{
+				key:     key,
+				request: request,
+			},
+			expected: beginRequestExpectedOutput{
+				err: domain.ErrInternal,
+			},
+		},
+		{
+			desc: "Success_ClaimedInDB",
+			mocks: func(
+				repo *mock.MockIdempotencyRepository,
+				cache *mock.MockCacheRepository,
+			) {
+				cache.EXPECT().
+					SetIfNotExists(gomock.Any(), gomock.Eq(cacheKey), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(false, cacheErr)
+				repo.EXPECT().
+					CreateIdempotentRequest(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil)
+			},
+			input: beginRequestTestedInput{
+				key:     key,
+				request: request,
+			},
+			expected: beginRequestExpectedOutput{
+				completed: false,
+				err:       nil,
+			},
+		},
+		{
+			desc: "Success_ReplayedFromDB",
+			mocks: func(
+				repo *mock.MockIdempotencyRepository,
+				cache *mock.MockCacheRepository,
+			) {
+				cache.EXPECT().
+					SetIfNotExists(gomock.Any(), gomock.Eq(cacheKey), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(false, cacheErr)
+				repo.EXPECT().
+					CreateIdempotentRequest(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(domain.ErrConflictingData)
+				repo.EXPECT().
+					GetIdempotentRequest(gomock.Any(), gomock.Eq(key)).
+					Times(1).
+					Return(completedRequest, nil)
+			},
+			input: beginRequestTestedInput{
+				key:     key,
+				request: request,
+			},
+			expected: beginRequestExpectedOutput{
+				completed: true,
+				err:       nil,
+			},
+		},
+		{
+			desc: "Fail_InternalError",
+			mocks: func(
+				repo *mock.MockIdempotencyRepository,
+				cache *mock.MockCacheRepository,
+			) {
+				cache.EXPECT().
+					SetIfNotExists(gomock.Any(), gomock.Eq(cacheKey), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(false, cacheErr)
+				repo.EXPECT().
+					CreateIdempotentRequest(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(domain.ErrInternal)
+			},
+			input: beginRequestTestedInput{
+				key:     key,
+				request: request,
+			},
+			expected: beginRequestExpectedOutput{
+				err: domain.ErrInternal,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mock.NewMockIdempotencyRepository(ctrl)
+			cache := mock.NewMockCacheRepository(ctrl)
+
+			tc.mocks(repo, cache)
+
+			idempotencyService := service.NewIdempotencyService(repo, cache)
+
+			idempotentRequest, err := idempotencyService.BeginRequest(ctx, tc.input.key, tc.input.request)
+			assert.Equal(t, tc.expected.err, err, "Error mismatch")
+			if tc.expected.err == nil {
+				assert.Equal(t, requestHash, idempotentRequest.RequestHash, "Request hash mismatch")
+				assert.Equal(t, tc.expected.completed, idempotentRequest.IsCompleted(), "Completion mismatch")
+			}
+		})
+	}
+}
+
+type completeRequestTestedInput struct {
+	request *domain.IdempotentRequest
+}
+
+type completeRequestExpectedOutput struct {
+	err error
+}
+
+func TestIdempotencyService_CompleteRequest(t *testing.T) {
+	ctx := context.Background()
+	key := "1:4f1c7a2e-checkout"
+	cacheKey := util.GenerateCacheKey("idempotency", key)
+	cacheErr := errors.New("redis: connection refused")
+
+	testCases := []struct {
+		desc  string
+		mocks func(
+			repo *mock.MockIdempotencyRepository,
+			cache *mock.MockCacheRepository,
+		)
+		input    completeRequestTestedInput
+		expected completeRequestExpectedOutput
+	}{
+		{
+			desc: "Success",
+			mocks: func(
+				repo *mock.MockIdempotencyRepository,
+				cache *mock.MockCacheRepository,
+			) {
+				repo.EXPECT().
+					CompleteIdempotentRequest(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil)
+				cache.EXPECT().
+					Set(gomock.Any(), gomock.Eq(cacheKey), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil)
+			},
+			input: completeRequestTestedInput{
+				request: &domain.IdempotentRequest{
+					Key:            key,
+					ResponseStatus: http.StatusOK,
+				},
+			},
+			expected: completeRequestExpectedOutput{
+				err: nil,
+			},
+		},
+		{
+			desc: "Success_StoredInDB",
+			mocks: func(
+				repo *mock.MockIdempotencyRepository,
+				cache *mock.MockCacheRepository,
+			) {
+				repo.EXPECT().
+					CompleteIdempotentRequest(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil)
+				cache.EXPECT().
+					Set(gomock.Any(), gomock.Eq(cacheKey), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(cacheErr)
+			},
+			input: completeRequestTestedInput{
+				request: &domain.IdempotentRequest{
+					Key:            key,
+					ResponseStatus: http.StatusOK,
+				},
+			},
+			expected: completeRequestExpectedOutput{
+				err: nil,
+			},
+		},
+		{
+			desc: "Success_StoredInCache",
+			mocks: func(
+				repo *mock.MockIdempotencyRepository,
+				cache *mock.MockCacheRepository,
+			) {
+				repo.EXPECT().
+					CompleteIdempotentRequest(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(domain.ErrInternal)
+				cache.EXPECT().
+					Set(gomock.Any(), gomock.Eq(cacheKey), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil)
+			},
+			input: completeRequestTestedInput{
+				request: &domain.IdempotentRequest{
+					Key:            key,
+					ResponseStatus: http.StatusOK,
+				},
+			},
+			expected: completeRequestExpectedOutput{
+				err: nil,
+			},
+		},
+		{
+			desc: "Fail_InternalError",
+			mocks: func(
+				repo *mock.MockIdempotencyRepository,
+				cache *mock.MockCacheRepository,
+			) {
+				cache.EXPECT().
+					Set(gomock.Any(), gomock.Eq(cacheKey), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(cacheErr)
+				repo.EXPECT().
+					CompleteIdempotentRequest(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(domain.ErrInternal)
+			},
+			input: completeRequestTestedInput{
+				request: &domain.IdempotentRequest{
+					Key:            key,
+					ResponseStatus: http.StatusOK,
+				},
+			},
+			expected: completeRequestExpectedOutput{
+				err: domain.ErrInternal,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mock.NewMockIdempotencyRepository(ctrl)
+			cache := mock.NewMockCacheRepository(ctrl)
+
+			tc.mocks(repo, cache)
+
+			idempotencyService := service.NewIdempotencyService(repo, cache)
+
+			err := idempotencyService.CompleteRequest(ctx, tc.input.request)
+			assert.Equal(t, tc.expected.err, err, "Error mismatch")
+		})
+	}
+}
+
+type releaseRequestTestedInput struct {
+	request *domain.IdempotentRequest
+}
+
+type releaseRequestExpectedOutput struct {
+	err error
+}
+
+func TestIdempotencyService_ReleaseRequest(t *testing.T) {
+	ctx := context.Background()
+	key := "1:4f1c7a2e-checkout"
+	cacheKey := util.GenerateCacheKey("idempotency", key)
+	cacheErr := errors.New("redis: connection refused")
+
+	testCases := []struct {
+		desc  string
+		mocks func(
+			repo *mock.MockIdempotencyRepository,
+			cache *mock.MockCacheRepository,
+		)
+		input    releaseRequestTestedInput
+		expected releaseRequestExpectedOutput
+	}{
+		{
+			desc: "Success",
+			mocks: func(
+				repo *mock.MockIdempotencyRepository,
+				cache *mock.MockCacheRepository,
+			) {
+				cache.EXPECT().
+					Delete(gomock.Any(), gomock.Eq(cacheKey)).
+					Times(1).
+					Return(nil)
+				repo.EXPECT().
+					DeleteIdempotentRequest(gomock.Any(), gomock.Eq(key)).
+					Times(1).
+					Return(nil)
+			},
+			input: releaseRequestTestedInput{
+				request: &domain.IdempotentRequest{Key: key},
+			},
+			expected: releaseRequestExpectedOutput{
+				err: nil,
+			},
+		},
+		{
+			desc: "Success_DeletedFromDB",
+			mocks: func(
+				repo *mock.MockIdempotencyRepository,
+				cache *mock.MockCacheRepository,
+			) {
+				cache.EXPECT().
+					Delete(gomock.Any(), gomock.Eq(cacheKey)).
+					Times(1).
+					Return(cacheErr)
+				repo.EXPECT().
+					DeleteIdempotentRequest(gomock.Any(), gomock.Eq(key)).
+					Times(1).
+					Return(nil)
+			},
+			input: releaseRequestTestedInput{
+				request: &domain.IdempotentRequest{Key: key},
+			},
+			expected: releaseRequestExpectedOutput{
+				err: nil,
+			},
+		},
+		{
+			desc: "Fail_InternalError",
+			mocks: func(
+				repo *mock.MockIdempotencyRepository,
+				cache *mock.MockCacheRepository,
+			) {
+				cache.EXPECT().
+					Delete(gomock.Any(), gomock.Eq(cacheKey)).
+					Times(1).
+					Return(cacheErr)
+				repo.EXPECT().
+					DeleteIdempotentRequest(gomock.Any(), gomock.Eq(key)).
+					Times(1).
+					Return(domain.ErrInternal)
+			},
+			input: releaseRequestTestedInput{
+				request: &domain.IdempotentRequest{Key: key},
+			},
+			expected: releaseRequestExpectedOutput{
+				err: domain.ErrInternal,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mock.NewMockIdempotencyRepository(ctrl)
+			cache := mock.NewMockCacheRepository(ctrl)
+
+			tc.mocks(repo, cache)
+
+			idempotencyService := service.NewIdempotencyService(repo, cache)
+
+			err := idempotencyService.ReleaseRequest(ctx, tc.input.request)
+			assert.Equal(t, tc.expected.err, err, "Error mismatch")
+		})
+	}
+}
@@ -0,0 +1,292 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"image/png"
+
+	"github.com/nikhil-shrestha/go-pos/internal/core/domain"
+	"github.com/nikhil-shrestha/go-pos/internal/core/port"
+	"github.com/nikhil-shrestha/go-pos/internal/core/util"
+	"github.com/google/uuid"
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+)
+
+const (
+	// maxImagePixels limits the dimensions of an uploaded image, since a small file can decode into a huge bitmap
+	maxImagePixels = 40_000_000
+	// thumbnailSize is the maximum width and height of a generated thumbnail
+	thumbnailSize = 256
+)
+
+// imageExtensions maps the supported image formats to their file extension
+var imageExtensions = map[string]string{
+	"jpeg": "jpg",
+	"png":  "png",
+	"webp": "webp",
+}
+
+/**
+ * ImageService implements port.ImageService interface
+ * and provides an access to the product and payment repositories,
+ * file storage, cache service and audit service
+ */
+type ImageService struct {
+	productRepo  port.ProductRepository
+	categoryRepo port.CategoryRepository
+	paymentRepo  port.PaymentRepository
+	storage      port.FileStorage
+	cache        port.CacheRepository
+	audit        port.AuditService
+}
+
+// NewImageService creates a new image service instance
+func NewImageService(productRepo port.ProductRepository, categoryRepo port.CategoryRepository, paymentRepo port.PaymentRepository, storage port.FileStorage, cache port.CacheRepository, audit port.AuditService) *ImageService {
+	return &ImageService{
+		productRepo,
+		categoryRepo,
+		paymentRepo,
+		storage,
+		cache,
+		audit,
+	}
+}
+
+// UploadProductImage stores a product image with its thumbnail and replaces the previous ones
+func (is *ImageService) UploadProductImage(ctx context.Context, id uint64, image *domain.File) (*domain.Product, error) {
+	existingProduct, err := is.productRepo.GetProductByID(ctx, id)
+	if err != nil {
+		if err == domain.ErrDataNotFound {
+			return nil, err
+		}
+		return nil, domain.ErrInternal
+	}
+
+	imageURL, thumbnailURL, err := is.uploadImage(ctx, fmt.Sprintf("products/%d", id), image)
+	if err != nil {
+		return nil, err
+	}
+
+	product := &domain.Product{
+		ID:        id,
+		Image:     imageURL,
+		Thumbnail: thumbnailURL,
+	}
+
+	var updatedProduct *domain.Product
+
+	err = is.audit.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+
+		updatedProduct, err = is.productRepo.UpdateProduct(ctx, product)
+		if err != nil {
+			return domain.ErrInternal
+		}
+
+		return is.audit.RecordAuditLog(ctx, &domain.AuditLog{Action: "product.update", Entity: "product", EntityID: id}, existingProduct, updatedProduct)
+	})
+	if err != nil {
+		is.deleteImages(ctx, imageURL, thumbnailURL)
+		return nil, err
+	}
+
+	category, err := is.categoryRepo.GetCategoryByID(ctx, existingProduct.CategoryID)
+	if err != nil {
+		if err == domain.ErrDataNotFound {
+			return nil, err
+		}
+		return nil, domain.ErrInternal
+	}
+
+	product.Category = category
+
+	is.deleteImages(ctx, existingProduct.Image, existingProduct.Thumbnail)
+
+	cacheKey := util.GenerateCacheKey("product", id)
+
+	err = is.cache.Delete(ctx, cacheKey)
+	if err != nil {
+		return nil, domain.ErrInternal
+	}
+
+	err = is.cache.DeleteByPrefix(ctx, "products:*")
+	if err != nil {
+		return nil, domain.ErrInternal
+	}
+
+	return product, nil
+}
+
+// UploadPaymentLogo stores a payment logo with its thumbnail and replaces the previous ones
+func (is *ImageService) UploadPaymentLogo(ctx context.Context, id uint64, image *domain.File) (*domain.Payment, error) {
+	existingPayment, err := is.paymentRepo.GetPaymentByID(ctx, id)
+	if err != nil {
+		if err == domain.ErrDataNotFound {
+			return nil, err
+		}
+		return nil, domain.ErrInternal
+	}
+
+	logoURL, thumbnailURL, err := is.uploadImage(ctx, fmt.Sprintf("payments/%d", id), image)
+	if err != nil {
+		return nil, err
+	}
+
+	payment := &domain.Payment{
+		ID:            id,
+		Logo:          logoURL,
+		LogoThumbnail: thumbnailURL,
+	}
+
+	var updatedPayment *domain.Payment
+
+	err = is.audit.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+
+		updatedPayment, err = is.paymentRepo.UpdatePayment(ctx, payment)
+		if err != nil {
+			return domain.ErrInternal
+		}
+
+		return is.audit.RecordAuditLog(ctx, &domain.AuditLog{Action: "payment.update", Entity: "payment", EntityID: id}, existingPayment, updatedPayment)
+	})
+	if err != nil {
+		is.deleteImages(ctx, logoURL, thumbnailURL)
+		return nil, err
+	}
+
+	is.deleteImages(ctx, existingPayment.Logo, existingPayment.LogoThumbnail)
+
+	cacheKey := util.GenerateCacheKey("payment", id)
+
+	err = is.cache.Delete(ctx, cacheKey)
+	if err != nil {
+		return nil, domain.ErrInternal
+	}
+
+	err = is.cache.DeleteByPrefix(ctx, "payments:*")
+	if err != nil {
+		return nil, domain.ErrInternal
+	}
+
+	return payment, nil
+}
+
+// uploadImage validates an image, generates its thumbnail, and stores both under a new name in the directory
+func (is *ImageService) uploadImage(ctx context.Context, dir string, image *domain.File) (string, string, error) {
+	original, thumbnail, err := newImageFiles(uuid.NewString(), image)
+	if err != nil {
+		return "", "", err
+	}
+
+	imageURL, err := is.storage.Upload(ctx, dir+"/"+original.Name, original)
+	if err != nil {
+		return "", "", domain.ErrInternal
+	}
+
+	thumbnailURL, err := is.storage.Upload(ctx, dir+"/"+thumbnail.Name, thumbnail)
+	if err != nil {
+		is.deleteImages(ctx, imageURL)
+		return "", "", domain.ErrInternal
+	}
+
+	return imageURL, thumbnailURL, nil
+}
+
+// deleteImages removes stored images on a best-effort basis, since a leftover file must not fail the request
+func (is *ImageService) deleteImages(ctx context.Context, urls ...string) {
+	for _, url := range urls {
+		if url != "" {
+			_ = is.storage.Delete(ctx, url)
+		}
+	}
+}
+
+// newImageFiles checks the size and the actual format of an image and returns it with its thumbnail,
+// named after the given name and the extension of their format
+func newImageFiles(name string, file *domain.File) (*domain.File, *domain.File, error) {
+	if len(file.Content) > domain.MaxImageSize {
+		return nil, nil, domain.ErrImageTooLarge
+	}
+
+	config, format, err := image.DecodeConfig(bytes.NewReader(file.Content))
+	if err != nil {
+		return nil, nil, domain.ErrUnsupportedImage
+	}
+
+	extension, ok := imageExtensions[format]
+	if !ok {
+		return nil, nil, domain.ErrUnsupportedImage
+	}
+
+	if config.Width*config.Height > maxImagePixels {
+		return nil, nil, domain.ErrImageTooLarge
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(file.Content))
+	if err != nil {
+		return nil, nil, domain.ErrUnsupportedImage
+	}
+
+	original := &domain.File{
+		Name:        name + "." + extension,
+		ContentType: "image/" + format,
+		Content:     file.Content,
+	}
+
+	thumbnail, err := newThumbnail(name+"_thumb", img, format)
+	if err != nil {
+		return nil, nil, domain.ErrInternal
+	}
+
+	return original, thumbnail, nil
+}
+
+// newThumbnail scales an image down to fit thumbnailSize, keeping JPEG images as JPEG and encoding the others as PNG
+func newThumbnail(name string, img image.Image, format string) (*domain.File, error) {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+
+	if width > thumbnailSize || height > thumbnailSize {
+		if width >= height {
+			height = max(1, height*thumbnailSize/width)
+			width = thumbnailSize
+		} else {
+			width = max(1, width*thumbnailSize/height)
+			height = thumbnailSize
+		}
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), img, bounds, draw.Over, nil)
+
+	var buf bytes.Buffer
+
+	if format == "jpeg" {
+		err := jpeg.Encode(&buf, dst, &jpeg.Options{Quality: 85})
+		if err != nil {
+			return nil, err
+		}
+
+		return &domain.File{
+			Name:        name + ".jpg",
+			ContentType: "image/jpeg",
+			Content:     buf.Bytes(),
+		}, nil
+	}
+
+	err := png.Encode(&buf, dst)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.File{
+		Name:        name + ".png",
+		ContentType: "image/png",
+		Content:     buf.Bytes(),
+	}, nil
+}
@@ -0,0 +1,369 @@
+package service_test
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/nikhil-shrestha/go-pos/internal/core/domain"
+	"github.com/nikhil-shrestha/go-pos/internal/core/port/mock"
+	"github.com/nikhil-shrestha/go-pos/internal/core/service"
+	"github.com/nikhil-shrestha/go-pos/internal/core/util"
+	"github.com/brianvoe/gofakeit/v6"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+// newTestImage encodes a blank PNG image of the given size
+func newTestImage(width, height int) []byte {
+	var buf bytes.Buffer
+	_ = png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, width, height)))
+	return buf.Bytes()
+}
+
+type uploadProductImageTestedInput struct {
+	id    uint64
+	image *domain.File
+}
+
+type uploadProductImageExpectedOutput struct {
+	product *domain.Product
+	err     error
+}
+
+func TestImageService_UploadProductImage(t *testing.T) {
+	ctx := context.Background()
+	productID := gofakeit.Uint64()
+	imageURL := gofakeit.URL()
+	thumbnailURL := gofakeit.URL()
+	existingProduct := &domain.Product{
+		ID:         productID,
+		CategoryID: gofakeit.Uint64(),
+		Image:      gofakeit.URL(),
+	}
+	category := &domain.Category{
+		ID:   existingProduct.CategoryID,
+		Name: gofakeit.ProductCategory(),
+	}
+	productOutput := &domain.Product{
+		ID:        productID,
+		Image:     imageURL,
+		Thumbnail: thumbnailURL,
+		Category:  category,
+	}
+	productImage := &domain.File{
+		Name:        "image.png",
+		ContentType: "image/png",
+		Content:     newTestImage(600, 300),
+	}
+
+	cacheKey := util.GenerateCacheKey("product", productID)
+
+	testCases := []struct {
+		desc  string
+		mocks func(
+			productRepo *mock.MockProductRepository,
+			categoryRepo *mock.MockCategoryRepository,
+			storage *mock.MockFileStorage,
+			cache *mock.MockCacheRepository,
+		)
+		input    uploadProductImageTestedInput
+		expected uploadProductImageExpectedOutput
+	}{
+		{
+			desc: "Success",
+			mocks: func(
+				productRepo *mock.MockProductRepository,
+				categoryRepo *mock.MockCategoryRepository,
+				storage *mock.MockFileStorage,
+				cache *mock.MockCacheRepository,
+			) {
+				productRepo.EXPECT().
+					GetProductByID(gomock.Any(), gomock.Eq(productID)).
+					Times(1).
+					Return(existingProduct, nil)
+				storage.EXPECT().
+					Upload(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(ctx context.Context, key string, file *domain.File) (string, error) {
+						assert.True(t, strings.HasPrefix(key, "products/"), "Key mismatch")
+						assert.Equal(t, productImage.Content, file.Content, "Image mismatch")
+						return imageURL, nil
+					})
+				storage.EXPECT().
+					Upload(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(ctx context.Context, key string, file *domain.File) (string, error) {
+						thumbnail, format, err := image.DecodeConfig(bytes.NewReader(file.Content))
+						assert.NoError(t, err, "Thumbnail is not an image")
+						assert.Equal(t, "png", format, "Thumbnail format mismatch")
+						assert.Equal(t, 256, thumbnail.Width, "Thumbnail width mismatch")
+						assert.Equal(t, 128, thumbnail.Height, "Thumbnail height mismatch")
+						return thumbnailURL, nil
+					})
+				productRepo.EXPECT().
+					UpdateProduct(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(productOutput, nil)
+				categoryRepo.EXPECT().
+					GetCategoryByID(gomock.Any(), gomock.Eq(existingProduct.CategoryID)).
+					Times(1).
+					Return(category, nil)
+				storage.EXPECT().
+					Delete(gomock.Any(), gomock.Eq(existingProduct.Image)).
+					Times(1).
+					Return(nil)
+				cache.EXPECT().
+					Delete(gomock.Any(), gomock.Eq(cacheKey)).
+					Times(1).
+					Return(nil)
+				cache.EXPECT().
+					DeleteByPrefix(gomock.Any(), gomock.Eq("products:*")).
+					Times(1).
+					Return(nil)
+			},
+			input: uploadProductImageTestedInput{
+				id:    productID,
+				image: productImage,
+			},
+			expected: uploadProductImageExpectedOutput{
+				product: productOutput,
+				err:     nil,
+			},
+		},
+		{
+			desc: "Fail_NotFound",
+			mocks: func(
+				productRepo *mock.MockProductRepository,
+				categoryRepo *mock.MockCategoryRepository,
+				storage *mock.MockFileStorage,
+				cache *mock.MockCacheRepository,
+			) {
+				productRepo.EXPECT().
+					GetProductByID(gomock.Any(), gomock.Eq(productID)).
+					Times(1).
+					Return(nil, domain.ErrDataNotFound)
+			},
+			input: uploadProductImageTestedInput{
+				id:    productID,
+				image: productImage,
+			},
+			expected: uploadProductImageExpectedOutput{
+				product: nil,
+				err:     domain.ErrDataNotFound,
+			},
+		},
+		{
+			desc: "Fail_UnsupportedImage",
+			mocks: func(
+				productRepo *mock.MockProductRepository,
+				categoryRepo *mock.MockCategoryRepository,
+				storage *mock.MockFileStorage,
+				cache *mock.MockCacheRepository,
+			) {
+				productRepo.EXPECT().
+					GetProductByID(gomock.Any(), gomock.Eq(productID)).
+					Times(1).
+					Return(existingProduct, nil)
+			},
+			input: uploadProductImageTestedInput{
+				id: productID,
+				image: &domain.File{
+					Name:        "image.png",
+					ContentType: "image/png",
+					Content:     []byte("<svg></svg>"),
+				},
+			},
+			expected: uploadProductImageExpectedOutput{
+				product: nil,
+				err:     domain.ErrUnsupportedImage,
+			},
+		},
+		{
+			desc: "Fail_ImageTooLarge",
+			mocks: func(
+				productRepo *mock.MockProductRepository,
+				categoryRepo *mock.MockCategoryRepository,
+				storage *mock.MockFileStorage,
+				cache *mock.MockCacheRepository,
+			) {
+				productRepo.EXPECT().
+					GetProductByID(gomock.Any(), gomock.Eq(productID)).
+					Times(1).
+					Return(existingProduct, nil)
+			},
+			input: uploadProductImageTestedInput{
+				id: productID,
+				image: &domain.File{
+					Name:        "image.png",
+					ContentType: "image/png",
+					Content:     make([]byte, domain.MaxImageSize+1),
+				},
+			},
+			expected: uploadProductImageExpectedOutput{
+				product: nil,
+				err:     domain.ErrImageTooLarge,
+			},
+		},
+		{
+			desc: "Fail_UploadThumbnail",
+			mocks: func(
+				productRepo *mock.MockProductRepository,
+				categoryRepo *mock.MockCategoryRepository,
+				storage *mock.MockFileStorage,
+				cache *mock.MockCacheRepository,
+			) {
+				productRepo.EXPECT().
+					GetProductByID(gomock.Any(), gomock.Eq(productID)).
+					Times(1).
+					Return(existingProduct, nil)
+				storage.EXPECT().
+					Upload(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(imageURL, nil)
+				storage.EXPECT().
+					Upload(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return("", domain.ErrInternal)
+				storage.EXPECT().
+					Delete(gomock.Any(), gomock.Eq(imageURL)).
+					Times(1).
+					Return(nil)
+			},
+			input: uploadProductImageTestedInput{
+				id:    productID,
+				image: productImage,
+			},
+			expected: uploadProductImageExpectedOutput{
+				product: nil,
+				err:     domain.ErrInternal,
+			},
+		},
+		{
+			desc: "Fail_UpdateProduct",
+			mocks: func(
+				productRepo *mock.MockProductRepository,
+				categoryRepo *mock.MockCategoryRepository,
+				storage *mock.MockFileStorage,
+				cache *mock.MockCacheRepository,
+			) {
+				productRepo.EXPECT().
+					GetProductByID(gomock.Any(), gomock.Eq(productID)).
+					Times(1).
+					Return(existingProduct, nil)
+				storage.EXPECT().
+					Upload(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(imageURL, nil)
+				storage.EXPECT().
+					Upload(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(thumbnailURL, nil)
+				productRepo.EXPECT().
+					UpdateProduct(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, domain.ErrInternal)
+				storage.EXPECT().
+					Delete(gomock.Any(), gomock.Eq(imageURL)).
+					Times(1).
+					Return(nil)
+				storage.EXPECT().
+					Delete(gomock.Any(), gomock.Eq(thumbnailURL)).
+					Times(1).
+					Return(nil)
+			},
+			input: uploadProductImageTestedInput{
+				id:    productID,
+				image: productImage,
+			},
+			expected: uploadProductImageExpectedOutput{
+				product: nil,
+				err:     domain.ErrInternal,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			productRepo := mock.NewMockProductRepository(ctrl)
+			categoryRepo := mock.NewMockCategoryRepository(ctrl)
+			paymentRepo := mock.NewMockPaymentRepository(ctrl)
+			storage := mock.NewMockFileStorage(ctrl)
+			cache := mock.NewMockCacheRepository(ctrl)
+
+			tc.mocks(productRepo, categoryRepo, storage, cache)
+
+			imageService := service.NewImageService(productRepo, categoryRepo, paymentRepo, storage, cache, newMockAuditService(ctrl))
+
+			product, err := imageService.UploadProductImage(ctx, tc.input.id, tc.input.image)
+			assert.Equal(t, tc.expected.err, err, "Error mismatch")
+			assert.Equal(t, tc.expected.product, product, "Product mismatch")
+		})
+	}
+}
+
+func TestImageService_UploadPaymentLogo(t *testing.T) {
+	ctx := context.Background()
+	paymentID := gofakeit.Uint64()
+	logoURL := gofakeit.URL()
+	thumbnailURL := gofakeit.URL()
+	logo := &domain.File{
+		Name:        "logo.png",
+		ContentType: "image/png",
+		Content:     newTestImage(64, 64),
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	productRepo := mock.NewMockProductRepository(ctrl)
+	categoryRepo := mock.NewMockCategoryRepository(ctrl)
+	paymentRepo := mock.NewMockPaymentRepository(ctrl)
+	storage := mock.NewMockFileStorage(ctrl)
+	cache := mock.NewMockCacheRepository(ctrl)
+
+	paymentRepo.EXPECT().
+		GetPaymentByID(gomock.Any(), gomock.Eq(paymentID)).
+		Times(1).
+		Return(&domain.Payment{ID: paymentID}, nil)
+	storage.EXPECT().
+		Upload(gomock.Any(), gomock.Any(), gomock.Any()).
+		Times(1).
+		Return(logoURL, nil)
+	storage.EXPECT().
+		Upload(gomock.Any(), gomock.Any(), gomock.Any()).
+		Times(1).
+		DoAndReturn(func(ctx context.Context, key string, file *domain.File) (string, error) {
+			thumbnail, _, err := image.DecodeConfig(bytes.NewReader(file.Content))
+			assert.NoError(t, err, "Thumbnail is not an image")
+			assert.Equal(t, 64, thumbnail.Width, "Small logo was resized")
+			return thumbnailURL, nil
+		})
+	paymentRepo.EXPECT().
+		UpdatePayment(gomock.Any(), gomock.Any()).
+		Times(1).
+		Return(&domain.Payment{ID: paymentID, Logo: logoURL, LogoThumbnail: thumbnailURL}, nil)
+	cache.EXPECT().
+		Delete(gomock.Any(), gomock.Eq(util.GenerateCacheKey("payment", paymentID))).
+		Times(1).
+		Return(nil)
+	cache.EXPECT().
+		DeleteByPrefix(gomock.Any(), gomock.Eq("payments:*")).
+		Times(1).
+		Return(nil)
+
+	imageService := service.NewImageService(productRepo, categoryRepo, paymentRepo, storage, cache, newMockAuditService(ctrl))
+
+	payment, err := imageService.UploadPaymentLogo(ctx, paymentID, logo)
+	assert.NoError(t, err, "Error mismatch")
+	assert.Equal(t, logoURL, payment.Logo, "Logo mismatch")
+	assert.Equal(t, thumbnailURL, payment.LogoThumbnail, "Logo thumbnail mismatch")
+}
@@ -0,0 +1,114 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/nikhil-shrestha/go-pos/internal/core/domain"
+	"github.com/nikhil-shrestha/go-pos/internal/core/port"
+	"github.com/nikhil-shrestha/go-pos/internal/core/util"
+)
+
+// lockoutPolicy describes how the failed attempts of an action are counted and how long they lock it.
+// Every failure past the maximum doubles the lockout, up to the longest lockout
+type lockoutPolicy struct {
+	name        string
+	err         error
+	maxAttempts int64
+	window      time.Duration
+	baseLockout time.Duration
+	maxLockout  time.Duration
+}
+
+var (
+	// loginAccountLockout locks password logins to an account, whatever client they come from
+	loginAccountLockout = lockoutPolicy{"login_account", domain.ErrAccountLocked, 5, time.Hour, 30 * time.Second, 15 * time.Minute}
+	// loginClientLockout locks password logins from a client IP, whatever account they try
+	loginClientLockout = lockoutPolicy{"login_client", domain.ErrAccountLocked, 20, time.Hour, time.Minute, time.Hour}
+	// pinLockout locks the PIN of a user, whether it is used to log in or to approve an override
+	pinLockout = lockoutPolicy{"pin", domain.ErrPinLocked, 5, 15 * time.Minute, 15 * time.Minute, 15 * time.Minute}
+	// twoFactorLockout locks the TOTP and recovery codes of a user, whichever are tried
+	twoFactorLockout = lockoutPolicy{"two_factor", domain.ErrTwoFactorLocked, 5, 15 * time.Minute, 15 * time.Minute, 15 * time.Minute}
+)
+
+// check returns a domain.LockoutError if the action is locked for the subject
+func (lp lockoutPolicy) check(ctx context.Context, cache port.CacheRepository, subject any) error {
+	var lockedUntil time.Time
+
+	cacheKey := util.GenerateCacheKey(lp.name+"_lock", subject)
+
+	lockedUntilSerialized, err := cache.Get(ctx, cacheKey)
+	if err != nil {
+		if err == domain.ErrDataNotFound {
+			return nil
+		}
+		return domain.ErrInternal
+	}
+
+	err = util.Deserialize(lockedUntilSerialized, &lockedUntil)
+	if err != nil {
+		return domain.ErrInternal
+	}
+
+	retryAfter := time.Until(lockedUntil)
+	if retryAfter <= 0 {
+		return nil
+	}
+
+	return &domain.LockoutError{
+		Err:        lp.err,
+		RetryAfter: retryAfter,
+	}
+}
+
+// fail counts a failed attempt of the subject and returns a domain.LockoutError if it locks the action
+func (lp lockoutPolicy) fail(ctx context.Context, cache port.CacheRepository, subject any) error {
+	attemptsKey := util.GenerateCacheKey(lp.name+"_attempts", subject)
+
+	count, err := cache.Increment(ctx, attemptsKey, lp.window)
+	if err != nil {
+		return domain.ErrInternal
+	}
+
+	if count < lp.maxAttempts {
+		return nil
+	}
+
+	lockout := lp.baseLockout
+	for i := lp.maxAttempts; i < count && lockout < lp.maxLockout; i++ {
+		lockout *= 2
+	}
+	lockout = min(lockout, lp.maxLockout)
+
+	lockedUntilSerialized, err := util.Serialize(time.Now().Add(lockout))
+	if err != nil {
+		return domain.ErrInternal
+	}
+
+	lockKey := util.GenerateCacheKey(lp.name+"_lock", subject)
+
+	err = cache.Set(ctx, lockKey, lockedUntilSerialized, lockout)
+	if err != nil {
+		return domain.ErrInternal
+	}
+
+	return &domain.LockoutError{
+		Err:        lp.err,
+		RetryAfter: lockout,
+	}
+}
+
+// reset forgets the failed attempts of the subject and lifts its lockout
+func (lp lockoutPolicy) reset(ctx context.Context, cache port.CacheRepository, subject any) error {
+	err := cache.Delete(ctx, util.GenerateCacheKey(lp.name+"_attempts", subject))
+	if err != nil {
+		return domain.ErrInternal
+	}
+
+	err = cache.Delete(ctx, util.GenerateCacheKey(lp.name+"_lock", subject))
+	if err != nil {
+		return domain.ErrInternal
+	}
+
+	return nil
+}
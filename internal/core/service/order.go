@@ -2,6 +2,8 @@ package service
 
 import (
 	"context"
+	"errors"
+	"log/slog"
 
 	"github.com/nikhil-shrestha/go-pos/internal/core/domain"
 	"github.com/nikhil-shrestha/go-pos/internal/core/port"
@@ -11,8 +13,8 @@ import (
 /**
  * OrderService implements port.OrderService, port.ProductService,
  * port.UserService and port.PaymentService interfaces and provides
- * an access to the order, product, user and payment repositories
- * and cache service
+ * an access to the order, product, user and payment repositories,
+ * audit service and cache service
  */
 type OrderService struct {
 	orderRepo    port.OrderRepository
@@ -20,110 +22,136 @@ type OrderService struct {
 	categoryRepo port.CategoryRepository
 	userRepo     port.UserRepository
 	paymentRepo  port.PaymentRepository
+	audit        port.AuditService
 	cache        port.CacheRepository
 }
 
 // NewOrderService creates a new order service instance
-func NewOrderService(orderRepo port.OrderRepository, productRepo port.ProductRepository, categoryRepo port.CategoryRepository, userRepo port.UserRepository, paymentRepo port.PaymentRepository, cache port.CacheRepository) *OrderService {
+func NewOrderService(orderRepo port.OrderRepository, productRepo port.ProductRepository, categoryRepo port.CategoryRepository, userRepo port.UserRepository, paymentRepo port.PaymentRepository, audit port.AuditService, cache port.CacheRepository) *OrderService {
 	return &OrderService{
 		orderRepo,
 		productRepo,
 		categoryRepo,
 		userRepo,
 		paymentRepo,
+		audit,
 		cache,
 	}
 }
 
-// CreateOrder creates a new order
+// CreateOrder creates a new order. Once the order is committed it is returned even if the cache
+// cannot be updated, so that a client never retries an order that was taken
 func (os *OrderService) CreateOrder(ctx context.Context, order *domain.Order) (*domain.Order, error) {
 	var totalPrice float64
-	for i, orderProduct := range order.Products {
-		product, err := os.productRepo.GetProductByID(ctx, orderProduct.ProductID)
-		if err != nil {
-			if err == domain.ErrDataNotFound {
-				return nil, err
-			}
-			return nil, domain.ErrInternal
-		}
 
-		if product.Stock < orderProduct.Quantity {
-			return nil, domain.ErrInsufficientStock
+	for _, orderProduct := range order.Products {
+		if orderProduct.Quantity <= 0 {
+			return nil, domain.ErrInvalidQuantity
 		}
-
-		order.Products[i].TotalPrice = product.Price * float64(orderProduct.Quantity)
-		totalPrice += order.Products[i].TotalPrice
 	}
 
-	if order.TotalPaid < totalPrice {
-		return nil, domain.ErrInsufficientPayment
+	_, err := os.paymentRepo.GetPaymentByID(ctx, order.PaymentID)
+	if err != nil {
+		if err == domain.ErrDataNotFound {
+			return nil, err
+		}
+		return nil, domain.ErrInternal
 	}
 
-	order.TotalPrice = totalPrice
-	order.TotalReturn = order.TotalPaid - order.TotalPrice
+	productIDs := make([]uint64, len(order.Products))
+	for i, orderProduct := range order.Products {
+		productIDs[i] = orderProduct.ProductID
+	}
 
-	order, err := os.orderRepo.CreateOrder(ctx, order)
+	products, err := os.productRepo.GetProductsByIDs(ctx, uniqueIDs(productIDs))
 	if err != nil {
 		return nil, domain.ErrInternal
 	}
 
-	user, err := os.userRepo.GetUserByID(ctx, order.UserID)
+	err = loadCategories(ctx, os.categoryRepo, products)
 	if err != nil {
-		if err == domain.ErrDataNotFound {
-			return nil, err
-		}
-		return nil, domain.ErrInternal
+		return nil, err
 	}
 
-	payment, err := os.paymentRepo.GetPaymentByID(ctx, order.PaymentID)
-	if err != nil {
-		if err == domain.ErrDataNotFound {
-			return nil, err
+	productsByID := mapByID(products, func(product *domain.Product) uint64 { return product.ID })
+
+	for i, orderProduct := range order.Products {
+		product, ok := productsByID[orderProduct.ProductID]
+		if !ok || product.DeletedAt != nil {
+			return nil, domain.ErrDataNotFound
 		}
-		return nil, domain.ErrInternal
+
+		order.Products[i].ProductName = product.Name
+		order.Products[i].ProductSKU = product.SKU
+		order.Products[i].ProductPrice = product.Price
+		order.Products[i].CategoryName = product.Category.Name
+		order.Products[i].TotalPrice = product.Price * float64(orderProduct.Quantity)
+		totalPrice += order.Products[i].TotalPrice
 	}
 
-	order.User = user
-	order.Payment = payment
+	if order.TotalPaid < totalPrice {
+		return nil, domain.ErrInsufficientPayment
+	}
 
-	for i, orderProduct := range order.Products {
-		product, err := os.productRepo.GetProductByID(ctx, orderProduct.ProductID)
+	order.TotalPrice = totalPrice
+	order.TotalReturn = order.TotalPaid - order.TotalPrice
+
+	err = os.audit.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+
+		order, err = os.orderRepo.CreateOrder(ctx, order)
 		if err != nil {
-			if err == domain.ErrDataNotFound {
-				return nil, err
+			if errors.Is(err, domain.ErrInsufficientStock) || err == domain.ErrDataNotFound || err == domain.ErrInvalidQuantity {
+				return err
 			}
-			return nil, domain.ErrInternal
+			return domain.ErrInternal
 		}
 
-		category, err := os.categoryRepo.GetCategoryByID(ctx, product.CategoryID)
+		orders := []domain.Order{*order}
+		err = os.loadOrderDetails(ctx, orders)
 		if err != nil {
-			if err == domain.ErrDataNotFound {
-				return nil, err
-			}
-			return nil, domain.ErrInternal
+			return err
 		}
+		order = &orders[0]
 
-		order.Products[i].Product = product
-		order.Products[i].Product.Category = category
+		return os.audit.RecordAuditLog(ctx, &domain.AuditLog{Action: "order.create", Entity: "order", EntityID: order.ID}, nil, order)
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	err = os.cache.DeleteByPrefix(ctx, "orders:*")
+	os.refreshOrderCache(ctx, order, products)
+
+	return order, nil
+}
+
+// refreshOrderCache caches a new order and drops the cached stock of its products. The order is committed by then,
+// so a failure is only logged: an error would make the client retry, and the retry would create the order again
+func (os *OrderService) refreshOrderCache(ctx context.Context, order *domain.Order, products []domain.Product) {
+	err := os.cache.DeleteByPrefix(ctx, "orders:*")
 	if err != nil {
-		return nil, domain.ErrInternal
+		slog.ErrorContext(ctx, "Error deleting the cached orders", "order", order.ID, "error", err)
 	}
 
-	cacheKey := util.GenerateCacheKey("order", order.ID)
-	orderSerialized, err := util.Serialize(order)
-	if err != nil {
-		return nil, domain.ErrInternal
+	for _, product := range products {
+		err = os.cache.Delete(ctx, util.GenerateCacheKey("product", product.ID))
+		if err != nil {
+			slog.ErrorContext(ctx, "Error deleting the cached product", "product", product.ID, "error", err)
+		}
 	}
 
-	err = os.cache.Set(ctx, cacheKey, orderSerialized, 0)
+	err = os.cache.DeleteByPrefix(ctx, "products:*")
 	if err != nil {
-		return nil, domain.ErrInternal
+		slog.ErrorContext(ctx, "Error deleting the cached products", "order", order.ID, "error", err)
 	}
 
-	return order, nil
+	orderSerialized, err := util.Serialize(order)
+	if err == nil {
+		err = os.cache.Set(ctx, util.GenerateCacheKey("order", order.ID), orderSerialized, 0)
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "Error caching the order", "order", order.ID, "error", err)
+	}
 }
 
 // GetOrder gets an order by ID
@@ -148,133 +176,263 @@ func (os *OrderService) GetOrder(ctx context.Context, id uint64) (*domain.Order,
 		return nil, domain.ErrInternal
 	}
 
-	user, err := os.userRepo.GetUserByID(ctx, order.UserID)
+	orders := []domain.Order{*order}
+	err = os.loadOrderDetails(ctx, orders)
+	if err != nil {
+		return nil, err
+	}
+	order = &orders[0]
+
+	orderSerialized, err := util.Serialize(order)
 	if err != nil {
-		if err == domain.ErrDataNotFound {
-			return nil, err
-		}
 		return nil, domain.ErrInternal
 	}
 
-	payment, err := os.paymentRepo.GetPaymentByID(ctx, order.PaymentID)
+	err = os.cache.Set(ctx, cacheKey, orderSerialized, 0)
 	if err != nil {
-		if err == domain.ErrDataNotFound {
-			return nil, err
-		}
 		return nil, domain.ErrInternal
 	}
 
-	order.User = user
-	order.Payment = payment
+	return order, nil
+}
 
-	for i, orderProduct := range order.Products {
-		product, err := os.productRepo.GetProductByID(ctx, orderProduct.ProductID)
-		if err != nil {
-			if err == domain.ErrDataNotFound {
-				return nil, err
-			}
-			return nil, domain.ErrInternal
-		}
+// ListOrders lists the orders matching the filter
+func (os *OrderService) ListOrders(ctx context.Context, filter *domain.OrderFilter, params *domain.ListParams) ([]domain.Order, uint64, error) {
+	var page domain.Page[domain.Order]
 
-		category, err := os.categoryRepo.GetCategoryByID(ctx, product.CategoryID)
+	err := validateOrderFilter(filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	cacheParams := util.GenerateCacheKeyParams(params.Skip, params.Limit, params.Sort, params.Order, filter)
+	cacheKey := util.GenerateCacheKey("orders", cacheParams)
+
+	cachedOrders, err := os.cache.Get(ctx, cacheKey)
+	if err == nil {
+		err := util.Deserialize(cachedOrders, &page)
 		if err != nil {
-			if err == domain.ErrDataNotFound {
-				return nil, err
-			}
-			return nil, domain.ErrInternal
+			return nil, 0, domain.ErrInternal
 		}
+		return page.Items, page.Total, nil
+	}
 
-		order.Products[i].Product = product
-		order.Products[i].Product.Category = category
+	orders, total, err := os.orderRepo.ListOrders(ctx, filter, params)
+	if err != nil {
+		return nil, 0, domain.ErrInternal
 	}
 
-	orderSerialized, err := util.Serialize(order)
+	err = os.loadOrderDetails(ctx, orders)
 	if err != nil {
-		return nil, domain.ErrInternal
+		return nil, 0, err
 	}
 
-	err = os.cache.Set(ctx, cacheKey, orderSerialized, 0)
+	ordersSerialized, err := util.Serialize(domain.Page[domain.Order]{Items: orders, Total: total})
 	if err != nil {
-		return nil, domain.ErrInternal
+		return nil, 0, domain.ErrInternal
 	}
 
-	return order, nil
+	err = os.cache.Set(ctx, cacheKey, ordersSerialized, 0)
+	if err != nil {
+		return nil, 0, domain.ErrInternal
+	}
+
+	return orders, total, nil
 }
 
-// ListOrders lists all orders
-func (os *OrderService) ListOrders(ctx context.Context, skip, limit uint64) ([]domain.Order, error) {
-	var orders []domain.Order
+// ListOrdersByCursor lists the orders matching the filter following the cursor
+func (os *OrderService) ListOrdersByCursor(ctx context.Context, filter *domain.OrderFilter, params *domain.CursorParams) ([]domain.Order, *domain.Cursor, error) {
+	var page domain.CursorPage[domain.Order]
+
+	err := validateOrderFilter(filter)
+	if err != nil {
+		return nil, nil, err
+	}
 
-	params := util.GenerateCacheKeyParams(skip, limit)
-	cacheKey := util.GenerateCacheKey("orders", params)
+	cacheParams := util.GenerateCacheKeyParams("cursor", params.Cursor, params.Limit, params.Order, filter)
+	cacheKey := util.GenerateCacheKey("orders", cacheParams)
 
 	cachedOrders, err := os.cache.Get(ctx, cacheKey)
 	if err == nil {
-		err := util.Deserialize(cachedOrders, &orders)
+		err := util.Deserialize(cachedOrders, &page)
 		if err != nil {
-			return nil, domain.ErrInternal
+			return nil, nil, domain.ErrInternal
 		}
-		return orders, nil
+		return page.Items, page.Next, nil
 	}
 
-	orders, err = os.orderRepo.ListOrders(ctx, skip, limit)
+	orders, next, err := os.orderRepo.ListOrdersByCursor(ctx, filter, params)
 	if err != nil {
-		return nil, domain.ErrInternal
+		return nil, nil, domain.ErrInternal
+	}
+
+	err = os.loadOrderDetails(ctx, orders)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ordersSerialized, err := util.Serialize(domain.CursorPage[domain.Order]{Items: orders, Next: next})
+	if err != nil {
+		return nil, nil, domain.ErrInternal
+	}
+
+	err = os.cache.Set(ctx, cacheKey, ordersSerialized, 0)
+	if err != nil {
+		return nil, nil, domain.ErrInternal
 	}
 
+	return orders, next, nil
+}
+
+// validateOrderFilter checks the date and total ranges of an order filter
+func validateOrderFilter(filter *domain.OrderFilter) error {
+	if filter.StartDate != nil && filter.EndDate != nil && !filter.StartDate.Before(*filter.EndDate) {
+		return domain.ErrInvalidDateRange
+	}
+
+	if filter.MaxTotal != 0 && filter.MinTotal > filter.MaxTotal {
+		return domain.ErrInvalidTotalRange
+	}
+
+	return nil
+}
+
+// loadOrderDetails sets the user and the payment of the orders, selecting each of them in a single query
+// however many orders there are. The products are not loaded as the order products keep a snapshot of them
+func (os *OrderService) loadOrderDetails(ctx context.Context, orders []domain.Order) error {
+	if len(orders) == 0 {
+		return nil
+	}
+
+	userIDs := make([]uint64, len(orders))
+	paymentIDs := make([]uint64, len(orders))
 	for i, order := range orders {
-		user, err := os.userRepo.GetUserByID(ctx, order.UserID)
-		if err != nil {
-			if err == domain.ErrDataNotFound {
-				return nil, err
-			}
-			return nil, domain.ErrInternal
+		userIDs[i] = order.UserID
+		paymentIDs[i] = order.PaymentID
+	}
+
+	users, err := os.userRepo.GetUsersByIDs(ctx, uniqueIDs(userIDs))
+	if err != nil {
+		return domain.ErrInternal
+	}
+
+	payments, err := os.paymentRepo.GetPaymentsByIDs(ctx, uniqueIDs(paymentIDs))
+	if err != nil {
+		return domain.ErrInternal
+	}
+
+	usersByID := mapByID(users, func(user *domain.User) uint64 { return user.ID })
+	paymentsByID := mapByID(payments, func(payment *domain.Payment) uint64 { return payment.ID })
+
+	for i, order := range orders {
+		user, ok := usersByID[order.UserID]
+		if !ok {
+			return domain.ErrDataNotFound
 		}
 
-		payment, err := os.paymentRepo.GetPaymentByID(ctx, order.PaymentID)
-		if err != nil {
-			if err == domain.ErrDataNotFound {
-				return nil, err
-			}
-			return nil, domain.ErrInternal
+		payment, ok := paymentsByID[order.PaymentID]
+		if !ok {
+			return domain.ErrDataNotFound
 		}
 
 		orders[i].User = user
 		orders[i].Payment = payment
 	}
 
-	for i, order := range orders {
-		for j, orderProduct := range order.Products {
-			product, err := os.productRepo.GetProductByID(ctx, orderProduct.ProductID)
-			if err != nil {
-				if err == domain.ErrDataNotFound {
-					return nil, err
-				}
-				return nil, domain.ErrInternal
-			}
+	return nil
+}
+
+// ExportOrders streams the orders matching the filter with their order products
+func (os *OrderService) ExportOrders(ctx context.Context, filter *domain.OrderFilter, fn func(order *domain.Order) error) error {
+	err := validateOrderFilter(filter)
+	if err != nil {
+		return err
+	}
+
+	err = os.orderRepo.StreamOrders(ctx, filter, fn)
+	if err != nil {
+		return domain.ErrInternal
+	}
+
+	return nil
+}
+
+// VoidOrder voids a completed order, restocks its products and records who voided it and who approved it
+func (os *OrderService) VoidOrder(ctx context.Context, id, userID, approverID uint64) (*domain.Order, error) {
+	existingOrder, err := os.orderRepo.GetOrderByID(ctx, id)
+	if err != nil {
+		if err == domain.ErrDataNotFound {
+			return nil, err
+		}
+		return nil, domain.ErrInternal
+	}
+
+	if existingOrder.Status == domain.OrderVoided {
+		return nil, domain.ErrOrderVoided
+	}
 
-			category, err := os.categoryRepo.GetCategoryByID(ctx, product.CategoryID)
-			if err != nil {
-				if err == domain.ErrDataNotFound {
-					return nil, err
-				}
-				return nil, domain.ErrInternal
+	var order *domain.Order
+
+	err = os.audit.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+
+		order, err = os.orderRepo.VoidOrder(ctx, id)
+		if err != nil {
+			if err == domain.ErrOrderVoided {
+				return err
 			}
+			return domain.ErrInternal
+		}
+
+		order.Products = existingOrder.Products
 
-			orders[i].Products[j].Product = product
-			orders[i].Products[j].Product.Category = category
+		auditLog := &domain.AuditLog{
+			UserID:     userID,
+			ApproverID: approverID,
+			Action:     string(domain.VoidOrderAction),
+			Entity:     "order",
+			EntityID:   order.ID,
 		}
+
+		return os.audit.RecordAuditLog(ctx, auditLog, existingOrder, order)
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	ordersSerialized, err := util.Serialize(orders)
+	for _, orderProduct := range order.Products {
+		err = os.cache.Delete(ctx, util.GenerateCacheKey("product", orderProduct.ProductID))
+		if err != nil {
+			return nil, domain.ErrInternal
+		}
+	}
+
+	err = os.cache.Delete(ctx, util.GenerateCacheKey("order", order.ID))
 	if err != nil {
 		return nil, domain.ErrInternal
 	}
 
-	err = os.cache.Set(ctx, cacheKey, ordersSerialized, 0)
+	err = os.cache.DeleteByPrefix(ctx, "orders:*")
 	if err != nil {
 		return nil, domain.ErrInternal
 	}
 
-	return orders, nil
+	err = os.cache.DeleteByPrefix(ctx, "products:*")
+	if err != nil {
+		return nil, domain.ErrInternal
+	}
+
+	return os.GetOrder(ctx, order.ID)
+}
+
+// OpenDrawer records who opened the cash drawer without a sale and who approved it
+func (os *OrderService) OpenDrawer(ctx context.Context, userID, approverID uint64) error {
+	auditLog := &domain.AuditLog{
+		UserID:     userID,
+		ApproverID: approverID,
+		Action:     string(domain.OpenDrawerAction),
+		Entity:     "drawer",
+	}
+
+	return os.audit.RecordAuditLog(ctx, auditLog, nil, nil)
 }
@@ -0,0 +1,732 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/nikhil-shrestha/go-pos/internal/core/domain"
+	"github.com/nikhil-shrestha/go-pos/internal/core/port/mock"
+	"github.com/nikhil-shrestha/go-pos/internal/core/service"
+	"github.com/nikhil-shrestha/go-pos/internal/core/util"
+	"github.com/brianvoe/gofakeit/v6"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+type listOrdersTestedInput struct {
+	filter *domain.OrderFilter
+	params *domain.ListParams
+}
+
+type listOrdersExpectedOutput struct {
+	orders []domain.Order
+	total  uint64
+	err    error
+}
+
+func TestOrderService_ListOrders(t *testing.T) {
+	var orders []domain.Order
+
+	for i := 0; i < 5; i++ {
+		orders = append(orders, domain.Order{
+			ID:           gofakeit.Uint64(),
+			UserID:       gofakeit.Uint64(),
+			PaymentID:    gofakeit.Uint64(),
+			CustomerName: gofakeit.Name(),
+			TotalPrice:   gofakeit.Float64(),
+			Status:       domain.OrderCompleted,
+			User:         &domain.User{Name: gofakeit.Name()},
+			Payment:      &domain.Payment{Name: gofakeit.CreditCardType()},
+		})
+	}
+
+	user := domain.User{
+		ID:   gofakeit.Uint64(),
+		Name: gofakeit.Name(),
+	}
+	payment := domain.Payment{
+		ID:   gofakeit.Uint64(),
+		Name: gofakeit.CreditCardType(),
+	}
+
+	var dbOrders, loadedOrders []domain.Order
+
+	for i := 0; i < 5; i++ {
+		order := domain.Order{
+			ID:        gofakeit.Uint64(),
+			UserID:    user.ID,
+			PaymentID: payment.ID,
+			Products: []domain.OrderProduct{
+				{
+					ProductID:    gofakeit.Uint64(),
+					ProductName:  gofakeit.ProductName(),
+					ProductPrice: gofakeit.Float64(),
+					CategoryName: gofakeit.ProductCategory(),
+					Quantity:     gofakeit.Int64(),
+				},
+			},
+		}
+		dbOrders = append(dbOrders, order)
+
+		order.User = &user
+		order.Payment = &payment
+		loadedOrders = append(loadedOrders, order)
+	}
+
+	ctx := context.Background()
+	total := gofakeit.Uint64()
+	startDate := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	endDate := startDate.AddDate(0, 1, 0)
+	listParams := &domain.ListParams{
+		Skip:  1,
+		Limit: 5,
+	}
+	filter := &domain.OrderFilter{
+		StartDate:    &startDate,
+		EndDate:      &endDate,
+		PaymentID:    gofakeit.Uint64(),
+		CustomerName: gofakeit.FirstName(),
+		MinTotal:     10,
+		MaxTotal:     100,
+		Status:       domain.OrderCompleted,
+	}
+
+	params := util.GenerateCacheKeyParams(listParams.Skip, listParams.Limit, listParams.Sort, listParams.Order, filter)
+	cacheKey := util.GenerateCacheKey("orders", params)
+	ordersSerialized, _ := util.Serialize(domain.Page[domain.Order]{Items: orders, Total: total})
+
+	testCases := []struct {
+		desc  string
+		mocks func(
+			orderRepo *mock.MockOrderRepository,
+			productRepo *mock.MockProductRepository,
+			categoryRepo *mock.MockCategoryRepository,
+			userRepo *mock.MockUserRepository,
+			paymentRepo *mock.MockPaymentRepository,
+			cache *mock.MockCacheRepository,
+		)
+		input    listOrdersTestedInput
+		expected listOrdersExpectedOutput
+	}{
+		{
+			desc: "Success_FromCache",
+			mocks: func(
+				orderRepo *mock.MockOrderRepository,
+				productRepo *mock.MockProductRepository,
+				categoryRepo *mock.MockCategoryRepository,
+				userRepo *mock.MockUserRepository,
+				paymentRepo *mock.MockPaymentRepository,
+				cache *mock.MockCacheRepository,
+			) {
+				cache.EXPECT().
+					Get(gomock.Any(), gomock.Eq(cacheKey)).
+					Return(ordersSerialized, nil)
+			},
+			input: listOrdersTestedInput{
+				filter: filter,
+				params: listParams,
+			},
+			expected: listOrdersExpectedOutput{
+				orders: orders,
+				total:  total,
+				err:    nil,
+			},
+		},
+		{
+			desc: "Success_FromDB",
+			mocks: func(
+				orderRepo *mock.MockOrderRepository,
+				productRepo *mock.MockProductRepository,
+				categoryRepo *mock.MockCategoryRepository,
+				userRepo *mock.MockUserRepository,
+				paymentRepo *mock.MockPaymentRepository,
+				cache *mock.MockCacheRepository,
+			) {
+				cache.EXPECT().
+					Get(gomock.Any(), gomock.Eq(cacheKey)).
+					Return(nil, domain.ErrDataNotFound)
+				orderRepo.EXPECT().
+					ListOrders(gomock.Any(), gomock.Eq(filter), gomock.Eq(listParams)).
+					Return(dbOrders, total, nil)
+				userRepo.EXPECT().
+					GetUsersByIDs(gomock.Any(), gomock.Eq([]uint64{user.ID})).
+					Times(1).
+					Return([]domain.User{user}, nil)
+				paymentRepo.EXPECT().
+					GetPaymentsByIDs(gomock.Any(), gomock.Eq([]uint64{payment.ID})).
+					Times(1).
+					Return([]domain.Payment{payment}, nil)
+				cache.EXPECT().
+					Set(gomock.Any(), gomock.Eq(cacheKey), gomock.Any(), gomock.Any()).
+					Return(nil)
+			},
+			input: listOrdersTestedInput{
+				filter: filter,
+				params: listParams,
+			},
+			expected: listOrdersExpectedOutput{
+				orders: loadedOrders,
+				total:  total,
+				err:    nil,
+			},
+		},
+		{
+			desc: "Fail_UserNotFound",
+			mocks: func(
+				orderRepo *mock.MockOrderRepository,
+				productRepo *mock.MockProductRepository,
+				categoryRepo *mock.MockCategoryRepository,
+				userRepo *mock.MockUserRepository,
+				paymentRepo *mock.MockPaymentRepository,
+				cache *mock.MockCacheRepository,
+			) {
+				cache.EXPECT().
+					Get(gomock.Any(), gomock.Eq(cacheKey)).
+					Return(nil, domain.ErrDataNotFound)
+				orderRepo.EXPECT().
+					ListOrders(gomock.Any(), gomock.Eq(filter), gomock.Eq(listParams)).
+					Return(dbOrders, total, nil)
+				userRepo.EXPECT().
+					GetUsersByIDs(gomock.Any(), gomock.Eq([]uint64{user.ID})).
+					Return([]domain.User{}, nil)
+				paymentRepo.EXPECT().
+					GetPaymentsByIDs(gomock.Any(), gomock.Eq([]uint64{payment.ID})).
+					Return([]domain.Payment{payment}, nil)
+			},
+			input: listOrdersTestedInput{
+				filter: filter,
+				params: listParams,
+			},
+			expected: listOrdersExpectedOutput{
+				orders: nil,
+				err:    domain.ErrDataNotFound,
+			},
+		},
+		{
+			desc: "Fail_InternalError",
+			mocks: func(
+				orderRepo *mock.MockOrderRepository,
+				productRepo *mock.MockProductRepository,
+				categoryRepo *mock.MockCategoryRepository,
+				userRepo *mock.MockUserRepository,
+				paymentRepo *mock.MockPaymentRepository,
+				cache *mock.MockCacheRepository,
+			) {
+				cache.EXPECT().
+					Get(gomock.Any(), gomock.Eq(cacheKey)).
+					Return(nil, domain.ErrDataNotFound)
+				orderRepo.EXPECT().
+					ListOrders(gomock.Any(), gomock.Eq(filter), gomock.Eq(listParams)).
+					Return(nil, uint64(0), domain.ErrInternal)
+			},
+			input: listOrdersTestedInput{
+				filter: filter,
+				params: listParams,
+			},
+			expected: listOrdersExpectedOutput{
+				orders: nil,
+				err:    domain.ErrInternal,
+			},
+		},
+		{
+			desc: "Fail_InvalidDateRange",
+			mocks: func(
+				orderRepo *mock.MockOrderRepository,
+				productRepo *mock.MockProductRepository,
+				categoryRepo *mock.MockCategoryRepository,
+				userRepo *mock.MockUserRepository,
+				paymentRepo *mock.MockPaymentRepository,
+				cache *mock.MockCacheRepository,
+			) {
+			},
+			input: listOrdersTestedInput{
+				filter: &domain.OrderFilter{
+					StartDate: &endDate,
+					EndDate:   &startDate,
+				},
+				params: listParams,
+			},
+			expected: listOrdersExpectedOutput{
+				orders: nil,
+				err:    domain.ErrInvalidDateRange,
+			},
+		},
+		{
+			desc: "Fail_InvalidTotalRange",
+			mocks: func(
+				orderRepo *mock.MockOrderRepository,
+				productRepo *mock.MockProductRepository,
+				categoryRepo *mock.MockCategoryRepository,
+				userRepo *mock.MockUserRepository,
+				paymentRepo *mock.MockPaymentRepository,
+				cache *mock.MockCacheRepository,
+			) {
+			},
+			input: listOrdersTestedInput{
+				filter: &domain.OrderFilter{
+					MinTotal: 100,
+					MaxTotal: 10,
+				},
+				params: listParams,
+			},
+			expected: listOrdersExpectedOutput{
+				orders: nil,
+				err:    domain.ErrInvalidTotalRange,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			orderRepo := mock.NewMockOrderRepository(ctrl)
+			productRepo := mock.NewMockProductRepository(ctrl)
+			categoryRepo := mock.NewMockCategoryRepository(ctrl)
+			userRepo := mock.NewMockUserRepository(ctrl)
+			paymentRepo := mock.NewMockPaymentRepository(ctrl)
+			cache := mock.NewMockCacheRepository(ctrl)
+
+			tc.mocks(orderRepo, productRepo, categoryRepo, userRepo, paymentRepo, cache)
+
+			orderService := service.NewOrderService(orderRepo, productRepo, categoryRepo, userRepo, paymentRepo, newMockAuditService(ctrl), cache)
+
+			orders, total, err := orderService.ListOrders(ctx, tc.input.filter, tc.input.params)
+			assert.Equal(t, tc.expected.err, err, "Error mismatch")
+			assert.Equal(t, tc.expected.orders, orders, "Orders mismatch")
+			assert.Equal(t, tc.expected.total, total, "Total mismatch")
+		})
+	}
+}
+
+type createOrderTestedInput struct {
+	order *domain.Order
+}
+
+type createOrderExpectedOutput struct {
+	order *domain.Order
+	err   error
+}
+
+func TestOrderService_CreateOrder(t *testing.T) {
+	ctx := context.Background()
+	payment := &domain.Payment{
+		ID:   gofakeit.Uint64(),
+		Name: gofakeit.CreditCardType(),
+	}
+	category := domain.Category{
+		ID:   gofakeit.Uint64(),
+		Name: gofakeit.ProductCategory(),
+	}
+	product := domain.Product{
+		ID:         gofakeit.Uint64(),
+		CategoryID: category.ID,
+		Name:       gofakeit.ProductName(),
+		Price:      10,
+		Stock:      5,
+	}
+	newOrder := func(quantity int64, totalPaid float64) *domain.Order {
+		return &domain.Order{
+			UserID:    gofakeit.Uint64(),
+			PaymentID: payment.ID,
+			TotalPaid: totalPaid,
+			Products: []domain.OrderProduct{
+				{
+					ProductID: product.ID,
+					Quantity:  quantity,
+				},
+			},
+		}
+	}
+	stockErr := &domain.InsufficientStockError{ProductIDs: []uint64{product.ID}}
+	user := domain.User{
+		ID:   gofakeit.Uint64(),
+		Name: gofakeit.Name(),
+	}
+	createdOrder := &domain.Order{
+		ID:          gofakeit.Uint64(),
+		UserID:      user.ID,
+		PaymentID:   payment.ID,
+		TotalPrice:  10,
+		TotalPaid:   10,
+		TotalReturn: 0,
+	}
+	orderOutput := *createdOrder
+	orderOutput.User = &user
+	orderOutput.Payment = payment
+
+	testCases := []struct {
+		desc  string
+		mocks func(
+			orderRepo *mock.MockOrderRepository,
+			productRepo *mock.MockProductRepository,
+			categoryRepo *mock.MockCategoryRepository,
+			userRepo *mock.MockUserRepository,
+			paymentRepo *mock.MockPaymentRepository,
+			cache *mock.MockCacheRepository,
+		)
+		input    createOrderTestedInput
+		expected createOrderExpectedOutput
+	}{
+		{
+			desc: "Success_CacheError",
+			mocks: func(
+				orderRepo *mock.MockOrderRepository,
+				productRepo *mock.MockProductRepository,
+				categoryRepo *mock.MockCategoryRepository,
+				userRepo *mock.MockUserRepository,
+				paymentRepo *mock.MockPaymentRepository,
+				cache *mock.MockCacheRepository,
+			) {
+				paymentRepo.EXPECT().
+					GetPaymentByID(gomock.Any(), gomock.Eq(payment.ID)).
+					Times(1).
+					Return(payment, nil)
+				productRepo.EXPECT().
+					GetProductsByIDs(gomock.Any(), gomock.Eq([]uint64{product.ID})).
+					Times(1).
+					Return([]domain.Product{product}, nil)
+				categoryRepo.EXPECT().
+					GetCategoriesByIDs(gomock.Any(), gomock.Eq([]uint64{category.ID})).
+					Times(1).
+					Return([]domain.Category{category}, nil)
+				orderRepo.EXPECT().
+					CreateOrder(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(createdOrder, nil)
+				userRepo.EXPECT().
+					GetUsersByIDs(gomock.Any(), gomock.Eq([]uint64{user.ID})).
+					Times(1).
+					Return([]domain.User{user}, nil)
+				paymentRepo.EXPECT().
+					GetPaymentsByIDs(gomock.Any(), gomock.Eq([]uint64{payment.ID})).
+					Times(1).
+					Return([]domain.Payment{*payment}, nil)
+				cache.EXPECT().
+					DeleteByPrefix(gomock.Any(), gomock.Any()).
+					Times(2).
+					Return(domain.ErrInternal)
+				cache.EXPECT().
+					Delete(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(domain.ErrInternal)
+				cache.EXPECT().
+					Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(domain.ErrInternal)
+			},
+			input: createOrderTestedInput{
+				order: newOrder(1, 10),
+			},
+			expected: createOrderExpectedOutput{
+				order: &orderOutput,
+				err:   nil,
+			},
+		},
+		{
+			desc: "Fail_InsufficientStock",
+			mocks: func(
+				orderRepo *mock.MockOrderRepository,
+				productRepo *mock.MockProductRepository,
+				categoryRepo *mock.MockCategoryRepository,
+				userRepo *mock.MockUserRepository,
+				paymentRepo *mock.MockPaymentRepository,
+				cache *mock.MockCacheRepository,
+			) {
+				paymentRepo.EXPECT().
+					GetPaymentByID(gomock.Any(), gomock.Eq(payment.ID)).
+					Times(1).
+					Return(payment, nil)
+				productRepo.EXPECT().
+					GetProductsByIDs(gomock.Any(), gomock.Eq([]uint64{product.ID})).
+					Times(1).
+					Return([]domain.Product{product}, nil)
+				categoryRepo.EXPECT().
+					GetCategoriesByIDs(gomock.Any(), gomock.Eq([]uint64{category.ID})).
+					Times(1).
+					Return([]domain.Category{category}, nil)
+				orderRepo.EXPECT().
+					CreateOrder(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, stockErr)
+			},
+			input: createOrderTestedInput{
+				order: newOrder(6, 60),
+			},
+			expected: createOrderExpectedOutput{
+				err: stockErr,
+			},
+		},
+		{
+			desc: "Fail_ProductDeletedMeanwhile",
+			mocks: func(
+				orderRepo *mock.MockOrderRepository,
+				productRepo *mock.MockProductRepository,
+				categoryRepo *mock.MockCategoryRepository,
+				userRepo *mock.MockUserRepository,
+				paymentRepo *mock.MockPaymentRepository,
+				cache *mock.MockCacheRepository,
+			) {
+				paymentRepo.EXPECT().
+					GetPaymentByID(gomock.Any(), gomock.Eq(payment.ID)).
+					Times(1).
+					Return(payment, nil)
+				productRepo.EXPECT().
+					GetProductsByIDs(gomock.Any(), gomock.Eq([]uint64{product.ID})).
+					Times(1).
+					Return([]domain.Product{product}, nil)
+				categoryRepo.EXPECT().
+					GetCategoriesByIDs(gomock.Any(), gomock.Eq([]uint64{category.ID})).
+					Times(1).
+					Return([]domain.Category{category}, nil)
+				orderRepo.EXPECT().
+					CreateOrder(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, domain.ErrDataNotFound)
+			},
+			input: createOrderTestedInput{
+				order: newOrder(1, 10),
+			},
+			expected: createOrderExpectedOutput{
+				err: domain.ErrDataNotFound,
+			},
+		},
+		{
+			desc: "Fail_InvalidQuantity",
+			mocks: func(
+				orderRepo *mock.MockOrderRepository,
+				productRepo *mock.MockProductRepository,
+				categoryRepo *mock.MockCategoryRepository,
+				userRepo *mock.MockUserRepository,
+				paymentRepo *mock.MockPaymentRepository,
+				cache *mock.MockCacheRepository,
+			) {
+			},
+			input: createOrderTestedInput{
+				order: newOrder(-1, 10),
+			},
+			expected: createOrderExpectedOutput{
+				err: domain.ErrInvalidQuantity,
+			},
+		},
+		{
+			desc: "Fail_InsufficientPayment",
+			mocks: func(
+				orderRepo *mock.MockOrderRepository,
+				productRepo *mock.MockProductRepository,
+				categoryRepo *mock.MockCategoryRepository,
+				userRepo *mock.MockUserRepository,
+				paymentRepo *mock.MockPaymentRepository,
+				cache *mock.MockCacheRepository,
+			) {
+				paymentRepo.EXPECT().
+					GetPaymentByID(gomock.Any(), gomock.Eq(payment.ID)).
+					Times(1).
+					Return(payment, nil)
+				productRepo.EXPECT().
+					GetProductsByIDs(gomock.Any(), gomock.Eq([]uint64{product.ID})).
+					Times(1).
+					Return([]domain.Product{product}, nil)
+				categoryRepo.EXPECT().
+					GetCategoriesByIDs(gomock.Any(), gomock.Eq([]uint64{category.ID})).
+					Times(1).
+					Return([]domain.Category{category}, nil)
+			},
+			input: createOrderTestedInput{
+				order: newOrder(2, 10),
+			},
+			expected: createOrderExpectedOutput{
+				err: domain.ErrInsufficientPayment,
+			},
+		},
+		{
+			desc: "Fail_ProductNotFound",
+			mocks: func(
+				orderRepo *mock.MockOrderRepository,
+				productRepo *mock.MockProductRepository,
+				categoryRepo *mock.MockCategoryRepository,
+				userRepo *mock.MockUserRepository,
+				paymentRepo *mock.MockPaymentRepository,
+				cache *mock.MockCacheRepository,
+			) {
+				paymentRepo.EXPECT().
+					GetPaymentByID(gomock.Any(), gomock.Eq(payment.ID)).
+					Times(1).
+					Return(payment, nil)
+				productRepo.EXPECT().
+					GetProductsByIDs(gomock.Any(), gomock.Eq([]uint64{product.ID})).
+					Times(1).
+					Return([]domain.Product{}, nil)
+			},
+			input: createOrderTestedInput{
+				order: newOrder(1, 10),
+			},
+			expected: createOrderExpectedOutput{
+				err: domain.ErrDataNotFound,
+			},
+		},
+		{
+			desc: "Fail_InternalError",
+			mocks: func(
+				orderRepo *mock.MockOrderRepository,
+				productRepo *mock.MockProductRepository,
+				categoryRepo *mock.MockCategoryRepository,
+				userRepo *mock.MockUserRepository,
+				paymentRepo *mock.MockPaymentRepository,
+				cache *mock.MockCacheRepository,
+			) {
+				paymentRepo.EXPECT().
+					GetPaymentByID(gomock.Any(), gomock.Eq(payment.ID)).
+					Times(1).
+					Return(payment, nil)
+				productRepo.EXPECT().
+					GetProductsByIDs(gomock.Any(), gomock.Eq([]uint64{product.ID})).
+					Times(1).
+					Return([]domain.Product{product}, nil)
+				categoryRepo.EXPECT().
+					GetCategoriesByIDs(gomock.Any(), gomock.Eq([]uint64{category.ID})).
+					Times(1).
+					Return([]domain.Category{category}, nil)
+				orderRepo.EXPECT().
+					CreateOrder(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, domain.ErrInternal)
+			},
+			input: createOrderTestedInput{
+				order: newOrder(1, 10),
+			},
+			expected: createOrderExpectedOutput{
+				err: domain.ErrInternal,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			orderRepo := mock.NewMockOrderRepository(ctrl)
+			productRepo := mock.NewMockProductRepository(ctrl)
+			categoryRepo := mock.NewMockCategoryRepository(ctrl)
+			userRepo := mock.NewMockUserRepository(ctrl)
+			paymentRepo := mock.NewMockPaymentRepository(ctrl)
+			cache := mock.NewMockCacheRepository(ctrl)
+
+			tc.mocks(orderRepo, productRepo, categoryRepo, userRepo, paymentRepo, cache)
+
+			orderService := service.NewOrderService(orderRepo, productRepo, categoryRepo, userRepo, paymentRepo, newMockAuditService(ctrl), cache)
+
+			order, err := orderService.CreateOrder(ctx, tc.input.order)
+			assert.Equal(t, tc.expected.err, err, "Error mismatch")
+			assert.Equal(t, tc.expected.order, order, "Order mismatch")
+		})
+	}
+}
+
+type exportOrdersTestedInput struct {
+	filter *domain.OrderFilter
+}
+
+type exportOrdersExpectedOutput struct {
+	orders []uint64
+	err    error
+}
+
+func TestOrderService_ExportOrders(t *testing.T) {
+	ctx := context.Background()
+	startDate := time.Now()
+	endDate := startDate.AddDate(0, 0, 1)
+	filter := &domain.OrderFilter{
+		Status:    domain.OrderCompleted,
+		StartDate: &startDate,
+		EndDate:   &endDate,
+	}
+	completedOrder := &domain.Order{
+		ID:     gofakeit.Uint64(),
+		Status: domain.OrderCompleted,
+	}
+
+	testCases := []struct {
+		desc     string
+		mocks    func(orderRepo *mock.MockOrderRepository)
+		input    exportOrdersTestedInput
+		expected exportOrdersExpectedOutput
+	}{
+		{
+			desc: "Success",
+			mocks: func(orderRepo *mock.MockOrderRepository) {
+				orderRepo.EXPECT().
+					StreamOrders(gomock.Any(), gomock.Eq(filter), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(ctx context.Context, filter *domain.OrderFilter, fn func(order *domain.Order) error) error {
+						return fn(completedOrder)
+					})
+			},
+			input: exportOrdersTestedInput{
+				filter: filter,
+			},
+			expected: exportOrdersExpectedOutput{
+				orders: []uint64{completedOrder.ID},
+				err:    nil,
+			},
+		},
+		{
+			desc:  "Fail_InvalidDateRange",
+			mocks: func(orderRepo *mock.MockOrderRepository) {},
+			input: exportOrdersTestedInput{
+				filter: &domain.OrderFilter{
+					StartDate: &endDate,
+					EndDate:   &startDate,
+				},
+			},
+			expected: exportOrdersExpectedOutput{
+				err: domain.ErrInvalidDateRange,
+			},
+		},
+		{
+			desc: "Fail_InternalError",
+			mocks: func(orderRepo *mock.MockOrderRepository) {
+				orderRepo.EXPECT().
+					StreamOrders(gomock.Any(), gomock.Eq(filter), gomock.Any()).
+					Times(1).
+					Return(domain.ErrInternal)
+			},
+			input: exportOrdersTestedInput{
+				filter: filter,
+			},
+			expected: exportOrdersExpectedOutput{
+				err: domain.ErrInternal,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			orderRepo := mock.NewMockOrderRepository(ctrl)
+
+			tc.mocks(orderRepo)
+
+			orderService := service.NewOrderService(orderRepo, mock.NewMockProductRepository(ctrl), mock.NewMockCategoryRepository(ctrl), mock.NewMockUserRepository(ctrl), mock.NewMockPaymentRepository(ctrl), newMockAuditService(ctrl), mock.NewMockCacheRepository(ctrl))
+
+			var exported []uint64
+			err := orderService.ExportOrders(ctx, tc.input.filter, func(order *domain.Order) error {
+				exported = append(exported, order.ID)
+				return nil
+			})
+			assert.Equal(t, tc.expected.err, err, "Error mismatch")
+			assert.Equal(t, tc.expected.orders, exported, "Orders mismatch")
+		})
+	}
+}
@@ -0,0 +1,158 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/nikhil-shrestha/go-pos/internal/core/domain"
+	"github.com/nikhil-shrestha/go-pos/internal/core/port"
+	"github.com/nikhil-shrestha/go-pos/internal/core/util"
+	"github.com/google/uuid"
+)
+
+// overrideDuration is how long a supervisor's approval can be used after it is given
+const overrideDuration = 2 * time.Minute
+
+/**
+ * OverrideService implements port.OverrideService interface
+ * and provides an access to the user and role repositories
+ * and cache service
+ */
+type OverrideService struct {
+	userRepo port.UserRepository
+	roleRepo port.RoleRepository
+	cache    port.CacheRepository
+}
+
+// NewOverrideService creates a new override service instance
+func NewOverrideService(userRepo port.UserRepository, roleRepo port.RoleRepository, cache port.CacheRepository) *OverrideService {
+	return &OverrideService{
+		userRepo,
+		roleRepo,
+		cache,
+	}
+}
+
+// RequestOverride checks the supervisor's credentials and permission and stores a single-use approval for the action.
+// Failed attempts count towards the login lockout of the supervisor's account
+func (ovs *OverrideService) RequestOverride(ctx context.Context, requester *domain.TokenPayload, action domain.OverrideAction, email, password string) (*domain.Override, error) {
+	_, ok := action.Permission()
+	if !ok {
+		return nil, domain.ErrInvalidOverride
+	}
+
+	account := strings.ToLower(email)
+
+	err := loginAccountLockout.check(ctx, ovs.cache, account)
+	if err != nil {
+		return nil, err
+	}
+
+	approver, err := ovs.userRepo.GetUserByEmail(ctx, email)
+	if err != nil && err != domain.ErrDataNotFound {
+		return nil, domain.ErrInternal
+	}
+
+	if approver == nil || util.ComparePassword(password, approver.Password) != nil {
+		err = loginAccountLockout.fail(ctx, ovs.cache, account)
+		if err != nil {
+			return nil, err
+		}
+		return nil, domain.ErrInvalidCredentials
+	}
+
+	err = loginAccountLockout.reset(ctx, ovs.cache, account)
+	if err != nil {
+		return nil, err
+	}
+
+	return ovs.approve(ctx, requester, action, approver)
+}
+
+// RequestPinOverride checks the supervisor's PIN and permission and stores a single-use approval for the action
+func (ovs *OverrideService) RequestPinOverride(ctx context.Context, requester *domain.TokenPayload, action domain.OverrideAction, approverID uint64, pin string) (*domain.Override, error) {
+	_, ok := action.Permission()
+	if !ok {
+		return nil, domain.ErrInvalidOverride
+	}
+
+	approver, err := verifyPin(ctx, ovs.userRepo, ovs.cache, approverID, pin)
+	if err != nil {
+		return nil, err
+	}
+
+	return ovs.approve(ctx, requester, action, approver)
+}
+
+// approve stores a single-use approval for the action if the supervisor is another user who is allowed to take it
+func (ovs *OverrideService) approve(ctx context.Context, requester *domain.TokenPayload, action domain.OverrideAction, approver *domain.User) (*domain.Override, error) {
+	permission, _ := action.Permission()
+
+	if approver.ID == requester.UserID {
+		return nil, domain.ErrSelfApproval
+	}
+
+	role, err := ovs.roleRepo.GetRoleByName(ctx, approver.Role)
+	if err != nil {
+		return nil, domain.ErrInternal
+	}
+
+	if !role.HasPermission(permission) {
+		return nil, domain.ErrForbidden
+	}
+
+	override := &domain.Override{
+		ID:          uuid.New(),
+		Action:      action,
+		RequestedBy: requester.UserID,
+		ApprovedBy:  approver.ID,
+		ExpiresAt:   time.Now().Add(overrideDuration),
+	}
+
+	overrideSerialized, err := util.Serialize(override)
+	if err != nil {
+		return nil, domain.ErrInternal
+	}
+
+	cacheKey := util.GenerateCacheKey("override", override.ID)
+
+	err = ovs.cache.Set(ctx, cacheKey, overrideSerialized, overrideDuration)
+	if err != nil {
+		return nil, domain.ErrInternal
+	}
+
+	return override, nil
+}
+
+// UseOverride removes the approval so it cannot be used twice, and checks that it was given to the requester for the action
+func (ovs *OverrideService) UseOverride(ctx context.Context, requester *domain.TokenPayload, action domain.OverrideAction, id string) (*domain.Override, error) {
+	var override *domain.Override
+
+	overrideID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, domain.ErrInvalidOverride
+	}
+
+	cacheKey := util.GenerateCacheKey("override", overrideID)
+
+	// Taking the approval out of the cache in one step lets only one of concurrent requests use it
+	overrideSerialized, err := ovs.cache.GetAndDelete(ctx, cacheKey)
+	if err != nil {
+		if err == domain.ErrDataNotFound {
+			return nil, domain.ErrInvalidOverride
+		}
+		return nil, domain.ErrInternal
+	}
+
+	err = util.Deserialize(overrideSerialized, &override)
+	if err != nil {
+		return nil, domain.ErrInternal
+	}
+
+	if override.Action != action || override.RequestedBy != requester.UserID || time.Now().After(override.ExpiresAt) {
+		return nil, domain.ErrInvalidOverride
+	}
+
+	return override, nil
+}
@@ -0,0 +1,400 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/nikhil-shrestha/go-pos/internal/core/domain"
+	"github.com/nikhil-shrestha/go-pos/internal/core/port/mock"
+	"github.com/nikhil-shrestha/go-pos/internal/core/service"
+	"github.com/nikhil-shrestha/go-pos/internal/core/util"
+	"github.com/brianvoe/gofakeit/v6"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+type requestOverrideTestedInput struct {
+	requester *domain.TokenPayload
+	action    domain.OverrideAction
+	email     string
+	password  string
+}
+
+type requestOverrideExpectedOutput struct {
+	approvedBy uint64
+	err        error
+}
+
+func TestOverrideService_RequestOverride(t *testing.T) {
+	ctx := context.Background()
+	email := gofakeit.Email()
+	password := gofakeit.Password(true, true, true, true, false, 8)
+	hashedPassword, _ := util.HashPassword(password)
+	manager := &domain.User{
+		ID:       1,
+		Email:    email,
+		Password: hashedPassword,
+		Role:     domain.Admin,
+	}
+	cashierManager := &domain.User{
+		ID:       3,
+		Email:    email,
+		Password: hashedPassword,
+		Role:     domain.Cashier,
+	}
+	adminRole := &domain.Role{
+		Name:        domain.Admin,
+		Permissions: []domain.Permission{domain.OrdersVoid, domain.DrawerOpen},
+	}
+	cashierRole := &domain.Role{
+		Name:        domain.Cashier,
+		Permissions: []domain.Permission{domain.OrdersCreate},
+	}
+	requester := &domain.TokenPayload{
+		ID:     uuid.New(),
+		UserID: 2,
+	}
+	selfRequester := &domain.TokenPayload{
+		ID:     uuid.New(),
+		UserID: manager.ID,
+	}
+	lockCacheKey := util.GenerateCacheKey("login_account_lock", email)
+	attemptsCacheKey := util.GenerateCacheKey("login_account_attempts", email)
+
+	testCases := []struct {
+		desc  string
+		mocks func(
+			userRepo *mock.MockUserRepository,
+			roleRepo *mock.MockRoleRepository,
+			cache *mock.MockCacheRepository,
+		)
+		input    requestOverrideTestedInput
+		expected requestOverrideExpectedOutput
+	}{
+		{
+			desc: "Success",
+			mocks: func(
+				userRepo *mock.MockUserRepository,
+				roleRepo *mock.MockRoleRepository,
+				cache *mock.MockCacheRepository,
+			) {
+				userRepo.EXPECT().
+					GetUserByEmail(gomock.Any(), gomock.Eq(email)).
+					Times(1).
+					Return(manager, nil)
+				roleRepo.EXPECT().
+					GetRoleByName(gomock.Any(), gomock.Eq(domain.Admin)).
+					Times(1).
+					Return(adminRole, nil)
+				cache.EXPECT().
+					Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Eq(2*time.Minute)).
+					Times(1).
+					Return(nil)
+			},
+			input: requestOverrideTestedInput{
+				requester: requester,
+				action:    domain.VoidOrderAction,
+				email:     email,
+				password:  password,
+			},
+			expected: requestOverrideExpectedOutput{
+				approvedBy: manager.ID,
+				err:        nil,
+			},
+		},
+		{
+			desc: "Fail_UnknownAction",
+			mocks: func(
+				userRepo *mock.MockUserRepository,
+				roleRepo *mock.MockRoleRepository,
+				cache *mock.MockCacheRepository,
+			) {
+			},
+			input: requestOverrideTestedInput{
+				requester: requester,
+				action:    "order.discount",
+				email:     email,
+				password:  password,
+			},
+			expected: requestOverrideExpectedOutput{
+				err: domain.ErrInvalidOverride,
+			},
+		},
+		{
+			desc: "Fail_ApproverNotFound",
+			mocks: func(
+				userRepo *mock.MockUserRepository,
+				roleRepo *mock.MockRoleRepository,
+				cache *mock.MockCacheRepository,
+			) {
+				userRepo.EXPECT().
+					GetUserByEmail(gomock.Any(), gomock.Eq(email)).
+					Times(1).
+					Return(nil, domain.ErrDataNotFound)
+			},
+			input: requestOverrideTestedInput{
+				requester: requester,
+				action:    domain.VoidOrderAction,
+				email:     email,
+				password:  password,
+			},
+			expected: requestOverrideExpectedOutput{
+				err: domain.ErrInvalidCredentials,
+			},
+		},
+		{
+			desc: "Fail_PasswordMismatch",
+			mocks: func(
+				userRepo *mock.MockUserRepository,
+				roleRepo *mock.MockRoleRepository,
+				cache *mock.MockCacheRepository,
+			) {
+				userRepo.EXPECT().
+					GetUserByEmail(gomock.Any(), gomock.Eq(email)).
+					Times(1).
+					Return(manager, nil)
+			},
+			input: requestOverrideTestedInput{
+				requester: requester,
+				action:    domain.VoidOrderAction,
+				email:     email,
+				password:  "wrong password",
+			},
+			expected: requestOverrideExpectedOutput{
+				err: domain.ErrInvalidCredentials,
+			},
+		},
+		{
+			desc: "Fail_SelfApproval",
+			mocks: func(
+				userRepo *mock.MockUserRepository,
+				roleRepo *mock.MockRoleRepository,
+				cache *mock.MockCacheRepository,
+			) {
+				userRepo.EXPECT().
+					GetUserByEmail(gomock.Any(), gomock.Eq(email)).
+					Times(1).
+					Return(manager, nil)
+			},
+			input: requestOverrideTestedInput{
+				requester: selfRequester,
+				action:    domain.VoidOrderAction,
+				email:     email,
+				password:  password,
+			},
+			expected: requestOverrideExpectedOutput{
+				err: domain.ErrSelfApproval,
+			},
+		},
+		{
+			desc: "Fail_ApproverLacksPermission",
+			mocks: func(
+				userRepo *mock.MockUserRepository,
+				roleRepo *mock.MockRoleRepository,
+				cache *mock.MockCacheRepository,
+			) {
+				userRepo.EXPECT().
+					GetUserByEmail(gomock.Any(), gomock.Eq(email)).
+					Times(1).
+					Return(cashierManager, nil)
+				roleRepo.EXPECT().
+					GetRoleByName(gomock.Any(), gomock.Eq(domain.Cashier)).
+					Times(1).
+					Return(cashierRole, nil)
+			},
+			input: requestOverrideTestedInput{
+				requester: requester,
+				action:    domain.OpenDrawerAction,
+				email:     email,
+				password:  password,
+			},
+			expected: requestOverrideExpectedOutput{
+				err: domain.ErrForbidden,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			userRepo := mock.NewMockUserRepository(ctrl)
+			roleRepo := mock.NewMockRoleRepository(ctrl)
+			cache := mock.NewMockCacheRepository(ctrl)
+
+			cache.EXPECT().
+				Get(gomock.Any(), gomock.Eq(lockCacheKey)).
+				AnyTimes().
+				Return(nil, domain.ErrDataNotFound)
+			cache.EXPECT().
+				Increment(gomock.Any(), gomock.Eq(attemptsCacheKey), gomock.Any()).
+				AnyTimes().
+				Return(int64(1), nil)
+			cache.EXPECT().
+				Delete(gomock.Any(), gomock.Any()).
+				AnyTimes().
+				Return(nil)
+
+			tc.mocks(userRepo, roleRepo, cache)
+
+			overrideService := service.NewOverrideService(userRepo, roleRepo, cache)
+
+			override, err := overrideService.RequestOverride(ctx, tc.input.requester, tc.input.action, tc.input.email, tc.input.password)
+			assert.Equal(t, tc.expected.err, err, "Error mismatch")
+			if tc.expected.err == nil {
+				assert.Equal(t, tc.expected.approvedBy, override.ApprovedBy, "Approver mismatch")
+				assert.Equal(t, tc.input.requester.UserID, override.RequestedBy, "Requester mismatch")
+				assert.Equal(t, tc.input.action, override.Action, "Action mismatch")
+			}
+		})
+	}
+}
+
+type useOverrideTestedInput struct {
+	requester *domain.TokenPayload
+	action    domain.OverrideAction
+	id        string
+}
+
+type useOverrideExpectedOutput struct {
+	approvedBy uint64
+	err        error
+}
+
+func TestOverrideService_UseOverride(t *testing.T) {
+	ctx := context.Background()
+	requester := &domain.TokenPayload{
+		ID:     uuid.New(),
+		UserID: 2,
+	}
+	otherRequester := &domain.TokenPayload{
+		ID:     uuid.New(),
+		UserID: 4,
+	}
+	override := &domain.Override{
+		ID:          uuid.New(),
+		Action:      domain.VoidOrderAction,
+		RequestedBy: requester.UserID,
+		ApprovedBy:  1,
+		ExpiresAt:   time.Now().Add(time.Minute),
+	}
+	overrideSerialized, _ := util.Serialize(override)
+	cacheKey := util.GenerateCacheKey("override", override.ID)
+
+	testCases := []struct {
+		desc     string
+		mocks    func(cache *mock.MockCacheRepository)
+		input    useOverrideTestedInput
+		expected useOverrideExpectedOutput
+	}{
+		{
+			desc: "Success",
+			mocks: func(cache *mock.MockCacheRepository) {
+				cache.EXPECT().
+					GetAndDelete(gomock.Any(), gomock.Eq(cacheKey)).
+					Times(1).
+					Return(overrideSerialized, nil)
+			},
+			input: useOverrideTestedInput{
+				requester: requester,
+				action:    domain.VoidOrderAction,
+				id:        override.ID.String(),
+			},
+			expected: useOverrideExpectedOutput{
+				approvedBy: override.ApprovedBy,
+				err:        nil,
+			},
+		},
+		{
+			desc:  "Fail_MalformedToken",
+			mocks: func(cache *mock.MockCacheRepository) {},
+			input: useOverrideTestedInput{
+				requester: requester,
+				action:    domain.VoidOrderAction,
+				id:        "not-a-token",
+			},
+			expected: useOverrideExpectedOutput{
+				err: domain.ErrInvalidOverride,
+			},
+		},
+		{
+			desc: "Fail_UsedOrExpired",
+			mocks: func(cache *mock.MockCacheRepository) {
+				cache.EXPECT().
+					GetAndDelete(gomock.Any(), gomock.Eq(cacheKey)).
+					Times(1).
+					Return(nil, domain.ErrDataNotFound)
+			},
+			input: useOverrideTestedInput{
+				requester: requester,
+				action:    domain.VoidOrderAction,
+				id:        override.ID.String(),
+			},
+			expected: useOverrideExpectedOutput{
+				err: domain.ErrInvalidOverride,
+			},
+		},
+		{
+			desc: "Fail_OtherAction",
+			mocks: func(cache *mock.MockCacheRepository) {
+				cache.EXPECT().
+					GetAndDelete(gomock.Any(), gomock.Eq(cacheKey)).
+					Times(1).
+					Return(overrideSerialized, nil)
+			},
+			input: useOverrideTestedInput{
+				requester: requester,
+				action:    domain.OpenDrawerAction,
+				id:        override.ID.String(),
+			},
+			expected: useOverrideExpectedOutput{
+				err: domain.ErrInvalidOverride,
+			},
+		},
+		{
+			desc: "Fail_OtherRequester",
+			mocks: func(cache *mock.MockCacheRepository) {
+				cache.EXPECT().
+					GetAndDelete(gomock.Any(), gomock.Eq(cacheKey)).
+					Times(1).
+					Return(overrideSerialized, nil)
+			},
+			input: useOverrideTestedInput{
+				requester: otherRequester,
+				action:    domain.VoidOrderAction,
+				id:        override.ID.String(),
+			},
+			expected: useOverrideExpectedOutput{
+				err: domain.ErrInvalidOverride,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			userRepo := mock.NewMockUserRepository(ctrl)
+			roleRepo := mock.NewMockRoleRepository(ctrl)
+			cache := mock.NewMockCacheRepository(ctrl)
+
+			tc.mocks(cache)
+
+			overrideService := service.NewOverrideService(userRepo, roleRepo, cache)
+
+			override, err := overrideService.UseOverride(ctx, tc.input.requester, tc.input.action, tc.input.id)
+			assert.Equal(t, tc.expected.err, err, "Error mismatch")
+			if tc.expected.err == nil {
+				assert.Equal(t, tc.expected.approvedBy, override.ApprovedBy, "Approver mismatch")
+			}
+		})
+	}
+}
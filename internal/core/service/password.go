@@ -0,0 +1,181 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nikhil-shrestha/go-pos/internal/core/domain"
+	"github.com/nikhil-shrestha/go-pos/internal/core/util"
+)
+
+// passwordResetDuration is how long a password reset token can be used
+const passwordResetDuration = time.Hour
+
+// ChangePassword changes the password of the user if the current password is right, and logs the user out of every session.
+// Wrong current passwords count towards the login lockout of the account
+func (as *AuthService) ChangePassword(ctx context.Context, userID uint64, currentPassword, newPassword string) error {
+	user, err := as.repo.GetUserByID(ctx, userID)
+	if err != nil {
+		if err == domain.ErrDataNotFound {
+			return err
+		}
+		return domain.ErrInternal
+	}
+
+	account := strings.ToLower(user.Email)
+
+	err = loginAccountLockout.check(ctx, as.cache, account)
+	if err != nil {
+		return err
+	}
+
+	err = util.ComparePassword(currentPassword, user.Password)
+	if err != nil {
+		err = loginAccountLockout.fail(ctx, as.cache, account)
+		if err != nil {
+			return err
+		}
+		return domain.ErrInvalidCredentials
+	}
+
+	if util.ComparePassword(newPassword, user.Password) == nil {
+		return domain.ErrNoUpdatedData
+	}
+
+	return as.setPassword(ctx, &domain.AuditLog{Action: "user.change_password"}, user, newPassword)
+}
+
+// RequestPasswordReset sends the user a single-use token to reset their password.
+// Unknown emails are ignored, so the response does not tell whether an account exists
+func (as *AuthService) RequestPasswordReset(ctx context.Context, email string) error {
+	user, err := as.repo.GetUserByEmail(ctx, email)
+	if err != nil {
+		if err == domain.ErrDataNotFound {
+			return nil
+		}
+		return domain.ErrInternal
+	}
+
+	secret, err := util.GenerateKey(32)
+	if err != nil {
+		return domain.ErrInternal
+	}
+
+	// Only the hash is stored and a new request replaces the token of the previous one
+	cacheKey := util.GenerateCacheKey("password_reset", user.ID)
+
+	err = as.cache.Set(ctx, cacheKey, []byte(util.HashKey(secret)), passwordResetDuration)
+	if err != nil {
+		return domain.ErrInternal
+	}
+
+	err = as.notifier.SendPasswordReset(ctx, user, newPasswordResetToken(user.ID, secret))
+	if err != nil {
+		return domain.ErrInternal
+	}
+
+	return nil
+}
+
+// ResetPassword sets a new password with a password reset token, which can only be used once, and logs the user out of every session
+func (as *AuthService) ResetPassword(ctx context.Context, token, password string) error {
+	userID, secret, ok := parsePasswordResetToken(token)
+	if !ok {
+		return domain.ErrInvalidResetToken
+	}
+
+	cacheKey := util.GenerateCacheKey("password_reset", userID)
+
+	secretHash, err := as.cache.Get(ctx, cacheKey)
+	if err != nil {
+		if err == domain.ErrDataNotFound {
+			return domain.ErrInvalidResetToken
+		}
+		return domain.ErrInternal
+	}
+
+	if !util.CompareKey(secret, string(secretHash)) {
+		return domain.ErrInvalidResetToken
+	}
+
+	err = as.cache.Delete(ctx, cacheKey)
+	if err != nil {
+		return domain.ErrInternal
+	}
+
+	user, err := as.repo.GetUserByID(ctx, userID)
+	if err != nil {
+		if err == domain.ErrDataNotFound {
+			return domain.ErrInvalidResetToken
+		}
+		return domain.ErrInternal
+	}
+
+	// Whoever resets a password is not logged in, so the log names the owner of the token
+	err = as.setPassword(ctx, &domain.AuditLog{UserID: user.ID, Action: "user.reset_password"}, user, password)
+	if err != nil {
+		return err
+	}
+
+	// Whoever locked the account guessing the old password no longer keeps its owner out
+	return loginAccountLockout.reset(ctx, as.cache, strings.ToLower(user.Email))
+}
+
+// setPassword stores the hash of the new password of the user, records it in the audit log and revokes every token issued to the user so far
+func (as *AuthService) setPassword(ctx context.Context, auditLog *domain.AuditLog, user *domain.User, password string) error {
+	hashedPassword, err := util.HashPassword(password)
+	if err != nil {
+		return domain.ErrInternal
+	}
+
+	err = as.audit.WithinTransaction(ctx, func(ctx context.Context) error {
+		updatedUser, err := as.repo.UpdateUser(ctx, &domain.User{
+			ID:       user.ID,
+			Password: hashedPassword,
+		})
+		if err != nil {
+			if err == domain.ErrDataNotFound {
+				return err
+			}
+			return domain.ErrInternal
+		}
+
+		auditLog.Entity = "user"
+		auditLog.EntityID = user.ID
+
+		return as.audit.RecordAuditLog(ctx, auditLog, user, updatedUser)
+	})
+	if err != nil {
+		return err
+	}
+
+	err = as.cache.Delete(ctx, util.GenerateCacheKey("user", user.ID))
+	if err != nil {
+		return domain.ErrInternal
+	}
+
+	return as.revokeUser(ctx, user.ID)
+}
+
+// newPasswordResetToken creates a password reset token, which is the user id followed by the secret
+func newPasswordResetToken(userID uint64, secret string) string {
+	return fmt.Sprintf("%d.%s", userID, secret)
+}
+
+// parsePasswordResetToken splits a password reset token into the user id and the secret
+func parsePasswordResetToken(token string) (uint64, string, bool) {
+	userPart, secret, ok := strings.Cut(token, ".")
+	if !ok || secret == "" {
+		return 0, "", false
+	}
+
+	userID, err := strconv.ParseUint(userPart, 10, 64)
+	if err != nil {
+		return 0, "", false
+	}
+
+	return userID, secret, true
+}
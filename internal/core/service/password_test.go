@@ -0,0 +1,357 @@
+package service_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nikhil-shrestha/go-pos/internal/core/domain"
+	"github.com/nikhil-shrestha/go-pos/internal/core/port/mock"
+	"github.com/nikhil-shrestha/go-pos/internal/core/service"
+	"github.com/nikhil-shrestha/go-pos/internal/core/util"
+	"github.com/brianvoe/gofakeit/v6"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+type changePasswordTestedInput struct {
+	currentPassword string
+	newPassword     string
+}
+
+func TestAuthService_ChangePassword(t *testing.T) {
+	ctx := context.Background()
+	password := gofakeit.Password(true, true, true, true, false, 8)
+	newPassword := gofakeit.Password(true, true, true, true, false, 12)
+	hashedPassword, _ := util.HashPassword(password)
+	user := &domain.User{
+		ID:       gofakeit.Uint64(),
+		Email:    gofakeit.Email(),
+		Password: hashedPassword,
+	}
+	lockCacheKey := util.GenerateCacheKey("login_account_lock", strings.ToLower(user.Email))
+	attemptsCacheKey := util.GenerateCacheKey("login_account_attempts", strings.ToLower(user.Email))
+	userCacheKey := util.GenerateCacheKey("user", user.ID)
+	revokedCacheKey := util.GenerateCacheKey("revoked_user", user.ID)
+
+	testCases := []struct {
+		desc  string
+		mocks func(
+			userRepo *mock.MockUserRepository,
+			cache *mock.MockCacheRepository,
+			auditRepo *mock.MockAuditRepository,
+		)
+		input    changePasswordTestedInput
+		expected error
+	}{
+		{
+			desc: "Success",
+			mocks: func(
+				userRepo *mock.MockUserRepository,
+				cache *mock.MockCacheRepository,
+				auditRepo *mock.MockAuditRepository,
+			) {
+				userRepo.EXPECT().GetUserByID(gomock.Any(), gomock.Eq(user.ID)).Return(user, nil)
+				cache.EXPECT().Get(gomock.Any(), gomock.Eq(lockCacheKey)).Return(nil, domain.ErrDataNotFound)
+				userRepo.EXPECT().
+					UpdateUser(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, updated *domain.User) (*domain.User, error) {
+						assert.Equal(t, user.ID, updated.ID, "User mismatch")
+						assert.NoError(t, util.ComparePassword(newPassword, updated.Password), "Password must be hashed")
+						updatedUser := *user
+						updatedUser.Password = updated.Password
+						return &updatedUser, nil
+					})
+				auditRepo.EXPECT().
+					CreateAuditLog(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, auditLog *domain.AuditLog) (*domain.AuditLog, error) {
+						assert.Equal(t, "user.change_password", auditLog.Action, "Action mismatch")
+						assert.Equal(t, user.ID, auditLog.EntityID, "Entity mismatch")
+						assert.Equal(t, map[string]domain.AuditChange{
+							"Password": {Before: "[REDACTED]", After: "[REDACTED]"},
+						}, auditLog.Changes, "Changes mismatch")
+						return auditLog, nil
+					})
+				cache.EXPECT().Delete(gomock.Any(), gomock.Eq(userCacheKey)).Return(nil)
+				cache.EXPECT().Set(gomock.Any(), gomock.Eq(revokedCacheKey), gomock.Any(), gomock.Eq(time.Hour)).Return(nil)
+			},
+			input: changePasswordTestedInput{
+				currentPassword: password,
+				newPassword:     newPassword,
+			},
+			expected: nil,
+		},
+		{
+			desc: "Fail_AuditError",
+			mocks: func(
+				userRepo *mock.MockUserRepository,
+				cache *mock.MockCacheRepository,
+				auditRepo *mock.MockAuditRepository,
+			) {
+				updatedUser := *user
+				userRepo.EXPECT().GetUserByID(gomock.Any(), gomock.Eq(user.ID)).Return(user, nil)
+				cache.EXPECT().Get(gomock.Any(), gomock.Eq(lockCacheKey)).Return(nil, domain.ErrDataNotFound)
+				userRepo.EXPECT().UpdateUser(gomock.Any(), gomock.Any()).Return(&updatedUser, nil)
+				auditRepo.EXPECT().CreateAuditLog(gomock.Any(), gomock.Any()).Return(nil, domain.ErrInternal)
+			},
+			input: changePasswordTestedInput{
+				currentPassword: password,
+				newPassword:     newPassword,
+			},
+			expected: domain.ErrInternal,
+		},
+		{
+			desc: "Fail_WrongCurrentPassword",
+			mocks: func(
+				userRepo *mock.MockUserRepository,
+				cache *mock.MockCacheRepository,
+				auditRepo *mock.MockAuditRepository,
+			) {
+				userRepo.EXPECT().GetUserByID(gomock.Any(), gomock.Eq(user.ID)).Return(user, nil)
+				cache.EXPECT().Get(gomock.Any(), gomock.Eq(lockCacheKey)).Return(nil, domain.ErrDataNotFound)
+				cache.EXPECT().Increment(gomock.Any(), gomock.Eq(attemptsCacheKey), gomock.Any()).Return(int64(1), nil)
+			},
+			input: changePasswordTestedInput{
+				currentPassword: "wrong password",
+				newPassword:     newPassword,
+			},
+			expected: domain.ErrInvalidCredentials,
+		},
+		{
+			desc: "Fail_SamePassword",
+			mocks: func(
+				userRepo *mock.MockUserRepository,
+				cache *mock.MockCacheRepository,
+				auditRepo *mock.MockAuditRepository,
+			) {
+				userRepo.EXPECT().GetUserByID(gomock.Any(), gomock.Eq(user.ID)).Return(user, nil)
+				cache.EXPECT().Get(gomock.Any(), gomock.Eq(lockCacheKey)).Return(nil, domain.ErrDataNotFound)
+			},
+			input: changePasswordTestedInput{
+				currentPassword: password,
+				newPassword:     password,
+			},
+			expected: domain.ErrNoUpdatedData,
+		},
+		{
+			desc: "Fail_InternalError",
+			mocks: func(
+				userRepo *mock.MockUserRepository,
+				cache *mock.MockCacheRepository,
+				auditRepo *mock.MockAuditRepository,
+			) {
+				userRepo.EXPECT().GetUserByID(gomock.Any(), gomock.Eq(user.ID)).Return(nil, domain.ErrInternal)
+			},
+			input: changePasswordTestedInput{
+				currentPassword: password,
+				newPassword:     newPassword,
+			},
+			expected: domain.ErrInternal,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			userRepo := mock.NewMockUserRepository(ctrl)
+			cache := mock.NewMockCacheRepository(ctrl)
+			auditRepo := mock.NewMockAuditRepository(ctrl)
+
+			tc.mocks(userRepo, cache, auditRepo)
+
+			authService := service.NewAuthService(userRepo, mock.NewMockRoleRepository(ctrl), mock.NewMockTerminalRepository(ctrl), mock.NewMockTokenService(ctrl), mock.NewMockTwoFactorService(ctrl), cache, mock.NewMockNotifier(ctrl), newAuditService(ctrl, auditRepo), time.Hour)
+
+			err := authService.ChangePassword(ctx, user.ID, tc.input.currentPassword, tc.input.newPassword)
+			assert.ErrorIs(t, err, tc.expected, "Error mismatch")
+		})
+	}
+}
+
+func TestAuthService_RequestPasswordReset(t *testing.T) {
+	ctx := context.Background()
+	user := &domain.User{
+		ID:    gofakeit.Uint64(),
+		Email: gofakeit.Email(),
+	}
+	resetCacheKey := util.GenerateCacheKey("password_reset", user.ID)
+
+	testCases := []struct {
+		desc  string
+		mocks func(
+			userRepo *mock.MockUserRepository,
+			cache *mock.MockCacheRepository,
+			notifier *mock.MockNotifier,
+		)
+		expected error
+	}{
+		{
+			desc: "Success",
+			mocks: func(
+				userRepo *mock.MockUserRepository,
+				cache *mock.MockCacheRepository,
+				notifier *mock.MockNotifier,
+			) {
+				var secretHash []byte
+
+				userRepo.EXPECT().GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).Return(user, nil)
+				cache.EXPECT().
+					Set(gomock.Any(), gomock.Eq(resetCacheKey), gomock.Any(), gomock.Eq(time.Hour)).
+					DoAndReturn(func(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+						secretHash = value
+						return nil
+					})
+				notifier.EXPECT().
+					SendPasswordReset(gomock.Any(), gomock.Eq(user), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, user *domain.User, token string) error {
+						userPart, secret, _ := strings.Cut(token, ".")
+						assert.Equal(t, fmt.Sprint(user.ID), userPart, "Token must start with the user id")
+						assert.True(t, util.CompareKey(secret, string(secretHash)), "Only the hash of the secret must be stored")
+						return nil
+					})
+			},
+			expected: nil,
+		},
+		{
+			desc: "Success_UnknownEmail",
+			mocks: func(
+				userRepo *mock.MockUserRepository,
+				cache *mock.MockCacheRepository,
+				notifier *mock.MockNotifier,
+			) {
+				userRepo.EXPECT().GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).Return(nil, domain.ErrDataNotFound)
+			},
+			expected: nil,
+		},
+		{
+			desc: "Fail_NotifierError",
+			mocks: func(
+				userRepo *mock.MockUserRepository,
+				cache *mock.MockCacheRepository,
+				notifier *mock.MockNotifier,
+			) {
+				userRepo.EXPECT().GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).Return(user, nil)
+				cache.EXPECT().Set(gomock.Any(), gomock.Eq(resetCacheKey), gomock.Any(), gomock.Any()).Return(nil)
+				notifier.EXPECT().SendPasswordReset(gomock.Any(), gomock.Any(), gomock.Any()).Return(domain.ErrInternal)
+			},
+			expected: domain.ErrInternal,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			userRepo := mock.NewMockUserRepository(ctrl)
+			cache := mock.NewMockCacheRepository(ctrl)
+			notifier := mock.NewMockNotifier(ctrl)
+
+			tc.mocks(userRepo, cache, notifier)
+
+			authService := service.NewAuthService(userRepo, mock.NewMockRoleRepository(ctrl), mock.NewMockTerminalRepository(ctrl), mock.NewMockTokenService(ctrl), mock.NewMockTwoFactorService(ctrl), cache, notifier, newMockAuditService(ctrl), time.Hour)
+
+			err := authService.RequestPasswordReset(ctx, user.Email)
+			assert.Equal(t, tc.expected, err, "Error mismatch")
+		})
+	}
+}
+
+func TestAuthService_ResetPassword(t *testing.T) {
+	ctx := context.Background()
+	password := gofakeit.Password(true, true, true, true, false, 12)
+	user := &domain.User{
+		ID:    gofakeit.Uint64(),
+		Email: gofakeit.Email(),
+	}
+	secret, _ := util.GenerateKey(32)
+	token := fmt.Sprintf("%d.%s", user.ID, secret)
+	resetCacheKey := util.GenerateCacheKey("password_reset", user.ID)
+	userCacheKey := util.GenerateCacheKey("user", user.ID)
+	revokedCacheKey := util.GenerateCacheKey("revoked_user", user.ID)
+	lockCacheKey := util.GenerateCacheKey("login_account_lock", strings.ToLower(user.Email))
+	attemptsCacheKey := util.GenerateCacheKey("login_account_attempts", strings.ToLower(user.Email))
+
+	testCases := []struct {
+		desc  string
+		mocks func(
+			userRepo *mock.MockUserRepository,
+			cache *mock.MockCacheRepository,
+		)
+		input    string
+		expected error
+	}{
+		{
+			desc: "Success",
+			mocks: func(
+				userRepo *mock.MockUserRepository,
+				cache *mock.MockCacheRepository,
+			) {
+				cache.EXPECT().Get(gomock.Any(), gomock.Eq(resetCacheKey)).Return([]byte(util.HashKey(secret)), nil)
+				cache.EXPECT().Delete(gomock.Any(), gomock.Eq(resetCacheKey)).Return(nil)
+				userRepo.EXPECT().GetUserByID(gomock.Any(), gomock.Eq(user.ID)).Return(user, nil)
+				userRepo.EXPECT().UpdateUser(gomock.Any(), gomock.Any()).Return(user, nil)
+				cache.EXPECT().Delete(gomock.Any(), gomock.Eq(userCacheKey)).Return(nil)
+				cache.EXPECT().Set(gomock.Any(), gomock.Eq(revokedCacheKey), gomock.Any(), gomock.Any()).Return(nil)
+				cache.EXPECT().Delete(gomock.Any(), gomock.Eq(attemptsCacheKey)).Return(nil)
+				cache.EXPECT().Delete(gomock.Any(), gomock.Eq(lockCacheKey)).Return(nil)
+			},
+			input:    token,
+			expected: nil,
+		},
+		{
+			desc:     "Fail_MalformedToken",
+			mocks:    func(userRepo *mock.MockUserRepository, cache *mock.MockCacheRepository) {},
+			input:    secret,
+			expected: domain.ErrInvalidResetToken,
+		},
+		{
+			desc: "Fail_UsedOrExpired",
+			mocks: func(
+				userRepo *mock.MockUserRepository,
+				cache *mock.MockCacheRepository,
+			) {
+				cache.EXPECT().Get(gomock.Any(), gomock.Eq(resetCacheKey)).Return(nil, domain.ErrDataNotFound)
+			},
+			input:    token,
+			expected: domain.ErrInvalidResetToken,
+		},
+		{
+			desc: "Fail_WrongSecret",
+			mocks: func(
+				userRepo *mock.MockUserRepository,
+				cache *mock.MockCacheRepository,
+			) {
+				cache.EXPECT().Get(gomock.Any(), gomock.Eq(resetCacheKey)).Return([]byte(util.HashKey(secret)), nil)
+			},
+			input:    fmt.Sprintf("%d.%s", user.ID, "guessed"),
+			expected: domain.ErrInvalidResetToken,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			userRepo := mock.NewMockUserRepository(ctrl)
+			cache := mock.NewMockCacheRepository(ctrl)
+
+			tc.mocks(userRepo, cache)
+
+			authService := service.NewAuthService(userRepo, mock.NewMockRoleRepository(ctrl), mock.NewMockTerminalRepository(ctrl), mock.NewMockTokenService(ctrl), mock.NewMockTwoFactorService(ctrl), cache, mock.NewMockNotifier(ctrl), newMockAuditService(ctrl), time.Hour)
+
+			err := authService.ResetPassword(ctx, tc.input, password)
+			assert.Equal(t, tc.expected, err, "Error mismatch")
+		})
+	}
+}
@@ -10,30 +10,41 @@ import (
 
 /**
  * PaymentService implements port.PaymentService interface
- * and provides an access to the payment repository
- * and cache service
+ * and provides an access to the payment repository,
+ * cache service and audit service
  */
 type PaymentService struct {
 	repo  port.PaymentRepository
 	cache port.CacheRepository
+	audit port.AuditService
 }
 
 // NewPaymentService creates a new payment service instance
-func NewPaymentService(repo port.PaymentRepository, cache port.CacheRepository) *PaymentService {
+func NewPaymentService(repo port.PaymentRepository, cache port.CacheRepository, audit port.AuditService) *PaymentService {
 	return &PaymentService{
 		repo,
 		cache,
+		audit,
 	}
 }
 
 // CreatePayment creates a new payment
 func (ps *PaymentService) CreatePayment(ctx context.Context, payment *domain.Payment) (*domain.Payment, error) {
-	payment, err := ps.repo.CreatePayment(ctx, payment)
-	if err != nil {
-		if err == domain.ErrConflictingData {
-			return nil, err
+	err := ps.audit.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+
+		payment, err = ps.repo.CreatePayment(ctx, payment)
+		if err != nil {
+			if err == domain.ErrConflictingData {
+				return err
+			}
+			return domain.ErrInternal
 		}
-		return nil, domain.ErrInternal
+
+		return ps.audit.RecordAuditLog(ctx, &domain.AuditLog{Action: "payment.create", Entity: "payment", EntityID: payment.ID}, nil, payment)
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	cacheKey := util.GenerateCacheKey("payment", payment.ID)
@@ -92,42 +103,42 @@ func (ps *PaymentService) GetPayment(ctx context.Context, id uint64) (*domain.Pa
 }
 
 // ListPayments retrieves a list of payments
-func (ps *PaymentService) ListPayments(ctx context.Context, skip, limit uint64) ([]domain.Payment, error) {
-	var payments []domain.Payment
+func (ps *PaymentService) ListPayments(ctx context.Context, includeDeleted bool, params *domain.ListParams) ([]domain.Payment, uint64, error) {
+	var page domain.Page[domain.Payment]
 
-	params := util.GenerateCacheKeyParams(skip, limit)
-	cacheKey := util.GenerateCacheKey("payments", params)
+	cacheParams := util.GenerateCacheKeyParams(params.Skip, params.Limit, params.Sort, params.Order, includeDeleted)
+	cacheKey := util.GenerateCacheKey("payments", cacheParams)
 
 	cachedPayments, err := ps.cache.Get(ctx, cacheKey)
 	if err == nil {
-		err := util.Deserialize(cachedPayments, &payments)
+		err := util.Deserialize(cachedPayments, &page)
 		if err != nil {
-			return nil, domain.ErrInternal
+			return nil, 0, domain.ErrInternal
 		}
 
-		return payments, nil
+		return page.Items, page.Total, nil
 	}
 
-	payments, err = ps.repo.ListPayments(ctx, skip, limit)
+	payments, total, err := ps.repo.ListPayments(ctx, includeDeleted, params)
 	if err != nil {
-		return nil, domain.ErrInternal
+		return nil, 0, domain.ErrInternal
 	}
 
-	paymentsSerialized, err := util.Serialize(payments)
+	paymentsSerialized, err := util.Serialize(domain.Page[domain.Payment]{Items: payments, Total: total})
 	if err != nil {
-		return nil, domain.ErrInternal
+		return nil, 0, domain.ErrInternal
 	}
 
 	err = ps.cache.Set(ctx, cacheKey, paymentsSerialized, 0)
 	if err != nil {
-		return nil, domain.ErrInternal
+		return nil, 0, domain.ErrInternal
 	}
 
-	return payments, nil
+	return payments, total, nil
 
 }
 
-// UpdatePayment updates a payment
+// UpdatePayment updates a payment, as long as it is still at the version the client read
 func (ps *PaymentService) UpdatePayment(ctx context.Context, payment *domain.Payment) (*domain.Payment, error) {
 	existingPayment, err := ps.repo.GetPaymentByID(ctx, payment.ID)
 	if err != nil {
@@ -137,18 +148,33 @@ func (ps *PaymentService) UpdatePayment(ctx context.Context, payment *domain.Pay
 		return nil, domain.ErrInternal
 	}
 
+	if existingPayment.Version != payment.Version {
+		return nil, domain.ErrVersionMismatch
+	}
+
 	emptyData := payment.Name == "" && payment.Type == "" && payment.Logo == ""
 	sameData := existingPayment.Name == payment.Name && existingPayment.Type == payment.Type && existingPayment.Logo == payment.Logo
 	if emptyData || sameData {
 		return nil, domain.ErrNoUpdatedData
 	}
 
-	_, err = ps.repo.UpdatePayment(ctx, payment)
-	if err != nil {
-		if err == domain.ErrConflictingData {
-			return nil, err
+	var updatedPayment *domain.Payment
+
+	err = ps.audit.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+
+		updatedPayment, err = ps.repo.UpdatePayment(ctx, payment)
+		if err != nil {
+			if err == domain.ErrConflictingData || err == domain.ErrDataNotFound || err == domain.ErrVersionMismatch {
+				return err
+			}
+			return domain.ErrInternal
 		}
-		return nil, domain.ErrInternal
+
+		return ps.audit.RecordAuditLog(ctx, &domain.AuditLog{Action: "payment.update", Entity: "payment", EntityID: payment.ID}, existingPayment, updatedPayment)
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	cacheKey := util.GenerateCacheKey("payment", payment.ID)
@@ -176,9 +202,9 @@ func (ps *PaymentService) UpdatePayment(ctx context.Context, payment *domain.Pay
 	return payment, nil
 }
 
-// DeletePayment deletes a payment
-func (ps *PaymentService) DeletePayment(ctx context.Context, id uint64) error {
-	_, err := ps.repo.GetPaymentByID(ctx, id)
+// DeletePayment soft deletes a payment, as long as it is still at the version the client read
+func (ps *PaymentService) DeletePayment(ctx context.Context, id, version uint64) error {
+	existingPayment, err := ps.repo.GetPaymentByID(ctx, id)
 	if err != nil {
 		if err == domain.ErrDataNotFound {
 			return err
@@ -186,6 +212,10 @@ func (ps *PaymentService) DeletePayment(ctx context.Context, id uint64) error {
 		return domain.ErrInternal
 	}
 
+	if existingPayment.Version != version {
+		return domain.ErrVersionMismatch
+	}
+
 	cacheKey := util.GenerateCacheKey("payment", id)
 
 	err = ps.cache.Delete(ctx, cacheKey)
@@ -198,5 +228,41 @@ func (ps *PaymentService) DeletePayment(ctx context.Context, id uint64) error {
 		return domain.ErrInternal
 	}
 
-	return ps.repo.DeletePayment(ctx, id)
+	return ps.audit.WithinTransaction(ctx, func(ctx context.Context) error {
+		err := ps.repo.DeletePayment(ctx, id, version)
+		if err != nil {
+			return err
+		}
+
+		return ps.audit.RecordAuditLog(ctx, &domain.AuditLog{Action: "payment.delete", Entity: "payment", EntityID: id}, existingPayment, nil)
+	})
+}
+
+// RestorePayment restores a soft deleted payment
+func (ps *PaymentService) RestorePayment(ctx context.Context, id uint64) (*domain.Payment, error) {
+	var payment *domain.Payment
+
+	err := ps.audit.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+
+		payment, err = ps.repo.RestorePayment(ctx, id)
+		if err != nil {
+			if err == domain.ErrDataNotFound || err == domain.ErrConflictingData {
+				return err
+			}
+			return domain.ErrInternal
+		}
+
+		return ps.audit.RecordAuditLog(ctx, &domain.AuditLog{Action: "payment.restore", Entity: "payment", EntityID: id}, nil, payment)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = ps.cache.DeleteByPrefix(ctx, "payments:*")
+	if err != nil {
+		return nil, domain.ErrInternal
+	}
+
+	return payment, nil
 }
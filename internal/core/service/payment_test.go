@@ -176,7 +176,7 @@ func TestPaymentService_CreatePayment(t *testing.T) {
 
 			tc.mocks(paymentRepo, cache)
 
-			paymentService := service.NewPaymentService(paymentRepo, cache)
+			paymentService := service.NewPaymentService(paymentRepo, cache, newMockAuditService(ctrl))
 
 			payment, err := paymentService.CreatePayment(ctx, tc.input.payment)
 			assert.Equal(t, tc.expected.err, err, "Error mismatch")
@@ -366,7 +366,7 @@ func TestPaymentService_GetPayment(t *testing.T) {
 
 			tc.mocks(paymentRepo, cache)
 
-			paymentService := service.NewPaymentService(paymentRepo, cache)
+			paymentService := service.NewPaymentService(paymentRepo, cache, newMockAuditService(ctrl))
 
 			payment, err := paymentService.GetPayment(ctx, tc.input.id)
 			assert.Equal(t, tc.expected.err, err, "Error mismatch")
@@ -376,12 +376,12 @@ func TestPaymentService_GetPayment(t *testing.T) {
 }
 
 type listPaymentsTestedInput struct {
-	skip  uint64
-	limit uint64
+	params *domain.ListParams
 }
 
 type listPaymentsExpectedOutput struct {
 	payments []domain.Payment
+	total    uint64
 	err      error
 }
 
@@ -400,10 +400,18 @@ func TestPaymentService_ListPayments(t *testing.T) {
 	ctx := context.Background()
 	skip := gofakeit.Uint64()
 	limit := gofakeit.Uint64()
+	total := gofakeit.Uint64()
+	listParams := &domain.ListParams{
+		Skip:  skip,
+		Limit: limit,
+		Sort:  "created_at",
+		Order: domain.Descending,
+	}
+	includeDeleted := false
 
-	params := util.GenerateCacheKeyParams(skip, limit)
+	params := util.GenerateCacheKeyParams(skip, limit, listParams.Sort, listParams.Order, includeDeleted)
 	cacheKey := util.GenerateCacheKey("payments", params)
-	paymentsSerialized, _ := util.Serialize(payments)
+	paymentsSerialized, _ := util.Serialize(domain.Page[domain.Payment]{Items: payments, Total: total})
 	ttl := time.Duration(0)
 
 	testCases := []struct {
@@ -426,11 +434,11 @@ func TestPaymentService_ListPayments(t *testing.T) {
 					Return(paymentsSerialized, nil)
 			},
 			input: listPaymentsTestedInput{
-				skip:  skip,
-				limit: limit,
+				params: listParams,
 			},
 			expected: listPaymentsExpectedOutput{
 				payments: payments,
+				total:    total,
 				err:      nil,
 			},
 		},
@@ -444,19 +452,19 @@ func TestPaymentService_ListPayments(t *testing.T) {
 					Get(gomock.Any(), gomock.Eq(cacheKey)).
 					Return(nil, domain.ErrDataNotFound)
 				paymentRepo.EXPECT().
-					ListPayments(gomock.Any(), gomock.Eq(skip), gomock.Eq(limit)).
-					Return(payments, nil)
-				paymentsSerialized, _ := util.Serialize(payments)
+					ListPayments(gomock.Any(), gomock.Eq(includeDeleted), gomock.Eq(listParams)).
+					Return(payments, total, nil)
+				paymentsSerialized, _ := util.Serialize(domain.Page[domain.Payment]{Items: payments, Total: total})
 				cache.EXPECT().
 					Set(gomock.Any(), gomock.Eq(cacheKey), gomock.Eq(paymentsSerialized), gomock.Eq(ttl)).
 					Return(nil)
 			},
 			input: listPaymentsTestedInput{
-				skip:  skip,
-				limit: limit,
+				params: listParams,
 			},
 			expected: listPaymentsExpectedOutput{
 				payments: payments,
+				total:    total,
 				err:      nil,
 			},
 		},
@@ -471,8 +479,7 @@ func TestPaymentService_ListPayments(t *testing.T) {
 					Return([]byte("invalid"), nil)
 			},
 			input: listPaymentsTestedInput{
-				skip:  skip,
-				limit: limit,
+				params: listParams,
 			},
 			expected: listPaymentsExpectedOutput{
 				payments: nil,
@@ -489,12 +496,11 @@ func TestPaymentService_ListPayments(t *testing.T) {
 					Get(gomock.Any(), gomock.Eq(cacheKey)).
 					Return(nil, domain.ErrDataNotFound)
 				paymentRepo.EXPECT().
-					ListPayments(gomock.Any(), gomock.Eq(skip), gomock.Eq(limit)).
-					Return(nil, domain.ErrInternal)
+					ListPayments(gomock.Any(), gomock.Eq(includeDeleted), gomock.Eq(listParams)).
+					Return(nil, uint64(0), domain.ErrInternal)
 			},
 			input: listPaymentsTestedInput{
-				skip:  skip,
-				limit: limit,
+				params: listParams,
 			},
 			expected: listPaymentsExpectedOutput{
 				payments: nil,
@@ -511,15 +517,14 @@ func TestPaymentService_ListPayments(t *testing.T) {
 					Get(gomock.Any(), gomock.Eq(cacheKey)).
 					Return(nil, domain.ErrDataNotFound)
 				paymentRepo.EXPECT().
-					ListPayments(gomock.Any(), gomock.Eq(skip), gomock.Eq(limit)).
-					Return(payments, nil)
+					ListPayments(gomock.Any(), gomock.Eq(includeDeleted), gomock.Eq(listParams)).
+					Return(payments, total, nil)
 				cache.EXPECT().
 					Set(gomock.Any(), gomock.Eq(cacheKey), gomock.Eq(paymentsSerialized), gomock.Eq(ttl)).
 					Return(domain.ErrInternal)
 			},
 			input: listPaymentsTestedInput{
-				skip:  skip,
-				limit: limit,
+				params: listParams,
 			},
 			expected: listPaymentsExpectedOutput{
 				payments: nil,
@@ -539,11 +544,12 @@ func TestPaymentService_ListPayments(t *testing.T) {
 
 			tc.mocks(paymentRepo, cache)
 
-			paymentService := service.NewPaymentService(paymentRepo, cache)
+			paymentService := service.NewPaymentService(paymentRepo, cache, newMockAuditService(ctrl))
 
-			payments, err := paymentService.ListPayments(ctx, tc.input.skip, tc.input.limit)
+			payments, total, err := paymentService.ListPayments(ctx, includeDeleted, tc.input.params)
 			assert.Equal(t, tc.expected.err, err, "Error mismatch")
 			assert.Equal(t, tc.expected.payments, payments, "Payments mismatch")
+			assert.Equal(t, tc.expected.total, total, "Total mismatch")
 		})
 	}
 }
@@ -641,6 +647,26 @@ func TestPaymentService_UpdatePayment(t *testing.T) {
 				err:     domain.ErrDataNotFound,
 			},
 		},
+		{
+			desc: "Fail_VersionMismatch",
+			mocks: func(
+				paymentRepo *mock.MockPaymentRepository,
+				cache *mock.MockCacheRepository,
+			) {
+				paymentRepo.EXPECT().
+					GetPaymentByID(gomock.Any(), gomock.Eq(paymentID)).
+					Return(&domain.Payment{
+						Version: paymentInput.Version + 1,
+					}, nil)
+			},
+			input: updatePaymentTestedInput{
+				payment: paymentInput,
+			},
+			expected: updatePaymentExpectedOutput{
+				payment: nil,
+				err:     domain.ErrVersionMismatch,
+			},
+		},
 		{
 			desc: "Fail_InternalErrorGetByID",
 			mocks: func(
@@ -832,7 +858,7 @@ func TestPaymentService_UpdatePayment(t *testing.T) {
 
 			tc.mocks(paymentRepo, cache)
 
-			paymentService := service.NewPaymentService(paymentRepo, cache)
+			paymentService := service.NewPaymentService(paymentRepo, cache, newMockAuditService(ctrl))
 
 			payment, err := paymentService.UpdatePayment(ctx, tc.input.payment)
 			assert.Equal(t, tc.expected.err, err, "Error mismatch")
@@ -842,7 +868,8 @@ func TestPaymentService_UpdatePayment(t *testing.T) {
 }
 
 type deletePaymentTestedInput struct {
-	id uint64
+	id      uint64
+	version uint64
 }
 
 type deletePaymentExpectedOutput struct {
@@ -852,6 +879,11 @@ type deletePaymentExpectedOutput struct {
 func TestPaymentService_DeletePayment(t *testing.T) {
 	ctx := context.Background()
 	paymentID := gofakeit.Uint64()
+	version := gofakeit.Uint64()
+	existingPayment := &domain.Payment{
+		ID:      paymentID,
+		Version: version,
+	}
 
 	cacheKey := util.GenerateCacheKey("payment", paymentID)
 
@@ -872,7 +904,7 @@ func TestPaymentService_DeletePayment(t *testing.T) {
 			) {
 				paymentRepo.EXPECT().
 					GetPaymentByID(gomock.Any(), gomock.Eq(paymentID)).
-					Return(&domain.Payment{}, nil)
+					Return(existingPayment, nil)
 				cache.EXPECT().
 					Delete(gomock.Any(), gomock.Eq(cacheKey)).
 					Return(nil)
@@ -880,11 +912,12 @@ func TestPaymentService_DeletePayment(t *testing.T) {
 					DeleteByPrefix(gomock.Any(), gomock.Eq("payments:*")).
 					Return(nil)
 				paymentRepo.EXPECT().
-					DeletePayment(gomock.Any(), gomock.Eq(paymentID)).
+					DeletePayment(gomock.Any(), gomock.Eq(paymentID), gomock.Eq(version)).
 					Return(nil)
 			},
 			input: deletePaymentTestedInput{
-				id: paymentID,
+				id:      paymentID,
+				version: version,
 			},
 			expected: deletePaymentExpectedOutput{
 				err: nil,
@@ -901,12 +934,31 @@ func TestPaymentService_DeletePayment(t *testing.T) {
 					Return(nil, domain.ErrDataNotFound)
 			},
 			input: deletePaymentTestedInput{
-				id: paymentID,
+				id:      paymentID,
+				version: version,
 			},
 			expected: deletePaymentExpectedOutput{
 				err: domain.ErrDataNotFound,
 			},
 		},
+		{
+			desc: "Fail_VersionMismatch",
+			mocks: func(
+				paymentRepo *mock.MockPaymentRepository,
+				cache *mock.MockCacheRepository,
+			) {
+				paymentRepo.EXPECT().
+					GetPaymentByID(gomock.Any(), gomock.Eq(paymentID)).
+					Return(existingPayment, nil)
+			},
+			input: deletePaymentTestedInput{
+				id:      paymentID,
+				version: version + 1,
+			},
+			expected: deletePaymentExpectedOutput{
+				err: domain.ErrVersionMismatch,
+			},
+		},
 		{
 			desc: "Fail_InternalErrorGetByID",
 			mocks: func(
@@ -918,7 +970,8 @@ func TestPaymentService_DeletePayment(t *testing.T) {
 					Return(nil, domain.ErrInternal)
 			},
 			input: deletePaymentTestedInput{
-				id: paymentID,
+				id:      paymentID,
+				version: version,
 			},
 			expected: deletePaymentExpectedOutput{
 				err: domain.ErrInternal,
@@ -932,13 +985,14 @@ func TestPaymentService_DeletePayment(t *testing.T) {
 			) {
 				paymentRepo.EXPECT().
 					GetPaymentByID(gomock.Any(), gomock.Eq(paymentID)).
-					Return(&domain.Payment{}, nil)
+					Return(existingPayment, nil)
 				cache.EXPECT().
 					Delete(gomock.Any(), gomock.Eq(cacheKey)).
 					Return(domain.ErrInternal)
 			},
 			input: deletePaymentTestedInput{
-				id: paymentID,
+				id:      paymentID,
+				version: version,
 			},
 			expected: deletePaymentExpectedOutput{
 				err: domain.ErrInternal,
@@ -952,7 +1006,7 @@ func TestPaymentService_DeletePayment(t *testing.T) {
 			) {
 				paymentRepo.EXPECT().
 					GetPaymentByID(gomock.Any(), gomock.Eq(paymentID)).
-					Return(&domain.Payment{}, nil)
+					Return(existingPayment, nil)
 				cache.EXPECT().
 					Delete(gomock.Any(), gomock.Eq(cacheKey)).
 					Return(nil)
@@ -961,7 +1015,8 @@ func TestPaymentService_DeletePayment(t *testing.T) {
 					Return(domain.ErrInternal)
 			},
 			input: deletePaymentTestedInput{
-				id: paymentID,
+				id:      paymentID,
+				version: version,
 			},
 			expected: deletePaymentExpectedOutput{
 				err: domain.ErrInternal,
@@ -975,7 +1030,7 @@ func TestPaymentService_DeletePayment(t *testing.T) {
 			) {
 				paymentRepo.EXPECT().
 					GetPaymentByID(gomock.Any(), gomock.Eq(paymentID)).
-					Return(&domain.Payment{}, nil)
+					Return(existingPayment, nil)
 				cache.EXPECT().
 					Delete(gomock.Any(), gomock.Eq(cacheKey)).
 					Return(nil)
@@ -983,11 +1038,12 @@ func TestPaymentService_DeletePayment(t *testing.T) {
 					DeleteByPrefix(gomock.Any(), gomock.Eq("payments:*")).
 					Return(nil)
 				paymentRepo.EXPECT().
-					DeletePayment(gomock.Any(), gomock.Eq(paymentID)).
+					DeletePayment(gomock.Any(), gomock.Eq(paymentID), gomock.Eq(version)).
 					Return(domain.ErrInternal)
 			},
 			input: deletePaymentTestedInput{
-				id: paymentID,
+				id:      paymentID,
+				version: version,
 			},
 			expected: deletePaymentExpectedOutput{
 				err: domain.ErrInternal,
@@ -1007,10 +1063,155 @@ func TestPaymentService_DeletePayment(t *testing.T) {
 
 			tc.mocks(paymentRepo, cache)
 
-			paymentService := service.NewPaymentService(paymentRepo, cache)
+			paymentService := service.NewPaymentService(paymentRepo, cache, newMockAuditService(ctrl))
 
-			err := paymentService.DeletePayment(ctx, tc.input.id)
+			err := paymentService.DeletePayment(ctx, tc.input.id, tc.input.version)
 			assert.Equal(t, tc.expected.err, err, "Error mismatch")
 		})
 	}
 }
+
+type restorePaymentTestedInput struct {
+	id uint64
+}
+
+type restorePaymentExpectedOutput struct {
+	payment *domain.Payment
+	err     error
+}
+
+func TestPaymentService_RestorePayment(t *testing.T) {
+	ctx := context.Background()
+	paymentID := gofakeit.Uint64()
+	paymentOutput := &domain.Payment{
+		ID:   paymentID,
+		Name: gofakeit.Name(),
+	}
+
+	testCases := []struct {
+		desc  string
+		mocks func(
+			paymentRepo *mock.MockPaymentRepository,
+			cache *mock.MockCacheRepository,
+		)
+		input    restorePaymentTestedInput
+		expected restorePaymentExpectedOutput
+	}{
+		{
+			desc: "Success",
+			mocks: func(
+				paymentRepo *mock.MockPaymentRepository,
+				cache *mock.MockCacheRepository,
+			) {
+				paymentRepo.EXPECT().
+					RestorePayment(gomock.Any(), gomock.Eq(paymentID)).
+					Return(paymentOutput, nil)
+				cache.EXPECT().
+					DeleteByPrefix(gomock.Any(), gomock.Eq("payments:*")).
+					Return(nil)
+			},
+			input: restorePaymentTestedInput{
+				id: paymentID,
+			},
+			expected: restorePaymentExpectedOutput{
+				payment: paymentOutput,
+				err:     nil,
+			},
+		},
+		{
+			desc: "Fail_NotFound",
+			mocks: func(
+				paymentRepo *mock.MockPaymentRepository,
+				cache *mock.MockCacheRepository,
+			) {
+				paymentRepo.EXPECT().
+					RestorePayment(gomock.Any(), gomock.Eq(paymentID)).
+					Return(nil, domain.ErrDataNotFound)
+			},
+			input: restorePaymentTestedInput{
+				id: paymentID,
+			},
+			expected: restorePaymentExpectedOutput{
+				payment: nil,
+				err:     domain.ErrDataNotFound,
+			},
+		},
+		{
+			desc: "Fail_Conflict",
+			mocks: func(
+				paymentRepo *mock.MockPaymentRepository,
+				cache *mock.MockCacheRepository,
+			) {
+				paymentRepo.EXPECT().
+					RestorePayment(gomock.Any(), gomock.Eq(paymentID)).
+					Return(nil, domain.ErrConflictingData)
+			},
+			input: restorePaymentTestedInput{
+				id: paymentID,
+			},
+			expected: restorePaymentExpectedOutput{
+				payment: nil,
+				err:     domain.ErrConflictingData,
+			},
+		},
+		{
+			desc: "Fail_InternalError",
+			mocks: func(
+				paymentRepo *mock.MockPaymentRepository,
+				cache *mock.MockCacheRepository,
+			) {
+				paymentRepo.EXPECT().
+					RestorePayment(gomock.Any(), gomock.Eq(paymentID)).
+					Return(nil, domain.ErrInternal)
+			},
+			input: restorePaymentTestedInput{
+				id: paymentID,
+			},
+			expected: restorePaymentExpectedOutput{
+				payment: nil,
+				err:     domain.ErrInternal,
+			},
+		},
+		{
+			desc: "Fail_DeleteCacheByPrefix",
+			mocks: func(
+				paymentRepo *mock.MockPaymentRepository,
+				cache *mock.MockCacheRepository,
+			) {
+				paymentRepo.EXPECT().
+					RestorePayment(gomock.Any(), gomock.Eq(paymentID)).
+					Return(paymentOutput, nil)
+				cache.EXPECT().
+					DeleteByPrefix(gomock.Any(), gomock.Eq("payments:*")).
+					Return(domain.ErrInternal)
+			},
+			input: restorePaymentTestedInput{
+				id: paymentID,
+			},
+			expected: restorePaymentExpectedOutput{
+				payment: nil,
+				err:     domain.ErrInternal,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			paymentRepo := mock.NewMockPaymentRepository(ctrl)
+			cache := mock.NewMockCacheRepository(ctrl)
+
+			tc.mocks(paymentRepo, cache)
+
+			paymentService := service.NewPaymentService(paymentRepo, cache, newMockAuditService(ctrl))
+
+			payment, err := paymentService.RestorePayment(ctx, tc.input.id)
+			assert.Equal(t, tc.expected.err, err, "Error mismatch")
+			assert.Equal(t, tc.expected.payment, payment, "Payment mismatch")
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package service
+
+import (
+	"context"
+
+	"github.com/nikhil-shrestha/go-pos/internal/core/domain"
+	"github.com/nikhil-shrestha/go-pos/internal/core/port"
+	"github.com/nikhil-shrestha/go-pos/internal/core/util"
+)
+
+// verifyPin returns the user if the PIN is theirs. Failed attempts are counted per user,
+// whether for a login or an override, and lock the PIN once there are too many
+func verifyPin(ctx context.Context, repo port.UserRepository, cache port.CacheRepository, userID uint64, pin string) (*domain.User, error) {
+	err := pinLockout.check(ctx, cache, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := repo.GetUserByID(ctx, userID)
+	if err != nil && err != domain.ErrDataNotFound {
+		return nil, domain.ErrInternal
+	}
+
+	if user == nil || user.Pin == "" || util.ComparePassword(pin, user.Pin) != nil {
+		err = pinLockout.fail(ctx, cache, userID)
+		if err != nil {
+			return nil, err
+		}
+		return nil, domain.ErrInvalidPin
+	}
+
+	err = pinLockout.reset(ctx, cache, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
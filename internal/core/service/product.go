@@ -11,20 +11,22 @@ import (
 /**
  * ProductService implements port.ProductService and port.CategoryService
  * interfaces and provides an access to the product and category repositories
- * and cache service
+ * cache service and audit service
  */
 type ProductService struct {
 	productRepo  port.ProductRepository
 	categoryRepo port.CategoryRepository
 	cache        port.CacheRepository
+	audit        port.AuditService
 }
 
 // NewProductService creates a new product service instance
-func NewProductService(productRepo port.ProductRepository, categoryRepo port.CategoryRepository, cache port.CacheRepository) *ProductService {
+func NewProductService(productRepo port.ProductRepository, categoryRepo port.CategoryRepository, cache port.CacheRepository, audit port.AuditService) *ProductService {
 	return &ProductService{
 		productRepo,
 		categoryRepo,
 		cache,
+		audit,
 	}
 }
 
@@ -40,12 +42,21 @@ func (ps *ProductService) CreateProduct(ctx context.Context, product *domain.Pro
 
 	product.Category = category
 
-	product, err = ps.productRepo.CreateProduct(ctx, product)
-	if err != nil {
-		if err == domain.ErrConflictingData {
-			return nil, err
+	err = ps.audit.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+
+		product, err = ps.productRepo.CreateProduct(ctx, product)
+		if err != nil {
+			if err == domain.ErrConflictingData {
+				return err
+			}
+			return domain.ErrInternal
 		}
-		return nil, domain.ErrInternal
+
+		return ps.audit.RecordAuditLog(ctx, &domain.AuditLog{Action: "product.create", Entity: "product", EntityID: product.ID}, nil, product)
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	cacheKey := util.GenerateCacheKey("product", product.ID)
@@ -113,52 +124,149 @@ func (ps *ProductService) GetProduct(ctx context.Context, id uint64) (*domain.Pr
 }
 
 // ListProducts retrieves a list of products
-func (ps *ProductService) ListProducts(ctx context.Context, search string, categoryID, skip, limit uint64) ([]domain.Product, error) {
-	var products []domain.Product
+func (ps *ProductService) ListProducts(ctx context.Context, search string, categoryID uint64, includeDeleted bool, params *domain.ListParams) ([]domain.Product, uint64, error) {
+	var page domain.Page[domain.Product]
 
-	params := util.GenerateCacheKeyParams(skip, limit, categoryID, search)
-	cacheKey := util.GenerateCacheKey("products", params)
+	cacheParams := util.GenerateCacheKeyParams(params.Skip, params.Limit, params.Sort, params.Order, includeDeleted, categoryID, search)
+	cacheKey := util.GenerateCacheKey("products", cacheParams)
 
 	cachedProducts, err := ps.cache.Get(ctx, cacheKey)
 	if err == nil {
-		err := util.Deserialize(cachedProducts, &products)
+		err := util.Deserialize(cachedProducts, &page)
 		if err != nil {
-			return nil, domain.ErrInternal
+			return nil, 0, domain.ErrInternal
 		}
-		return products, nil
+		return page.Items, page.Total, nil
 	}
 
-	products, err = ps.productRepo.ListProducts(ctx, search, categoryID, skip, limit)
+	products, total, err := ps.productRepo.ListProducts(ctx, search, categoryID, includeDeleted, params)
 	if err != nil {
-		return nil, domain.ErrInternal
+		return nil, 0, domain.ErrInternal
 	}
 
-	for i, product := range products {
-		category, err := ps.categoryRepo.GetCategoryByID(ctx, product.CategoryID)
+	err = loadCategories(ctx, ps.categoryRepo, products)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	productsSerialized, err := util.Serialize(domain.Page[domain.Product]{Items: products, Total: total})
+	if err != nil {
+		return nil, 0, domain.ErrInternal
+	}
+
+	err = ps.cache.Set(ctx, cacheKey, productsSerialized, 0)
+	if err != nil {
+		return nil, 0, domain.ErrInternal
+	}
+
+	return products, total, nil
+}
+
+// ListProductsByCursor retrieves the products following the cursor
+func (ps *ProductService) ListProductsByCursor(ctx context.Context, search string, categoryID uint64, includeDeleted bool, params *domain.CursorParams) ([]domain.Product, *domain.Cursor, error) {
+	var page domain.CursorPage[domain.Product]
+
+	cacheParams := util.GenerateCacheKeyParams("cursor", params.Cursor, params.Limit, params.Order, includeDeleted, categoryID, search)
+	cacheKey := util.GenerateCacheKey("products", cacheParams)
+
+	cachedProducts, err := ps.cache.Get(ctx, cacheKey)
+	if err == nil {
+		err := util.Deserialize(cachedProducts, &page)
 		if err != nil {
-			if err == domain.ErrDataNotFound {
-				return nil, err
+			return nil, nil, domain.ErrInternal
+		}
+		return page.Items, page.Next, nil
+	}
+
+	products, next, err := ps.productRepo.ListProductsByCursor(ctx, search, categoryID, includeDeleted, params)
+	if err != nil {
+		return nil, nil, domain.ErrInternal
+	}
+
+	err = loadCategories(ctx, ps.categoryRepo, products)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	productsSerialized, err := util.Serialize(domain.CursorPage[domain.Product]{Items: products, Next: next})
+	if err != nil {
+		return nil, nil, domain.ErrInternal
+	}
+
+	err = ps.cache.Set(ctx, cacheKey, productsSerialized, 0)
+	if err != nil {
+		return nil, nil, domain.ErrInternal
+	}
+
+	return products, next, nil
+}
+
+// ExportProducts streams the products matching the filters with their category
+func (ps *ProductService) ExportProducts(ctx context.Context, search string, categoryID uint64, fn func(product *domain.Product) error) error {
+	err := ps.productRepo.StreamProducts(ctx, search, categoryID, fn)
+	if err != nil {
+		return domain.ErrInternal
+	}
+
+	return nil
+}
+
+// ImportProducts creates or updates the products of an import and clears the product caches once it is applied
+func (ps *ProductService) ImportProducts(ctx context.Context, productImport *domain.ProductImport) (*domain.ProductImport, error) {
+	err := ps.audit.WithinTransaction(ctx, func(ctx context.Context) error {
+		err := ps.productRepo.ImportProducts(ctx, productImport)
+		if err != nil {
+			return domain.ErrInternal
+		}
+
+		if !productImport.Applied {
+			return nil
+		}
+
+		for _, row := range productImport.Rows {
+			err = ps.audit.RecordAuditLog(ctx, &domain.AuditLog{Action: "product." + string(row.Action), Entity: "product", EntityID: row.Product.ID}, row.Previous, row.Product)
+			if err != nil {
+				return err
 			}
-			return nil, domain.ErrInternal
 		}
 
-		products[i].Category = category
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if !productImport.Applied {
+		return productImport, nil
+	}
+
+	for _, row := range productImport.Rows {
+		if row.Action != domain.ProductUpdated {
+			continue
+		}
+
+		cacheKey := util.GenerateCacheKey("product", row.Product.ID)
+
+		err = ps.cache.Delete(ctx, cacheKey)
+		if err != nil {
+			return nil, domain.ErrInternal
+		}
 	}
 
-	productsSerialized, err := util.Serialize(products)
+	err = ps.cache.DeleteByPrefix(ctx, "products:*")
 	if err != nil {
 		return nil, domain.ErrInternal
 	}
 
-	err = ps.cache.Set(ctx, cacheKey, productsSerialized, 0)
+	err = ps.cache.DeleteByPrefix(ctx, "categories:*")
 	if err != nil {
 		return nil, domain.ErrInternal
 	}
 
-	return products, nil
+	return productImport, nil
 }
 
-// UpdateProduct updates a product
+// UpdateProduct updates a product, as long as it is still at the version the client read
 func (ps *ProductService) UpdateProduct(ctx context.Context, product *domain.Product) (*domain.Product, error) {
 	existingProduct, err := ps.productRepo.GetProductByID(ctx, product.ID)
 	if err != nil {
@@ -168,14 +276,20 @@ func (ps *ProductService) UpdateProduct(ctx context.Context, product *domain.Pro
 		return nil, domain.ErrInternal
 	}
 
+	if existingProduct.Version != product.Version {
+		return nil, domain.ErrVersionMismatch
+	}
+
 	emptyData := product.CategoryID == 0 &&
 		product.Name == "" &&
+		product.Barcode == "" &&
 		product.Image == "" &&
 		product.Price == 0 &&
 		product.Stock == 0
 
 	sameData := existingProduct.CategoryID == product.CategoryID &&
 		existingProduct.Name == product.Name &&
+		existingProduct.Barcode == product.Barcode &&
 		existingProduct.Image == product.Image &&
 		existingProduct.Price == product.Price &&
 		existingProduct.Stock == product.Stock
@@ -198,12 +312,23 @@ func (ps *ProductService) UpdateProduct(ctx context.Context, product *domain.Pro
 
 	product.Category = category
 
-	_, err = ps.productRepo.UpdateProduct(ctx, product)
-	if err != nil {
-		if err == domain.ErrConflictingData {
-			return nil, err
+	var updatedProduct *domain.Product
+
+	err = ps.audit.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+
+		updatedProduct, err = ps.productRepo.UpdateProduct(ctx, product)
+		if err != nil {
+			if err == domain.ErrConflictingData || err == domain.ErrDataNotFound || err == domain.ErrVersionMismatch {
+				return err
+			}
+			return domain.ErrInternal
 		}
-		return nil, domain.ErrInternal
+
+		return ps.audit.RecordAuditLog(ctx, &domain.AuditLog{Action: "product.update", Entity: "product", EntityID: product.ID}, existingProduct, updatedProduct)
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	cacheKey := util.GenerateCacheKey("product", product.ID)
@@ -231,9 +356,9 @@ func (ps *ProductService) UpdateProduct(ctx context.Context, product *domain.Pro
 	return product, nil
 }
 
-// DeleteProduct deletes a product
-func (ps *ProductService) DeleteProduct(ctx context.Context, id uint64) error {
-	_, err := ps.productRepo.GetProductByID(ctx, id)
+// DeleteProduct soft deletes a product, as long as it is still at the version the client read
+func (ps *ProductService) DeleteProduct(ctx context.Context, id, version uint64) error {
+	existingProduct, err := ps.productRepo.GetProductByID(ctx, id)
 	if err != nil {
 		if err == domain.ErrDataNotFound {
 			return err
@@ -241,6 +366,10 @@ func (ps *ProductService) DeleteProduct(ctx context.Context, id uint64) error {
 		return domain.ErrInternal
 	}
 
+	if existingProduct.Version != version {
+		return domain.ErrVersionMismatch
+	}
+
 	cacheKey := util.GenerateCacheKey("product", id)
 
 	err = ps.cache.Delete(ctx, cacheKey)
@@ -253,5 +382,51 @@ func (ps *ProductService) DeleteProduct(ctx context.Context, id uint64) error {
 		return domain.ErrInternal
 	}
 
-	return ps.productRepo.DeleteProduct(ctx, id)
+	return ps.audit.WithinTransaction(ctx, func(ctx context.Context) error {
+		err := ps.productRepo.DeleteProduct(ctx, id, version)
+		if err != nil {
+			return err
+		}
+
+		return ps.audit.RecordAuditLog(ctx, &domain.AuditLog{Action: "product.delete", Entity: "product", EntityID: id}, existingProduct, nil)
+	})
+}
+
+// RestoreProduct restores a soft deleted product
+func (ps *ProductService) RestoreProduct(ctx context.Context, id uint64) (*domain.Product, error) {
+	var product *domain.Product
+
+	err := ps.audit.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+
+		product, err = ps.productRepo.RestoreProduct(ctx, id)
+		if err != nil {
+			if err == domain.ErrDataNotFound || err == domain.ErrConflictingData {
+				return err
+			}
+			return domain.ErrInternal
+		}
+
+		category, err := ps.categoryRepo.GetCategoryByID(ctx, product.CategoryID)
+		if err != nil {
+			if err == domain.ErrDataNotFound {
+				return err
+			}
+			return domain.ErrInternal
+		}
+
+		product.Category = category
+
+		return ps.audit.RecordAuditLog(ctx, &domain.AuditLog{Action: "product.restore", Entity: "product", EntityID: id}, nil, product)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = ps.cache.DeleteByPrefix(ctx, "products:*")
+	if err != nil {
+		return nil, domain.ErrInternal
+	}
+
+	return product, nil
 }
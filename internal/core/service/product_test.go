@@ -270,7 +270,7 @@ func TestProductService_CreateProduct(t *testing.T) {
 
 			tc.mocks(productRepo, categoryRepo, cache)
 
-			productService := service.NewProductService(productRepo, categoryRepo, cache)
+			productService := service.NewProductService(productRepo, categoryRepo, cache, newMockAuditService(ctrl))
 
 			product, err := productService.CreateProduct(ctx, tc.input.product)
 			assert.Equal(t, tc.expected.err, err, "Error mismatch")
@@ -550,7 +550,7 @@ func TestProductService_GetProduct(t *testing.T) {
 
 			tc.mocks(productRepo, categoryRepo, cache)
 
-			productService := service.NewProductService(productRepo, categoryRepo, cache)
+			productService := service.NewProductService(productRepo, categoryRepo, cache, newMockAuditService(ctrl))
 
 			product, err := productService.GetProduct(ctx, tc.input.id)
 			assert.Equal(t, tc.expected.err, err, "Error mismatch")
@@ -562,12 +562,12 @@ func TestProductService_GetProduct(t *testing.T) {
 type listProductsTestedInput struct {
 	search     string
 	categoryID uint64
-	skip       uint64
-	limit      uint64
+	params     *domain.ListParams
 }
 
 type listProductsExpectedOutput struct {
 	products []domain.Product
+	total    uint64
 	err      error
 }
 
@@ -600,11 +600,19 @@ func TestProductService_ListProducts(t *testing.T) {
 	ctx := context.Background()
 	skip := gofakeit.Uint64()
 	limit := gofakeit.Uint64()
+	total := gofakeit.Uint64()
+	listParams := &domain.ListParams{
+		Skip:  skip,
+		Limit: limit,
+		Sort:  "created_at",
+		Order: domain.Descending,
+	}
 	search := ""
+	includeDeleted := false
 
-	params := util.GenerateCacheKeyParams(skip, limit, categoryID, search)
+	params := util.GenerateCacheKeyParams(skip, limit, listParams.Sort, listParams.Order, includeDeleted, categoryID, search)
 	cacheKey := util.GenerateCacheKey("products", params)
-	productsSerialized, _ := util.Serialize(products)
+	productsSerialized, _ := util.Serialize(domain.Page[domain.Product]{Items: products, Total: total})
 	ttl := time.Duration(0)
 
 	testCases := []struct {
@@ -632,11 +640,11 @@ func TestProductService_ListProducts(t *testing.T) {
 			input: listProductsTestedInput{
 				search:     search,
 				categoryID: categoryID,
-				skip:       skip,
-				limit:      limit,
+				params:     listParams,
 			},
 			expected: listProductsExpectedOutput{
 				products: products,
+				total:    total,
 				err:      nil,
 			},
 		},
@@ -652,16 +660,14 @@ func TestProductService_ListProducts(t *testing.T) {
 					Times(1).
 					Return(nil, domain.ErrDataNotFound)
 				productRepo.EXPECT().
-					ListProducts(gomock.Any(), gomock.Eq(search), gomock.Eq(categoryID), gomock.Eq(skip), gomock.Eq(limit)).
-					Times(1).
-					Return(products, nil)
-				for i := range products {
-					categoryRepo.EXPECT().
-						GetCategoryByID(gomock.Any(), gomock.Eq(products[i].CategoryID)).
-						Times(1).
-						Return(category, nil)
-				}
-				productsSerialized, _ := util.Serialize(products)
+					ListProducts(gomock.Any(), gomock.Eq(search), gomock.Eq(categoryID), gomock.Eq(includeDeleted), gomock.Eq(listParams)).
+					Times(1).
+					Return(products, total, nil)
+				categoryRepo.EXPECT().
+					GetCategoriesByIDs(gomock.Any(), gomock.Eq([]uint64{categoryID})).
+					Times(1).
+					Return([]domain.Category{*category}, nil)
+				productsSerialized, _ := util.Serialize(domain.Page[domain.Product]{Items: products, Total: total})
 				cache.EXPECT().
 					Set(gomock.Any(), gomock.Eq(cacheKey), gomock.Eq(productsSerialized), gomock.Eq(ttl)).
 					Times(1).
@@ -670,11 +676,11 @@ func TestProductService_ListProducts(t *testing.T) {
 			input: listProductsTestedInput{
 				search:     search,
 				categoryID: categoryID,
-				skip:       skip,
-				limit:      limit,
+				params:     listParams,
 			},
 			expected: listProductsExpectedOutput{
 				products: products,
+				total:    total,
 				err:      nil,
 			},
 		},
@@ -693,8 +699,7 @@ func TestProductService_ListProducts(t *testing.T) {
 			input: listProductsTestedInput{
 				search:     search,
 				categoryID: categoryID,
-				skip:       skip,
-				limit:      limit,
+				params:     listParams,
 			},
 			expected: listProductsExpectedOutput{
 				products: nil,
@@ -713,15 +718,14 @@ func TestProductService_ListProducts(t *testing.T) {
 					Times(1).
 					Return(nil, domain.ErrDataNotFound)
 				productRepo.EXPECT().
-					ListProducts(gomock.Any(), gomock.Eq(search), gomock.Eq(categoryID), gomock.Eq(skip), gomock.Eq(limit)).
+					ListProducts(gomock.Any(), gomock.Eq(search), gomock.Eq(categoryID), gomock.Eq(includeDeleted), gomock.Eq(listParams)).
 					Times(1).
-					Return(nil, domain.ErrInternal)
+					Return(nil, uint64(0), domain.ErrInternal)
 			},
 			input: listProductsTestedInput{
 				search:     search,
 				categoryID: categoryID,
-				skip:       skip,
-				limit:      limit,
+				params:     listParams,
 			},
 			expected: listProductsExpectedOutput{
 				products: nil,
@@ -741,19 +745,18 @@ func TestProductService_ListProducts(t *testing.T) {
 					Times(1).
 					Return(nil, domain.ErrDataNotFound)
 				productRepo.EXPECT().
-					ListProducts(gomock.Any(), gomock.Eq(search), gomock.Eq(categoryID), gomock.Eq(skip), gomock.Eq(limit)).
+					ListProducts(gomock.Any(), gomock.Eq(search), gomock.Eq(categoryID), gomock.Eq(includeDeleted), gomock.Eq(listParams)).
 					Times(1).
-					Return(products, nil)
+					Return(products, total, nil)
 				categoryRepo.EXPECT().
-					GetCategoryByID(gomock.Any(), gomock.Eq(products[0].CategoryID)).
+					GetCategoriesByIDs(gomock.Any(), gomock.Eq([]uint64{categoryID})).
 					Times(1).
-					Return(nil, domain.ErrDataNotFound)
+					Return([]domain.Category{}, nil)
 			},
 			input: listProductsTestedInput{
 				search:     search,
 				categoryID: categoryID,
-				skip:       skip,
-				limit:      limit,
+				params:     listParams,
 			},
 			expected: listProductsExpectedOutput{
 				products: nil,
@@ -773,19 +776,18 @@ func TestProductService_ListProducts(t *testing.T) {
 					Times(1).
 					Return(nil, domain.ErrDataNotFound)
 				productRepo.EXPECT().
-					ListProducts(gomock.Any(), gomock.Eq(search), gomock.Eq(categoryID), gomock.Eq(skip), gomock.Eq(limit)).
+					ListProducts(gomock.Any(), gomock.Eq(search), gomock.Eq(categoryID), gomock.Eq(includeDeleted), gomock.Eq(listParams)).
 					Times(1).
-					Return(products, nil)
+					Return(products, total, nil)
 				categoryRepo.EXPECT().
-					GetCategoryByID(gomock.Any(), gomock.Eq(products[0].CategoryID)).
+					GetCategoriesByIDs(gomock.Any(), gomock.Eq([]uint64{categoryID})).
 					Times(1).
 					Return(nil, domain.ErrInternal)
 			},
 			input: listProductsTestedInput{
 				search:     search,
 				categoryID: categoryID,
-				skip:       skip,
-				limit:      limit,
+				params:     listParams,
 			},
 			expected: listProductsExpectedOutput{
 				products: nil,
@@ -805,16 +807,14 @@ func TestProductService_ListProducts(t *testing.T) {
 					Times(1).
 					Return(nil, domain.ErrDataNotFound)
 				productRepo.EXPECT().
-					ListProducts(gomock.Any(), gomock.Eq(search), gomock.Eq(categoryID), gomock.Eq(skip), gomock.Eq(limit)).
-					Times(1).
-					Return(products, nil)
-				for i := range products {
-					categoryRepo.EXPECT().
-						GetCategoryByID(gomock.Any(), gomock.Eq(products[i].CategoryID)).
-						Times(1).
-						Return(category, nil)
-				}
-				productsSerialized, _ := util.Serialize(products)
+					ListProducts(gomock.Any(), gomock.Eq(search), gomock.Eq(categoryID), gomock.Eq(includeDeleted), gomock.Eq(listParams)).
+					Times(1).
+					Return(products, total, nil)
+				categoryRepo.EXPECT().
+					GetCategoriesByIDs(gomock.Any(), gomock.Eq([]uint64{categoryID})).
+					Times(1).
+					Return([]domain.Category{*category}, nil)
+				productsSerialized, _ := util.Serialize(domain.Page[domain.Product]{Items: products, Total: total})
 				cache.EXPECT().
 					Set(gomock.Any(), gomock.Eq(cacheKey), gomock.Eq(productsSerialized), gomock.Eq(ttl)).
 					Times(1).
@@ -823,8 +823,7 @@ func TestProductService_ListProducts(t *testing.T) {
 			input: listProductsTestedInput{
 				search:     search,
 				categoryID: categoryID,
-				skip:       skip,
-				limit:      limit,
+				params:     listParams,
 			},
 			expected: listProductsExpectedOutput{
 				products: nil,
@@ -847,11 +846,299 @@ func TestProductService_ListProducts(t *testing.T) {
 
 			tc.mocks(productRepo, categoryRepo, cache)
 
-			productService := service.NewProductService(productRepo, categoryRepo, cache)
+			productService := service.NewProductService(productRepo, categoryRepo, cache, newMockAuditService(ctrl))
 
-			products, err := productService.ListProducts(ctx, tc.input.search, tc.input.categoryID, tc.input.skip, tc.input.limit)
+			products, total, err := productService.ListProducts(ctx, tc.input.search, tc.input.categoryID, includeDeleted, tc.input.params)
 			assert.Equal(t, tc.expected.err, err, "Error mismatch")
 			assert.Equal(t, tc.expected.products, products, "Products mismatch")
+			assert.Equal(t, tc.expected.total, total, "Total mismatch")
+		})
+	}
+}
+
+type listProductsByCursorTestedInput struct {
+	search     string
+	categoryID uint64
+	params     *domain.CursorParams
+}
+
+type listProductsByCursorExpectedOutput struct {
+	products []domain.Product
+	next     *domain.Cursor
+	err      error
+}
+
+func TestProductService_ListProductsByCursor(t *testing.T) {
+	var products []domain.Product
+
+	categoryID := gofakeit.Uint64()
+	category := &domain.Category{
+		ID:   categoryID,
+		Name: gofakeit.ProductCategory(),
+	}
+
+	for i := 0; i < 5; i++ {
+		productSKU, _ := uuid.NewUUID()
+		products = append(products, domain.Product{
+			ID:         gofakeit.Uint64(),
+			SKU:        productSKU,
+			Name:       gofakeit.ProductName(),
+			Stock:      gofakeit.Int64(),
+			Price:      gofakeit.Float64(),
+			CategoryID: categoryID,
+			Category:   category,
+		})
+	}
+
+	ctx := context.Background()
+	search := gofakeit.ProductName()
+	includeDeleted := false
+	cursorParams := &domain.CursorParams{
+		Cursor: &domain.Cursor{
+			CreatedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			ID:        gofakeit.Uint64(),
+		},
+		Limit: 5,
+		Order: domain.Descending,
+	}
+	next := &domain.Cursor{
+		CreatedAt: time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC),
+		ID:        products[4].ID,
+	}
+
+	params := util.GenerateCacheKeyParams("cursor", cursorParams.Cursor, cursorParams.Limit, cursorParams.Order, includeDeleted, categoryID, search)
+	cacheKey := util.GenerateCacheKey("products", params)
+	productsSerialized, _ := util.Serialize(domain.CursorPage[domain.Product]{Items: products, Next: next})
+	ttl := time.Duration(0)
+
+	testCases := []struct {
+		desc  string
+		mocks func(
+			productRepo *mock.MockProductRepository,
+			categoryRepo *mock.MockCategoryRepository,
+			cache *mock.MockCacheRepository,
+		)
+		input    listProductsByCursorTestedInput
+		expected listProductsByCursorExpectedOutput
+	}{
+		{
+			desc: "Success_FromCache",
+			mocks: func(
+				productRepo *mock.MockProductRepository,
+				categoryRepo *mock.MockCategoryRepository,
+				cache *mock.MockCacheRepository,
+			) {
+				cache.EXPECT().
+					Get(gomock.Any(), gomock.Eq(cacheKey)).
+					Times(1).
+					Return(productsSerialized, nil)
+			},
+			input: listProductsByCursorTestedInput{
+				search:     search,
+				categoryID: categoryID,
+				params:     cursorParams,
+			},
+			expected: listProductsByCursorExpectedOutput{
+				products: products,
+				next:     next,
+				err:      nil,
+			},
+		},
+		{
+			desc: "Success_FromDB",
+			mocks: func(
+				productRepo *mock.MockProductRepository,
+				categoryRepo *mock.MockCategoryRepository,
+				cache *mock.MockCacheRepository,
+			) {
+				cache.EXPECT().
+					Get(gomock.Any(), gomock.Eq(cacheKey)).
+					Times(1).
+					Return(nil, domain.ErrDataNotFound)
+				productRepo.EXPECT().
+					ListProductsByCursor(gomock.Any(), gomock.Eq(search), gomock.Eq(categoryID), gomock.Eq(includeDeleted), gomock.Eq(cursorParams)).
+					Times(1).
+					Return(products, next, nil)
+				categoryRepo.EXPECT().
+					GetCategoriesByIDs(gomock.Any(), gomock.Eq([]uint64{categoryID})).
+					Times(1).
+					Return([]domain.Category{*category}, nil)
+				cache.EXPECT().
+					Set(gomock.Any(), gomock.Eq(cacheKey), gomock.Eq(productsSerialized), gomock.Eq(ttl)).
+					Times(1).
+					Return(nil)
+			},
+			input: listProductsByCursorTestedInput{
+				search:     search,
+				categoryID: categoryID,
+				params:     cursorParams,
+			},
+			expected: listProductsByCursorExpectedOutput{
+				products: products,
+				next:     next,
+				err:      nil,
+			},
+		},
+		{
+			desc: "Fail_InternalError",
+			mocks: func(
+				productRepo *mock.MockProductRepository,
+				categoryRepo *mock.MockCategoryRepository,
+				cache *mock.MockCacheRepository,
+			) {
+				cache.EXPECT().
+					Get(gomock.Any(), gomock.Eq(cacheKey)).
+					Times(1).
+					Return(nil, domain.ErrDataNotFound)
+				productRepo.EXPECT().
+					ListProductsByCursor(gomock.Any(), gomock.Eq(search), gomock.Eq(categoryID), gomock.Eq(includeDeleted), gomock.Eq(cursorParams)).
+					Times(1).
+					Return(nil, nil, domain.ErrInternal)
+			},
+			input: listProductsByCursorTestedInput{
+				search:     search,
+				categoryID: categoryID,
+				params:     cursorParams,
+			},
+			expected: listProductsByCursorExpectedOutput{
+				products: nil,
+				next:     nil,
+				err:      domain.ErrInternal,
+			},
+		},
+		{
+			desc: "Fail_CategoryNotFound",
+			mocks: func(
+				productRepo *mock.MockProductRepository,
+				categoryRepo *mock.MockCategoryRepository,
+				cache *mock.MockCacheRepository,
+			) {
+				cache.EXPECT().
+					Get(gomock.Any(), gomock.Eq(cacheKey)).
+					Times(1).
+					Return(nil, domain.ErrDataNotFound)
+				productRepo.EXPECT().
+					ListProductsByCursor(gomock.Any(), gomock.Eq(search), gomock.Eq(categoryID), gomock.Eq(includeDeleted), gomock.Eq(cursorParams)).
+					Times(1).
+					Return(products, next, nil)
+				categoryRepo.EXPECT().
+					GetCategoriesByIDs(gomock.Any(), gomock.Eq([]uint64{categoryID})).
+					Times(1).
+					Return([]domain.Category{}, nil)
+			},
+			input: listProductsByCursorTestedInput{
+				search:     search,
+				categoryID: categoryID,
+				params:     cursorParams,
+			},
+			expected: listProductsByCursorExpectedOutput{
+				products: nil,
+				next:     nil,
+				err:      domain.ErrDataNotFound,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			// t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			productRepo := mock.NewMockProductRepository(ctrl)
+			categoryRepo := mock.NewMockCategoryRepository(ctrl)
+			cache := mock.NewMockCacheRepository(ctrl)
+
+			tc.mocks(productRepo, categoryRepo, cache)
+
+			productService := service.NewProductService(productRepo, categoryRepo, cache, newMockAuditService(ctrl))
+
+			products, next, err := productService.ListProductsByCursor(ctx, tc.input.search, tc.input.categoryID, includeDeleted, tc.input.params)
+			assert.Equal(t, tc.expected.err, err, "Error mismatch")
+			assert.Equal(t, tc.expected.products, products, "Products mismatch")
+			assert.Equal(t, tc.expected.next, next, "Next cursor mismatch")
+		})
+	}
+}
+
+func TestProductService_ExportProducts(t *testing.T) {
+	var products []domain.Product
+
+	ctx := context.Background()
+	search := gofakeit.Name()
+	categoryID := gofakeit.Uint64()
+
+	for i := 0; i < 10; i++ {
+		productSKU, _ := uuid.NewUUID()
+		products = append(products, domain.Product{
+			ID:         gofakeit.Uint64(),
+			SKU:        productSKU,
+			Name:       gofakeit.ProductName(),
+			CategoryID: categoryID,
+		})
+	}
+
+	testCases := []struct {
+		desc     string
+		mocks    func(productRepo *mock.MockProductRepository)
+		expected []domain.Product
+		err      error
+	}{
+		{
+			desc: "Success",
+			mocks: func(productRepo *mock.MockProductRepository) {
+				productRepo.EXPECT().
+					StreamProducts(gomock.Any(), gomock.Eq(search), gomock.Eq(categoryID), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(ctx context.Context, search string, categoryID uint64, fn func(product *domain.Product) error) error {
+						for i := range products {
+							err := fn(&products[i])
+							if err != nil {
+								return err
+							}
+						}
+						return nil
+					})
+			},
+			expected: products,
+			err:      nil,
+		},
+		{
+			desc: "Fail_InternalError",
+			mocks: func(productRepo *mock.MockProductRepository) {
+				productRepo.EXPECT().
+					StreamProducts(gomock.Any(), gomock.Eq(search), gomock.Eq(categoryID), gomock.Any()).
+					Times(1).
+					Return(domain.ErrInternal)
+			},
+			expected: nil,
+			err:      domain.ErrInternal,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			productRepo := mock.NewMockProductRepository(ctrl)
+			categoryRepo := mock.NewMockCategoryRepository(ctrl)
+			cache := mock.NewMockCacheRepository(ctrl)
+
+			tc.mocks(productRepo)
+
+			productService := service.NewProductService(productRepo, categoryRepo, cache, newMockAuditService(ctrl))
+
+			var exported []domain.Product
+			err := productService.ExportProducts(ctx, search, categoryID, func(product *domain.Product) error {
+				exported = append(exported, *product)
+				return nil
+			})
+			assert.Equal(t, tc.err, err, "Error mismatch")
+			assert.Equal(t, tc.expected, exported, "Products mismatch")
 		})
 	}
 }
@@ -987,6 +1274,29 @@ func TestProductService_UpdateProduct(t *testing.T) {
 				err:     domain.ErrDataNotFound,
 			},
 		},
+		{
+			desc: "Fail_VersionMismatch",
+			mocks: func(
+				productRepo *mock.MockProductRepository,
+				categoryRepo *mock.MockCategoryRepository,
+				cache *mock.MockCacheRepository,
+			) {
+
+				productRepo.EXPECT().
+					GetProductByID(gomock.Any(), gomock.Eq(productID)).
+					Times(1).
+					Return(&domain.Product{
+						Version: productInput.Version + 1,
+					}, nil)
+			},
+			input: updateProductTestedInput{
+				product: productInput,
+			},
+			expected: updateProductExpectedOutput{
+				product: nil,
+				err:     domain.ErrVersionMismatch,
+			},
+		},
 		{
 			desc: "Fail_InternalError",
 			mocks: func(
@@ -1285,7 +1595,7 @@ func TestProductService_UpdateProduct(t *testing.T) {
 
 			tc.mocks(productRepo, categoryRepo, cache)
 
-			productService := service.NewProductService(productRepo, categoryRepo, cache)
+			productService := service.NewProductService(productRepo, categoryRepo, cache, newMockAuditService(ctrl))
 
 			product, err := productService.UpdateProduct(ctx, tc.input.product)
 			assert.Equal(t, tc.expected.err, err, "Error mismatch")
@@ -1295,7 +1605,8 @@ func TestProductService_UpdateProduct(t *testing.T) {
 }
 
 type deleteProductTestedInput struct {
-	id uint64
+	id      uint64
+	version uint64
 }
 
 type deleteProductExpectedOutput struct {
@@ -1305,6 +1616,11 @@ type deleteProductExpectedOutput struct {
 func TestProductService_DeleteProduct(t *testing.T) {
 	ctx := context.Background()
 	productID := gofakeit.Uint64()
+	version := gofakeit.Uint64()
+	existingProduct := &domain.Product{
+		ID:      productID,
+		Version: version,
+	}
 
 	cacheKey := util.GenerateCacheKey("product", productID)
 
@@ -1328,7 +1644,7 @@ func TestProductService_DeleteProduct(t *testing.T) {
 				productRepo.EXPECT().
 					GetProductByID(gomock.Any(), gomock.Eq(productID)).
 					Times(1).
-					Return(nil, nil)
+					Return(existingProduct, nil)
 				cache.EXPECT().
 					Delete(gomock.Any(), gomock.Eq(cacheKey)).
 					Times(1).
@@ -1338,12 +1654,13 @@ func TestProductService_DeleteProduct(t *testing.T) {
 					Times(1).
 					Return(nil)
 				productRepo.EXPECT().
-					DeleteProduct(gomock.Any(), gomock.Eq(productID)).
+					DeleteProduct(gomock.Any(), gomock.Eq(productID), gomock.Eq(version)).
 					Times(1).
 					Return(nil)
 			},
 			input: deleteProductTestedInput{
-				id: productID,
+				id:      productID,
+				version: version,
 			},
 			expected: deleteProductExpectedOutput{
 				err: nil,
@@ -1362,12 +1679,33 @@ func TestProductService_DeleteProduct(t *testing.T) {
 					Return(nil, domain.ErrDataNotFound)
 			},
 			input: deleteProductTestedInput{
-				id: productID,
+				id:      productID,
+				version: version,
 			},
 			expected: deleteProductExpectedOutput{
 				err: domain.ErrDataNotFound,
 			},
 		},
+		{
+			desc: "Fail_VersionMismatch",
+			mocks: func(
+				productRepo *mock.MockProductRepository,
+				categoryRepo *mock.MockCategoryRepository,
+				cache *mock.MockCacheRepository,
+			) {
+				productRepo.EXPECT().
+					GetProductByID(gomock.Any(), gomock.Eq(productID)).
+					Times(1).
+					Return(existingProduct, nil)
+			},
+			input: deleteProductTestedInput{
+				id:      productID,
+				version: version + 1,
+			},
+			expected: deleteProductExpectedOutput{
+				err: domain.ErrVersionMismatch,
+			},
+		},
 		{
 			desc: "Fail_InternalErrorGetByID",
 			mocks: func(
@@ -1381,7 +1719,8 @@ func TestProductService_DeleteProduct(t *testing.T) {
 					Return(nil, domain.ErrInternal)
 			},
 			input: deleteProductTestedInput{
-				id: productID,
+				id:      productID,
+				version: version,
 			},
 			expected: deleteProductExpectedOutput{
 				err: domain.ErrInternal,
@@ -1397,14 +1736,15 @@ func TestProductService_DeleteProduct(t *testing.T) {
 				productRepo.EXPECT().
 					GetProductByID(gomock.Any(), gomock.Eq(productID)).
 					Times(1).
-					Return(nil, nil)
+					Return(existingProduct, nil)
 				cache.EXPECT().
 					Delete(gomock.Any(), gomock.Eq(cacheKey)).
 					Times(1).
 					Return(domain.ErrInternal)
 			},
 			input: deleteProductTestedInput{
-				id: productID,
+				id:      productID,
+				version: version,
 			},
 			expected: deleteProductExpectedOutput{
 				err: domain.ErrInternal,
@@ -1420,7 +1760,7 @@ func TestProductService_DeleteProduct(t *testing.T) {
 				productRepo.EXPECT().
 					GetProductByID(gomock.Any(), gomock.Eq(productID)).
 					Times(1).
-					Return(nil, nil)
+					Return(existingProduct, nil)
 				cache.EXPECT().
 					Delete(gomock.Any(), gomock.Eq(cacheKey)).
 					Times(1).
@@ -1431,7 +1771,8 @@ func TestProductService_DeleteProduct(t *testing.T) {
 					Return(domain.ErrInternal)
 			},
 			input: deleteProductTestedInput{
-				id: productID,
+				id:      productID,
+				version: version,
 			},
 			expected: deleteProductExpectedOutput{
 				err: domain.ErrInternal,
@@ -1447,7 +1788,7 @@ func TestProductService_DeleteProduct(t *testing.T) {
 				productRepo.EXPECT().
 					GetProductByID(gomock.Any(), gomock.Eq(productID)).
 					Times(1).
-					Return(nil, nil)
+					Return(existingProduct, nil)
 				cache.EXPECT().
 					Delete(gomock.Any(), gomock.Eq(cacheKey)).
 					Times(1).
@@ -1457,12 +1798,13 @@ func TestProductService_DeleteProduct(t *testing.T) {
 					Times(1).
 					Return(nil)
 				productRepo.EXPECT().
-					DeleteProduct(gomock.Any(), gomock.Eq(productID)).
+					DeleteProduct(gomock.Any(), gomock.Eq(productID), gomock.Eq(version)).
 					Times(1).
 					Return(domain.ErrInternal)
 			},
 			input: deleteProductTestedInput{
-				id: productID,
+				id:      productID,
+				version: version,
 			},
 			expected: deleteProductExpectedOutput{
 				err: domain.ErrInternal,
@@ -1483,10 +1825,371 @@ func TestProductService_DeleteProduct(t *testing.T) {
 
 			tc.mocks(productRepo, categoryRepo, cache)
 
-			productService := service.NewProductService(productRepo, categoryRepo, cache)
+			productService := service.NewProductService(productRepo, categoryRepo, cache, newMockAuditService(ctrl))
+
+			err := productService.DeleteProduct(ctx, tc.input.id, tc.input.version)
+			assert.Equal(t, tc.expected.err, err, "Error mismatch")
+		})
+	}
+}
+
+type restoreProductTestedInput struct {
+	id uint64
+}
+
+type restoreProductExpectedOutput struct {
+	product *domain.Product
+	err     error
+}
+
+func TestProductService_RestoreProduct(t *testing.T) {
+	ctx := context.Background()
+	productID := gofakeit.Uint64()
+	categoryID := gofakeit.Uint64()
+	category := &domain.Category{
+		ID:   categoryID,
+		Name: gofakeit.ProductCategory(),
+	}
+
+	restoredProduct := &domain.Product{
+		ID:         productID,
+		CategoryID: categoryID,
+		Name:       gofakeit.ProductName(),
+		Stock:      gofakeit.Int64(),
+		Price:      gofakeit.Float64(),
+	}
+	productOutput := &domain.Product{
+		ID:         productID,
+		CategoryID: categoryID,
+		Name:       restoredProduct.Name,
+		Stock:      restoredProduct.Stock,
+		Price:      restoredProduct.Price,
+		Category:   category,
+	}
+
+	testCases := []struct {
+		desc  string
+		mocks func(
+			productRepo *mock.MockProductRepository,
+			categoryRepo *mock.MockCategoryRepository,
+			cache *mock.MockCacheRepository,
+		)
+		input    restoreProductTestedInput
+		expected restoreProductExpectedOutput
+	}{
+		{
+			desc: "Success",
+			mocks: func(
+				productRepo *mock.MockProductRepository,
+				categoryRepo *mock.MockCategoryRepository,
+				cache *mock.MockCacheRepository,
+			) {
+				productRepo.EXPECT().
+					RestoreProduct(gomock.Any(), gomock.Eq(productID)).
+					Times(1).
+					Return(restoredProduct, nil)
+				categoryRepo.EXPECT().
+					GetCategoryByID(gomock.Any(), gomock.Eq(categoryID)).
+					Times(1).
+					Return(category, nil)
+				cache.EXPECT().
+					DeleteByPrefix(gomock.Any(), gomock.Eq("products:*")).
+					Times(1).
+					Return(nil)
+			},
+			input: restoreProductTestedInput{
+				id: productID,
+			},
+			expected: restoreProductExpectedOutput{
+				product: productOutput,
+				err:     nil,
+			},
+		},
+		{
+			desc: "Fail_NotFound",
+			mocks: func(
+				productRepo *mock.MockProductRepository,
+				categoryRepo *mock.MockCategoryRepository,
+				cache *mock.MockCacheRepository,
+			) {
+				productRepo.EXPECT().
+					RestoreProduct(gomock.Any(), gomock.Eq(productID)).
+					Times(1).
+					Return(nil, domain.ErrDataNotFound)
+			},
+			input: restoreProductTestedInput{
+				id: productID,
+			},
+			expected: restoreProductExpectedOutput{
+				product: nil,
+				err:     domain.ErrDataNotFound,
+			},
+		},
+		{
+			desc: "Fail_Conflict",
+			mocks: func(
+				productRepo *mock.MockProductRepository,
+				categoryRepo *mock.MockCategoryRepository,
+				cache *mock.MockCacheRepository,
+			) {
+				productRepo.EXPECT().
+					RestoreProduct(gomock.Any(), gomock.Eq(productID)).
+					Times(1).
+					Return(nil, domain.ErrConflictingData)
+			},
+			input: restoreProductTestedInput{
+				id: productID,
+			},
+			expected: restoreProductExpectedOutput{
+				product: nil,
+				err:     domain.ErrConflictingData,
+			},
+		},
+		{
+			desc: "Fail_InternalError",
+			mocks: func(
+				productRepo *mock.MockProductRepository,
+				categoryRepo *mock.MockCategoryRepository,
+				cache *mock.MockCacheRepository,
+			) {
+				productRepo.EXPECT().
+					RestoreProduct(gomock.Any(), gomock.Eq(productID)).
+					Times(1).
+					Return(nil, domain.ErrInternal)
+			},
+			input: restoreProductTestedInput{
+				id: productID,
+			},
+			expected: restoreProductExpectedOutput{
+				product: nil,
+				err:     domain.ErrInternal,
+			},
+		},
+		{
+			desc: "Fail_CategoryNotFound",
+			mocks: func(
+				productRepo *mock.MockProductRepository,
+				categoryRepo *mock.MockCategoryRepository,
+				cache *mock.MockCacheRepository,
+			) {
+				productRepo.EXPECT().
+					RestoreProduct(gomock.Any(), gomock.Eq(productID)).
+					Times(1).
+					Return(restoredProduct, nil)
+				categoryRepo.EXPECT().
+					GetCategoryByID(gomock.Any(), gomock.Eq(categoryID)).
+					Times(1).
+					Return(nil, domain.ErrDataNotFound)
+			},
+			input: restoreProductTestedInput{
+				id: productID,
+			},
+			expected: restoreProductExpectedOutput{
+				product: nil,
+				err:     domain.ErrDataNotFound,
+			},
+		},
+		{
+			desc: "Fail_DeleteCacheByPrefix",
+			mocks: func(
+				productRepo *mock.MockProductRepository,
+				categoryRepo *mock.MockCategoryRepository,
+				cache *mock.MockCacheRepository,
+			) {
+				productRepo.EXPECT().
+					RestoreProduct(gomock.Any(), gomock.Eq(productID)).
+					Times(1).
+					Return(restoredProduct, nil)
+				categoryRepo.EXPECT().
+					GetCategoryByID(gomock.Any(), gomock.Eq(categoryID)).
+					Times(1).
+					Return(category, nil)
+				cache.EXPECT().
+					DeleteByPrefix(gomock.Any(), gomock.Eq("products:*")).
+					Times(1).
+					Return(domain.ErrInternal)
+			},
+			input: restoreProductTestedInput{
+				id: productID,
+			},
+			expected: restoreProductExpectedOutput{
+				product: nil,
+				err:     domain.ErrInternal,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			productRepo := mock.NewMockProductRepository(ctrl)
+			categoryRepo := mock.NewMockCategoryRepository(ctrl)
+			cache := mock.NewMockCacheRepository(ctrl)
+
+			tc.mocks(productRepo, categoryRepo, cache)
+
+			productService := service.NewProductService(productRepo, categoryRepo, cache, newMockAuditService(ctrl))
 
-			err := productService.DeleteProduct(ctx, tc.input.id)
+			product, err := productService.RestoreProduct(ctx, tc.input.id)
 			assert.Equal(t, tc.expected.err, err, "Error mismatch")
+			assert.Equal(t, tc.expected.product, product, "Product mismatch")
+		})
+	}
+}
+
+func TestProductService_ImportProducts(t *testing.T) {
+	ctx := context.Background()
+	productID := gofakeit.Uint64()
+	productCacheKey := util.GenerateCacheKey("product", productID)
+	previousName := gofakeit.ProductName()
+
+	newImport := func(dryRun bool) *domain.ProductImport {
+		return &domain.ProductImport{
+			DryRun: dryRun,
+			Rows: []domain.ProductImportRow{
+				{
+					Line: 2,
+					Product: &domain.Product{
+						Name:     gofakeit.ProductName(),
+						Category: &domain.Category{Name: gofakeit.ProductCategory()},
+					},
+				},
+				{
+					Line: 3,
+					Product: &domain.Product{
+						Name:     gofakeit.ProductName(),
+						Category: &domain.Category{Name: gofakeit.ProductCategory()},
+					},
+				},
+			},
+		}
+	}
+
+	apply := func(ctx context.Context, productImport *domain.ProductImport) error {
+		productImport.Rows[0].Action = domain.ProductCreated
+		productImport.Rows[0].Product.ID = gofakeit.Uint64()
+		productImport.Rows[1].Action = domain.ProductUpdated
+		productImport.Rows[1].Product.ID = productID
+		productImport.Rows[1].Previous = &domain.Product{ID: productID, Name: previousName}
+		productImport.Applied = !productImport.DryRun
+		return nil
+	}
+
+	testCases := []struct {
+		desc    string
+		mocks   func(productRepo *mock.MockProductRepository, cache *mock.MockCacheRepository, auditRepo *mock.MockAuditRepository)
+		dryRun  bool
+		applied bool
+		err     error
+	}{
+		{
+			desc: "Success_Applied",
+			mocks: func(productRepo *mock.MockProductRepository, cache *mock.MockCacheRepository, auditRepo *mock.MockAuditRepository) {
+				productRepo.EXPECT().
+					ImportProducts(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(apply)
+				auditRepo.EXPECT().
+					CreateAuditLog(gomock.Any(), gomock.Any()).
+					Times(2).
+					DoAndReturn(func(ctx context.Context, auditLog *domain.AuditLog) (*domain.AuditLog, error) {
+						if auditLog.Action == "product.update" {
+							assert.Equal(t, previousName, auditLog.Changes["Name"].Before, "Previous name mismatch")
+						}
+						return auditLog, nil
+					})
+				cache.EXPECT().
+					Delete(gomock.Any(), gomock.Eq(productCacheKey)).
+					Times(1).
+					Return(nil)
+				cache.EXPECT().
+					DeleteByPrefix(gomock.Any(), gomock.Eq("products:*")).
+					Times(1).
+					Return(nil)
+				cache.EXPECT().
+					DeleteByPrefix(gomock.Any(), gomock.Eq("categories:*")).
+					Times(1).
+					Return(nil)
+			},
+			dryRun:  false,
+			applied: true,
+			err:     nil,
+		},
+		{
+			desc: "Success_DryRun",
+			mocks: func(productRepo *mock.MockProductRepository, cache *mock.MockCacheRepository, auditRepo *mock.MockAuditRepository) {
+				productRepo.EXPECT().
+					ImportProducts(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(apply)
+			},
+			dryRun:  true,
+			applied: false,
+			err:     nil,
+		},
+		{
+			desc: "Fail_InternalError",
+			mocks: func(productRepo *mock.MockProductRepository, cache *mock.MockCacheRepository, auditRepo *mock.MockAuditRepository) {
+				productRepo.EXPECT().
+					ImportProducts(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(domain.ErrInternal)
+			},
+			dryRun: false,
+			err:    domain.ErrInternal,
+		},
+		{
+			desc: "Fail_DeleteCacheByPrefix",
+			mocks: func(productRepo *mock.MockProductRepository, cache *mock.MockCacheRepository, auditRepo *mock.MockAuditRepository) {
+				productRepo.EXPECT().
+					ImportProducts(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(apply)
+				auditRepo.EXPECT().
+					CreateAuditLog(gomock.Any(), gomock.Any()).
+					Times(2).
+					Return(&domain.AuditLog{}, nil)
+				cache.EXPECT().
+					Delete(gomock.Any(), gomock.Eq(productCacheKey)).
+					Times(1).
+					Return(nil)
+				cache.EXPECT().
+					DeleteByPrefix(gomock.Any(), gomock.Eq("products:*")).
+					Times(1).
+					Return(domain.ErrInternal)
+			},
+			dryRun: false,
+			err:    domain.ErrInternal,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			productRepo := mock.NewMockProductRepository(ctrl)
+			categoryRepo := mock.NewMockCategoryRepository(ctrl)
+			cache := mock.NewMockCacheRepository(ctrl)
+
+			auditRepo := mock.NewMockAuditRepository(ctrl)
+
+			tc.mocks(productRepo, cache, auditRepo)
+
+			productService := service.NewProductService(productRepo, categoryRepo, cache, newAuditService(ctrl, auditRepo))
+
+			productImport, err := productService.ImportProducts(ctx, newImport(tc.dryRun))
+			assert.Equal(t, tc.err, err, "Error mismatch")
+
+			if tc.err == nil {
+				assert.Equal(t, tc.applied, productImport.Applied, "Applied mismatch")
+			}
 		})
 	}
 }
@@ -0,0 +1,274 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/nikhil-shrestha/go-pos/internal/core/domain"
+	"github.com/nikhil-shrestha/go-pos/internal/core/port"
+	"github.com/nikhil-shrestha/go-pos/internal/core/util"
+)
+
+/**
+ * RoleService implements port.RoleService interface
+ * and provides an access to the role repository,
+ * cache service and audit service
+ */
+type RoleService struct {
+	repo            port.RoleRepository
+	cache           port.CacheRepository
+	audit           port.AuditService
+	refreshDuration time.Duration
+}
+
+// NewRoleService creates a new role service instance
+func NewRoleService(repo port.RoleRepository, cache port.CacheRepository, audit port.AuditService, refreshDuration time.Duration) *RoleService {
+	return &RoleService{
+		repo,
+		cache,
+		audit,
+		refreshDuration,
+	}
+}
+
+// CreateRole creates a new role
+func (rs *RoleService) CreateRole(ctx context.Context, role *domain.Role) (*domain.Role, error) {
+	err := rs.audit.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+
+		role, err = rs.repo.CreateRole(ctx, role)
+		if err != nil {
+			if err == domain.ErrConflictingData {
+				return err
+			}
+			return domain.ErrInternal
+		}
+
+		return rs.audit.RecordAuditLog(ctx, &domain.AuditLog{Action: "role.create", Entity: "role", EntityID: role.ID}, nil, role)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	cacheKey := util.GenerateCacheKey("role", role.ID)
+	roleSerialized, err := util.Serialize(role)
+	if err != nil {
+		return nil, domain.ErrInternal
+	}
+
+	err = rs.cache.Set(ctx, cacheKey, roleSerialized, 0)
+	if err != nil {
+		return nil, domain.ErrInternal
+	}
+
+	err = rs.cache.DeleteByPrefix(ctx, "roles:*")
+	if err != nil {
+		return nil, domain.ErrInternal
+	}
+
+	return role, nil
+}
+
+// GetRole retrieves a role by id
+func (rs *RoleService) GetRole(ctx context.Context, id uint64) (*domain.Role, error) {
+	var role *domain.Role
+
+	cacheKey := util.GenerateCacheKey("role", id)
+	cachedRole, err := rs.cache.Get(ctx, cacheKey)
+	if err == nil {
+		err := util.Deserialize(cachedRole, &role)
+		if err != nil {
+			return nil, domain.ErrInternal
+		}
+		return role, nil
+	}
+
+	role, err = rs.repo.GetRoleByID(ctx, id)
+	if err != nil {
+		if err == domain.ErrDataNotFound {
+			return nil, err
+		}
+		return nil, domain.ErrInternal
+	}
+
+	roleSerialized, err := util.Serialize(role)
+	if err != nil {
+		return nil, domain.ErrInternal
+	}
+
+	err = rs.cache.Set(ctx, cacheKey, roleSerialized, 0)
+	if err != nil {
+		return nil, domain.ErrInternal
+	}
+
+	return role, nil
+}
+
+// ListRoles retrieves a list of roles
+func (rs *RoleService) ListRoles(ctx context.Context, params *domain.ListParams) ([]domain.Role, uint64, error) {
+	var page domain.Page[domain.Role]
+
+	cacheParams := util.GenerateCacheKeyParams(params.Skip, params.Limit, params.Sort, params.Order)
+	cacheKey := util.GenerateCacheKey("roles", cacheParams)
+
+	cachedRoles, err := rs.cache.Get(ctx, cacheKey)
+	if err == nil {
+		err := util.Deserialize(cachedRoles, &page)
+		if err != nil {
+			return nil, 0, domain.ErrInternal
+		}
+		return page.Items, page.Total, nil
+	}
+
+	roles, total, err := rs.repo.ListRoles(ctx, params)
+	if err != nil {
+		return nil, 0, domain.ErrInternal
+	}
+
+	rolesSerialized, err := util.Serialize(domain.Page[domain.Role]{Items: roles, Total: total})
+	if err != nil {
+		return nil, 0, domain.ErrInternal
+	}
+
+	err = rs.cache.Set(ctx, cacheKey, rolesSerialized, 0)
+	if err != nil {
+		return nil, 0, domain.ErrInternal
+	}
+
+	return roles, total, nil
+}
+
+// UpdateRole renames a role or replaces its permissions. The permissions of a user's tokens
+// only change once the user logs in again or refreshes the access token
+func (rs *RoleService) UpdateRole(ctx context.Context, role *domain.Role) (*domain.Role, error) {
+	existingRole, err := rs.repo.GetRoleByID(ctx, role.ID)
+	if err != nil {
+		if err == domain.ErrDataNotFound {
+			return nil, err
+		}
+		return nil, domain.ErrInternal
+	}
+
+	emptyData := role.Name == "" && role.Permissions == nil
+	sameName := role.Name == "" || existingRole.Name == role.Name
+	samePermissions := role.Permissions == nil || slices.Equal(existingRole.Permissions, role.Permissions)
+	sameData := sameName && samePermissions
+	if emptyData || sameData {
+		return nil, domain.ErrNoUpdatedData
+	}
+
+	renamedBuiltIn := existingRole.IsBuiltIn() && !sameName
+	changedAdmin := existingRole.Name == domain.Admin && !samePermissions
+	if renamedBuiltIn || changedAdmin {
+		return nil, domain.ErrBuiltInRole
+	}
+
+	var updatedRole *domain.Role
+
+	err = rs.audit.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+
+		updatedRole, err = rs.repo.UpdateRole(ctx, role)
+		if err != nil {
+			if err == domain.ErrConflictingData {
+				return err
+			}
+			return domain.ErrInternal
+		}
+
+		return rs.audit.RecordAuditLog(ctx, &domain.AuditLog{Action: "role.update", Entity: "role", EntityID: role.ID}, existingRole, updatedRole)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	cacheKey := util.GenerateCacheKey("role", role.ID)
+
+	err = rs.cache.Delete(ctx, cacheKey)
+	if err != nil {
+		return nil, domain.ErrInternal
+	}
+
+	roleSerialized, err := util.Serialize(role)
+	if err != nil {
+		return nil, domain.ErrInternal
+	}
+
+	err = rs.cache.Set(ctx, cacheKey, roleSerialized, 0)
+	if err != nil {
+		return nil, domain.ErrInternal
+	}
+
+	err = rs.cache.DeleteByPrefix(ctx, "roles:*")
+	if err != nil {
+		return nil, domain.ErrInternal
+	}
+
+	// Access tokens carry the permissions of the role they were issued with, under the name it had then
+	if !samePermissions {
+		err = revokeRole(ctx, rs.cache, existingRole.Name, rs.refreshDuration)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Renaming a role renames it for its users too
+	if !sameName {
+		err = rs.cache.DeleteByPrefix(ctx, "user:*")
+		if err != nil {
+			return nil, domain.ErrInternal
+		}
+
+		err = rs.cache.DeleteByPrefix(ctx, "users:*")
+		if err != nil {
+			return nil, domain.ErrInternal
+		}
+	}
+
+	return role, nil
+}
+
+// DeleteRole deletes a role that no user has
+func (rs *RoleService) DeleteRole(ctx context.Context, id uint64) error {
+	existingRole, err := rs.repo.GetRoleByID(ctx, id)
+	if err != nil {
+		if err == domain.ErrDataNotFound {
+			return err
+		}
+		return domain.ErrInternal
+	}
+
+	if existingRole.IsBuiltIn() {
+		return domain.ErrBuiltInRole
+	}
+
+	err = rs.audit.WithinTransaction(ctx, func(ctx context.Context) error {
+		err := rs.repo.DeleteRole(ctx, id)
+		if err != nil {
+			if err == domain.ErrConflictingData {
+				return err
+			}
+			return domain.ErrInternal
+		}
+
+		return rs.audit.RecordAuditLog(ctx, &domain.AuditLog{Action: "role.delete", Entity: "role", EntityID: id}, existingRole, nil)
+	})
+	if err != nil {
+		return err
+	}
+
+	cacheKey := util.GenerateCacheKey("role", id)
+
+	err = rs.cache.Delete(ctx, cacheKey)
+	if err != nil {
+		return domain.ErrInternal
+	}
+
+	err = rs.cache.DeleteByPrefix(ctx, "roles:*")
+	if err != nil {
+		return domain.ErrInternal
+	}
+
+	return nil
+}
@@ -0,0 +1,257 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/nikhil-shrestha/go-pos/internal/core/domain"
+	"github.com/nikhil-shrestha/go-pos/internal/core/port/mock"
+	"github.com/nikhil-shrestha/go-pos/internal/core/service"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+type updateRoleTestedInput struct {
+	role *domain.Role
+}
+
+type updateRoleExpectedOutput struct {
+	role *domain.Role
+	err  error
+}
+
+func TestRoleService_UpdateRole(t *testing.T) {
+	ctx := context.Background()
+	adminRole := &domain.Role{
+		ID:          1,
+		Name:        domain.Admin,
+		Permissions: domain.Permissions,
+	}
+	cashierRole := &domain.Role{
+		ID:          2,
+		Name:        domain.Cashier,
+		Permissions: []domain.Permission{domain.OrdersCreate},
+	}
+	supervisorRole := &domain.Role{
+		ID:          3,
+		Name:        "supervisor",
+		Permissions: []domain.Permission{domain.OrdersCreate},
+	}
+	updatedSupervisorRole := &domain.Role{
+		ID:          3,
+		Name:        "shift_supervisor",
+		Permissions: []domain.Permission{domain.OrdersCreate, domain.ReportsView},
+	}
+	updatedCashierRole := &domain.Role{
+		ID:          2,
+		Permissions: []domain.Permission{domain.OrdersCreate, domain.OrdersExport},
+	}
+
+	testCases := []struct {
+		desc     string
+		mocks    func(roleRepo *mock.MockRoleRepository, cache *mock.MockCacheRepository)
+		input    updateRoleTestedInput
+		expected updateRoleExpectedOutput
+	}{
+		{
+			desc: "Success",
+			mocks: func(roleRepo *mock.MockRoleRepository, cache *mock.MockCacheRepository) {
+				roleRepo.EXPECT().
+					GetRoleByID(gomock.Any(), gomock.Eq(supervisorRole.ID)).
+					Return(supervisorRole, nil)
+				roleRepo.EXPECT().
+					UpdateRole(gomock.Any(), gomock.Eq(updatedSupervisorRole)).
+					Return(updatedSupervisorRole, nil)
+				cache.EXPECT().Delete(gomock.Any(), gomock.Eq("role:3")).Return(nil)
+				cache.EXPECT().Set(gomock.Any(), gomock.Eq("role:3"), gomock.Any(), gomock.Any()).Return(nil)
+				cache.EXPECT().DeleteByPrefix(gomock.Any(), gomock.Eq("roles:*")).Return(nil)
+				cache.EXPECT().Set(gomock.Any(), gomock.Eq("revoked_role:supervisor"), gomock.Any(), gomock.Eq(time.Hour)).Return(nil)
+				cache.EXPECT().DeleteByPrefix(gomock.Any(), gomock.Eq("user:*")).Return(nil)
+				cache.EXPECT().DeleteByPrefix(gomock.Any(), gomock.Eq("users:*")).Return(nil)
+			},
+			input: updateRoleTestedInput{
+				role: updatedSupervisorRole,
+			},
+			expected: updateRoleExpectedOutput{
+				role: updatedSupervisorRole,
+			},
+		},
+		{
+			desc: "Success_BuiltInPermissions",
+			mocks: func(roleRepo *mock.MockRoleRepository, cache *mock.MockCacheRepository) {
+				roleRepo.EXPECT().
+					GetRoleByID(gomock.Any(), gomock.Eq(cashierRole.ID)).
+					Return(cashierRole, nil)
+				roleRepo.EXPECT().
+					UpdateRole(gomock.Any(), gomock.Eq(updatedCashierRole)).
+					Return(updatedCashierRole, nil)
+				cache.EXPECT().Delete(gomock.Any(), gomock.Eq("role:2")).Return(nil)
+				cache.EXPECT().Set(gomock.Any(), gomock.Eq("role:2"), gomock.Any(), gomock.Any()).Return(nil)
+				cache.EXPECT().DeleteByPrefix(gomock.Any(), gomock.Eq("roles:*")).Return(nil)
+				cache.EXPECT().Set(gomock.Any(), gomock.Eq("revoked_role:cashier"), gomock.Any(), gomock.Eq(time.Hour)).Return(nil)
+			},
+			input: updateRoleTestedInput{
+				role: updatedCashierRole,
+			},
+			expected: updateRoleExpectedOutput{
+				role: updatedCashierRole,
+			},
+		},
+		{
+			desc: "Fail_RevokeRole",
+			mocks: func(roleRepo *mock.MockRoleRepository, cache *mock.MockCacheRepository) {
+				roleRepo.EXPECT().
+					GetRoleByID(gomock.Any(), gomock.Eq(cashierRole.ID)).
+					Return(cashierRole, nil)
+				roleRepo.EXPECT().
+					UpdateRole(gomock.Any(), gomock.Eq(updatedCashierRole)).
+					Return(updatedCashierRole, nil)
+				cache.EXPECT().Delete(gomock.Any(), gomock.Eq("role:2")).Return(nil)
+				cache.EXPECT().Set(gomock.Any(), gomock.Eq("role:2"), gomock.Any(), gomock.Any()).Return(nil)
+				cache.EXPECT().DeleteByPrefix(gomock.Any(), gomock.Eq("roles:*")).Return(nil)
+				cache.EXPECT().Set(gomock.Any(), gomock.Eq("revoked_role:cashier"), gomock.Any(), gomock.Eq(time.Hour)).Return(domain.ErrInternal)
+			},
+			input: updateRoleTestedInput{
+				role: updatedCashierRole,
+			},
+			expected: updateRoleExpectedOutput{
+				err: domain.ErrInternal,
+			},
+		},
+		{
+			desc: "Fail_RenameBuiltIn",
+			mocks: func(roleRepo *mock.MockRoleRepository, cache *mock.MockCacheRepository) {
+				roleRepo.EXPECT().
+					GetRoleByID(gomock.Any(), gomock.Eq(cashierRole.ID)).
+					Return(cashierRole, nil)
+			},
+			input: updateRoleTestedInput{
+				role: &domain.Role{ID: cashierRole.ID, Name: "clerk"},
+			},
+			expected: updateRoleExpectedOutput{
+				err: domain.ErrBuiltInRole,
+			},
+		},
+		{
+			desc: "Fail_ChangeAdminPermissions",
+			mocks: func(roleRepo *mock.MockRoleRepository, cache *mock.MockCacheRepository) {
+				roleRepo.EXPECT().
+					GetRoleByID(gomock.Any(), gomock.Eq(adminRole.ID)).
+					Return(adminRole, nil)
+			},
+			input: updateRoleTestedInput{
+				role: &domain.Role{ID: adminRole.ID, Permissions: []domain.Permission{domain.OrdersCreate}},
+			},
+			expected: updateRoleExpectedOutput{
+				err: domain.ErrBuiltInRole,
+			},
+		},
+		{
+			desc: "Fail_NoUpdatedData",
+			mocks: func(roleRepo *mock.MockRoleRepository, cache *mock.MockCacheRepository) {
+				roleRepo.EXPECT().
+					GetRoleByID(gomock.Any(), gomock.Eq(supervisorRole.ID)).
+					Return(supervisorRole, nil)
+			},
+			input: updateRoleTestedInput{
+				role: &domain.Role{ID: supervisorRole.ID, Name: supervisorRole.Name, Permissions: supervisorRole.Permissions},
+			},
+			expected: updateRoleExpectedOutput{
+				err: domain.ErrNoUpdatedData,
+			},
+		},
+		{
+			desc: "Fail_NotFound",
+			mocks: func(roleRepo *mock.MockRoleRepository, cache *mock.MockCacheRepository) {
+				roleRepo.EXPECT().
+					GetRoleByID(gomock.Any(), gomock.Eq(supervisorRole.ID)).
+					Return(nil, domain.ErrDataNotFound)
+			},
+			input: updateRoleTestedInput{
+				role: updatedSupervisorRole,
+			},
+			expected: updateRoleExpectedOutput{
+				err: domain.ErrDataNotFound,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			roleRepo := mock.NewMockRoleRepository(ctrl)
+			cache := mock.NewMockCacheRepository(ctrl)
+
+			tc.mocks(roleRepo, cache)
+
+			roleService := service.NewRoleService(roleRepo, cache, newMockAuditService(ctrl), time.Hour)
+
+			role, err := roleService.UpdateRole(ctx, tc.input.role)
+			assert.Equal(t, tc.expected.err, err, "Error mismatch")
+			assert.Equal(t, tc.expected.role, role, "Role mismatch")
+		})
+	}
+}
+
+func TestRoleService_DeleteRole(t *testing.T) {
+	ctx := context.Background()
+
+	testCases := []struct {
+		desc     string
+		mocks    func(roleRepo *mock.MockRoleRepository, cache *mock.MockCacheRepository)
+		expected error
+	}{
+		{
+			desc: "Success",
+			mocks: func(roleRepo *mock.MockRoleRepository, cache *mock.MockCacheRepository) {
+				roleRepo.EXPECT().GetRoleByID(gomock.Any(), gomock.Eq(uint64(3))).Return(&domain.Role{ID: 3, Name: "supervisor"}, nil)
+				roleRepo.EXPECT().DeleteRole(gomock.Any(), gomock.Eq(uint64(3))).Return(nil)
+				cache.EXPECT().Delete(gomock.Any(), gomock.Eq("role:3")).Return(nil)
+				cache.EXPECT().DeleteByPrefix(gomock.Any(), gomock.Eq("roles:*")).Return(nil)
+			},
+		},
+		{
+			desc: "Fail_BuiltIn",
+			mocks: func(roleRepo *mock.MockRoleRepository, cache *mock.MockCacheRepository) {
+				roleRepo.EXPECT().GetRoleByID(gomock.Any(), gomock.Eq(uint64(3))).Return(&domain.Role{ID: 3, Name: domain.Cashier}, nil)
+			},
+			expected: domain.ErrBuiltInRole,
+		},
+		{
+			desc: "Fail_InUse",
+			mocks: func(roleRepo *mock.MockRoleRepository, cache *mock.MockCacheRepository) {
+				roleRepo.EXPECT().GetRoleByID(gomock.Any(), gomock.Eq(uint64(3))).Return(&domain.Role{ID: 3, Name: "supervisor"}, nil)
+				roleRepo.EXPECT().DeleteRole(gomock.Any(), gomock.Eq(uint64(3))).Return(domain.ErrConflictingData)
+			},
+			expected: domain.ErrConflictingData,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			roleRepo := mock.NewMockRoleRepository(ctrl)
+			cache := mock.NewMockCacheRepository(ctrl)
+
+			tc.mocks(roleRepo, cache)
+
+			roleService := service.NewRoleService(roleRepo, cache, newMockAuditService(ctrl), time.Hour)
+
+			err := roleService.DeleteRole(ctx, 3)
+			assert.Equal(t, tc.expected, err, "Error mismatch")
+		})
+	}
+}
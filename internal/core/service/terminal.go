@@ -0,0 +1,140 @@
+package service
+
+import (
+	"context"
+
+	"github.com/nikhil-shrestha/go-pos/internal/core/domain"
+	"github.com/nikhil-shrestha/go-pos/internal/core/port"
+	"github.com/nikhil-shrestha/go-pos/internal/core/util"
+)
+
+// terminalKeySize is the number of random bytes of a terminal key
+const terminalKeySize = 32
+
+/**
+ * TerminalService implements port.TerminalService interface
+ * and provides an access to the terminal repository,
+ * cache service and audit service
+ */
+type TerminalService struct {
+	repo  port.TerminalRepository
+	cache port.CacheRepository
+	audit port.AuditService
+}
+
+// NewTerminalService creates a new terminal service instance
+func NewTerminalService(repo port.TerminalRepository, cache port.CacheRepository, audit port.AuditService) *TerminalService {
+	return &TerminalService{
+		repo,
+		cache,
+		audit,
+	}
+}
+
+// RegisterTerminal generates a key for the terminal and stores only its hash
+func (ts *TerminalService) RegisterTerminal(ctx context.Context, terminal *domain.Terminal) (*domain.Terminal, error) {
+	key, err := util.GenerateKey(terminalKeySize)
+	if err != nil {
+		return nil, domain.ErrInternal
+	}
+
+	terminal.KeyHash = util.HashKey(key)
+
+	err = ts.audit.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+
+		terminal, err = ts.repo.CreateTerminal(ctx, terminal)
+		if err != nil {
+			if err == domain.ErrConflictingData {
+				return err
+			}
+			return domain.ErrInternal
+		}
+
+		return ts.audit.RecordAuditLog(ctx, &domain.AuditLog{Action: "terminal.create", Entity: "terminal", EntityID: terminal.ID}, nil, terminal)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = ts.cache.DeleteByPrefix(ctx, "terminals:*")
+	if err != nil {
+		return nil, domain.ErrInternal
+	}
+
+	terminal.Key = key
+
+	return terminal, nil
+}
+
+// ListTerminals lists all terminals
+func (ts *TerminalService) ListTerminals(ctx context.Context, params *domain.ListParams) ([]domain.Terminal, uint64, error) {
+	var page domain.Page[domain.Terminal]
+
+	cacheParams := util.GenerateCacheKeyParams(params.Skip, params.Limit, params.Sort, params.Order)
+	cacheKey := util.GenerateCacheKey("terminals", cacheParams)
+
+	cachedTerminals, err := ts.cache.Get(ctx, cacheKey)
+	if err == nil {
+		err := util.Deserialize(cachedTerminals, &page)
+		if err != nil {
+			return nil, 0, domain.ErrInternal
+		}
+
+		return page.Items, page.Total, nil
+	}
+
+	terminals, total, err := ts.repo.ListTerminals(ctx, params)
+	if err != nil {
+		return nil, 0, domain.ErrInternal
+	}
+
+	terminalsSerialized, err := util.Serialize(domain.Page[domain.Terminal]{Items: terminals, Total: total})
+	if err != nil {
+		return nil, 0, domain.ErrInternal
+	}
+
+	err = ts.cache.Set(ctx, cacheKey, terminalsSerialized, 0)
+	if err != nil {
+		return nil, 0, domain.ErrInternal
+	}
+
+	return terminals, total, nil
+}
+
+// DeleteTerminal deletes a terminal, after which the tokens bound to it are rejected
+func (ts *TerminalService) DeleteTerminal(ctx context.Context, id uint64) error {
+	existingTerminal, err := ts.repo.GetTerminalByID(ctx, id)
+	if err != nil {
+		if err == domain.ErrDataNotFound {
+			return err
+		}
+		return domain.ErrInternal
+	}
+
+	err = ts.audit.WithinTransaction(ctx, func(ctx context.Context) error {
+		err := ts.repo.DeleteTerminal(ctx, id)
+		if err != nil {
+			return domain.ErrInternal
+		}
+
+		return ts.audit.RecordAuditLog(ctx, &domain.AuditLog{Action: "terminal.delete", Entity: "terminal", EntityID: id}, existingTerminal, nil)
+	})
+	if err != nil {
+		return err
+	}
+
+	cacheKey := util.GenerateCacheKey("terminal", id)
+
+	err = ts.cache.Delete(ctx, cacheKey)
+	if err != nil {
+		return domain.ErrInternal
+	}
+
+	err = ts.cache.DeleteByPrefix(ctx, "terminals:*")
+	if err != nil {
+		return domain.ErrInternal
+	}
+
+	return nil
+}
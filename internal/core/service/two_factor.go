@@ -0,0 +1,464 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/nikhil-shrestha/go-pos/internal/core/domain"
+	"github.com/nikhil-shrestha/go-pos/internal/core/port"
+	"github.com/nikhil-shrestha/go-pos/internal/core/util"
+	"github.com/google/uuid"
+)
+
+const (
+	// loginChallengeDuration is how long a login challenge can be answered
+	loginChallengeDuration = 5 * time.Minute
+	// recoveryCodeCount is how many recovery codes an enrolment gets
+	recoveryCodeCount = 10
+	// totpReplayDuration is how long a TOTP code is remembered, which covers the period it is valid for with clock drift
+	totpReplayDuration = 90 * time.Second
+)
+
+/**
+ * TwoFactorService implements port.TwoFactorService interface
+ * and provides an access to the two-factor and user repositories,
+ * cache service and audit service
+ */
+type TwoFactorService struct {
+	repo     port.TwoFactorRepository
+	userRepo port.UserRepository
+	cache    port.CacheRepository
+	audit    port.AuditService
+	policy   *domain.TwoFactorPolicy
+}
+
+// NewTwoFactorService creates a new two-factor service instance
+func NewTwoFactorService(repo port.TwoFactorRepository, userRepo port.UserRepository, cache port.CacheRepository, audit port.AuditService, policy *domain.TwoFactorPolicy) *TwoFactorService {
+	return &TwoFactorService{
+		repo,
+		userRepo,
+		cache,
+		audit,
+		policy,
+	}
+}
+
+// SetupTwoFactor provisions a new secret for the user, replacing a pending one
+func (tfs *TwoFactorService) SetupTwoFactor(ctx context.Context, userID uint64) (*domain.TwoFactorSetup, error) {
+	user, err := tfs.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		if err == domain.ErrDataNotFound {
+			return nil, err
+		}
+		return nil, domain.ErrInternal
+	}
+
+	return tfs.setup(ctx, user)
+}
+
+// EnableTwoFactor confirms the pending secret of the user with a code of it and returns the recovery codes
+func (tfs *TwoFactorService) EnableTwoFactor(ctx context.Context, userID uint64, code string) ([]string, error) {
+	twoFactor, err := tfs.getTwoFactor(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if twoFactor.IsEnabled() {
+		return nil, domain.ErrTwoFactorEnabled
+	}
+
+	return tfs.enable(ctx, twoFactor, code)
+}
+
+// RegenerateRecoveryCodes replaces the recovery codes of the user after checking a code, so the previous ones stop working
+func (tfs *TwoFactorService) RegenerateRecoveryCodes(ctx context.Context, userID uint64, code string) ([]string, error) {
+	twoFactor, err := tfs.getEnabledTwoFactor(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tfs.verifyCode(ctx, twoFactor, code)
+	if err != nil {
+		return nil, err
+	}
+
+	recoveryCodes, hashes, err := newRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+
+	err = tfs.audit.WithinTransaction(ctx, func(ctx context.Context) error {
+		err := tfs.repo.UpdateRecoveryCodes(ctx, userID, hashes)
+		if err != nil {
+			return domain.ErrInternal
+		}
+
+		updatedTwoFactor := *twoFactor
+		updatedTwoFactor.RecoveryCodes = hashes
+
+		return tfs.audit.RecordAuditLog(ctx, &domain.AuditLog{Action: "two_factor.regenerate_recovery_codes", Entity: "two_factor", EntityID: userID}, twoFactor, &updatedTwoFactor)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return recoveryCodes, nil
+}
+
+// DisableTwoFactor turns off two-factor authentication of the user after checking a code, unless their role requires it
+func (tfs *TwoFactorService) DisableTwoFactor(ctx context.Context, userID uint64, code string) error {
+	user, err := tfs.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		if err == domain.ErrDataNotFound {
+			return err
+		}
+		return domain.ErrInternal
+	}
+
+	if tfs.policy.IsRequired(user.Role) {
+		return domain.ErrTwoFactorRequired
+	}
+
+	twoFactor, err := tfs.getEnabledTwoFactor(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	err = tfs.verifyCode(ctx, twoFactor, code)
+	if err != nil {
+		return err
+	}
+
+	return tfs.audit.WithinTransaction(ctx, func(ctx context.Context) error {
+		err := tfs.repo.DeleteTwoFactor(ctx, userID)
+		if err != nil {
+			return domain.ErrInternal
+		}
+
+		return tfs.audit.RecordAuditLog(ctx, &domain.AuditLog{Action: "two_factor.disable", Entity: "two_factor", EntityID: userID}, twoFactor, nil)
+	})
+}
+
+// ResetTwoFactor turns off two-factor authentication of the user without a code and lifts its lockout.
+// A user whose role requires it has to enrol again on their next login
+func (tfs *TwoFactorService) ResetTwoFactor(ctx context.Context, userID uint64) error {
+	_, err := tfs.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		if err == domain.ErrDataNotFound {
+			return err
+		}
+		return domain.ErrInternal
+	}
+
+	twoFactor, err := tfs.repo.GetTwoFactor(ctx, userID)
+	if err != nil && err != domain.ErrDataNotFound {
+		return domain.ErrInternal
+	}
+
+	err = tfs.audit.WithinTransaction(ctx, func(ctx context.Context) error {
+		err := tfs.repo.DeleteTwoFactor(ctx, userID)
+		if err != nil {
+			return domain.ErrInternal
+		}
+
+		return tfs.audit.RecordAuditLog(ctx, &domain.AuditLog{Action: "two_factor.reset", Entity: "two_factor", EntityID: userID}, twoFactor, nil)
+	})
+	if err != nil {
+		return err
+	}
+
+	return twoFactorLockout.reset(ctx, tfs.cache, userID)
+}
+
+// IsRequired reports whether users of the role must answer a two-factor challenge to log in
+func (tfs *TwoFactorService) IsRequired(role domain.UserRole) bool {
+	return tfs.policy.IsRequired(role)
+}
+
+// CreateChallenge starts a login challenge if the user is enrolled, or a setup challenge if their role requires two-factor authentication.
+// It returns nil if the user can log in with the password only
+func (tfs *TwoFactorService) CreateChallenge(ctx context.Context, user *domain.User) (*domain.LoginChallenge, error) {
+	twoFactor, err := tfs.repo.GetTwoFactor(ctx, user.ID)
+	if err != nil && err != domain.ErrDataNotFound {
+		return nil, domain.ErrInternal
+	}
+
+	enabled := twoFactor != nil && twoFactor.IsEnabled()
+	if !enabled && !tfs.policy.IsRequired(user.Role) {
+		return nil, nil
+	}
+
+	challenge := &domain.LoginChallenge{
+		ID:        uuid.New(),
+		UserID:    user.ID,
+		Setup:     !enabled,
+		ExpiresAt: time.Now().Add(loginChallengeDuration),
+	}
+
+	challengeSerialized, err := util.Serialize(challenge)
+	if err != nil {
+		return nil, domain.ErrInternal
+	}
+
+	cacheKey := util.GenerateCacheKey("login_challenge", challenge.ID)
+
+	err = tfs.cache.Set(ctx, cacheKey, challengeSerialized, loginChallengeDuration)
+	if err != nil {
+		return nil, domain.ErrInternal
+	}
+
+	return challenge, nil
+}
+
+// SetupChallenge provisions a new secret for the user of a setup challenge, who cannot log in to set it up otherwise
+func (tfs *TwoFactorService) SetupChallenge(ctx context.Context, challengeID string) (*domain.TwoFactorSetup, error) {
+	challenge, err := tfs.getChallenge(ctx, challengeID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !challenge.Setup {
+		return nil, domain.ErrTwoFactorEnabled
+	}
+
+	user, err := tfs.userRepo.GetUserByID(ctx, challenge.UserID)
+	if err != nil {
+		if err == domain.ErrDataNotFound {
+			return nil, domain.ErrInvalidChallenge
+		}
+		return nil, domain.ErrInternal
+	}
+
+	return tfs.setup(ctx, user)
+}
+
+// VerifyChallenge answers a login challenge with a TOTP or recovery code. The code of a setup challenge
+// confirms the new enrolment, whose recovery codes are returned. The challenge can be answered only once
+func (tfs *TwoFactorService) VerifyChallenge(ctx context.Context, challengeID, code string) (uint64, []string, error) {
+	var recoveryCodes []string
+
+	challenge, err := tfs.getChallenge(ctx, challengeID)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	twoFactor, err := tfs.getTwoFactor(ctx, challenge.UserID)
+	if err != nil {
+		if err == domain.ErrTwoFactorNotEnabled && !challenge.Setup {
+			return 0, nil, domain.ErrInvalidChallenge
+		}
+		return 0, nil, err
+	}
+
+	switch {
+	case twoFactor.IsEnabled():
+		err = tfs.verifyCode(ctx, twoFactor, code)
+	case challenge.Setup:
+		recoveryCodes, err = tfs.enable(ctx, twoFactor, code)
+	default:
+		err = domain.ErrInvalidChallenge
+	}
+	if err != nil {
+		return 0, nil, err
+	}
+
+	err = tfs.cache.Delete(ctx, util.GenerateCacheKey("login_challenge", challenge.ID))
+	if err != nil {
+		return 0, nil, domain.ErrInternal
+	}
+
+	return challenge.UserID, recoveryCodes, nil
+}
+
+// setup generates a secret for the user and stores it as a pending enrolment
+func (tfs *TwoFactorService) setup(ctx context.Context, user *domain.User) (*domain.TwoFactorSetup, error) {
+	secret, uri, qrCode, err := util.GenerateTotp(tfs.policy.Issuer, user.Email)
+	if err != nil {
+		return nil, domain.ErrInternal
+	}
+
+	_, err = tfs.repo.SaveTwoFactor(ctx, &domain.TwoFactor{
+		UserID: user.ID,
+		Secret: secret,
+	})
+	if err != nil {
+		if err == domain.ErrTwoFactorEnabled {
+			return nil, err
+		}
+		return nil, domain.ErrInternal
+	}
+
+	return &domain.TwoFactorSetup{
+		Secret: secret,
+		URI:    uri,
+		QRCode: qrCode,
+	}, nil
+}
+
+// enable confirms a pending enrolment with a code of its secret and returns the recovery codes
+func (tfs *TwoFactorService) enable(ctx context.Context, twoFactor *domain.TwoFactor, code string) ([]string, error) {
+	err := tfs.verifyCode(ctx, twoFactor, code)
+	if err != nil {
+		return nil, err
+	}
+
+	recoveryCodes, hashes, err := newRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+
+	err = tfs.audit.WithinTransaction(ctx, func(ctx context.Context) error {
+		enabledTwoFactor, err := tfs.repo.EnableTwoFactor(ctx, twoFactor.UserID, hashes)
+		if err != nil {
+			if err == domain.ErrTwoFactorEnabled {
+				return err
+			}
+			return domain.ErrInternal
+		}
+
+		// A setup challenge enrols the user before they are logged in, so the log names them rather than the actor
+		auditLog := &domain.AuditLog{
+			UserID:   twoFactor.UserID,
+			Action:   "two_factor.enable",
+			Entity:   "two_factor",
+			EntityID: twoFactor.UserID,
+		}
+
+		return tfs.audit.RecordAuditLog(ctx, auditLog, twoFactor, enabledTwoFactor)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return recoveryCodes, nil
+}
+
+// verifyCode checks a TOTP code, or a recovery code of an enabled enrolment which is used up.
+// Failed attempts lock two-factor codes of the user once there are too many
+func (tfs *TwoFactorService) verifyCode(ctx context.Context, twoFactor *domain.TwoFactor, code string) error {
+	err := twoFactorLockout.check(ctx, tfs.cache, twoFactor.UserID)
+	if err != nil {
+		return err
+	}
+
+	valid, err := tfs.checkCode(ctx, twoFactor, code)
+	if err != nil {
+		return err
+	}
+
+	if !valid {
+		err = twoFactorLockout.fail(ctx, tfs.cache, twoFactor.UserID)
+		if err != nil {
+			return err
+		}
+		return domain.ErrInvalidTwoFactorCode
+	}
+
+	return twoFactorLockout.reset(ctx, tfs.cache, twoFactor.UserID)
+}
+
+// checkCode reports whether the code is an unused TOTP code or recovery code of the enrolment
+func (tfs *TwoFactorService) checkCode(ctx context.Context, twoFactor *domain.TwoFactor, code string) (bool, error) {
+	if util.ValidateTotp(code, twoFactor.Secret) {
+		// A code stays valid for a while, so every code used is remembered until it expires to stop it from being
+		// replayed. Only the request that stores the code may use it, even if the same code is submitted concurrently
+		cacheKey := util.GenerateCacheKey("totp_used", util.GenerateCacheKeyParams(twoFactor.UserID, code))
+
+		unused, err := tfs.cache.SetIfNotExists(ctx, cacheKey, []byte{1}, totpReplayDuration)
+		if err != nil {
+			return false, domain.ErrInternal
+		}
+
+		return unused, nil
+	}
+
+	if !twoFactor.IsEnabled() {
+		return false, nil
+	}
+
+	err := tfs.repo.UseRecoveryCode(ctx, twoFactor.UserID, util.HashKey(normalizeRecoveryCode(code)))
+	if err != nil {
+		if err == domain.ErrDataNotFound {
+			return false, nil
+		}
+		return false, domain.ErrInternal
+	}
+
+	return true, nil
+}
+
+// getTwoFactor retrieves the enrolment of the user, pending or not
+func (tfs *TwoFactorService) getTwoFactor(ctx context.Context, userID uint64) (*domain.TwoFactor, error) {
+	twoFactor, err := tfs.repo.GetTwoFactor(ctx, userID)
+	if err != nil {
+		if err == domain.ErrDataNotFound {
+			return nil, domain.ErrTwoFactorNotEnabled
+		}
+		return nil, domain.ErrInternal
+	}
+
+	return twoFactor, nil
+}
+
+// getEnabledTwoFactor retrieves the enrolment of the user if it is confirmed
+func (tfs *TwoFactorService) getEnabledTwoFactor(ctx context.Context, userID uint64) (*domain.TwoFactor, error) {
+	twoFactor, err := tfs.getTwoFactor(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !twoFactor.IsEnabled() {
+		return nil, domain.ErrTwoFactorNotEnabled
+	}
+
+	return twoFactor, nil
+}
+
+// getChallenge retrieves a login challenge that has not been answered yet
+func (tfs *TwoFactorService) getChallenge(ctx context.Context, challengeID string) (*domain.LoginChallenge, error) {
+	var challenge *domain.LoginChallenge
+
+	id, err := uuid.Parse(challengeID)
+	if err != nil {
+		return nil, domain.ErrInvalidChallenge
+	}
+
+	challengeSerialized, err := tfs.cache.Get(ctx, util.GenerateCacheKey("login_challenge", id))
+	if err != nil {
+		if err == domain.ErrDataNotFound {
+			return nil, domain.ErrInvalidChallenge
+		}
+		return nil, domain.ErrInternal
+	}
+
+	err = util.Deserialize(challengeSerialized, &challenge)
+	if err != nil {
+		return nil, domain.ErrInternal
+	}
+
+	return challenge, nil
+}
+
+// newRecoveryCodes generates recovery codes, formatted for the user, and the hashes that are stored
+func newRecoveryCodes() ([]string, []string, error) {
+	recoveryCodes := make([]string, recoveryCodeCount)
+	hashes := make([]string, recoveryCodeCount)
+
+	for i := range recoveryCodeCount {
+		code, err := util.GenerateKey(5)
+		if err != nil {
+			return nil, nil, domain.ErrInternal
+		}
+
+		recoveryCodes[i] = code[:5] + "-" + code[5:]
+		hashes[i] = util.HashKey(code)
+	}
+
+	return recoveryCodes, hashes, nil
+}
+
+// normalizeRecoveryCode strips the formatting of a recovery code as typed by the user
+func normalizeRecoveryCode(code string) string {
+	return strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+}
@@ -0,0 +1,458 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/nikhil-shrestha/go-pos/internal/core/domain"
+	"github.com/nikhil-shrestha/go-pos/internal/core/port/mock"
+	"github.com/nikhil-shrestha/go-pos/internal/core/service"
+	"github.com/nikhil-shrestha/go-pos/internal/core/util"
+	"github.com/brianvoe/gofakeit/v6"
+	"github.com/google/uuid"
+	"github.com/pquerna/otp/totp"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+type createChallengeExpectedOutput struct {
+	challenge bool
+	setup     bool
+	err       error
+}
+
+func TestTwoFactorService_CreateChallenge(t *testing.T) {
+	ctx := context.Background()
+	policy := &domain.TwoFactorPolicy{
+		Issuer:        "Go POS",
+		RequiredRoles: []domain.UserRole{domain.Admin},
+	}
+	cashier := &domain.User{
+		ID:   gofakeit.Uint64(),
+		Role: domain.Cashier,
+	}
+	admin := &domain.User{
+		ID:   gofakeit.Uint64(),
+		Role: domain.Admin,
+	}
+	enabledAt := time.Now()
+	enabled := &domain.TwoFactor{
+		UserID:    cashier.ID,
+		EnabledAt: &enabledAt,
+	}
+	pending := &domain.TwoFactor{
+		UserID: cashier.ID,
+	}
+
+	testCases := []struct {
+		desc     string
+		mocks    func(repo *mock.MockTwoFactorRepository, cache *mock.MockCacheRepository)
+		input    *domain.User
+		expected createChallengeExpectedOutput
+	}{
+		{
+			desc: "Success_NotEnrolled",
+			mocks: func(repo *mock.MockTwoFactorRepository, cache *mock.MockCacheRepository) {
+				repo.EXPECT().GetTwoFactor(gomock.Any(), gomock.Eq(cashier.ID)).Return(nil, domain.ErrDataNotFound)
+			},
+			input:    cashier,
+			expected: createChallengeExpectedOutput{},
+		},
+		{
+			desc: "Success_Pending",
+			mocks: func(repo *mock.MockTwoFactorRepository, cache *mock.MockCacheRepository) {
+				repo.EXPECT().GetTwoFactor(gomock.Any(), gomock.Eq(cashier.ID)).Return(pending, nil)
+			},
+			input:    cashier,
+			expected: createChallengeExpectedOutput{},
+		},
+		{
+			desc: "Success_Enrolled",
+			mocks: func(repo *mock.MockTwoFactorRepository, cache *mock.MockCacheRepository) {
+				repo.EXPECT().GetTwoFactor(gomock.Any(), gomock.Eq(cashier.ID)).Return(enabled, nil)
+				cache.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Eq(5*time.Minute)).Return(nil)
+			},
+			input: cashier,
+			expected: createChallengeExpectedOutput{
+				challenge: true,
+			},
+		},
+		{
+			desc: "Success_RequiredSetup",
+			mocks: func(repo *mock.MockTwoFactorRepository, cache *mock.MockCacheRepository) {
+				repo.EXPECT().GetTwoFactor(gomock.Any(), gomock.Eq(admin.ID)).Return(nil, domain.ErrDataNotFound)
+				cache.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			},
+			input: admin,
+			expected: createChallengeExpectedOutput{
+				challenge: true,
+				setup:     true,
+			},
+		},
+		{
+			desc: "Fail_InternalError",
+			mocks: func(repo *mock.MockTwoFactorRepository, cache *mock.MockCacheRepository) {
+				repo.EXPECT().GetTwoFactor(gomock.Any(), gomock.Eq(cashier.ID)).Return(nil, domain.ErrInternal)
+			},
+			input: cashier,
+			expected: createChallengeExpectedOutput{
+				err: domain.ErrInternal,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mock.NewMockTwoFactorRepository(ctrl)
+			userRepo := mock.NewMockUserRepository(ctrl)
+			cache := mock.NewMockCacheRepository(ctrl)
+
+			tc.mocks(repo, cache)
+
+			twoFactorService := service.NewTwoFactorService(repo, userRepo, cache, newMockAuditService(ctrl), policy)
+
+			challenge, err := twoFactorService.CreateChallenge(ctx, tc.input)
+			assert.Equal(t, tc.expected.err, err, "Error mismatch")
+			assert.Equal(t, tc.expected.challenge, challenge != nil, "Challenge mismatch")
+			if challenge != nil {
+				assert.Equal(t, tc.input.ID, challenge.UserID, "User mismatch")
+				assert.Equal(t, tc.expected.setup, challenge.Setup, "Setup mismatch")
+			}
+		})
+	}
+}
+
+type verifyChallengeExpectedOutput struct {
+	recoveryCodes int
+	err           error
+}
+
+func TestTwoFactorService_VerifyChallenge(t *testing.T) {
+	ctx := context.Background()
+	policy := &domain.TwoFactorPolicy{
+		Issuer: "Go POS",
+	}
+	secret, _, _, _ := util.GenerateTotp(policy.Issuer, gofakeit.Email())
+	code, _ := totp.GenerateCode(secret, time.Now())
+	userID := gofakeit.Uint64()
+	enabledAt := time.Now()
+	enabled := &domain.TwoFactor{
+		UserID:    userID,
+		Secret:    secret,
+		EnabledAt: &enabledAt,
+	}
+	pending := &domain.TwoFactor{
+		UserID: userID,
+		Secret: secret,
+	}
+	challenge := &domain.LoginChallenge{
+		ID:     uuid.New(),
+		UserID: userID,
+	}
+	setupChallenge := &domain.LoginChallenge{
+		ID:     uuid.New(),
+		UserID: userID,
+		Setup:  true,
+	}
+	challengeSerialized, _ := util.Serialize(challenge)
+	setupChallengeSerialized, _ := util.Serialize(setupChallenge)
+	challengeCacheKey := util.GenerateCacheKey("login_challenge", challenge.ID)
+	setupChallengeCacheKey := util.GenerateCacheKey("login_challenge", setupChallenge.ID)
+	lockCacheKey := util.GenerateCacheKey("two_factor_lock", userID)
+	attemptsCacheKey := util.GenerateCacheKey("two_factor_attempts", userID)
+	usedCacheKey := util.GenerateCacheKey("totp_used", util.GenerateCacheKeyParams(userID, code))
+	recoveryCode := "3f9a1-c07be"
+
+	testCases := []struct {
+		desc     string
+		mocks    func(repo *mock.MockTwoFactorRepository, cache *mock.MockCacheRepository)
+		input    *domain.LoginChallenge
+		code     string
+		expected verifyChallengeExpectedOutput
+	}{
+		{
+			desc: "Success_Totp",
+			mocks: func(repo *mock.MockTwoFactorRepository, cache *mock.MockCacheRepository) {
+				cache.EXPECT().Get(gomock.Any(), gomock.Eq(challengeCacheKey)).Return(challengeSerialized, nil)
+				repo.EXPECT().GetTwoFactor(gomock.Any(), gomock.Eq(userID)).Return(enabled, nil)
+				cache.EXPECT().Get(gomock.Any(), gomock.Eq(lockCacheKey)).Return(nil, domain.ErrDataNotFound)
+				cache.EXPECT().SetIfNotExists(gomock.Any(), gomock.Eq(usedCacheKey), gomock.Any(), gomock.Any()).Return(true, nil)
+				cache.EXPECT().Delete(gomock.Any(), gomock.Eq(attemptsCacheKey)).Return(nil)
+				cache.EXPECT().Delete(gomock.Any(), gomock.Eq(lockCacheKey)).Return(nil)
+				cache.EXPECT().Delete(gomock.Any(), gomock.Eq(challengeCacheKey)).Return(nil)
+			},
+			input:    challenge,
+			code:     code,
+			expected: verifyChallengeExpectedOutput{},
+		},
+		{
+			desc: "Success_RecoveryCode",
+			mocks: func(repo *mock.MockTwoFactorRepository, cache *mock.MockCacheRepository) {
+				cache.EXPECT().Get(gomock.Any(), gomock.Eq(challengeCacheKey)).Return(challengeSerialized, nil)
+				repo.EXPECT().GetTwoFactor(gomock.Any(), gomock.Eq(userID)).Return(enabled, nil)
+				cache.EXPECT().Get(gomock.Any(), gomock.Eq(lockCacheKey)).Return(nil, domain.ErrDataNotFound)
+				repo.EXPECT().UseRecoveryCode(gomock.Any(), gomock.Eq(userID), gomock.Eq(util.HashKey("3f9a1c07be"))).Return(nil)
+				cache.EXPECT().Delete(gomock.Any(), gomock.Eq(attemptsCacheKey)).Return(nil)
+				cache.EXPECT().Delete(gomock.Any(), gomock.Eq(lockCacheKey)).Return(nil)
+				cache.EXPECT().Delete(gomock.Any(), gomock.Eq(challengeCacheKey)).Return(nil)
+			},
+			input:    challenge,
+			code:     recoveryCode,
+			expected: verifyChallengeExpectedOutput{},
+		},
+		{
+			desc: "Success_Setup",
+			mocks: func(repo *mock.MockTwoFactorRepository, cache *mock.MockCacheRepository) {
+				cache.EXPECT().Get(gomock.Any(), gomock.Eq(setupChallengeCacheKey)).Return(setupChallengeSerialized, nil)
+				repo.EXPECT().GetTwoFactor(gomock.Any(), gomock.Eq(userID)).Return(pending, nil)
+				cache.EXPECT().Get(gomock.Any(), gomock.Eq(lockCacheKey)).Return(nil, domain.ErrDataNotFound)
+				cache.EXPECT().SetIfNotExists(gomock.Any(), gomock.Eq(usedCacheKey), gomock.Any(), gomock.Any()).Return(true, nil)
+				cache.EXPECT().Delete(gomock.Any(), gomock.Eq(attemptsCacheKey)).Return(nil)
+				cache.EXPECT().Delete(gomock.Any(), gomock.Eq(lockCacheKey)).Return(nil)
+				repo.EXPECT().
+					EnableTwoFactor(gomock.Any(), gomock.Eq(userID), gomock.Len(10)).
+					Return(enabled, nil)
+				cache.EXPECT().Delete(gomock.Any(), gomock.Eq(setupChallengeCacheKey)).Return(nil)
+			},
+			input: setupChallenge,
+			code:  code,
+			expected: verifyChallengeExpectedOutput{
+				recoveryCodes: 10,
+			},
+		},
+		{
+			desc: "Fail_Replayed",
+			mocks: func(repo *mock.MockTwoFactorRepository, cache *mock.MockCacheRepository) {
+				cache.EXPECT().Get(gomock.Any(), gomock.Eq(challengeCacheKey)).Return(challengeSerialized, nil)
+				repo.EXPECT().GetTwoFactor(gomock.Any(), gomock.Eq(userID)).Return(enabled, nil)
+				cache.EXPECT().Get(gomock.Any(), gomock.Eq(lockCacheKey)).Return(nil, domain.ErrDataNotFound)
+				cache.EXPECT().SetIfNotExists(gomock.Any(), gomock.Eq(usedCacheKey), gomock.Any(), gomock.Any()).Return(false, nil)
+				cache.EXPECT().Increment(gomock.Any(), gomock.Eq(attemptsCacheKey), gomock.Any()).Return(int64(1), nil)
+			},
+			input: challenge,
+			code:  code,
+			expected: verifyChallengeExpectedOutput{
+				err: domain.ErrInvalidTwoFactorCode,
+			},
+		},
+		{
+			desc: "Fail_WrongCode",
+			mocks: func(repo *mock.MockTwoFactorRepository, cache *mock.MockCacheRepository) {
+				cache.EXPECT().Get(gomock.Any(), gomock.Eq(challengeCacheKey)).Return(challengeSerialized, nil)
+				repo.EXPECT().GetTwoFactor(gomock.Any(), gomock.Eq(userID)).Return(enabled, nil)
+				cache.EXPECT().Get(gomock.Any(), gomock.Eq(lockCacheKey)).Return(nil, domain.ErrDataNotFound)
+				repo.EXPECT().UseRecoveryCode(gomock.Any(), gomock.Eq(userID), gomock.Any()).Return(domain.ErrDataNotFound)
+				cache.EXPECT().Increment(gomock.Any(), gomock.Eq(attemptsCacheKey), gomock.Any()).Return(int64(1), nil)
+			},
+			input: challenge,
+			code:  "not-a-code",
+			expected: verifyChallengeExpectedOutput{
+				err: domain.ErrInvalidTwoFactorCode,
+			},
+		},
+		{
+			desc: "Fail_ExpiredChallenge",
+			mocks: func(repo *mock.MockTwoFactorRepository, cache *mock.MockCacheRepository) {
+				cache.EXPECT().Get(gomock.Any(), gomock.Eq(challengeCacheKey)).Return(nil, domain.ErrDataNotFound)
+			},
+			input: challenge,
+			code:  code,
+			expected: verifyChallengeExpectedOutput{
+				err: domain.ErrInvalidChallenge,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mock.NewMockTwoFactorRepository(ctrl)
+			userRepo := mock.NewMockUserRepository(ctrl)
+			cache := mock.NewMockCacheRepository(ctrl)
+
+			tc.mocks(repo, cache)
+
+			twoFactorService := service.NewTwoFactorService(repo, userRepo, cache, newMockAuditService(ctrl), policy)
+
+			verifiedUserID, recoveryCodes, err := twoFactorService.VerifyChallenge(ctx, tc.input.ID.String(), tc.code)
+			assert.Equal(t, tc.expected.err, err, "Error mismatch")
+			assert.Len(t, recoveryCodes, tc.expected.recoveryCodes, "Recovery codes mismatch")
+			if tc.expected.err == nil {
+				assert.Equal(t, userID, verifiedUserID, "User mismatch")
+			}
+		})
+	}
+}
+
+func TestTwoFactorService_DisableTwoFactor(t *testing.T) {
+	ctx := context.Background()
+	policy := &domain.TwoFactorPolicy{
+		Issuer:        "Go POS",
+		RequiredRoles: []domain.UserRole{domain.Admin},
+	}
+	admin := &domain.User{
+		ID:   gofakeit.Uint64(),
+		Role: domain.Admin,
+	}
+	cashier := &domain.User{
+		ID:   gofakeit.Uint64(),
+		Role: domain.Cashier,
+	}
+
+	testCases := []struct {
+		desc     string
+		mocks    func(repo *mock.MockTwoFactorRepository, userRepo *mock.MockUserRepository)
+		input    *domain.User
+		expected error
+	}{
+		{
+			desc: "Fail_Required",
+			mocks: func(repo *mock.MockTwoFactorRepository, userRepo *mock.MockUserRepository) {
+				userRepo.EXPECT().GetUserByID(gomock.Any(), gomock.Eq(admin.ID)).Return(admin, nil)
+			},
+			input:    admin,
+			expected: domain.ErrTwoFactorRequired,
+		},
+		{
+			desc: "Fail_NotEnabled",
+			mocks: func(repo *mock.MockTwoFactorRepository, userRepo *mock.MockUserRepository) {
+				userRepo.EXPECT().GetUserByID(gomock.Any(), gomock.Eq(cashier.ID)).Return(cashier, nil)
+				repo.EXPECT().GetTwoFactor(gomock.Any(), gomock.Eq(cashier.ID)).Return(nil, domain.ErrDataNotFound)
+			},
+			input:    cashier,
+			expected: domain.ErrTwoFactorNotEnabled,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mock.NewMockTwoFactorRepository(ctrl)
+			userRepo := mock.NewMockUserRepository(ctrl)
+			cache := mock.NewMockCacheRepository(ctrl)
+
+			tc.mocks(repo, userRepo)
+
+			twoFactorService := service.NewTwoFactorService(repo, userRepo, cache, newMockAuditService(ctrl), policy)
+
+			err := twoFactorService.DisableTwoFactor(ctx, tc.input.ID, "123456")
+			assert.Equal(t, tc.expected, err, "Error mismatch")
+		})
+	}
+}
+
+func TestTwoFactorService_ResetTwoFactor(t *testing.T) {
+	ctx := context.Background()
+	policy := &domain.TwoFactorPolicy{
+		Issuer: "Go POS",
+	}
+	user := &domain.User{
+		ID:   gofakeit.Uint64(),
+		Role: domain.Cashier,
+	}
+	enabledAt := time.Now()
+	twoFactor := &domain.TwoFactor{
+		UserID:        user.ID,
+		Secret:        "JBSWY3DPEHPK3PXP",
+		RecoveryCodes: []string{"hash"},
+		EnabledAt:     &enabledAt,
+	}
+
+	testCases := []struct {
+		desc  string
+		mocks func(
+			repo *mock.MockTwoFactorRepository,
+			userRepo *mock.MockUserRepository,
+			cache *mock.MockCacheRepository,
+			auditRepo *mock.MockAuditRepository,
+		)
+		expected error
+	}{
+		{
+			desc: "Success",
+			mocks: func(
+				repo *mock.MockTwoFactorRepository,
+				userRepo *mock.MockUserRepository,
+				cache *mock.MockCacheRepository,
+				auditRepo *mock.MockAuditRepository,
+			) {
+				userRepo.EXPECT().GetUserByID(gomock.Any(), gomock.Eq(user.ID)).Return(user, nil)
+				repo.EXPECT().GetTwoFactor(gomock.Any(), gomock.Eq(user.ID)).Return(twoFactor, nil)
+				repo.EXPECT().DeleteTwoFactor(gomock.Any(), gomock.Eq(user.ID)).Return(nil)
+				auditRepo.EXPECT().
+					CreateAuditLog(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, auditLog *domain.AuditLog) (*domain.AuditLog, error) {
+						assert.Equal(t, "two_factor.reset", auditLog.Action, "Action mismatch")
+						assert.Equal(t, user.ID, auditLog.EntityID, "Entity mismatch")
+						assert.Equal(t, domain.AuditChange{Before: "[REDACTED]"}, auditLog.Changes["Secret"], "Secret must be redacted")
+						assert.Equal(t, domain.AuditChange{Before: "[REDACTED]"}, auditLog.Changes["RecoveryCodes"], "Recovery codes must be redacted")
+						return auditLog, nil
+					})
+				cache.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+			},
+			expected: nil,
+		},
+		{
+			desc: "Fail_AuditError",
+			mocks: func(
+				repo *mock.MockTwoFactorRepository,
+				userRepo *mock.MockUserRepository,
+				cache *mock.MockCacheRepository,
+				auditRepo *mock.MockAuditRepository,
+			) {
+				userRepo.EXPECT().GetUserByID(gomock.Any(), gomock.Eq(user.ID)).Return(user, nil)
+				repo.EXPECT().GetTwoFactor(gomock.Any(), gomock.Eq(user.ID)).Return(twoFactor, nil)
+				repo.EXPECT().DeleteTwoFactor(gomock.Any(), gomock.Eq(user.ID)).Return(nil)
+				auditRepo.EXPECT().CreateAuditLog(gomock.Any(), gomock.Any()).Return(nil, domain.ErrInternal)
+			},
+			expected: domain.ErrInternal,
+		},
+		{
+			desc: "Fail_NotFound",
+			mocks: func(
+				repo *mock.MockTwoFactorRepository,
+				userRepo *mock.MockUserRepository,
+				cache *mock.MockCacheRepository,
+				auditRepo *mock.MockAuditRepository,
+			) {
+				userRepo.EXPECT().GetUserByID(gomock.Any(), gomock.Eq(user.ID)).Return(nil, domain.ErrDataNotFound)
+			},
+			expected: domain.ErrDataNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mock.NewMockTwoFactorRepository(ctrl)
+			userRepo := mock.NewMockUserRepository(ctrl)
+			cache := mock.NewMockCacheRepository(ctrl)
+			auditRepo := mock.NewMockAuditRepository(ctrl)
+
+			tc.mocks(repo, userRepo, cache, auditRepo)
+
+			twoFactorService := service.NewTwoFactorService(repo, userRepo, cache, newAuditService(ctrl, auditRepo), policy)
+
+			err := twoFactorService.ResetTwoFactor(ctx, user.ID)
+			assert.ErrorIs(t, err, tc.expected, "Error mismatch")
+		})
+	}
+}
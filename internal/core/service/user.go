@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"time"
 
 	"github.com/nikhil-shrestha/go-pos/internal/core/domain"
 	"github.com/nikhil-shrestha/go-pos/internal/core/port"
@@ -10,19 +11,23 @@ import (
 
 /**
  * UserService implements port.UserService interface
- * and provides an access to the user repository
- * and cache service
+ * and provides an access to the user repository,
+ * cache service and audit service
  */
 type UserService struct {
-	repo  port.UserRepository
-	cache port.CacheRepository
+	repo            port.UserRepository
+	cache           port.CacheRepository
+	audit           port.AuditService
+	refreshDuration time.Duration
 }
 
 // NewUserService creates a new user service instance
-func NewUserService(repo port.UserRepository, cache port.CacheRepository) *UserService {
+func NewUserService(repo port.UserRepository, cache port.CacheRepository, audit port.AuditService, refreshDuration time.Duration) *UserService {
 	return &UserService{
 		repo,
 		cache,
+		audit,
+		refreshDuration,
 	}
 }
 
@@ -35,12 +40,21 @@ func (us *UserService) Register(ctx context.Context, user *domain.User) (*domain
 
 	user.Password = hashedPassword
 
-	user, err = us.repo.CreateUser(ctx, user)
-	if err != nil {
-		if err == domain.ErrConflictingData {
-			return nil, err
+	err = us.audit.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+
+		user, err = us.repo.CreateUser(ctx, user)
+		if err != nil {
+			if err == domain.ErrConflictingData {
+				return err
+			}
+			return domain.ErrInternal
 		}
-		return nil, domain.ErrInternal
+
+		return us.audit.RecordAuditLog(ctx, &domain.AuditLog{Action: "user.create", Entity: "user", EntityID: user.ID}, nil, user)
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	cacheKey := util.GenerateCacheKey("user", user.ID)
@@ -98,40 +112,41 @@ func (us *UserService) GetUser(ctx context.Context, id uint64) (*domain.User, er
 }
 
 // ListUsers lists all users
-func (us *UserService) ListUsers(ctx context.Context, skip, limit uint64) ([]domain.User, error) {
-	var users []domain.User
+func (us *UserService) ListUsers(ctx context.Context, includeDeleted bool, params *domain.ListParams) ([]domain.User, uint64, error) {
+	var page domain.Page[domain.User]
 
-	params := util.GenerateCacheKeyParams(skip, limit)
-	cacheKey := util.GenerateCacheKey("users", params)
+	cacheParams := util.GenerateCacheKeyParams(params.Skip, params.Limit, params.Sort, params.Order, includeDeleted)
+	cacheKey := util.GenerateCacheKey("users", cacheParams)
 
 	cachedUsers, err := us.cache.Get(ctx, cacheKey)
 	if err == nil {
-		err := util.Deserialize(cachedUsers, &users)
+		err := util.Deserialize(cachedUsers, &page)
 		if err != nil {
-			return nil, domain.ErrInternal
+			return nil, 0, domain.ErrInternal
 		}
-		return users, nil
+		return page.Items, page.Total, nil
 	}
 
-	users, err = us.repo.ListUsers(ctx, skip, limit)
+	users, total, err := us.repo.ListUsers(ctx, includeDeleted, params)
 	if err != nil {
-		return nil, domain.ErrInternal
+		return nil, 0, domain.ErrInternal
 	}
 
-	usersSerialized, err := util.Serialize(users)
+	usersSerialized, err := util.Serialize(domain.Page[domain.User]{Items: users, Total: total})
 	if err != nil {
-		return nil, domain.ErrInternal
+		return nil, 0, domain.ErrInternal
 	}
 
 	err = us.cache.Set(ctx, cacheKey, usersSerialized, 0)
 	if err != nil {
-		return nil, domain.ErrInternal
+		return nil, 0, domain.ErrInternal
 	}
 
-	return users, nil
+	return users, total, nil
 }
 
-// UpdateUser updates a user's name, email, and password
+// UpdateUser updates a user's name, email, and password, as long as it is still at the version the client read.
+// Changing the role of the user revokes their access tokens and sessions
 func (us *UserService) UpdateUser(ctx context.Context, user *domain.User) (*domain.User, error) {
 	existingUser, err := us.repo.GetUserByID(ctx, user.ID)
 	if err != nil {
@@ -141,13 +156,20 @@ func (us *UserService) UpdateUser(ctx context.Context, user *domain.User) (*doma
 		return nil, domain.ErrInternal
 	}
 
+	if existingUser.Version != user.Version {
+		return nil, domain.ErrVersionMismatch
+	}
+
 	emptyData := user.Name == "" &&
 		user.Email == "" &&
 		user.Password == "" &&
+		user.Pin == "" &&
 		user.Role == ""
 	sameData := existingUser.Name == user.Name &&
 		existingUser.Email == user.Email &&
-		existingUser.Role == user.Role
+		existingUser.Role == user.Role &&
+		user.Password == "" &&
+		user.Pin == ""
 	if emptyData || sameData {
 		return nil, domain.ErrNoUpdatedData
 	}
@@ -163,12 +185,30 @@ func (us *UserService) UpdateUser(ctx context.Context, user *domain.User) (*doma
 
 	user.Password = hashedPassword
 
-	_, err = us.repo.UpdateUser(ctx, user)
-	if err != nil {
-		if err == domain.ErrConflictingData {
-			return nil, err
+	if user.Pin != "" {
+		user.Pin, err = util.HashPassword(user.Pin)
+		if err != nil {
+			return nil, domain.ErrInternal
 		}
-		return nil, domain.ErrInternal
+	}
+
+	var updatedUser *domain.User
+
+	err = us.audit.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+
+		updatedUser, err = us.repo.UpdateUser(ctx, user)
+		if err != nil {
+			if err == domain.ErrConflictingData || err == domain.ErrDataNotFound || err == domain.ErrVersionMismatch {
+				return err
+			}
+			return domain.ErrInternal
+		}
+
+		return us.audit.RecordAuditLog(ctx, &domain.AuditLog{Action: "user.update", Entity: "user", EntityID: user.ID}, existingUser, updatedUser)
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	cacheKey := util.GenerateCacheKey("user", user.ID)
@@ -193,12 +233,21 @@ func (us *UserService) UpdateUser(ctx context.Context, user *domain.User) (*doma
 		return nil, domain.ErrInternal
 	}
 
+	// Access tokens and sessions carry the permissions of the role the user had when they were issued
+	if user.Role != "" && user.Role != existingUser.Role {
+		err = revokeUser(ctx, us.cache, user.ID, us.refreshDuration)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return user, nil
 }
 
-// DeleteUser deletes a user by ID
-func (us *UserService) DeleteUser(ctx context.Context, id uint64) error {
-	_, err := us.repo.GetUserByID(ctx, id)
+// DeleteUser soft deletes a user by ID, as long as it is still at the version the client read,
+// and revokes the access tokens and sessions of the user
+func (us *UserService) DeleteUser(ctx context.Context, id, version uint64) error {
+	existingUser, err := us.repo.GetUserByID(ctx, id)
 	if err != nil {
 		if err == domain.ErrDataNotFound {
 			return err
@@ -206,6 +255,10 @@ func (us *UserService) DeleteUser(ctx context.Context, id uint64) error {
 		return domain.ErrInternal
 	}
 
+	if existingUser.Version != version {
+		return domain.ErrVersionMismatch
+	}
+
 	cacheKey := util.GenerateCacheKey("user", id)
 
 	err = us.cache.Delete(ctx, cacheKey)
@@ -218,5 +271,52 @@ func (us *UserService) DeleteUser(ctx context.Context, id uint64) error {
 		return domain.ErrInternal
 	}
 
-	return us.repo.DeleteUser(ctx, id)
+	err = us.audit.WithinTransaction(ctx, func(ctx context.Context) error {
+		err := us.repo.DeleteUser(ctx, id, version)
+		if err != nil {
+			return err
+		}
+
+		return us.audit.RecordAuditLog(ctx, &domain.AuditLog{Action: "user.delete", Entity: "user", EntityID: id}, existingUser, nil)
+	})
+	if err != nil {
+		return err
+	}
+
+	// API keys of deleted users are not found anymore, but cached ones would still be accepted
+	err = us.cache.DeleteByPrefix(ctx, "api_key:*")
+	if err != nil {
+		return domain.ErrInternal
+	}
+
+	return revokeUser(ctx, us.cache, id, us.refreshDuration)
+}
+
+// RestoreUser restores a soft deleted user
+func (us *UserService) RestoreUser(ctx context.Context, id uint64) (*domain.User, error) {
+	var user *domain.User
+
+	err := us.audit.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+
+		user, err = us.repo.RestoreUser(ctx, id)
+		if err != nil {
+			if err == domain.ErrDataNotFound || err == domain.ErrConflictingData {
+				return err
+			}
+			return domain.ErrInternal
+		}
+
+		return us.audit.RecordAuditLog(ctx, &domain.AuditLog{Action: "user.restore", Entity: "user", EntityID: id}, nil, user)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = us.cache.DeleteByPrefix(ctx, "users:*")
+	if err != nil {
+		return nil, domain.ErrInternal
+	}
+
+	return user, nil
 }
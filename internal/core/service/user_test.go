@@ -178,7 +178,7 @@ func TestUserService_Register(t *testing.T) {
 
 			tc.mocks(userRepo, cache)
 
-			userService := service.NewUserService(userRepo, cache)
+			userService := service.NewUserService(userRepo, cache, newMockAuditService(ctrl), time.Hour)
 
 			user, err := userService.Register(ctx, tc.input.user)
 			assert.Equal(t, tc.expected.err, err, "Error mismatch")
@@ -360,7 +360,7 @@ func TestUserService_GetUser(t *testing.T) {
 
 			tc.mocks(userRepo, cache)
 
-			userService := service.NewUserService(userRepo, cache)
+			userService := service.NewUserService(userRepo, cache, newMockAuditService(ctrl), time.Hour)
 
 			user, err := userService.GetUser(ctx, tc.input.id)
 			assert.Equal(t, tc.expected.err, err, "Error mismatch")
@@ -370,12 +370,12 @@ func TestUserService_GetUser(t *testing.T) {
 }
 
 type listUsersTestedInput struct {
-	skip  uint64
-	limit uint64
+	params *domain.ListParams
 }
 
 type listUsersExpectedOutput struct {
 	users []domain.User
+	total uint64
 	err   error
 }
 
@@ -398,10 +398,18 @@ func TestUserService_ListUsers(t *testing.T) {
 	ctx := context.Background()
 	skip := gofakeit.Uint64()
 	limit := gofakeit.Uint64()
+	total := gofakeit.Uint64()
+	listParams := &domain.ListParams{
+		Skip:  skip,
+		Limit: limit,
+		Sort:  "created_at",
+		Order: domain.Descending,
+	}
+	includeDeleted := false
 
-	params := util.GenerateCacheKeyParams(skip, limit)
+	params := util.GenerateCacheKeyParams(skip, limit, listParams.Sort, listParams.Order, includeDeleted)
 	cacheKey := util.GenerateCacheKey("users", params)
-	usersSerialized, _ := util.Serialize(users)
+	usersSerialized, _ := util.Serialize(domain.Page[domain.User]{Items: users, Total: total})
 	ttl := time.Duration(0)
 
 	testCases := []struct {
@@ -424,11 +432,11 @@ func TestUserService_ListUsers(t *testing.T) {
 					Return(usersSerialized, nil)
 			},
 			input: listUsersTestedInput{
-				skip:  skip,
-				limit: limit,
+				params: listParams,
 			},
 			expected: listUsersExpectedOutput{
 				users: users,
+				total: total,
 				err:   nil,
 			},
 		},
@@ -442,18 +450,18 @@ func TestUserService_ListUsers(t *testing.T) {
 					Get(gomock.Any(), gomock.Eq(cacheKey)).
 					Return(nil, domain.ErrDataNotFound)
 				userRepo.EXPECT().
-					ListUsers(gomock.Any(), gomock.Eq(skip), gomock.Eq(limit)).
-					Return(users, nil)
+					ListUsers(gomock.Any(), gomock.Eq(includeDeleted), gomock.Eq(listParams)).
+					Return(users, total, nil)
 				cache.EXPECT().
 					Set(gomock.Any(), gomock.Eq(cacheKey), gomock.Eq(usersSerialized), gomock.Eq(ttl)).
 					Return(nil)
 			},
 			input: listUsersTestedInput{
-				skip:  skip,
-				limit: limit,
+				params: listParams,
 			},
 			expected: listUsersExpectedOutput{
 				users: users,
+				total: total,
 				err:   nil,
 			},
 		},
@@ -468,8 +476,7 @@ func TestUserService_ListUsers(t *testing.T) {
 					Return([]byte("invalid"), nil)
 			},
 			input: listUsersTestedInput{
-				skip:  skip,
-				limit: limit,
+				params: listParams,
 			},
 			expected: listUsersExpectedOutput{
 				users: nil,
@@ -486,12 +493,11 @@ func TestUserService_ListUsers(t *testing.T) {
 					Get(gomock.Any(), gomock.Eq(cacheKey)).
 					Return(nil, domain.ErrDataNotFound)
 				userRepo.EXPECT().
-					ListUsers(gomock.Any(), gomock.Eq(skip), gomock.Eq(limit)).
-					Return(nil, domain.ErrInternal)
+					ListUsers(gomock.Any(), gomock.Eq(includeDeleted), gomock.Eq(listParams)).
+					Return(nil, uint64(0), domain.ErrInternal)
 			},
 			input: listUsersTestedInput{
-				skip:  skip,
-				limit: limit,
+				params: listParams,
 			},
 			expected: listUsersExpectedOutput{
 				users: nil,
@@ -508,15 +514,14 @@ func TestUserService_ListUsers(t *testing.T) {
 					Get(gomock.Any(), gomock.Eq(cacheKey)).
 					Return(nil, domain.ErrDataNotFound)
 				userRepo.EXPECT().
-					ListUsers(gomock.Any(), gomock.Eq(skip), gomock.Eq(limit)).
-					Return(users, nil)
+					ListUsers(gomock.Any(), gomock.Eq(includeDeleted), gomock.Eq(listParams)).
+					Return(users, total, nil)
 				cache.EXPECT().
 					Set(gomock.Any(), gomock.Eq(cacheKey), gomock.Eq(usersSerialized), gomock.Eq(ttl)).
 					Return(domain.ErrInternal)
 			},
 			input: listUsersTestedInput{
-				skip:  skip,
-				limit: limit,
+				params: listParams,
 			},
 			expected: listUsersExpectedOutput{
 				users: nil,
@@ -537,11 +542,12 @@ func TestUserService_ListUsers(t *testing.T) {
 
 			tc.mocks(userRepo, cache)
 
-			userService := service.NewUserService(userRepo, cache)
+			userService := service.NewUserService(userRepo, cache, newMockAuditService(ctrl), time.Hour)
 
-			users, err := userService.ListUsers(ctx, tc.input.skip, tc.input.limit)
+			users, total, err := userService.ListUsers(ctx, includeDeleted, tc.input.params)
 			assert.Equal(t, tc.expected.err, err, "Error mismatch")
 			assert.Equal(t, tc.expected.users, users, "Users mismatch")
+			assert.Equal(t, tc.expected.total, total, "Total mismatch")
 		})
 	}
 }
@@ -580,8 +586,25 @@ func TestUserService_UpdateUser(t *testing.T) {
 		Role:  domain.Admin,
 	}
 
+	pinInput := &domain.User{
+		ID:    userID,
+		Name:  existingUser.Name,
+		Email: existingUser.Email,
+		Role:  existingUser.Role,
+		Pin:   "1234",
+	}
+	pinOutput := &domain.User{
+		ID:    userID,
+		Name:  existingUser.Name,
+		Email: existingUser.Email,
+		Role:  existingUser.Role,
+		Pin:   "hashed pin",
+	}
+
 	cacheKey := util.GenerateCacheKey("user", userID)
+	revokedCacheKey := util.GenerateCacheKey("revoked_user", userID)
 	userSerialized, _ := util.Serialize(userOutput)
+	pinSerialized, _ := util.Serialize(pinOutput)
 	ttl := time.Duration(0)
 
 	testCases := []struct {
@@ -614,6 +637,9 @@ func TestUserService_UpdateUser(t *testing.T) {
 				cache.EXPECT().
 					DeleteByPrefix(gomock.Any(), gomock.Eq("users:*")).
 					Return(nil)
+				cache.EXPECT().
+					Set(gomock.Any(), gomock.Eq(revokedCacheKey), gomock.Any(), gomock.Eq(time.Hour)).
+					Return(nil)
 			},
 			input: updateUserTestedInput{
 				user: userInput,
@@ -623,6 +649,40 @@ func TestUserService_UpdateUser(t *testing.T) {
 				err:  nil,
 			},
 		},
+		{
+			desc: "Success_PinOnly",
+			mocks: func(
+				userRepo *mock.MockUserRepository,
+				cache *mock.MockCacheRepository,
+			) {
+				userRepo.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(userID)).
+					Return(existingUser, nil)
+				userRepo.EXPECT().
+					UpdateUser(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, user *domain.User) (*domain.User, error) {
+						assert.NoError(t, util.ComparePassword("1234", user.Pin), "PIN must be hashed")
+						user.Pin = pinOutput.Pin
+						return user, nil
+					})
+				cache.EXPECT().
+					Delete(gomock.Any(), gomock.Eq(cacheKey)).
+					Return(nil)
+				cache.EXPECT().
+					Set(gomock.Any(), gomock.Eq(cacheKey), gomock.Eq(pinSerialized), gomock.Eq(ttl)).
+					Return(nil)
+				cache.EXPECT().
+					DeleteByPrefix(gomock.Any(), gomock.Eq("users:*")).
+					Return(nil)
+			},
+			input: updateUserTestedInput{
+				user: pinInput,
+			},
+			expected: updateUserExpectedOutput{
+				user: pinOutput,
+				err:  nil,
+			},
+		},
 		{
 			desc: "Fail_NotFound",
 			mocks: func(
@@ -641,6 +701,26 @@ func TestUserService_UpdateUser(t *testing.T) {
 				err:  domain.ErrDataNotFound,
 			},
 		},
+		{
+			desc: "Fail_VersionMismatch",
+			mocks: func(
+				userRepo *mock.MockUserRepository,
+				cache *mock.MockCacheRepository,
+			) {
+				userRepo.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(userID)).
+					Return(&domain.User{
+						Version: userInput.Version + 1,
+					}, nil)
+			},
+			input: updateUserTestedInput{
+				user: userInput,
+			},
+			expected: updateUserExpectedOutput{
+				user: nil,
+				err:  domain.ErrVersionMismatch,
+			},
+		},
 		{
 			desc: "Fail_InternalErrorGetByID",
 			mocks: func(
@@ -820,6 +900,39 @@ func TestUserService_UpdateUser(t *testing.T) {
 				err:  domain.ErrInternal,
 			},
 		},
+		{
+			desc: "Fail_RevokeUser",
+			mocks: func(
+				userRepo *mock.MockUserRepository,
+				cache *mock.MockCacheRepository,
+			) {
+				userRepo.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(userID)).
+					Return(existingUser, nil)
+				userRepo.EXPECT().
+					UpdateUser(gomock.Any(), gomock.Eq(userInput)).
+					Return(userOutput, nil)
+				cache.EXPECT().
+					Delete(gomock.Any(), gomock.Eq(cacheKey)).
+					Return(nil)
+				cache.EXPECT().
+					Set(gomock.Any(), gomock.Eq(cacheKey), gomock.Eq(userSerialized), gomock.Eq(ttl)).
+					Return(nil)
+				cache.EXPECT().
+					DeleteByPrefix(gomock.Any(), gomock.Eq("users:*")).
+					Return(nil)
+				cache.EXPECT().
+					Set(gomock.Any(), gomock.Eq(revokedCacheKey), gomock.Any(), gomock.Eq(time.Hour)).
+					Return(domain.ErrInternal)
+			},
+			input: updateUserTestedInput{
+				user: userInput,
+			},
+			expected: updateUserExpectedOutput{
+				user: nil,
+				err:  domain.ErrInternal,
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -835,7 +948,7 @@ func TestUserService_UpdateUser(t *testing.T) {
 
 			tc.mocks(userRepo, cache)
 
-			userService := service.NewUserService(userRepo, cache)
+			userService := service.NewUserService(userRepo, cache, newMockAuditService(ctrl), time.Hour)
 
 			user, err := userService.UpdateUser(ctx, tc.input.user)
 			assert.Equal(t, tc.expected.err, err, "Error mismatch")
@@ -845,7 +958,8 @@ func TestUserService_UpdateUser(t *testing.T) {
 }
 
 type deleteUserTestedInput struct {
-	id uint64
+	id      uint64
+	version uint64
 }
 
 type deleteUserExpectedOutput struct {
@@ -855,8 +969,14 @@ type deleteUserExpectedOutput struct {
 func TestUserService_DeleteUser(t *testing.T) {
 	ctx := context.Background()
 	userID := gofakeit.Uint64()
+	version := gofakeit.Uint64()
+	existingUser := &domain.User{
+		ID:      userID,
+		Version: version,
+	}
 
 	cacheKey := util.GenerateCacheKey("user", userID)
+	revokedCacheKey := util.GenerateCacheKey("revoked_user", userID)
 
 	testCases := []struct {
 		desc  string
@@ -875,7 +995,7 @@ func TestUserService_DeleteUser(t *testing.T) {
 			) {
 				userRepo.EXPECT().
 					GetUserByID(gomock.Any(), gomock.Eq(userID)).
-					Return(&domain.User{}, nil)
+					Return(existingUser, nil)
 				cache.EXPECT().
 					Delete(gomock.Any(), gomock.Eq(cacheKey)).
 					Return(nil)
@@ -883,11 +1003,18 @@ func TestUserService_DeleteUser(t *testing.T) {
 					DeleteByPrefix(gomock.Any(), gomock.Eq("users:*")).
 					Return(nil)
 				userRepo.EXPECT().
-					DeleteUser(gomock.Any(), gomock.Eq(userID)).
+					DeleteUser(gomock.Any(), gomock.Eq(userID), gomock.Eq(version)).
+					Return(nil)
+				cache.EXPECT().
+					DeleteByPrefix(gomock.Any(), gomock.Eq("api_key:*")).
+					Return(nil)
+				cache.EXPECT().
+					Set(gomock.Any(), gomock.Eq(revokedCacheKey), gomock.Any(), gomock.Eq(time.Hour)).
 					Return(nil)
 			},
 			input: deleteUserTestedInput{
-				id: userID,
+				id:      userID,
+				version: version,
 			},
 			expected: deleteUserExpectedOutput{
 				err: nil,
@@ -904,12 +1031,31 @@ func TestUserService_DeleteUser(t *testing.T) {
 					Return(nil, domain.ErrDataNotFound)
 			},
 			input: deleteUserTestedInput{
-				id: userID,
+				id:      userID,
+				version: version,
 			},
 			expected: deleteUserExpectedOutput{
 				err: domain.ErrDataNotFound,
 			},
 		},
+		{
+			desc: "Fail_VersionMismatch",
+			mocks: func(
+				userRepo *mock.MockUserRepository,
+				cache *mock.MockCacheRepository,
+			) {
+				userRepo.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(userID)).
+					Return(existingUser, nil)
+			},
+			input: deleteUserTestedInput{
+				id:      userID,
+				version: version + 1,
+			},
+			expected: deleteUserExpectedOutput{
+				err: domain.ErrVersionMismatch,
+			},
+		},
 		{
 			desc: "Fail_InternalErrorGetByID",
 			mocks: func(
@@ -921,7 +1067,8 @@ func TestUserService_DeleteUser(t *testing.T) {
 					Return(nil, domain.ErrInternal)
 			},
 			input: deleteUserTestedInput{
-				id: userID,
+				id:      userID,
+				version: version,
 			},
 			expected: deleteUserExpectedOutput{
 				err: domain.ErrInternal,
@@ -935,13 +1082,14 @@ func TestUserService_DeleteUser(t *testing.T) {
 			) {
 				userRepo.EXPECT().
 					GetUserByID(gomock.Any(), gomock.Eq(userID)).
-					Return(&domain.User{}, nil)
+					Return(existingUser, nil)
 				cache.EXPECT().
 					Delete(gomock.Any(), gomock.Eq(cacheKey)).
 					Return(domain.ErrInternal)
 			},
 			input: deleteUserTestedInput{
-				id: userID,
+				id:      userID,
+				version: version,
 			},
 			expected: deleteUserExpectedOutput{
 				err: domain.ErrInternal,
@@ -955,7 +1103,7 @@ func TestUserService_DeleteUser(t *testing.T) {
 			) {
 				userRepo.EXPECT().
 					GetUserByID(gomock.Any(), gomock.Eq(userID)).
-					Return(&domain.User{}, nil)
+					Return(existingUser, nil)
 				cache.EXPECT().
 					Delete(gomock.Any(), gomock.Eq(cacheKey)).
 					Return(nil)
@@ -964,7 +1112,8 @@ func TestUserService_DeleteUser(t *testing.T) {
 					Return(domain.ErrInternal)
 			},
 			input: deleteUserTestedInput{
-				id: userID,
+				id:      userID,
+				version: version,
 			},
 			expected: deleteUserExpectedOutput{
 				err: domain.ErrInternal,
@@ -976,12 +1125,9 @@ func TestUserService_DeleteUser(t *testing.T) {
 				userRepo *mock.MockUserRepository,
 				cache *mock.MockCacheRepository,
 			) {
-				user := &domain.User{
-					ID: userID,
-				}
 				userRepo.EXPECT().
 					GetUserByID(gomock.Any(), gomock.Eq(userID)).
-					Return(user, nil)
+					Return(existingUser, nil)
 				cache.EXPECT().
 					Delete(gomock.Any(), gomock.Eq(cacheKey)).
 					Return(nil)
@@ -989,11 +1135,75 @@ func TestUserService_DeleteUser(t *testing.T) {
 					DeleteByPrefix(gomock.Any(), gomock.Eq("users:*")).
 					Return(nil)
 				userRepo.EXPECT().
-					DeleteUser(gomock.Any(), gomock.Eq(userID)).
+					DeleteUser(gomock.Any(), gomock.Eq(userID), gomock.Eq(version)).
 					Return(domain.ErrInternal)
 			},
 			input: deleteUserTestedInput{
-				id: userID,
+				id:      userID,
+				version: version,
+			},
+			expected: deleteUserExpectedOutput{
+				err: domain.ErrInternal,
+			},
+		},
+		{
+			desc: "Fail_DeleteAPIKeyCache",
+			mocks: func(
+				userRepo *mock.MockUserRepository,
+				cache *mock.MockCacheRepository,
+			) {
+				userRepo.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(userID)).
+					Return(existingUser, nil)
+				cache.EXPECT().
+					Delete(gomock.Any(), gomock.Eq(cacheKey)).
+					Return(nil)
+				cache.EXPECT().
+					DeleteByPrefix(gomock.Any(), gomock.Eq("users:*")).
+					Return(nil)
+				userRepo.EXPECT().
+					DeleteUser(gomock.Any(), gomock.Eq(userID), gomock.Eq(version)).
+					Return(nil)
+				cache.EXPECT().
+					DeleteByPrefix(gomock.Any(), gomock.Eq("api_key:*")).
+					Return(domain.ErrInternal)
+			},
+			input: deleteUserTestedInput{
+				id:      userID,
+				version: version,
+			},
+			expected: deleteUserExpectedOutput{
+				err: domain.ErrInternal,
+			},
+		},
+		{
+			desc: "Fail_RevokeUser",
+			mocks: func(
+				userRepo *mock.MockUserRepository,
+				cache *mock.MockCacheRepository,
+			) {
+				userRepo.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(userID)).
+					Return(existingUser, nil)
+				cache.EXPECT().
+					Delete(gomock.Any(), gomock.Eq(cacheKey)).
+					Return(nil)
+				cache.EXPECT().
+					DeleteByPrefix(gomock.Any(), gomock.Eq("users:*")).
+					Return(nil)
+				userRepo.EXPECT().
+					DeleteUser(gomock.Any(), gomock.Eq(userID), gomock.Eq(version)).
+					Return(nil)
+				cache.EXPECT().
+					DeleteByPrefix(gomock.Any(), gomock.Eq("api_key:*")).
+					Return(nil)
+				cache.EXPECT().
+					Set(gomock.Any(), gomock.Eq(revokedCacheKey), gomock.Any(), gomock.Eq(time.Hour)).
+					Return(domain.ErrInternal)
+			},
+			input: deleteUserTestedInput{
+				id:      userID,
+				version: version,
 			},
 			expected: deleteUserExpectedOutput{
 				err: domain.ErrInternal,
@@ -1013,10 +1223,155 @@ func TestUserService_DeleteUser(t *testing.T) {
 
 			tc.mocks(userRepo, cache)
 
-			userService := service.NewUserService(userRepo, cache)
+			userService := service.NewUserService(userRepo, cache, newMockAuditService(ctrl), time.Hour)
 
-			err := userService.DeleteUser(ctx, tc.input.id)
+			err := userService.DeleteUser(ctx, tc.input.id, tc.input.version)
 			assert.Equal(t, tc.expected.err, err, "Error mismatch")
 		})
 	}
 }
+
+type restoreUserTestedInput struct {
+	id uint64
+}
+
+type restoreUserExpectedOutput struct {
+	user *domain.User
+	err  error
+}
+
+func TestUserService_RestoreUser(t *testing.T) {
+	ctx := context.Background()
+	userID := gofakeit.Uint64()
+	userOutput := &domain.User{
+		ID:   userID,
+		Name: gofakeit.Name(),
+	}
+
+	testCases := []struct {
+		desc  string
+		mocks func(
+			userRepo *mock.MockUserRepository,
+			cache *mock.MockCacheRepository,
+		)
+		input    restoreUserTestedInput
+		expected restoreUserExpectedOutput
+	}{
+		{
+			desc: "Success",
+			mocks: func(
+				userRepo *mock.MockUserRepository,
+				cache *mock.MockCacheRepository,
+			) {
+				userRepo.EXPECT().
+					RestoreUser(gomock.Any(), gomock.Eq(userID)).
+					Return(userOutput, nil)
+				cache.EXPECT().
+					DeleteByPrefix(gomock.Any(), gomock.Eq("users:*")).
+					Return(nil)
+			},
+			input: restoreUserTestedInput{
+				id: userID,
+			},
+			expected: restoreUserExpectedOutput{
+				user: userOutput,
+				err:  nil,
+			},
+		},
+		{
+			desc: "Fail_NotFound",
+			mocks: func(
+				userRepo *mock.MockUserRepository,
+				cache *mock.MockCacheRepository,
+			) {
+				userRepo.EXPECT().
+					RestoreUser(gomock.Any(), gomock.Eq(userID)).
+					Return(nil, domain.ErrDataNotFound)
+			},
+			input: restoreUserTestedInput{
+				id: userID,
+			},
+			expected: restoreUserExpectedOutput{
+				user: nil,
+				err:  domain.ErrDataNotFound,
+			},
+		},
+		{
+			desc: "Fail_Conflict",
+			mocks: func(
+				userRepo *mock.MockUserRepository,
+				cache *mock.MockCacheRepository,
+			) {
+				userRepo.EXPECT().
+					RestoreUser(gomock.Any(), gomock.Eq(userID)).
+					Return(nil, domain.ErrConflictingData)
+			},
+			input: restoreUserTestedInput{
+				id: userID,
+			},
+			expected: restoreUserExpectedOutput{
+				user: nil,
+				err:  domain.ErrConflictingData,
+			},
+		},
+		{
+			desc: "Fail_InternalError",
+			mocks: func(
+				userRepo *mock.MockUserRepository,
+				cache *mock.MockCacheRepository,
+			) {
+				userRepo.EXPECT().
+					RestoreUser(gomock.Any(), gomock.Eq(userID)).
+					Return(nil, domain.ErrInternal)
+			},
+			input: restoreUserTestedInput{
+				id: userID,
+			},
+			expected: restoreUserExpectedOutput{
+				user: nil,
+				err:  domain.ErrInternal,
+			},
+		},
+		{
+			desc: "Fail_DeleteCacheByPrefix",
+			mocks: func(
+				userRepo *mock.MockUserRepository,
+				cache *mock.MockCacheRepository,
+			) {
+				userRepo.EXPECT().
+					RestoreUser(gomock.Any(), gomock.Eq(userID)).
+					Return(userOutput, nil)
+				cache.EXPECT().
+					DeleteByPrefix(gomock.Any(), gomock.Eq("users:*")).
+					Return(domain.ErrInternal)
+			},
+			input: restoreUserTestedInput{
+				id: userID,
+			},
+			expected: restoreUserExpectedOutput{
+				user: nil,
+				err:  domain.ErrInternal,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			userRepo := mock.NewMockUserRepository(ctrl)
+			cache := mock.NewMockCacheRepository(ctrl)
+
+			tc.mocks(userRepo, cache)
+
+			userService := service.NewUserService(userRepo, cache, newMockAuditService(ctrl), time.Hour)
+
+			user, err := userService.RestoreUser(ctx, tc.input.id)
+			assert.Equal(t, tc.expected.err, err, "Error mismatch")
+			assert.Equal(t, tc.expected.user, user, "User mismatch")
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package util
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+)
+
+// GenerateKey generates a random secret of the given number of bytes, encoded as hex
+func GenerateKey(size int) (string, error) {
+	key := make([]byte, size)
+
+	_, err := rand.Read(key)
+	if err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(key), nil
+}
+
+// HashKey hashes a random secret using SHA-256, which unlike a password does not need a slow hash
+func HashKey(key string) string {
+	hash := sha256.Sum256([]byte(key))
+
+	return hex.EncodeToString(hash[:])
+}
+
+// CompareKey compares input secret with hashed secret in constant time
+func CompareKey(key, hashedKey string) bool {
+	return subtle.ConstantTimeCompare([]byte(HashKey(key)), []byte(hashedKey)) == 1
+}
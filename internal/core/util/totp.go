@@ -0,0 +1,39 @@
+package util
+
+import (
+	"bytes"
+	"image/png"
+	"strings"
+
+	"github.com/pquerna/otp/totp"
+)
+
+// GenerateTotp generates an RFC 6238 secret for the account and returns it with its otpauth URI and a PNG QR code of the URI
+func GenerateTotp(issuer, account string) (string, string, []byte, error) {
+	key, err := totp.Generate(totp.GenerateOpts{
+		Issuer:      issuer,
+		AccountName: account,
+	})
+	if err != nil {
+		return "", "", nil, err
+	}
+
+	img, err := key.Image(256, 256)
+	if err != nil {
+		return "", "", nil, err
+	}
+
+	var qrCode bytes.Buffer
+
+	err = png.Encode(&qrCode, img)
+	if err != nil {
+		return "", "", nil, err
+	}
+
+	return key.Secret(), key.URL(), qrCode.Bytes(), nil
+}
+
+// ValidateTotp reports whether the code is the current code of the secret, allowing one period of clock drift
+func ValidateTotp(code, secret string) bool {
+	return totp.Validate(strings.TrimSpace(code), secret)
+}